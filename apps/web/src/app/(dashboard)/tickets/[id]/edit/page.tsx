@@ -31,12 +31,14 @@ import {
 import { toast } from 'sonner';
 import { apiGet, apiPatch } from '@/lib/api';
 import { useLanguage } from '@/components/providers/LanguageProvider';
+import { useCatalogStore } from '@/stores';
+import { catalogName, catalogOptions } from '@/stores/catalogStore';
 
 const editTicketSchema = z.object({
   title: z.string().min(5, 'Title must be at least 5 characters'),
   description: z.string().min(10, 'Description must be at least 10 characters'),
-  priority: z.enum(['LOW', 'MEDIUM', 'HIGH', 'CRITICAL']),
-  category: z.enum(['ELECTRICAL', 'PLUMBING', 'HVAC', 'IT', 'GENERAL', 'OTHER']),
+  priority: z.string().min(1, 'Priority is required'),
+  category: z.string().min(1, 'Category is required'),
   location: z.string().min(2, 'Location is required'),
   status: z.enum(['OPEN', 'IN_PROGRESS', 'PENDING', 'RESOLVED', 'CLOSED']),
 });
@@ -59,10 +61,18 @@ interface EditTicketPageProps {
 
 export default function EditTicketPage({ params }: EditTicketPageProps) {
   const { id } = use(params);
-  const { t } = useLanguage();
+  const { t, locale } = useLanguage();
   const router = useRouter();
   const [isLoading, setIsLoading] = useState(true);
   const [isSubmitting, setIsSubmitting] = useState(false);
+  // The ticket's saved category and priority stay selectable even if an
+  // admin has since deactivated them
+  const [original, setOriginal] = useState<{ category?: string; priority?: string }>({});
+  const { categories, priorities, fetchCatalog } = useCatalogStore();
+
+  useEffect(() => {
+    fetchCatalog();
+  }, [fetchCatalog]);
 
   const {
     register,
@@ -81,11 +91,12 @@ export default function EditTicketPage({ params }: EditTicketPageProps) {
         const response = await apiGet<{ success: boolean; data: Ticket }>(`/tickets/${id}`);
         if (response.success) {
           const ticket = response.data;
+          setOriginal({ category: ticket.category, priority: ticket.priority });
           reset({
             title: ticket.title,
             description: ticket.description,
-            priority: ticket.priority as EditTicketForm['priority'],
-            category: ticket.category as EditTicketForm['category'],
+            priority: ticket.priority,
+            category: ticket.category,
             location: ticket.location,
             status: ticket.status as EditTicketForm['status'],
           });
@@ -184,16 +195,17 @@ export default function EditTicketPage({ params }: EditTicketPageProps) {
                   <Label className="text-sm font-medium">ความสำคัญ</Label>
                   <Select
                     value={watch('priority')}
-                    onValueChange={(value) => setValue('priority', value as EditTicketForm['priority'])}
+                    onValueChange={(value) => setValue('priority', value)}
                   >
                     <SelectTrigger className="h-11">
                       <SelectValue />
                     </SelectTrigger>
                     <SelectContent>
-                      <SelectItem value="LOW">ต่ำ</SelectItem>
-                      <SelectItem value="MEDIUM">ปานกลาง</SelectItem>
-                      <SelectItem value="HIGH">สูง</SelectItem>
-                      <SelectItem value="CRITICAL">วิกฤต</SelectItem>
+                      {catalogOptions(priorities, original.priority).map((priority) => (
+                        <SelectItem key={priority.id} value={priority.code}>
+                          {catalogName(priority, locale)}
+                        </SelectItem>
+                      ))}
                     </SelectContent>
                   </Select>
                 </div>
@@ -222,18 +234,17 @@ export default function EditTicketPage({ params }: EditTicketPageProps) {
                   </Label>
                   <Select
                     value={watch('category')}
-                    onValueChange={(value) => setValue('category', value as EditTicketForm['category'])}
+                    onValueChange={(value) => setValue('category', value)}
                   >
                     <SelectTrigger className="h-11">
                       <SelectValue />
                     </SelectTrigger>
                     <SelectContent>
-                      <SelectItem value="ELECTRICAL">{t.ticket.categories.ELECTRICAL}</SelectItem>
-                      <SelectItem value="PLUMBING">{t.ticket.categories.PLUMBING}</SelectItem>
-                      <SelectItem value="HVAC">{t.ticket.categories.HVAC}</SelectItem>
-                      <SelectItem value="IT">{t.ticket.categories.IT}</SelectItem>
-                      <SelectItem value="GENERAL">{t.ticket.categories.GENERAL}</SelectItem>
-                      <SelectItem value="OTHER">{t.ticket.categories.OTHER}</SelectItem>
+                      {catalogOptions(categories, original.category).map((category) => (
+                        <SelectItem key={category.id} value={category.code}>
+                          {catalogName(category, locale)}
+                        </SelectItem>
+                      ))}
                     </SelectContent>
                   </Select>
                 </div>
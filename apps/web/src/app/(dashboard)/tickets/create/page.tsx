@@ -1,7 +1,8 @@
 'use client';
 
-import { useState } from 'react';
+import { useEffect, useState } from 'react';
 import { useRouter } from 'next/navigation';
+import { isAxiosError } from 'axios';
 import { useForm } from 'react-hook-form';
 import { zodResolver } from '@hookform/resolvers/zod';
 import { z } from 'zod';
@@ -22,6 +23,14 @@ import { Input } from '@/components/ui/input';
 import { Label } from '@/components/ui/label';
 import { Textarea } from '@/components/ui/textarea';
 import { Card, CardContent, CardDescription, CardHeader, CardTitle } from '@/components/ui/card';
+import {
+  Dialog,
+  DialogContent,
+  DialogDescription,
+  DialogFooter,
+  DialogHeader,
+  DialogTitle,
+} from '@/components/ui/dialog';
 import {
   Select,
   SelectContent,
@@ -32,23 +41,32 @@ import {
 import { toast } from 'sonner';
 import { apiPost } from '@/lib/api';
 import { useLanguage } from '@/components/providers/LanguageProvider';
+import type { DuplicateCandidate } from '@/types';
+import { useCatalogStore } from '@/stores';
+import { catalogName, catalogOptions } from '@/stores/catalogStore';
 
 const createTicketSchema = z.object({
   title: z.string().min(5, 'Title must be at least 5 characters'),
   description: z.string().min(10, 'Description must be at least 10 characters'),
-  priority: z.enum(['LOW', 'MEDIUM', 'HIGH', 'CRITICAL']),
-  category: z.enum(['ELECTRICAL', 'PLUMBING', 'HVAC', 'IT', 'GENERAL', 'OTHER']),
+  // Left unset so the API applies the category's default priority
+  priority: z.string().optional(),
+  category: z.string().min(1, 'Category is required'),
   location: z.string().min(2, 'Location is required'),
 });
 
 type CreateTicketForm = z.infer<typeof createTicketSchema>;
 
 export default function CreateTicketPage() {
-  const { t } = useLanguage();
+  const { t, locale } = useLanguage();
   const router = useRouter();
+  const { categories, fetchCatalog } = useCatalogStore();
   const [isSubmitting, setIsSubmitting] = useState(false);
   const [attachments, setAttachments] = useState<File[]>([]);
   const [dragActive, setDragActive] = useState(false);
+  // Set when the API reports likely duplicates; the form data waits here
+  // until the user comments on one of them or creates the ticket anyway.
+  const [duplicates, setDuplicates] = useState<DuplicateCandidate[]>([]);
+  const [pending, setPending] = useState<CreateTicketForm | null>(null);
 
   const {
     register,
@@ -59,11 +77,14 @@ export default function CreateTicketPage() {
   } = useForm<CreateTicketForm>({
     resolver: zodResolver(createTicketSchema),
     defaultValues: {
-      priority: 'MEDIUM',
       category: 'GENERAL',
     },
   });
 
+  useEffect(() => {
+    fetchCatalog();
+  }, [fetchCatalog]);
+
   const handleDrag = (e: React.DragEvent) => {
     e.preventDefault();
     e.stopPropagation();
@@ -93,22 +114,44 @@ export default function CreateTicketPage() {
     setAttachments((prev) => prev.filter((_, i) => i !== index));
   };
 
-  const onSubmit = async (data: CreateTicketForm) => {
+  const submitTicket = async (data: CreateTicketForm, options: { createAnyway?: boolean; duplicateOf?: string } = {}) => {
     setIsSubmitting(true);
     try {
       // In a real app, handle file uploads separately or as form data
       // For now, we'll just send the JSON data
-      await apiPost('/tickets', data);
-      
+      await apiPost('/tickets', { ...data, ...options });
+
+      setDuplicates([]);
+      setPending(null);
+      if (options.duplicateOf) {
+        toast.success(t.ticket.duplicates.commented);
+        router.push(`/tickets/${options.duplicateOf}`);
+        return;
+      }
       toast.success(t.common.success);
       router.push('/dashboard');
-    } catch {
+    } catch (error) {
+      const found = isAxiosError<{ duplicates?: DuplicateCandidate[] }>(error) && error.response?.status === 409
+        ? error.response.data.duplicates
+        : undefined;
+      if (found && found.length > 0) {
+        setDuplicates(found);
+        setPending(data);
+        return;
+      }
       toast.error(t.common.error);
     } finally {
       setIsSubmitting(false);
     }
   };
 
+  const onSubmit = (data: CreateTicketForm) => submitTicket(data);
+
+  const closeDuplicates = () => {
+    setDuplicates([]);
+    setPending(null);
+  };
+
   return (
     <div className="min-h-screen w-full bg-linear-to-br from-slate-50 to-slate-100 dark:from-background dark:to-muted/30 p-4 sm:p-8 flex items-center justify-center">
       <div className="w-full max-w-2xl space-y-6">
@@ -173,21 +216,23 @@ export default function CreateTicketPage() {
                     <Tag className="h-4 w-4 text-primary" /> {t.ticket.form.category}
                   </Label>
                   <Select
-                    defaultValue="GENERAL"
-                    onValueChange={(value) => setValue('category', value as CreateTicketForm['category'])}
+                    value={watch('category')}
+                    onValueChange={(value) => setValue('category', value)}
                   >
                     <SelectTrigger className="h-11 hover:border-primary/50 transition-all focus:ring-primary/20">
                       <SelectValue placeholder={t.ticket.form.categoryPlaceholder} />
                     </SelectTrigger>
                     <SelectContent>
-                      <SelectItem value="ELECTRICAL">{t.ticket.categories.ELECTRICAL}</SelectItem>
-                      <SelectItem value="PLUMBING">{t.ticket.categories.PLUMBING}</SelectItem>
-                      <SelectItem value="HVAC">{t.ticket.categories.HVAC}</SelectItem>
-                      <SelectItem value="IT">{t.ticket.categories.IT}</SelectItem>
-                      <SelectItem value="GENERAL">{t.ticket.categories.GENERAL}</SelectItem>
-                      <SelectItem value="OTHER">{t.ticket.categories.OTHER}</SelectItem>
+                      {catalogOptions(categories).map((category) => (
+                        <SelectItem key={category.id} value={category.code}>
+                          {catalogName(category, locale)}
+                        </SelectItem>
+                      ))}
                     </SelectContent>
                   </Select>
+                  {errors.category && (
+                    <p className="text-xs text-destructive font-medium animate-in slide-in-from-top-1">{errors.category.message}</p>
+                  )}
                 </div>
               </div>
 
@@ -290,6 +335,52 @@ export default function CreateTicketPage() {
             </form>
           </CardContent>
         </Card>
+
+        {/* Likely duplicates reported by the API */}
+        <Dialog open={pending !== null} onOpenChange={(open) => !open && closeDuplicates()}>
+          <DialogContent className="sm:max-w-lg">
+            <DialogHeader>
+              <DialogTitle>{t.ticket.duplicates.title}</DialogTitle>
+              <DialogDescription>{t.ticket.duplicates.description}</DialogDescription>
+            </DialogHeader>
+            <div className="space-y-3 max-h-80 overflow-y-auto">
+              {duplicates.map(({ ticket, score }) => (
+                <div key={ticket.id} className="border rounded-lg p-3 flex items-start justify-between gap-3">
+                  <div className="min-w-0">
+                    <Link href={`/tickets/${ticket.id}`} className="text-sm font-medium hover:underline truncate block">
+                      {ticket.title}
+                    </Link>
+                    <p className="text-xs text-muted-foreground">
+                      {t.ticket.statuses[ticket.status]}{ticket.location && ` · ${ticket.location}`} · {Math.round(score * 100)}% {t.ticket.duplicates.match}
+                    </p>
+                  </div>
+                  <Button
+                    type="button"
+                    variant="outline"
+                    size="sm"
+                    disabled={isSubmitting}
+                    onClick={() => pending && submitTicket(pending, { duplicateOf: ticket.id })}
+                  >
+                    {t.ticket.duplicates.commentInstead}
+                  </Button>
+                </div>
+              ))}
+            </div>
+            <DialogFooter>
+              <Button type="button" variant="ghost" onClick={closeDuplicates} disabled={isSubmitting}>
+                {t.common.cancel}
+              </Button>
+              <Button
+                type="button"
+                disabled={isSubmitting}
+                onClick={() => pending && submitTicket(pending, { createAnyway: true })}
+              >
+                {isSubmitting && <Loader2 className="mr-2 h-4 w-4 animate-spin" />}
+                {t.ticket.duplicates.createAnyway}
+              </Button>
+            </DialogFooter>
+          </DialogContent>
+        </Dialog>
       </div>
     </div>
   );
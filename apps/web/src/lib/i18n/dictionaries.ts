@@ -124,8 +124,17 @@ export const dictionaries = {
         OPEN: 'เปิดใหม่',
         PENDING: 'รอตรวจสอบ',
         IN_PROGRESS: 'กำลังดำเนินการ',
+        AWAITING_APPROVAL: 'รออนุมัติค่าใช้จ่าย',
         RESOLVED: 'เสร็จสิ้น',
         CLOSED: 'ปิดงาน',
+      },
+      duplicates: {
+        title: 'พบรายการแจ้งซ่อมที่คล้ายกัน',
+        description: 'ปัญหานี้อาจมีผู้แจ้งไว้แล้ว คุณสามารถเพิ่มรายละเอียดเป็นความคิดเห็นในรายการเดิม หรือสร้างรายการใหม่ต่อไป',
+        match: 'ความคล้าย',
+        commentInstead: 'เพิ่มเป็นความคิดเห็น',
+        createAnyway: 'สร้างรายการใหม่',
+        commented: 'เพิ่มความคิดเห็นในรายการเดิมแล้ว',
       }
     },
     ticketList: {
@@ -172,6 +181,7 @@ export const dictionaries = {
         USER: 'ผู้ใช้ทั่วไป',
         TECHNICIAN: 'ช่างเทคนิค',
         ADMIN: 'ผู้ดูแลระบบ',
+        APPROVER: 'ผู้อนุมัติ',
       },
     },
     settings: {
@@ -353,8 +363,17 @@ export const dictionaries = {
         OPEN: 'Open',
         PENDING: 'Pending',
         IN_PROGRESS: 'In Progress',
+        AWAITING_APPROVAL: 'Awaiting Approval',
         RESOLVED: 'Resolved',
         CLOSED: 'Closed',
+      },
+      duplicates: {
+        title: 'Similar requests already exist',
+        description: 'This issue may already be reported. Add your details as a comment on an existing request, or create a new one anyway.',
+        match: 'match',
+        commentInstead: 'Comment instead',
+        createAnyway: 'Create anyway',
+        commented: 'Your details were added to the existing request',
       }
     },
     ticketList: {
@@ -401,6 +420,7 @@ export const dictionaries = {
         USER: 'User',
         TECHNICIAN: 'Technician',
         ADMIN: 'Admin',
+        APPROVER: 'Approver',
       },
     },
     settings: {
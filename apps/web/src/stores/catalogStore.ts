@@ -0,0 +1,52 @@
+import { create } from 'zustand';
+import { Category, Priority } from '@/types';
+import { apiGet } from '@/lib/api';
+import type { Locale } from '@/lib/i18n/dictionaries';
+
+interface CatalogState {
+  // Admin-managed ticket categories and priorities, inactive ones included
+  categories: Category[];
+  priorities: Priority[];
+  isLoaded: boolean;
+  isLoading: boolean;
+
+  // Loads both lists once; later calls reuse them unless forced
+  fetchCatalog: (force?: boolean) => Promise<void>;
+}
+
+export const useCatalogStore = create<CatalogState>()((set, get) => ({
+  categories: [],
+  priorities: [],
+  isLoaded: false,
+  isLoading: false,
+
+  fetchCatalog: async (force = false) => {
+    const { isLoaded, isLoading } = get();
+    if ((isLoaded && !force) || isLoading) return;
+
+    set({ isLoading: true });
+    try {
+      const [categories, priorities] = await Promise.all([
+        apiGet<{ data: Category[] }>('/categories'),
+        apiGet<{ data: Priority[] }>('/priorities'),
+      ]);
+      set({
+        categories: [...categories.data].sort((a, b) => a.sortOrder - b.sortOrder),
+        priorities: [...priorities.data].sort((a, b) => a.rank - b.rank),
+        isLoaded: true,
+        isLoading: false,
+      });
+    } catch {
+      set({ isLoading: false });
+    }
+  },
+}));
+
+// Display name of a category or priority in the given locale
+export const catalogName = (item: Category | Priority, locale: Locale) =>
+  locale === 'th' && item.nameTh ? item.nameTh : item.nameEn;
+
+// Options for a select: the active entries, plus the current value so a
+// ticket filed under a since-deactivated entry keeps it
+export const catalogOptions = <T extends Category | Priority>(items: T[], current?: string) =>
+  items.filter((item) => item.active || item.code === current);
@@ -2,3 +2,4 @@ export { useAuthStore } from './authStore';
 export { useTicketStore } from './ticketStore';
 export { useNotificationStore } from './notificationStore';
 export { useUserStore } from './userStore';
+export { useCatalogStore } from './catalogStore';
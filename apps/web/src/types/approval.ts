@@ -0,0 +1,67 @@
+// Approval types
+import type { TicketStatus } from './ticket';
+import type { User, UserRole } from './user';
+
+export type ApproverType = 'USER' | 'ROLE' | 'DEPARTMENT_HEAD';
+export type ApprovalStatus = 'PENDING' | 'APPROVED' | 'REJECTED' | 'EXPIRED' | 'CANCELLED';
+export type ApprovalTaskStatus = 'WAITING' | 'PENDING' | 'APPROVED' | 'REJECTED' | 'CANCELLED';
+
+export interface ApprovalStep {
+  id: string;
+  workflowId: string;
+  stage: number;
+  name?: string;
+  approverType: ApproverType;
+  approverId?: string;
+  approverRole?: UserRole;
+  approver?: User;
+}
+
+export interface ApprovalWorkflow {
+  id: string;
+  name: string;
+  description?: string;
+  expiresAfterHours: number;
+  blockedStatuses: TicketStatus[];
+  active: boolean;
+  createdById: string;
+  steps?: ApprovalStep[];
+  createdAt: string;
+  updatedAt: string;
+}
+
+export interface ApprovalTask {
+  id: string;
+  requestId: string;
+  stage: number;
+  name?: string;
+  approverId?: string;
+  approverRole?: UserRole;
+  approverDepartment?: string;
+  status: ApprovalTaskStatus;
+  delegatedFromId?: string;
+  decidedById?: string;
+  decidedAt?: string;
+  comment?: string;
+  approver?: User;
+  decidedBy?: User;
+}
+
+export interface ApprovalRequest {
+  id: string;
+  workflowId: string;
+  ticketId?: string;
+  requesterId: string;
+  title: string;
+  description?: string;
+  status: ApprovalStatus;
+  stage: number;
+  blockedStatuses: TicketStatus[];
+  expiresAt?: string;
+  completedAt?: string;
+  workflow?: ApprovalWorkflow;
+  requester?: User;
+  tasks?: ApprovalTask[];
+  createdAt: string;
+  updatedAt: string;
+}
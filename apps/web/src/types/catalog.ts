@@ -0,0 +1,52 @@
+// Ticket category and priority types
+import type { TicketCategory, TicketPriority } from './ticket';
+import type { User } from './user';
+
+export interface Category {
+  id: string;
+  code: TicketCategory;
+  nameEn: string;
+  nameTh: string;
+  parentId?: string;
+  icon?: string; // lucide icon name
+  defaultPriority?: TicketPriority;
+  defaultAssigneeId?: string;
+  defaultAssignee?: User;
+  active: boolean; // inactive categories cannot be chosen for new tickets
+  sortOrder: number;
+  createdAt: string;
+  updatedAt: string;
+}
+
+export interface Priority {
+  id: string;
+  code: TicketPriority;
+  nameEn: string;
+  nameTh: string;
+  rank: number; // higher is more urgent
+  color?: string; // "#rrggbb"
+  slaPolicyId?: string; // used when no policy matches the category
+  active: boolean;
+  createdAt: string;
+  updatedAt: string;
+}
+
+export interface CreateCategoryInput {
+  code: string;
+  nameEn: string;
+  nameTh?: string;
+  parentId?: string;
+  icon?: string;
+  defaultPriority?: TicketPriority;
+  defaultAssigneeId?: string;
+  sortOrder?: number;
+}
+
+export interface CreatePriorityInput {
+  code: string;
+  nameEn: string;
+  nameTh?: string;
+  rank: number;
+  color?: string;
+  slaPolicyId?: string;
+}
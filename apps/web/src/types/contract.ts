@@ -0,0 +1,20 @@
+// Warranty and service contract types
+export type ContractType = 'WARRANTY' | 'SERVICE';
+
+export interface ServiceContract {
+  id: string;
+  assetId: string;
+  type: ContractType;
+  vendor: string;
+  contractNumber?: string;
+  coverage?: string;
+  startsAt: string;
+  endsAt: string;
+  contactName?: string;
+  contactPhone?: string;
+  contactEmail?: string;
+  notes?: string;
+  reminderSentAt?: string;
+  createdAt: string;
+  updatedAt: string;
+}
@@ -0,0 +1,22 @@
+// Custom field types
+import type { TicketCategory } from './ticket';
+
+export type CustomFieldType = 'TEXT' | 'NUMBER' | 'DATE' | 'SELECT' | 'MULTI_SELECT' | 'BOOLEAN' | 'USER';
+
+// Value of a custom field: DATE is "2026-03-31", USER is a user ID
+export type CustomFieldValue = string | number | boolean | string[];
+
+export interface CustomField {
+  id: string;
+  key: string; // fixed once created
+  label: string;
+  type: CustomFieldType; // fixed once created
+  description?: string;
+  categories: TicketCategory[]; // empty for every category
+  options: string[]; // choices of SELECT and MULTI_SELECT fields
+  required: boolean;
+  active: boolean;
+  sortOrder: number;
+  createdAt: string;
+  updatedAt: string;
+}
@@ -0,0 +1,30 @@
+// Department and budget types
+export interface Department {
+  id: string;
+  name: string;
+  costCenter?: string;
+  annualBudget: number;
+  monthlyBudget: number; // 0 spreads the annual budget evenly
+  createdAt: string;
+  updatedAt: string;
+}
+
+export interface MonthBudget {
+  month: string; // "2026-03"
+  budget: number;
+  actual: number;
+  remaining: number;
+  percentUsed: number | null; // null without a budget
+  tickets: number;
+}
+
+export interface DepartmentBudget {
+  department: Department;
+  year: number;
+  budget: number;
+  actual: number;
+  remaining: number;
+  percentUsed: number | null;
+  tickets: number;
+  months: MonthBudget[];
+}
@@ -2,3 +2,12 @@
 export * from './user';
 export * from './ticket';
 export * from './api';
+export * from './approval';
+export * from './meter';
+export * from './contract';
+export * from './vendor';
+export * from './purchase';
+export * from './department';
+export * from './tag';
+export * from './customField';
+export * from './catalog';
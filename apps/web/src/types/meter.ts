@@ -0,0 +1,55 @@
+// Meter types
+import type { TicketPriority } from './ticket';
+import type { User } from './user';
+
+export type MeterRuleType = 'THRESHOLD' | 'DELTA';
+export type MeterOperator = 'GT' | 'GTE' | 'LT' | 'LTE';
+
+export interface MeterRule {
+  id: string;
+  meterId: string;
+  name: string;
+  type: MeterRuleType;
+  operator?: MeterOperator;
+  // Limit of a THRESHOLD rule, interval of a DELTA rule
+  value: number;
+  baseline?: number;
+  active: boolean;
+  createdById: string;
+  ticketTitle?: string;
+  ticketDescription?: string;
+  ticketPriority: TicketPriority;
+  assigneeId?: string;
+  lastTicketId?: string;
+  lastTriggeredAt?: string;
+  createdAt: string;
+  updatedAt: string;
+}
+
+export interface Meter {
+  id: string;
+  assetId: string;
+  name: string;
+  unit?: string;
+  cumulative: boolean;
+  lastValue?: number;
+  lastReadAt?: string;
+  rules?: MeterRule[];
+  createdAt: string;
+  updatedAt: string;
+}
+
+export interface MeterReading {
+  id: string;
+  meterId: string;
+  value: number;
+  readAt: string;
+  recordedById: string;
+  recordedBy?: User;
+  createdAt: string;
+}
+
+export interface MeterImportResult {
+  imported: number;
+  errors: { line: number; error: string }[];
+}
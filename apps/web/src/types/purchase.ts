@@ -0,0 +1,48 @@
+// Purchase request types
+export type PurchaseStatus = 'DRAFT' | 'SUBMITTED' | 'APPROVED' | 'ORDERED' | 'RECEIVED' | 'CANCELLED';
+
+export interface PurchaseRequestLine {
+  id: string;
+  requestId: string;
+  position: number;
+  partId?: string;
+  part?: {
+    id: string;
+    sku: string;
+    name: string;
+    unit: string;
+  };
+  description: string;
+  quantity: number;
+  unit: string;
+  unitCost: number;
+}
+
+export interface PurchaseRequest {
+  id: string;
+  ticketId: string;
+  supplier?: string;
+  status: PurchaseStatus;
+  estimatedCost: number;
+  notes?: string;
+  orderReference?: string;
+  requestedById: string;
+  requestedBy?: {
+    id: string;
+    name: string;
+  };
+  submittedAt?: string;
+  approvedById?: string;
+  approvedBy?: {
+    id: string;
+    name: string;
+  };
+  approvedAt?: string;
+  orderedAt?: string;
+  receivedById?: string;
+  receivedAt?: string;
+  cancelledAt?: string;
+  lines?: PurchaseRequestLine[];
+  createdAt: string;
+  updatedAt: string;
+}
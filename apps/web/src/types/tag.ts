@@ -0,0 +1,29 @@
+// Tag types
+export interface Tag {
+  id: string;
+  name: string;
+  color: string; // "#rrggbb"
+  description?: string;
+  createdAt: string;
+  updatedAt: string;
+}
+
+export interface TagCount {
+  id: string;
+  name: string;
+  color: string;
+  tickets: number;
+}
+
+// Result of adding or removing tags on tickets
+export interface TagChange {
+  added: number;
+  removed: number;
+  tickets: number; // tickets whose tags changed
+}
+
+export interface BulkTagInput {
+  ticketIds: string[];
+  add?: string[];
+  remove?: string[];
+}
@@ -1,7 +1,16 @@
 // Ticket types
-export type TicketStatus = 'OPEN' | 'IN_PROGRESS' | 'PENDING' | 'RESOLVED' | 'CLOSED';
-export type TicketPriority = 'LOW' | 'MEDIUM' | 'HIGH' | 'CRITICAL';
-export type TicketCategory = 'ELECTRICAL' | 'PLUMBING' | 'HVAC' | 'IT' | 'GENERAL' | 'OTHER';
+import type { ServiceContract } from './contract';
+import type { Vendor } from './vendor';
+import type { Department } from './department';
+import type { Tag } from './tag';
+import type { CustomFieldValue } from './customField';
+
+export type CostApprovalStatus = 'PENDING' | 'APPROVED' | 'REJECTED';
+export type TicketStatus = 'OPEN' | 'IN_PROGRESS' | 'PENDING' | 'AWAITING_APPROVAL' | 'RESOLVED' | 'CLOSED';
+// Codes of the admin-defined priorities and categories (see catalog.ts);
+// the listed ones are the built-ins.
+export type TicketPriority = 'LOW' | 'MEDIUM' | 'HIGH' | 'CRITICAL' | (string & {});
+export type TicketCategory = 'ELECTRICAL' | 'PLUMBING' | 'HVAC' | 'IT' | 'GENERAL' | 'OTHER' | (string & {});
 
 export interface Ticket {
   id: string;
@@ -11,6 +20,7 @@ export interface Ticket {
   priority: TicketPriority;
   category: TicketCategory;
   location?: string;
+  locationId?: string;
   
   // Relations
   createdById: string;
@@ -26,18 +36,100 @@ export interface Ticket {
     email: string;
   };
   
+  // Preventive maintenance schedule that generated the ticket
+  scheduleId?: string;
+  
+  // Asset the ticket was raised against
+  assetId?: string;
+  vendorCost?: number;
+  partsCost?: number;
+  laborCost?: number;
+  totalCost?: number;
+  estimatedLaborCost?: number;
+  estimatedPartsCost?: number;
+  estimatedVendorCost?: number;
+  estimatedCost?: number;
+  costApproval?: CostApprovalStatus | '';
+  approvedCost?: number;
+  costDecidedById?: string;
+  costDecidedAt?: string;
+  costDecisionReason?: string;
+  
+  // Warranty or service contract covering the asset: call the vendor
+  contractId?: string;
+  contract?: ServiceContract;
+  
+  // Outside vendor the ticket is dispatched to
+  vendorId?: string;
+  vendor?: Vendor;
+  vendorAssignedAt?: string;
+  vendorRespondedAt?: string;
+  
+  // Department the costs are charged to (the requester's unless overridden)
+  departmentId?: string;
+  department?: Department;
+  
+  // Sub-tickets of a larger job
+  parentId?: string;
+  children?: Ticket[]; // with ?children=true
+  progress?: TicketProgress;
+  
+  // Set when closed as a duplicate of another ticket
+  mergedIntoId?: string;
+  
+  tags?: Tag[];
+  customFields: Record<string, CustomFieldValue>; // by field key
+  
+  // Reporter without an account (QR tag reports)
+  reporterName?: string;
+  reporterContact?: string;
+  
   // Attachments
   attachments?: Attachment[];
   
+  // Checklists copied from templates
+  checklists?: TicketChecklist[];
+  
   // SLA
   dueDate?: string;
+  dueDateManual: boolean; // set by hand; kept when the SLA policy changes
   resolvedAt?: string;
+  closedAt?: string;
+  slaPolicyId?: string;
+  responseDueAt?: string;
+  firstRespondedAt?: string;
+  slaPausedAt?: string;
+  responseBreached?: boolean;
+  resolutionBreached?: boolean;
   
   // Timestamps
   createdAt: string;
   updatedAt: string;
 }
 
+// Rollup of a ticket's direct sub-tickets
+export interface TicketProgress {
+  total: number;
+  resolved: number; // resolved or closed
+}
+
+// Links between tickets, read from the ticket they are listed on
+export type TicketLinkType = 'DUPLICATE_OF' | 'DUPLICATED_BY' | 'BLOCKS' | 'BLOCKED_BY' | 'RELATED_TO';
+
+export interface TicketLink {
+  id: string;
+  type: TicketLinkType;
+  ticket: Ticket; // the other ticket
+  createdById: string;
+  createdAt: string;
+}
+
+// Open ticket that a new ticket may repeat; score runs from 0 to 1
+export interface DuplicateCandidate {
+  ticket: Ticket;
+  score: number;
+}
+
 export interface Attachment {
   id: string;
   filename: string;
@@ -48,6 +140,60 @@ export interface Attachment {
   createdAt: string;
 }
 
+export type ChecklistItemType = 'CHECK' | 'READING' | 'PHOTO';
+
+export interface ChecklistTemplateItem {
+  id: string;
+  templateId: string;
+  position: number;
+  label: string;
+  type: ChecklistItemType;
+  required: boolean;
+  unit?: string;
+}
+
+export interface ChecklistTemplate {
+  id: string;
+  name: string;
+  description?: string;
+  category?: TicketCategory;
+  assetCategory?: TicketCategory;
+  active: boolean;
+  items?: ChecklistTemplateItem[];
+  createdAt: string;
+  updatedAt: string;
+}
+
+export interface TicketChecklistItem {
+  id: string;
+  checklistId: string;
+  position: number;
+  label: string;
+  type: ChecklistItemType;
+  required: boolean;
+  unit?: string;
+  done: boolean;
+  reading?: number;
+  attachmentId?: string;
+  attachment?: Attachment;
+  note?: string;
+  completedById?: string;
+  completedBy?: {
+    id: string;
+    name: string;
+  };
+  completedAt?: string;
+}
+
+export interface TicketChecklist {
+  id: string;
+  ticketId: string;
+  templateId?: string;
+  name: string;
+  items: TicketChecklistItem[];
+  createdAt: string;
+}
+
 export interface TicketComment {
   id: string;
   content: string;
@@ -58,6 +204,7 @@ export interface TicketComment {
     name: string;
     avatar?: string;
   };
+  vendorId?: string; // posted by the vendor through their link
   createdAt: string;
 }
 
@@ -79,9 +226,13 @@ export interface TicketLog {
 export interface CreateTicketInput {
   title: string;
   description: string;
-  priority: TicketPriority;
+  priority?: TicketPriority; // defaults to the category's priority
   category: TicketCategory;
   location?: string;
+  departmentId?: string;
+  createAnyway?: boolean; // skip the duplicate check
+  duplicateOf?: string; // comment on this ticket instead of creating one
+  customFields?: Record<string, CustomFieldValue>;
 }
 
 export interface UpdateTicketInput {
@@ -90,4 +241,9 @@ export interface UpdateTicketInput {
   status?: TicketStatus;
   priority?: TicketPriority;
   assignedToId?: string;
+  departmentId?: string;
+  parentId?: string;
+  customFields?: Record<string, CustomFieldValue | null>; // null clears a field
+  dueDate?: string;
+  recomputeDueDate?: boolean; // replace a hand-set due date with the SLA target
 }
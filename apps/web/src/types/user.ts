@@ -1,5 +1,5 @@
 // User types
-export type UserRole = 'USER' | 'TECHNICIAN' | 'ADMIN';
+export type UserRole = 'USER' | 'TECHNICIAN' | 'ADMIN' | 'APPROVER';
 
 export interface User {
   id: string;
@@ -9,6 +9,8 @@ export interface User {
   avatar?: string;
   phone?: string;
   department?: string;
+  departmentId?: string;
+  departmentHead?: boolean;
   createdAt: string;
   updatedAt: string;
 }
@@ -0,0 +1,74 @@
+// Vendor / external contractor types
+import type { Attachment, Ticket, TicketCategory, TicketPriority, TicketStatus } from './ticket';
+
+export interface VendorContact {
+  id: string;
+  vendorId: string;
+  name: string;
+  title?: string;
+  phone?: string;
+  email?: string;
+  primary: boolean;
+}
+
+export interface Vendor {
+  id: string;
+  name: string;
+  email?: string;
+  phone?: string;
+  address?: string;
+  categories: TicketCategory[];
+  notes?: string;
+  active: boolean;
+  contacts?: VendorContact[];
+  createdAt: string;
+  updatedAt: string;
+}
+
+export interface VendorDispatch {
+  ticket: Ticket;
+  url: string;
+  expiresAt: string;
+}
+
+export interface VendorStats {
+  vendorId: string;
+  name: string;
+  tickets: number;
+  resolved: number;
+  avgResponseHours: number | null;
+  avgResolutionHours: number | null;
+  totalCost: number;
+  averageCost: number;
+}
+
+// What a vendor sees through their ticket link
+export interface VendorComment {
+  author: string;
+  fromVendor: boolean;
+  content: string;
+  createdAt: string;
+}
+
+export interface VendorTicketView {
+  id: string;
+  title: string;
+  description: string;
+  status: TicketStatus;
+  priority: TicketPriority;
+  category: TicketCategory;
+  location?: string;
+  asset?: {
+    code: string;
+    name: string;
+    make?: string;
+    model?: string;
+    serialNumber?: string;
+  };
+  dueDate?: string;
+  createdAt: string;
+  vendor: string;
+  comments: VendorComment[];
+  attachments: Attachment[];
+  expiresAt: string;
+}
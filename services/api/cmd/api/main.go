@@ -1,8 +1,10 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/maintenance-system/api/internal/config"
@@ -10,6 +12,7 @@ import (
 	"github.com/maintenance-system/api/internal/handler"
 	"github.com/maintenance-system/api/internal/middleware"
 	"github.com/maintenance-system/api/internal/repository"
+	"github.com/maintenance-system/api/internal/scheduler"
 	"github.com/maintenance-system/api/internal/service"
 	"github.com/maintenance-system/api/internal/websocket"
 )
@@ -17,6 +20,12 @@ import (
 func main() {
 	// Load config
 	cfg := config.Load()
+	if cfg.AssetTagSecret == "" || cfg.AssetTagSecret == cfg.JWTSecret {
+		if !cfg.Development() {
+			log.Fatal("ASSET_TAG_SECRET must be set and differ from JWT_SECRET")
+		}
+		log.Println("WARNING: ASSET_TAG_SECRET is unset or equals JWT_SECRET; QR tags are not safe outside development")
+	}
 
 	// Connect to database
 	db, err := database.NewPostgresDB(cfg)
@@ -33,21 +42,170 @@ func main() {
 	ticketRepo := repository.NewTicketRepository(db)
 	commentRepo := repository.NewCommentRepository(db)
 	ticketLogRepo := repository.NewTicketLogRepository(db)
+	ticketLinkRepo := repository.NewTicketLinkRepository(db)
 	attachmentRepo := repository.NewAttachmentRepository(db)
+	slaPolicyRepo := repository.NewSLAPolicyRepository(db)
+	calendarRepo := repository.NewCalendarRepository(db)
+	approvalThresholdRepo := repository.NewApprovalThresholdRepository(db)
+	escalationRepo := repository.NewEscalationRepository(db)
+	scheduleRepo := repository.NewScheduleRepository(db)
+	assetRepo := repository.NewAssetRepository(db)
+	locationRepo := repository.NewLocationRepository(db)
+	inventoryRepo := repository.NewInventoryRepository(db)
+	notificationRepo := repository.NewNotificationRepository(db)
+	timeEntryRepo := repository.NewTimeEntryRepository(db)
+	approvalRepo := repository.NewApprovalRepository(db)
+	checklistRepo := repository.NewChecklistRepository(db)
+	contractRepo := repository.NewContractRepository(db)
+	meterRepo := repository.NewMeterRepository(db)
+	vendorRepo := repository.NewVendorRepository(db)
+	purchaseRepo := repository.NewPurchaseRepository(db)
+	departmentRepo := repository.NewDepartmentRepository(db)
+	tagRepo := repository.NewTagRepository(db)
+	customFieldRepo := repository.NewCustomFieldRepository(db)
+	categoryRepo := repository.NewCategoryRepository(db)
+	priorityRepo := repository.NewPriorityRepository(db)
+	transactor := repository.NewTransactor(db)
 
 	// Initialize services
 	authService := service.NewAuthService(userRepo, cfg)
-	ticketService := service.NewTicketService(ticketRepo, commentRepo, userRepo, ticketLogRepo, hub)
-	userService := service.NewUserService(userRepo)
+	calendarService := service.NewCalendarService(calendarRepo)
+	slaService := service.NewSLAService(slaPolicyRepo, calendarService)
+	categoryService := service.NewCategoryService(categoryRepo, priorityRepo, userRepo)
+	priorityService := service.NewPriorityService(priorityRepo, slaPolicyRepo)
+	costApprovalService := service.NewCostApprovalService(approvalThresholdRepo)
+	notificationService := service.NewNotificationService(notificationRepo, userRepo, hub)
+	approvalService := service.NewApprovalService(approvalRepo, ticketRepo, userRepo, transactor, notificationService, hub)
+	checklistService := service.NewChecklistService(checklistRepo, ticketRepo, assetRepo, attachmentRepo, transactor, hub)
+	contractService := service.NewContractService(contractRepo, assetRepo, notificationService, cfg.ContractReminderDays)
+	ticketService := service.NewTicketService(ticketRepo, commentRepo, userRepo, departmentRepo, ticketLogRepo, ticketLinkRepo, slaService, costApprovalService, approvalService, checklistService, contractService, categoryService, transactor, hub)
+	userService := service.NewUserService(userRepo, departmentRepo)
+	emailService := service.NewEmailService(cfg)
+	escalationService := service.NewEscalationService(escalationRepo, userRepo, priorityService, transactor, emailService, hub)
+	scheduleService := service.NewScheduleService(scheduleRepo, ticketService)
+	assetService := service.NewAssetService(assetRepo)
+	locationService := service.NewLocationService(locationRepo)
+	inventoryService := service.NewInventoryService(inventoryRepo, ticketRepo, transactor, notificationService)
+	timeService := service.NewTimeService(timeEntryRepo, ticketRepo, userRepo, transactor)
+	meterService := service.NewMeterService(meterRepo, assetRepo, userRepo, ticketService, transactor)
+	vendorService := service.NewVendorService(vendorRepo, ticketRepo, userRepo, ticketService, emailService, service.VendorLinkOptions{
+		BaseURL:   cfg.PublicBaseURL,
+		ValidDays: cfg.VendorLinkDays,
+	})
+	purchaseService := service.NewPurchaseService(purchaseRepo, ticketRepo, inventoryRepo, ticketService, transactor, notificationService)
+	departmentService := service.NewDepartmentService(departmentRepo, userRepo, notificationService)
+	duplicateService := service.NewDuplicateService(ticketRepo, cfg.DuplicateWindowDays)
+	tagService := service.NewTagService(tagRepo, ticketRepo, transactor, hub)
+	customFieldService := service.NewCustomFieldService(customFieldRepo, userRepo)
+	assetTagService := service.NewAssetTagService(assetRepo, userRepo, ticketService, duplicateService, customFieldService, service.LabelOptions{
+		BaseURL:  cfg.PublicBaseURL,
+		Secret:   cfg.AssetTagSecret,
+		FontPath: cfg.LabelFontPath,
+	})
+	captcha := service.NewCaptchaVerifier(cfg.CaptchaSecret, cfg.CaptchaVerifyURL)
+	// Without a captcha secret every token passes, so anonymous reports
+	// are only taken that way in development.
+	publicReports := cfg.CaptchaSecret != "" || cfg.Development()
+	if !publicReports {
+		log.Println("ERROR: CAPTCHA_SECRET is not set; public problem reports from asset tags are disabled")
+	}
 
 	// Initialize handlers
 	authHandler := handler.NewAuthHandler(authService)
-	ticketHandler := handler.NewTicketHandler(ticketService)
+	ticketHandler := handler.NewTicketHandler(ticketService, duplicateService, customFieldService)
 	userHandler := handler.NewUserHandler(userService)
-	attachmentHandler := handler.NewAttachmentHandler(attachmentRepo)
+	attachmentHandler := handler.NewAttachmentHandler(attachmentRepo, ticketService)
+	slaHandler := handler.NewSLAHandler(slaService)
+	calendarHandler := handler.NewCalendarHandler(calendarService)
+	costApprovalHandler := handler.NewCostApprovalHandler(costApprovalService)
+	escalationHandler := handler.NewEscalationHandler(escalationService)
+	scheduleHandler := handler.NewScheduleHandler(scheduleService)
+	assetHandler := handler.NewAssetHandler(assetService)
+	locationHandler := handler.NewLocationHandler(locationService)
+	assetTagHandler := handler.NewAssetTagHandler(assetTagService, captcha)
+	inventoryHandler := handler.NewInventoryHandler(inventoryService)
+	notificationHandler := handler.NewNotificationHandler(notificationService)
+	timeHandler := handler.NewTimeHandler(timeService)
+	approvalHandler := handler.NewApprovalHandler(approvalService)
+	checklistHandler := handler.NewChecklistHandler(checklistService)
+	meterHandler := handler.NewMeterHandler(meterService)
+	contractHandler := handler.NewContractHandler(contractService)
+	vendorHandler := handler.NewVendorHandler(vendorService, cfg.MaxUploadSize)
+	purchaseHandler := handler.NewPurchaseHandler(purchaseService)
+	departmentHandler := handler.NewDepartmentHandler(departmentService)
+	tagHandler := handler.NewTagHandler(tagService)
+	customFieldHandler := handler.NewCustomFieldHandler(customFieldService)
+	categoryHandler := handler.NewCategoryHandler(categoryService)
+	priorityHandler := handler.NewPriorityHandler(priorityService)
+	if err := handler.RegisterValidators(categoryService, priorityService); err != nil {
+		log.Fatalf("Failed to register validators: %v", err)
+	}
+
+	// Background jobs
+	jobs := scheduler.New(
+		scheduler.Job{
+			Name:     "escalation",
+			Interval: time.Duration(cfg.EscalationIntervalSeconds) * time.Second,
+			Run: func(ctx context.Context, now time.Time) error {
+				n, err := escalationService.Run(ctx, now)
+				if n > 0 {
+					log.Printf("Escalated %d tickets", n)
+				}
+				return err
+			},
+		},
+		scheduler.Job{
+			Name:     "schedules",
+			Interval: time.Duration(cfg.ScheduleIntervalSeconds) * time.Second,
+			Run: func(ctx context.Context, now time.Time) error {
+				n, err := scheduleService.Run(ctx, now)
+				if n > 0 {
+					log.Printf("Generated %d scheduled tickets", n)
+				}
+				return err
+			},
+		},
+		scheduler.Job{
+			Name:     "approvals",
+			Interval: time.Duration(cfg.ApprovalIntervalSeconds) * time.Second,
+			Run: func(ctx context.Context, now time.Time) error {
+				n, err := approvalService.ExpireDue(ctx, now)
+				if n > 0 {
+					log.Printf("Expired %d approval requests", n)
+				}
+				return err
+			},
+		},
+		scheduler.Job{
+			Name:     "contracts",
+			Interval: time.Duration(cfg.ContractIntervalSeconds) * time.Second,
+			Run: func(ctx context.Context, now time.Time) error {
+				n, err := contractService.RemindExpiring(ctx, now)
+				if n > 0 {
+					log.Printf("Sent reminders for %d expiring contracts", n)
+				}
+				return err
+			},
+		},
+		scheduler.Job{
+			Name:     "budgets",
+			Interval: time.Duration(cfg.BudgetIntervalSeconds) * time.Second,
+			Run: func(ctx context.Context, now time.Time) error {
+				n, err := departmentService.CheckBudgets(ctx, now)
+				if n > 0 {
+					log.Printf("Sent %d department budget alerts", n)
+				}
+				return err
+			},
+		},
+	)
+	jobs.Start(context.Background())
 
 	// Setup Gin router
 	r := gin.Default()
+	if err := r.SetTrustedProxies(cfg.TrustedProxies); err != nil {
+		log.Fatalf("Invalid TRUSTED_PROXIES: %v", err)
+	}
 
 	// Middleware
 	r.Use(middleware.CORSMiddleware(cfg))
@@ -81,6 +239,25 @@ func main() {
 			auth.PATCH("/profile", middleware.AuthMiddleware(cfg), authHandler.UpdateProfile)
 		}
 
+		// Asset tag routes (public; sign-in optional when reporting)
+		public := api.Group("/public")
+		{
+			public.GET("/assets/:token", assetTagHandler.Resolve)
+			if publicReports {
+				public.POST("/assets/:token/tickets",
+					middleware.RateLimit(cfg.PublicReportLimit, time.Hour),
+					middleware.OptionalAuth(cfg),
+					assetTagHandler.Report)
+			}
+
+			// Vendor ticket links
+			public.GET("/vendor/:token", vendorHandler.PortalTicket)
+			public.POST("/vendor/:token/comments", vendorHandler.PortalComment)
+			public.POST("/vendor/:token/attachments",
+				middleware.RateLimit(cfg.VendorUploadLimit, time.Hour),
+				vendorHandler.PortalAttachment)
+		}
+
 		// Protected routes
 		protected := api.Group("")
 		protected.Use(middleware.AuthMiddleware(cfg))
@@ -91,16 +268,322 @@ func main() {
 				tickets.POST("", ticketHandler.Create)
 				tickets.GET("", ticketHandler.GetAll)
 				tickets.GET("/stats", ticketHandler.GetStats) // Added stats endpoint
+				tickets.POST("/duplicates", ticketHandler.CheckDuplicates)
+				tickets.POST("/tags", middleware.RequireTechnician(), tagHandler.Bulk)
 				tickets.GET("/:id", ticketHandler.GetByID)
 				tickets.PATCH("/:id", ticketHandler.Update)
 				tickets.DELETE("/:id", ticketHandler.Delete)
 				tickets.POST("/:id/assign", middleware.RequireTechnician(), ticketHandler.Assign)
+				tickets.POST("/:id/cost-approval/approve", middleware.RequireApprover(), ticketHandler.ApproveCost)
+				tickets.POST("/:id/cost-approval/reject", middleware.RequireApprover(), ticketHandler.RejectCost)
+				tickets.GET("/:id/approvals", approvalHandler.GetByTicket)
+				tickets.GET("/:id/children", ticketHandler.GetChildren)
+				tickets.POST("/:id/children", middleware.RequireTechnician(), ticketHandler.CreateChild)
+				tickets.GET("/:id/links", ticketHandler.GetLinks)
+				tickets.POST("/:id/links", middleware.RequireTechnician(), ticketHandler.AddLink)
+				tickets.DELETE("/:id/links/:linkId", middleware.RequireTechnician(), ticketHandler.RemoveLink)
+				tickets.POST("/:id/merge", middleware.RequireTechnician(), ticketHandler.Merge)
+				tickets.POST("/:id/tags", middleware.RequireTechnician(), tagHandler.AddToTicket)
+				tickets.DELETE("/:id/tags/:tagId", middleware.RequireTechnician(), tagHandler.RemoveFromTicket)
 				tickets.POST("/:id/comments", ticketHandler.AddComment)
 				tickets.GET("/:id/comments", ticketHandler.GetComments)
 				tickets.GET("/:id/logs", ticketHandler.GetLogs)
 				tickets.POST("/:id/attachments", attachmentHandler.Upload)
 				tickets.GET("/:id/attachments", attachmentHandler.GetByTicketID)
 				tickets.DELETE("/:id/attachments/:attachmentId", attachmentHandler.Delete)
+				tickets.GET("/:id/parts", inventoryHandler.GetTicketParts)
+				tickets.POST("/:id/parts", middleware.RequireTechnician(), inventoryHandler.IssueToTicket)
+				tickets.POST("/:id/parts/return", middleware.RequireTechnician(), inventoryHandler.ReturnFromTicket)
+				tickets.GET("/:id/purchase-requests", purchaseHandler.GetByTicket)
+				tickets.POST("/:id/purchase-requests", middleware.RequireTechnician(), purchaseHandler.Create)
+				tickets.GET("/:id/time-entries", timeHandler.GetTicketTime)
+				tickets.POST("/:id/time-entries", middleware.RequireTechnician(), timeHandler.Create)
+				tickets.POST("/:id/time-entries/start", middleware.RequireTechnician(), timeHandler.Start)
+				tickets.GET("/:id/checklists", checklistHandler.GetTicketChecklists)
+				tickets.POST("/:id/checklists", middleware.RequireTechnician(), checklistHandler.AddToTicket)
+				tickets.DELETE("/:id/checklists/:checklistId", middleware.RequireTechnician(), checklistHandler.RemoveFromTicket)
+				tickets.PATCH("/:id/checklist-items/:itemId", middleware.RequireTechnician(), checklistHandler.UpdateItem)
+				tickets.POST("/:id/vendor", middleware.RequireTechnician(), vendorHandler.Dispatch)
+				tickets.DELETE("/:id/vendor-links", middleware.RequireTechnician(), vendorHandler.RevokeLinks)
+			}
+
+			// SLA policy routes (read for all, write for admins)
+			slaPolicies := protected.Group("/sla-policies")
+			{
+				slaPolicies.GET("", slaHandler.GetAll)
+				slaPolicies.GET("/:id", slaHandler.GetByID)
+				slaPolicies.POST("", middleware.RequireAdmin(), slaHandler.Create)
+				slaPolicies.PATCH("/:id", middleware.RequireAdmin(), slaHandler.Update)
+				slaPolicies.DELETE("/:id", middleware.RequireAdmin(), slaHandler.Delete)
+			}
+
+			// Working calendar routes (Admin only)
+			calendars := protected.Group("/calendars")
+			calendars.Use(middleware.RequireAdmin())
+			{
+				calendars.GET("", calendarHandler.GetAll)
+				calendars.POST("", calendarHandler.Create)
+				calendars.GET("/:id", calendarHandler.GetByID)
+				calendars.PATCH("/:id", calendarHandler.Update)
+				calendars.DELETE("/:id", calendarHandler.Delete)
+				calendars.PUT("/:id/hours", calendarHandler.SetHours)
+				calendars.POST("/:id/holidays", calendarHandler.AddHoliday)
+				calendars.POST("/:id/holidays/import", calendarHandler.ImportHolidays)
+				calendars.DELETE("/:id/holidays/:holidayId", calendarHandler.DeleteHoliday)
+				calendars.GET("/:id/business-time/add", calendarHandler.AddBusinessTime)
+				calendars.GET("/:id/business-time/elapsed", calendarHandler.BusinessTimeBetween)
+			}
+
+			// Cost approval threshold routes (Admin only)
+			approvalThresholds := protected.Group("/approval-thresholds")
+			approvalThresholds.Use(middleware.RequireAdmin())
+			{
+				approvalThresholds.GET("", costApprovalHandler.GetAll)
+				approvalThresholds.POST("", costApprovalHandler.Create)
+				approvalThresholds.GET("/:id", costApprovalHandler.GetByID)
+				approvalThresholds.PATCH("/:id", costApprovalHandler.Update)
+				approvalThresholds.DELETE("/:id", costApprovalHandler.Delete)
+			}
+
+			// Approval workflow routes (read for all, write for admins)
+			approvalWorkflows := protected.Group("/approval-workflows")
+			{
+				approvalWorkflows.GET("", approvalHandler.GetWorkflows)
+				approvalWorkflows.GET("/:id", approvalHandler.GetWorkflow)
+				approvalWorkflows.POST("", middleware.RequireAdmin(), approvalHandler.CreateWorkflow)
+				approvalWorkflows.PATCH("/:id", middleware.RequireAdmin(), approvalHandler.UpdateWorkflow)
+				approvalWorkflows.DELETE("/:id", middleware.RequireAdmin(), approvalHandler.DeleteWorkflow)
+			}
+
+			// Approval request routes
+			approvals := protected.Group("/approvals")
+			{
+				approvals.GET("", approvalHandler.GetAll)
+				approvals.POST("", approvalHandler.Create)
+				approvals.GET("/pending", approvalHandler.Pending)
+				approvals.GET("/:id", approvalHandler.GetByID)
+				approvals.POST("/:id/approve", approvalHandler.Approve)
+				approvals.POST("/:id/reject", approvalHandler.Reject)
+				approvals.POST("/:id/delegate", approvalHandler.Delegate)
+				approvals.POST("/:id/cancel", approvalHandler.Cancel)
+			}
+
+			// Checklist template routes (read for technicians, write for admins)
+			checklistTemplates := protected.Group("/checklist-templates")
+			checklistTemplates.Use(middleware.RequireTechnician())
+			{
+				checklistTemplates.GET("", checklistHandler.GetTemplates)
+				checklistTemplates.GET("/:id", checklistHandler.GetTemplate)
+				checklistTemplates.POST("", middleware.RequireAdmin(), checklistHandler.CreateTemplate)
+				checklistTemplates.PATCH("/:id", middleware.RequireAdmin(), checklistHandler.UpdateTemplate)
+				checklistTemplates.DELETE("/:id", middleware.RequireAdmin(), checklistHandler.DeleteTemplate)
+			}
+
+			// Escalation rule routes (Admin only)
+			escalationRules := protected.Group("/escalation-rules")
+			escalationRules.Use(middleware.RequireAdmin())
+			{
+				escalationRules.GET("", escalationHandler.GetAll)
+				escalationRules.POST("", escalationHandler.Create)
+				escalationRules.GET("/:id", escalationHandler.GetByID)
+				escalationRules.PATCH("/:id", escalationHandler.Update)
+				escalationRules.DELETE("/:id", escalationHandler.Delete)
+			}
+
+			// Asset routes (read for all, write for admins)
+			assets := protected.Group("/assets")
+			{
+				assets.GET("", assetHandler.GetAll)
+				assets.GET("/labels", middleware.RequireTechnician(), assetTagHandler.Labels)
+				assets.GET("/:id", assetHandler.GetByID)
+				assets.GET("/:id/history", assetHandler.GetHistory)
+				assets.GET("/:id/qr", middleware.RequireTechnician(), assetTagHandler.QRCode)
+				assets.GET("/:id/meters", meterHandler.GetByAsset)
+				assets.POST("/:id/meters", middleware.RequireAdmin(), meterHandler.Create)
+				assets.GET("/:id/contracts", contractHandler.GetByAsset)
+				assets.POST("", middleware.RequireAdmin(), assetHandler.Create)
+				assets.PATCH("/:id", middleware.RequireAdmin(), assetHandler.Update)
+				assets.DELETE("/:id", middleware.RequireAdmin(), assetHandler.Delete)
+			}
+
+			// Warranty and service contract routes (read for all, write for admins)
+			contracts := protected.Group("/contracts")
+			{
+				contracts.GET("", contractHandler.GetAll)
+				contracts.GET("/expiring", contractHandler.Expiring)
+				contracts.GET("/:id", contractHandler.GetByID)
+				contracts.POST("", middleware.RequireAdmin(), contractHandler.Create)
+				contracts.PATCH("/:id", middleware.RequireAdmin(), contractHandler.Update)
+				contracts.DELETE("/:id", middleware.RequireAdmin(), contractHandler.Delete)
+			}
+
+			// Vendor routes (read for technicians, write for admins)
+			vendors := protected.Group("/vendors")
+			vendors.Use(middleware.RequireTechnician())
+			{
+				vendors.GET("", vendorHandler.GetAll)
+				vendors.GET("/stats", vendorHandler.Stats)
+				vendors.GET("/:id", vendorHandler.GetByID)
+				vendors.POST("", middleware.RequireAdmin(), vendorHandler.Create)
+				vendors.PATCH("/:id", middleware.RequireAdmin(), vendorHandler.Update)
+				vendors.DELETE("/:id", middleware.RequireAdmin(), vendorHandler.Delete)
+			}
+
+			// Meter routes (readings from technicians, setup by admins)
+			meters := protected.Group("/meters")
+			meters.Use(middleware.RequireTechnician())
+			{
+				meters.POST("/readings/import", meterHandler.ImportReadings)
+				meters.GET("/:id", meterHandler.GetByID)
+				meters.PATCH("/:id", middleware.RequireAdmin(), meterHandler.Update)
+				meters.DELETE("/:id", middleware.RequireAdmin(), meterHandler.Delete)
+				meters.GET("/:id/readings", meterHandler.GetReadings)
+				meters.POST("/:id/readings", meterHandler.RecordReading)
+				meters.POST("/:id/rules", middleware.RequireAdmin(), meterHandler.CreateRule)
+				meters.PATCH("/:id/rules/:ruleId", middleware.RequireAdmin(), meterHandler.UpdateRule)
+				meters.DELETE("/:id/rules/:ruleId", middleware.RequireAdmin(), meterHandler.DeleteRule)
+			}
+
+			// Location routes (read for all, write for admins)
+			locations := protected.Group("/locations")
+			{
+				locations.GET("", locationHandler.GetAll)
+				locations.GET("/:id", locationHandler.GetByID)
+				locations.POST("", middleware.RequireAdmin(), locationHandler.Create)
+				locations.POST("/migrate-legacy", middleware.RequireAdmin(), locationHandler.MigrateLegacy)
+				locations.PATCH("/:id", middleware.RequireAdmin(), locationHandler.Update)
+				locations.DELETE("/:id", middleware.RequireAdmin(), locationHandler.Delete)
+			}
+
+			// Department routes (read for all, write for admins, budgets for approvers)
+			departments := protected.Group("/departments")
+			{
+				departments.GET("", departmentHandler.GetAll)
+				departments.GET("/budget", middleware.RequireApprover(), departmentHandler.BudgetReport)
+				departments.GET("/:id", departmentHandler.GetByID)
+				departments.GET("/:id/budget", middleware.RequireApprover(), departmentHandler.Budget)
+				departments.POST("", middleware.RequireAdmin(), departmentHandler.Create)
+				departments.PATCH("/:id", middleware.RequireAdmin(), departmentHandler.Update)
+				departments.DELETE("/:id", middleware.RequireAdmin(), departmentHandler.Delete)
+			}
+
+			// Tag routes (read for all, write for admins)
+			tags := protected.Group("/tags")
+			{
+				tags.GET("", tagHandler.GetAll)
+				tags.GET("/:id", tagHandler.GetByID)
+				tags.POST("", middleware.RequireAdmin(), tagHandler.Create)
+				tags.PATCH("/:id", middleware.RequireAdmin(), tagHandler.Update)
+				tags.DELETE("/:id", middleware.RequireAdmin(), tagHandler.Delete)
+				tags.POST("/:id/merge", middleware.RequireAdmin(), tagHandler.Merge)
+			}
+
+			// Custom field routes (read for all, write for admins)
+			customFields := protected.Group("/custom-fields")
+			{
+				customFields.GET("", customFieldHandler.GetAll)
+				customFields.GET("/:id", customFieldHandler.GetByID)
+				customFields.POST("", middleware.RequireAdmin(), customFieldHandler.Create)
+				customFields.PATCH("/:id", middleware.RequireAdmin(), customFieldHandler.Update)
+				customFields.DELETE("/:id", middleware.RequireAdmin(), customFieldHandler.Delete)
+			}
+
+			// Ticket category routes (read for all, write for admins)
+			categories := protected.Group("/categories")
+			{
+				categories.GET("", categoryHandler.GetAll)
+				categories.GET("/:id", categoryHandler.GetByID)
+				categories.POST("", middleware.RequireAdmin(), categoryHandler.Create)
+				categories.PATCH("/:id", middleware.RequireAdmin(), categoryHandler.Update)
+				categories.DELETE("/:id", middleware.RequireAdmin(), categoryHandler.Delete)
+			}
+
+			// Ticket priority routes (read for all, write for admins)
+			priorities := protected.Group("/priorities")
+			{
+				priorities.GET("", priorityHandler.GetAll)
+				priorities.GET("/:id", priorityHandler.GetByID)
+				priorities.POST("", middleware.RequireAdmin(), priorityHandler.Create)
+				priorities.PATCH("/:id", middleware.RequireAdmin(), priorityHandler.Update)
+				priorities.DELETE("/:id", middleware.RequireAdmin(), priorityHandler.Delete)
+			}
+
+			// Maintenance schedule routes (read for technicians, write for admins)
+			schedules := protected.Group("/schedules")
+			schedules.Use(middleware.RequireTechnician())
+			{
+				schedules.GET("", scheduleHandler.GetAll)
+				schedules.GET("/:id", scheduleHandler.GetByID)
+				schedules.GET("/:id/preview", scheduleHandler.Preview)
+				schedules.GET("/:id/occurrences", scheduleHandler.GetOccurrences)
+				schedules.POST("", middleware.RequireAdmin(), scheduleHandler.Create)
+				schedules.PATCH("/:id", middleware.RequireAdmin(), scheduleHandler.Update)
+				schedules.DELETE("/:id", middleware.RequireAdmin(), scheduleHandler.Delete)
+			}
+
+			// Spare part routes (technicians record stock, admins manage it)
+			parts := protected.Group("/parts")
+			parts.Use(middleware.RequireTechnician())
+			{
+				parts.GET("", inventoryHandler.GetParts)
+				parts.GET("/:id", inventoryHandler.GetPart)
+				parts.GET("/:id/movements", inventoryHandler.GetMovements)
+				parts.POST("/:id/receive", inventoryHandler.Receive)
+				parts.POST("/:id/adjust", middleware.RequireAdmin(), inventoryHandler.Adjust)
+				parts.POST("", middleware.RequireAdmin(), inventoryHandler.CreatePart)
+				parts.PATCH("/:id", middleware.RequireAdmin(), inventoryHandler.UpdatePart)
+				parts.DELETE("/:id", middleware.RequireAdmin(), inventoryHandler.DeletePart)
+			}
+
+			// Stock location routes (read for technicians, write for admins)
+			stockLocations := protected.Group("/stock-locations")
+			stockLocations.Use(middleware.RequireTechnician())
+			{
+				stockLocations.GET("", inventoryHandler.GetStockLocations)
+				stockLocations.POST("", middleware.RequireAdmin(), inventoryHandler.CreateStockLocation)
+				stockLocations.PATCH("/:id", middleware.RequireAdmin(), inventoryHandler.UpdateStockLocation)
+				stockLocations.DELETE("/:id", middleware.RequireAdmin(), inventoryHandler.DeleteStockLocation)
+			}
+
+			// Purchase request routes (technicians request, approvers approve)
+			purchaseRequests := protected.Group("/purchase-requests")
+			{
+				purchaseRequests.GET("", purchaseHandler.GetAll)
+				purchaseRequests.GET("/:id", purchaseHandler.GetByID)
+				purchaseRequests.PATCH("/:id", middleware.RequireTechnician(), purchaseHandler.Update)
+				purchaseRequests.DELETE("/:id", middleware.RequireTechnician(), purchaseHandler.Delete)
+				purchaseRequests.POST("/:id/submit", middleware.RequireTechnician(), purchaseHandler.Submit)
+				purchaseRequests.POST("/:id/approve", middleware.RequireApprover(), purchaseHandler.Approve)
+				purchaseRequests.POST("/:id/order", middleware.RequireTechnician(), purchaseHandler.Order)
+				purchaseRequests.POST("/:id/receive", middleware.RequireTechnician(), purchaseHandler.Receive)
+				purchaseRequests.POST("/:id/cancel", middleware.RequireTechnician(), purchaseHandler.Cancel)
+			}
+
+			// Time tracking routes (technicians track, admins correct)
+			timeEntries := protected.Group("/time-entries")
+			timeEntries.Use(middleware.RequireTechnician())
+			{
+				timeEntries.GET("/current", timeHandler.GetCurrent)
+				timeEntries.POST("/current/stop", timeHandler.Stop)
+				timeEntries.GET("/summary", middleware.RequireAdmin(), timeHandler.Summary)
+				timeEntries.PATCH("/:id", middleware.RequireAdmin(), timeHandler.Correct)
+				timeEntries.DELETE("/:id", middleware.RequireAdmin(), timeHandler.Delete)
+			}
+
+			// Labor rate routes (Admin only)
+			laborRates := protected.Group("/labor-rates")
+			laborRates.Use(middleware.RequireAdmin())
+			{
+				laborRates.GET("", timeHandler.GetRates)
+				laborRates.PUT("/:role", timeHandler.SetRate)
+			}
+
+			// Notification routes (the current user's own)
+			notifications := protected.Group("/notifications")
+			{
+				notifications.GET("", notificationHandler.GetAll)
+				notifications.PATCH("/:id/read", notificationHandler.MarkAsRead)
+				notifications.POST("/read-all", notificationHandler.MarkAllAsRead)
 			}
 
 			// User routes (Admin only)
@@ -4,6 +4,7 @@ import (
 	"log"
 	"os"
 	"strconv"
+	"strings"
 
 	"github.com/joho/godotenv"
 )
@@ -12,6 +13,8 @@ type Config struct {
 	// Server
 	ServerPort string
 	ServerHost string
+	// "development" relaxes checks that protect public endpoints
+	Environment string
 
 	// Database
 	DBHost     string
@@ -34,6 +37,10 @@ type Config struct {
 	// CORS
 	AllowedOrigins []string
 
+	// Proxies whose X-Forwarded-For is believed; none by default, so rate
+	// limits key on the connecting address
+	TrustedProxies []string
+
 	// Upload
 	MaxUploadSize int64
 	UploadDir     string
@@ -44,6 +51,32 @@ type Config struct {
 	SMTPUser     string
 	SMTPPassword string
 	SMTPFrom     string
+
+	// Public problem reports from asset QR tags
+	PublicBaseURL     string
+	AssetTagSecret    string
+	CaptchaSecret     string
+	CaptchaVerifyURL  string
+	PublicReportLimit int
+	LabelFontPath     string
+
+	// Background jobs
+	EscalationIntervalSeconds int
+	ScheduleIntervalSeconds   int
+	ApprovalIntervalSeconds   int
+	ContractIntervalSeconds   int
+	BudgetIntervalSeconds     int
+
+	// Days before a contract ends that admins are reminded (0 disables)
+	ContractReminderDays int
+
+	// Days a vendor's ticket link stays valid
+	VendorLinkDays int
+	// Uploads allowed per client IP per hour through vendor links
+	VendorUploadLimit int
+
+	// Days back that new tickets are checked for duplicates (0 disables)
+	DuplicateWindowDays int
 }
 
 func Load() *Config {
@@ -56,6 +89,8 @@ func Load() *Config {
 		ServerPort: getEnv("SERVER_PORT", "8080"),
 		ServerHost: getEnv("SERVER_HOST", "0.0.0.0"),
 
+		Environment: getEnv("APP_ENV", "production"),
+
 		// Database
 		DBHost:     getEnv("DB_HOST", "localhost"),
 		DBPort:     getEnv("DB_PORT", "5432"),
@@ -80,6 +115,8 @@ func Load() *Config {
 			"http://127.0.0.1:3000",
 		},
 
+		TrustedProxies: getEnvAsList("TRUSTED_PROXIES"),
+
 		// Upload
 		MaxUploadSize: 10 * 1024 * 1024, // 10MB
 		UploadDir:     getEnv("UPLOAD_DIR", "./uploads"),
@@ -90,9 +127,36 @@ func Load() *Config {
 		SMTPUser:     getEnv("SMTP_USER", ""),
 		SMTPPassword: getEnv("SMTP_PASSWORD", ""),
 		SMTPFrom:     getEnv("SMTP_FROM", "noreply@maintenance-system.local"),
+
+		// Public problem reports from asset QR tags
+		PublicBaseURL:     getEnv("PUBLIC_BASE_URL", "http://localhost:3000"),
+		AssetTagSecret:    getEnv("ASSET_TAG_SECRET", ""),
+		CaptchaSecret:     getEnv("CAPTCHA_SECRET", ""),
+		CaptchaVerifyURL:  getEnv("CAPTCHA_VERIFY_URL", "https://challenges.cloudflare.com/turnstile/v0/siteverify"),
+		PublicReportLimit: getEnvAsInt("PUBLIC_REPORT_LIMIT", 10),
+		LabelFontPath:     getEnv("LABEL_FONT_PATH", ""),
+
+		// Background jobs (0 disables a job)
+		EscalationIntervalSeconds: getEnvAsInt("ESCALATION_INTERVAL_SECONDS", 60),
+		ScheduleIntervalSeconds:   getEnvAsInt("SCHEDULE_INTERVAL_SECONDS", 300),
+		ApprovalIntervalSeconds:   getEnvAsInt("APPROVAL_INTERVAL_SECONDS", 300),
+		ContractIntervalSeconds:   getEnvAsInt("CONTRACT_INTERVAL_SECONDS", 3600),
+		BudgetIntervalSeconds:     getEnvAsInt("BUDGET_INTERVAL_SECONDS", 3600),
+
+		ContractReminderDays: getEnvAsInt("CONTRACT_REMINDER_DAYS", 30),
+
+		VendorLinkDays:    getEnvAsInt("VENDOR_LINK_DAYS", 30),
+		VendorUploadLimit: getEnvAsInt("VENDOR_UPLOAD_LIMIT", 30),
+
+		DuplicateWindowDays: getEnvAsInt("DUPLICATE_WINDOW_DAYS", 14),
 	}
 }
 
+// Development reports whether the API runs in a development setup.
+func (c *Config) Development() bool {
+	return c.Environment == "development"
+}
+
 func getEnv(key, defaultValue string) string {
 	if value := os.Getenv(key); value != "" {
 		return value
@@ -100,6 +164,16 @@ func getEnv(key, defaultValue string) string {
 	return defaultValue
 }
 
+func getEnvAsList(key string) []string {
+	var list []string
+	for _, value := range strings.Split(os.Getenv(key), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			list = append(list, value)
+		}
+	}
+	return list
+}
+
 func getEnvAsInt(key string, defaultValue int) int {
 	if value := os.Getenv(key); value != "" {
 		if intVal, err := strconv.Atoi(value); err == nil {
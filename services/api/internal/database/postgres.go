@@ -24,18 +24,186 @@ func NewPostgresDB(cfg *config.Config) (*gorm.DB, error) {
 		return nil, fmt.Errorf("failed to connect to database: %w", err)
 	}
 
+	if err := renameColumns(db); err != nil {
+		return nil, fmt.Errorf("failed to migrate database: %w", err)
+	}
+
 	// Auto-migrate models
 	if err := db.AutoMigrate(
+		&domain.Department{},
 		&domain.User{},
+		&domain.Location{},
+		&domain.Asset{},
+		&domain.Tag{},
+		&domain.CustomField{},
 		&domain.Ticket{},
 		&domain.Comment{},
 		&domain.TicketLog{},
 		&domain.Attachment{},
 		&domain.Notification{},
+		&domain.WorkingCalendar{},
+		&domain.WorkingHours{},
+		&domain.Holiday{},
+		&domain.SLAPolicy{},
+		&domain.EscalationRule{},
+		&domain.TicketEscalation{},
+		&domain.MaintenanceSchedule{},
+		&domain.ScheduleOccurrence{},
+		&domain.Part{},
+		&domain.StockLocation{},
+		&domain.StockLevel{},
+		&domain.StockMovement{},
+		&domain.TimeEntry{},
+		&domain.LaborRate{},
+		&domain.ApprovalThreshold{},
+		&domain.ApprovalWorkflow{},
+		&domain.ApprovalStep{},
+		&domain.ApprovalRequest{},
+		&domain.ApprovalTask{},
+		&domain.ChecklistTemplate{},
+		&domain.ChecklistTemplateItem{},
+		&domain.TicketChecklist{},
+		&domain.TicketChecklistItem{},
+		&domain.Meter{},
+		&domain.MeterReading{},
+		&domain.MeterRule{},
+		&domain.ServiceContract{},
+		&domain.Vendor{},
+		&domain.VendorContact{},
+		&domain.VendorAccess{},
+		&domain.PurchaseRequest{},
+		&domain.PurchaseRequestLine{},
+		&domain.BudgetAlert{},
+		&domain.TicketLink{},
+		&domain.Category{},
+		&domain.Priority{},
 	); err != nil {
 		return nil, fmt.Errorf("failed to migrate database: %w", err)
 	}
 
+	if err := linkDepartments(db); err != nil {
+		return nil, fmt.Errorf("failed to migrate database: %w", err)
+	}
+
+	if err := migrateTicketTypes(db); err != nil {
+		return nil, fmt.Errorf("failed to migrate database: %w", err)
+	}
+
+	if err := seed(db); err != nil {
+		return nil, fmt.Errorf("failed to seed database: %w", err)
+	}
+
 	log.Println("Database connected and migrated successfully")
 	return db, nil
 }
+
+// renameColumns carries data over for columns whose field was renamed,
+// which AutoMigrate would otherwise add as new, empty columns.
+func renameColumns(db *gorm.DB) error {
+	renames := []struct {
+		model    interface{}
+		from, to string
+	}{
+		{&domain.Ticket{}, "actual_cost", "vendor_cost"},
+	}
+
+	m := db.Migrator()
+	for _, r := range renames {
+		if m.HasColumn(r.model, r.from) && !m.HasColumn(r.model, r.to) {
+			if err := m.RenameColumn(r.model, r.from, r.to); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// linkDepartments turns the free-text departments of users into department
+// records the first time departments are migrated, links the users to them
+// and charges existing tickets to their requester's department.
+func linkDepartments(db *gorm.DB) error {
+	var count int64
+	if err := db.Model(&domain.Department{}).Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return nil
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		statements := []string{
+			`INSERT INTO departments (name, created_at, updated_at)
+				SELECT DISTINCT department, NOW(), NOW() FROM users WHERE department <> ''
+				ON CONFLICT DO NOTHING`,
+			`UPDATE users SET department_id = d.id FROM departments d
+				WHERE users.department_id IS NULL AND users.department = d.name`,
+			`UPDATE tickets SET department_id = u.department_id FROM users u
+				WHERE tickets.department_id IS NULL AND tickets.created_by_id = u.id`,
+		}
+		for _, statement := range statements {
+			if err := tx.Exec(statement).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// migrateTicketTypes fills the category and priority tables the first time
+// they are migrated: the built-in categories and priorities, plus any other
+// code already stored on tickets or settings so existing values stay valid.
+func migrateTicketTypes(db *gorm.DB) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		var count int64
+		if err := tx.Model(&domain.Category{}).Count(&count).Error; err != nil {
+			return err
+		}
+		if count == 0 {
+			categories := append([]domain.Category(nil), defaultCategories...)
+			if err := tx.Create(&categories).Error; err != nil {
+				return err
+			}
+			if err := tx.Exec(`INSERT INTO ticket_categories (code, name_en, created_at, updated_at)
+				SELECT code, code, NOW(), NOW() FROM (
+					SELECT category AS code FROM tickets
+					UNION SELECT category FROM sla_policies
+					UNION SELECT category FROM escalation_rules
+					UNION SELECT category FROM approval_thresholds
+					UNION SELECT ticket_category FROM maintenance_schedules
+					UNION SELECT category FROM assets
+					UNION SELECT category FROM checklist_templates
+					UNION SELECT asset_category FROM checklist_templates
+					UNION SELECT jsonb_array_elements_text(categories) FROM vendors
+					UNION SELECT jsonb_array_elements_text(categories) FROM custom_fields
+				) used WHERE code <> ''
+				ON CONFLICT DO NOTHING`).Error; err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Model(&domain.Priority{}).Count(&count).Error; err != nil {
+			return err
+		}
+		if count == 0 {
+			priorities := append([]domain.Priority(nil), defaultPriorities...)
+			if err := tx.Create(&priorities).Error; err != nil {
+				return err
+			}
+			// Unknown priorities rank above the built-in ones until an
+			// admin places them.
+			if err := tx.Exec(`INSERT INTO ticket_priorities (code, name_en, rank, created_at, updated_at)
+				SELECT code, code, (SELECT MAX(rank) FROM ticket_priorities) + ROW_NUMBER() OVER (ORDER BY code), NOW(), NOW()
+				FROM (
+					SELECT priority AS code FROM tickets
+					UNION SELECT priority FROM sla_policies
+					UNION SELECT priority FROM escalation_rules
+					UNION SELECT ticket_priority FROM maintenance_schedules
+					UNION SELECT ticket_priority FROM meter_rules
+				) used
+				WHERE code <> '' AND code NOT IN (SELECT code FROM ticket_priorities)`).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,104 @@
+package database
+
+import (
+	"time"
+
+	"github.com/maintenance-system/api/internal/domain"
+	"gorm.io/gorm"
+)
+
+// defaultCalendar is the technicians' week: Monday to Saturday,
+// 08:00-17:00 Bangkok time.
+func defaultCalendar() domain.WorkingCalendar {
+	cal := domain.WorkingCalendar{Name: "Default", Timezone: "Asia/Bangkok", IsDefault: true}
+	for d := time.Monday; d <= time.Saturday; d++ {
+		cal.Hours = append(cal.Hours, domain.WorkingHours{Weekday: d, StartTime: "08:00", EndTime: "17:00"})
+	}
+	return cal
+}
+
+// defaultSLAPolicies are installed on first start so new tickets get
+// deadlines before an admin has configured anything.
+var defaultSLAPolicies = []domain.SLAPolicy{
+	{Name: "Critical", Priority: domain.PriorityCritical, ResponseMinutes: 30, ResolutionMinutes: 4 * 60},
+	{Name: "Critical electrical", Priority: domain.PriorityCritical, Category: domain.CategoryElectrical, ResponseMinutes: 15, ResolutionMinutes: 2 * 60},
+	{Name: "High", Priority: domain.PriorityHigh, ResponseMinutes: 60, ResolutionMinutes: 8 * 60},
+	{Name: "Medium", Priority: domain.PriorityMedium, ResponseMinutes: 4 * 60, ResolutionMinutes: 24 * 60},
+	{Name: "Low", Priority: domain.PriorityLow, ResponseMinutes: 8 * 60, ResolutionMinutes: 72 * 60},
+	{Name: "Low other", Priority: domain.PriorityLow, Category: domain.CategoryOther, ResponseMinutes: 24 * 60, ResolutionMinutes: 7 * 24 * 60},
+}
+
+// defaultCategories and defaultPriorities are the ticket categories and
+// priorities that used to be fixed in code, installed on first start.
+var defaultCategories = []domain.Category{
+	{Code: domain.CategoryElectrical, NameEN: "Electrical", NameTH: "ไฟฟ้า", Icon: "zap", Active: true, SortOrder: 1},
+	{Code: domain.CategoryPlumbing, NameEN: "Plumbing", NameTH: "ประปา", Icon: "droplets", Active: true, SortOrder: 2},
+	{Code: domain.CategoryHVAC, NameEN: "HVAC", NameTH: "แอร์/เครื่องปรับอากาศ", Icon: "wind", Active: true, SortOrder: 3},
+	{Code: domain.CategoryIT, NameEN: "IT Support", NameTH: "ไอที/คอมพิวเตอร์", Icon: "monitor", Active: true, SortOrder: 4},
+	{Code: domain.CategoryGeneral, NameEN: "General Maintenance", NameTH: "ทั่วไป", Icon: "wrench", Active: true, SortOrder: 5},
+	{Code: domain.CategoryOther, NameEN: "Other", NameTH: "อื่นๆ", Icon: "circle-help", Active: true, SortOrder: 6},
+}
+
+var defaultPriorities = []domain.Priority{
+	{Code: domain.PriorityLow, NameEN: "Low", NameTH: "ต่ำ", Rank: 1, Color: "#6b7280", Active: true},
+	{Code: domain.PriorityMedium, NameEN: "Medium", NameTH: "ปานกลาง", Rank: 2, Color: "#3b82f6", Active: true},
+	{Code: domain.PriorityHigh, NameEN: "High", NameTH: "สูง", Rank: 3, Color: "#f97316", Active: true},
+	{Code: domain.PriorityCritical, NameEN: "Critical", NameTH: "ด่วนมาก", Rank: 4, Color: "#ef4444", Active: true},
+}
+
+func seed(db *gorm.DB) error {
+	var count int64
+	if err := db.Model(&domain.WorkingCalendar{}).Count(&count).Error; err != nil {
+		return err
+	}
+	if count == 0 {
+		cal := defaultCalendar()
+		if err := db.Create(&cal).Error; err != nil {
+			return err
+		}
+	}
+
+	if err := db.Unscoped().Model(&domain.SLAPolicy{}).Count(&count).Error; err != nil {
+		return err
+	}
+	if count == 0 {
+		policies := append([]domain.SLAPolicy(nil), defaultSLAPolicies...)
+		if err := db.Create(&policies).Error; err != nil {
+			return err
+		}
+	}
+
+	if err := db.Model(&domain.User{}).Where("email = ?", domain.PublicReporterEmail).Count(&count).Error; err != nil {
+		return err
+	}
+	if count == 0 {
+		// "!" is not a bcrypt hash, so nobody can log in as this user.
+		reporter := domain.User{
+			Email:    domain.PublicReporterEmail,
+			Password: "!",
+			Name:     "Public reporter",
+			Role:     domain.RoleUser,
+			Status:   domain.StatusInactive,
+		}
+		if err := db.Create(&reporter).Error; err != nil {
+			return err
+		}
+	}
+
+	if err := db.Model(&domain.User{}).Where("email = ?", domain.VendorPortalEmail).Count(&count).Error; err != nil {
+		return err
+	}
+	if count == 0 {
+		portal := domain.User{
+			Email:    domain.VendorPortalEmail,
+			Password: "!",
+			Name:     "Vendor portal",
+			Role:     domain.RoleUser,
+			Status:   domain.StatusInactive,
+		}
+		if err := db.Create(&portal).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
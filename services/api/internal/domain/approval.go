@@ -0,0 +1,194 @@
+package domain
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ApproverType string
+
+const (
+	ApproverUser           ApproverType = "USER"
+	ApproverRole           ApproverType = "ROLE"
+	ApproverDepartmentHead ApproverType = "DEPARTMENT_HEAD" // of the requester's department
+)
+
+type ApprovalStatus string
+
+const (
+	ApprovalPending   ApprovalStatus = "PENDING"
+	ApprovalApproved  ApprovalStatus = "APPROVED"
+	ApprovalRejected  ApprovalStatus = "REJECTED"
+	ApprovalExpired   ApprovalStatus = "EXPIRED"
+	ApprovalCancelled ApprovalStatus = "CANCELLED"
+)
+
+type ApprovalTaskStatus string
+
+const (
+	// TaskWaiting belongs to a stage that has not started yet.
+	TaskWaiting   ApprovalTaskStatus = "WAITING"
+	TaskPending   ApprovalTaskStatus = "PENDING"
+	TaskApproved  ApprovalTaskStatus = "APPROVED"
+	TaskRejected  ApprovalTaskStatus = "REJECTED"
+	TaskCancelled ApprovalTaskStatus = "CANCELLED" // the request finished without it
+)
+
+// TicketStatuses is a list of ticket statuses stored in a jsonb column.
+type TicketStatuses []TicketStatus
+
+func (TicketStatuses) GormDataType() string {
+	return "jsonb"
+}
+
+func (s TicketStatuses) Value() (driver.Value, error) {
+	if s == nil {
+		return "[]", nil
+	}
+	b, err := json.Marshal(s)
+	return string(b), err
+}
+
+func (s *TicketStatuses) Scan(value interface{}) error {
+	var b []byte
+	switch v := value.(type) {
+	case nil:
+		*s = nil
+		return nil
+	case []byte:
+		b = v
+	case string:
+		b = []byte(v)
+	default:
+		return fmt.Errorf("unsupported TicketStatuses source %T", value)
+	}
+	return json.Unmarshal(b, s)
+}
+
+// Contains reports whether status is in the list.
+func (s TicketStatuses) Contains(status TicketStatus) bool {
+	for _, st := range s {
+		if st == status {
+			return true
+		}
+	}
+	return false
+}
+
+// ApprovalWorkflow defines who signs off a kind of request, such as
+// replacing an asset or calling in an external contractor.
+type ApprovalWorkflow struct {
+	ID          uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	Name        string    `gorm:"uniqueIndex;not null" json:"name"`
+	Description string    `json:"description,omitempty"`
+	// ExpiresAfterHours is how long a request may wait for a decision; 0
+	// means it never expires.
+	ExpiresAfterHours int `gorm:"default:0" json:"expiresAfterHours"`
+	// BlockedStatuses are the statuses a ticket cannot move to while one of
+	// its requests for this workflow is pending.
+	BlockedStatuses TicketStatuses `json:"blockedStatuses"`
+	Active          bool           `gorm:"default:true" json:"active"`
+	CreatedByID     uuid.UUID      `gorm:"type:uuid;not null" json:"createdById"`
+	CreatedAt       time.Time      `json:"createdAt"`
+	UpdatedAt       time.Time      `json:"updatedAt"`
+
+	// Relations
+	Steps []ApprovalStep `gorm:"foreignKey:WorkflowID;constraint:OnDelete:CASCADE" json:"steps,omitempty"`
+}
+
+func (ApprovalWorkflow) TableName() string {
+	return "approval_workflows"
+}
+
+// ApprovalStep is one approver in a workflow. Stages run in order; the
+// steps of a stage run in parallel and all must approve before the next
+// stage starts.
+type ApprovalStep struct {
+	ID           uuid.UUID    `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	WorkflowID   uuid.UUID    `gorm:"type:uuid;not null;index" json:"workflowId"`
+	Stage        int          `gorm:"not null" json:"stage"`
+	Name         string       `json:"name,omitempty"`
+	ApproverType ApproverType `gorm:"type:varchar(20);not null" json:"approverType"`
+	ApproverID   *uuid.UUID   `gorm:"type:uuid" json:"approverId,omitempty"`                     // for USER
+	ApproverRole UserRole     `gorm:"type:varchar(20);default:''" json:"approverRole,omitempty"` // for ROLE
+
+	// Relations
+	Approver *User `gorm:"foreignKey:ApproverID" json:"approver,omitempty"`
+}
+
+func (ApprovalStep) TableName() string {
+	return "approval_steps"
+}
+
+// ApprovalRequest is one run of a workflow, optionally attached to a
+// ticket. The workflow's steps are copied into tasks when it is created.
+type ApprovalRequest struct {
+	ID          uuid.UUID      `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	WorkflowID  uuid.UUID      `gorm:"type:uuid;not null;index" json:"workflowId"`
+	TicketID    *uuid.UUID     `gorm:"type:uuid;index" json:"ticketId,omitempty"`
+	RequesterID uuid.UUID      `gorm:"type:uuid;not null;index" json:"requesterId"`
+	Title       string         `gorm:"not null" json:"title"`
+	Description string         `gorm:"type:text" json:"description,omitempty"`
+	Status      ApprovalStatus `gorm:"type:varchar(20);not null;index" json:"status"`
+	// Stage is the stage currently waiting for decisions.
+	Stage           int            `gorm:"not null" json:"stage"`
+	BlockedStatuses TicketStatuses `json:"blockedStatuses"`
+	ExpiresAt       *time.Time     `gorm:"index" json:"expiresAt,omitempty"`
+	CompletedAt     *time.Time     `json:"completedAt,omitempty"`
+	CreatedAt       time.Time      `json:"createdAt"`
+	UpdatedAt       time.Time      `json:"updatedAt"`
+
+	// Relations
+	Workflow  *ApprovalWorkflow `gorm:"foreignKey:WorkflowID" json:"workflow,omitempty"`
+	Requester *User             `gorm:"foreignKey:RequesterID" json:"requester,omitempty"`
+	Tasks     []ApprovalTask    `gorm:"foreignKey:RequestID;constraint:OnDelete:CASCADE" json:"tasks,omitempty"`
+}
+
+func (ApprovalRequest) TableName() string {
+	return "approval_requests"
+}
+
+// ApprovalTask is a step of a request. It is decided by ApproverID when
+// set, otherwise by anyone with ApproverRole or by a head of
+// ApproverDepartment.
+type ApprovalTask struct {
+	ID                 uuid.UUID          `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	RequestID          uuid.UUID          `gorm:"type:uuid;not null;index" json:"requestId"`
+	Stage              int                `gorm:"not null" json:"stage"`
+	Name               string             `json:"name,omitempty"`
+	ApproverID         *uuid.UUID         `gorm:"type:uuid;index" json:"approverId,omitempty"`
+	ApproverRole       UserRole           `gorm:"type:varchar(20);default:''" json:"approverRole,omitempty"`
+	ApproverDepartment string             `gorm:"default:''" json:"approverDepartment,omitempty"`
+	Status             ApprovalTaskStatus `gorm:"type:varchar(20);not null" json:"status"`
+	DelegatedFromID    *uuid.UUID         `gorm:"type:uuid" json:"delegatedFromId,omitempty"`
+	DecidedByID        *uuid.UUID         `gorm:"type:uuid" json:"decidedById,omitempty"`
+	DecidedAt          *time.Time         `json:"decidedAt,omitempty"`
+	Comment            string             `json:"comment,omitempty"`
+	CreatedAt          time.Time          `json:"createdAt"`
+	UpdatedAt          time.Time          `json:"updatedAt"`
+
+	// Relations
+	Approver  *User `gorm:"foreignKey:ApproverID" json:"approver,omitempty"`
+	DecidedBy *User `gorm:"foreignKey:DecidedByID" json:"decidedBy,omitempty"`
+}
+
+func (ApprovalTask) TableName() string {
+	return "approval_tasks"
+}
+
+// CanDecide reports whether user may decide the task.
+func (t *ApprovalTask) CanDecide(user *User) bool {
+	switch {
+	case t.ApproverID != nil:
+		return *t.ApproverID == user.ID
+	case t.ApproverRole != "":
+		return user.Role == t.ApproverRole
+	case t.ApproverDepartment != "":
+		return user.DepartmentHead && user.Department == t.ApproverDepartment
+	}
+	return false
+}
@@ -0,0 +1,45 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type AssetStatus string
+
+const (
+	AssetActive           AssetStatus = "ACTIVE"
+	AssetUnderMaintenance AssetStatus = "UNDER_MAINTENANCE"
+	AssetOutOfService     AssetStatus = "OUT_OF_SERVICE"
+	AssetRetired          AssetStatus = "RETIRED"
+)
+
+// Asset is a piece of equipment that tickets can be raised against, such
+// as an air conditioner or a pump.
+type Asset struct {
+	ID                uuid.UUID      `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	Code              string         `gorm:"uniqueIndex:idx_asset_code,where:deleted_at IS NULL;not null" json:"code"`
+	Name              string         `gorm:"not null" json:"name"`
+	Category          TicketCategory `gorm:"type:varchar(20);default:'GENERAL'" json:"category"`
+	Make              string         `json:"make,omitempty"`
+	Model             string         `json:"model,omitempty"`
+	SerialNumber      string         `json:"serialNumber,omitempty"`
+	InstallDate       *time.Time     `json:"installDate,omitempty"`
+	WarrantyExpiresAt *time.Time     `json:"warrantyExpiresAt,omitempty"`
+	Location          string         `json:"location,omitempty"`
+	LocationID        *uuid.UUID     `gorm:"type:uuid;index" json:"locationId,omitempty"`
+	Status            AssetStatus    `gorm:"type:varchar(20);default:'ACTIVE'" json:"status"`
+	Properties        JSONMap        `gorm:"default:'{}'" json:"properties"`
+	CreatedAt         time.Time      `json:"createdAt"`
+	UpdatedAt         time.Time      `json:"updatedAt"`
+	DeletedAt         gorm.DeletedAt `gorm:"index" json:"-"`
+
+	// Relations
+	LocationRef *Location `gorm:"foreignKey:LocationID" json:"locationRef,omitempty"`
+}
+
+func (Asset) TableName() string {
+	return "assets"
+}
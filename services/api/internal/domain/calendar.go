@@ -0,0 +1,54 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// WorkingCalendar describes when technicians are on duty. SLA deadlines and
+// due dates only count time that falls inside its working hours.
+type WorkingCalendar struct {
+	ID        uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	Name      string    `gorm:"uniqueIndex;not null" json:"name"`
+	Timezone  string    `gorm:"not null;default:'Asia/Bangkok'" json:"timezone"`
+	IsDefault bool      `gorm:"default:false" json:"isDefault"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+
+	// Relations
+	Hours    []WorkingHours `gorm:"foreignKey:CalendarID;constraint:OnDelete:CASCADE" json:"hours"`
+	Holidays []Holiday      `gorm:"foreignKey:CalendarID;constraint:OnDelete:CASCADE" json:"holidays"`
+}
+
+func (WorkingCalendar) TableName() string {
+	return "working_calendars"
+}
+
+// WorkingHours is one working window on a weekday. Times are "HH:MM" in
+// the calendar's timezone; a day may have several windows.
+type WorkingHours struct {
+	ID         uuid.UUID    `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	CalendarID uuid.UUID    `gorm:"type:uuid;not null;index" json:"calendarId"`
+	Weekday    time.Weekday `gorm:"not null" json:"weekday"`
+	StartTime  string       `gorm:"type:varchar(5);not null" json:"startTime"`
+	EndTime    string       `gorm:"type:varchar(5);not null" json:"endTime"`
+}
+
+func (WorkingHours) TableName() string {
+	return "working_hours"
+}
+
+// Holiday is a whole non-working day. Date is "YYYY-MM-DD" in the
+// calendar's timezone.
+type Holiday struct {
+	ID         uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	CalendarID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_holiday_calendar_date" json:"calendarId"`
+	Date       string    `gorm:"type:varchar(10);not null;uniqueIndex:idx_holiday_calendar_date" json:"date"`
+	Name       string    `json:"name"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
+
+func (Holiday) TableName() string {
+	return "holidays"
+}
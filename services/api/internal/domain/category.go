@@ -0,0 +1,61 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Category is a kind of ticket work, managed by admins. Tickets and the
+// records keyed by category (SLA policies, schedules, assets...) store its
+// Code, so the code cannot change once created.
+type Category struct {
+	ID       uuid.UUID      `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	Code     TicketCategory `gorm:"type:varchar(20);uniqueIndex;not null" json:"code"`
+	NameEN   string         `gorm:"column:name_en;not null" json:"nameEn"`
+	NameTH   string         `gorm:"column:name_th" json:"nameTh"`
+	ParentID *uuid.UUID     `gorm:"type:uuid;index" json:"parentId,omitempty"`
+	Icon     string         `json:"icon,omitempty"` // icon name in the web app
+
+	// Applied to new tickets of the category that do not set their own
+	DefaultPriority   TicketPriority `gorm:"type:varchar(20);default:''" json:"defaultPriority,omitempty"`
+	DefaultAssigneeID *uuid.UUID     `gorm:"type:uuid" json:"defaultAssigneeId,omitempty"`
+
+	Active    bool      `gorm:"default:true" json:"active"` // inactive categories cannot be chosen for new records
+	SortOrder int       `gorm:"default:0" json:"sortOrder"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+
+	// Relations
+	DefaultAssignee *User `gorm:"foreignKey:DefaultAssigneeID" json:"defaultAssignee,omitempty"`
+}
+
+func (Category) TableName() string {
+	return "ticket_categories"
+}
+
+// Priority is a ticket priority, managed by admins. Like categories,
+// records store its Code.
+type Priority struct {
+	ID     uuid.UUID      `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	Code   TicketPriority `gorm:"type:varchar(20);uniqueIndex;not null" json:"code"`
+	NameEN string         `gorm:"column:name_en;not null" json:"nameEn"`
+	NameTH string         `gorm:"column:name_th" json:"nameTh"`
+	Rank   int            `gorm:"not null;default:0" json:"rank"` // higher is more urgent
+	Color  string         `gorm:"type:varchar(7)" json:"color,omitempty"`
+
+	// SLA policy for tickets of this priority that no policy of their own
+	// priority matches, so a new priority can share existing targets.
+	SLAPolicyID *uuid.UUID `gorm:"column:sla_policy_id;type:uuid" json:"slaPolicyId,omitempty"`
+
+	Active    bool      `gorm:"default:true" json:"active"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+
+	// Relations
+	SLAPolicy *SLAPolicy `gorm:"foreignKey:SLAPolicyID" json:"slaPolicy,omitempty"`
+}
+
+func (Priority) TableName() string {
+	return "ticket_priorities"
+}
@@ -0,0 +1,95 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ChecklistItemType string
+
+const (
+	ChecklistCheck   ChecklistItemType = "CHECK"   // ticked off
+	ChecklistReading ChecklistItemType = "READING" // needs a numeric reading
+	ChecklistPhoto   ChecklistItemType = "PHOTO"   // needs a photo attached to the ticket
+)
+
+// ChecklistTemplate is a standard procedure, such as lock-out/tag-out or
+// an inspection round, copied onto new tickets it applies to. Category
+// matches the ticket's category and AssetCategory the category of its
+// asset; an empty value matches any.
+type ChecklistTemplate struct {
+	ID            uuid.UUID      `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	Name          string         `gorm:"not null" json:"name"`
+	Description   string         `gorm:"type:text" json:"description,omitempty"`
+	Category      TicketCategory `gorm:"type:varchar(20);not null;default:''" json:"category,omitempty"`
+	AssetCategory TicketCategory `gorm:"type:varchar(20);not null;default:''" json:"assetCategory,omitempty"`
+	Active        bool           `gorm:"default:true" json:"active"`
+	CreatedAt     time.Time      `json:"createdAt"`
+	UpdatedAt     time.Time      `json:"updatedAt"`
+
+	// Relations
+	Items []ChecklistTemplateItem `gorm:"foreignKey:TemplateID;constraint:OnDelete:CASCADE" json:"items,omitempty"`
+}
+
+func (ChecklistTemplate) TableName() string {
+	return "checklist_templates"
+}
+
+type ChecklistTemplateItem struct {
+	ID         uuid.UUID         `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	TemplateID uuid.UUID         `gorm:"type:uuid;not null;index" json:"templateId"`
+	Position   int               `gorm:"not null" json:"position"`
+	Label      string            `gorm:"not null" json:"label"`
+	Type       ChecklistItemType `gorm:"type:varchar(20);not null;default:'CHECK'" json:"type"`
+	Required   bool              `gorm:"not null" json:"required"`
+	Unit       string            `json:"unit,omitempty"` // of a reading, e.g. "bar"
+}
+
+func (ChecklistTemplateItem) TableName() string {
+	return "checklist_template_items"
+}
+
+// TicketChecklist is a copy of a template on a ticket. Later changes to
+// the template do not affect it.
+type TicketChecklist struct {
+	ID         uuid.UUID  `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	TicketID   uuid.UUID  `gorm:"type:uuid;not null;index" json:"ticketId"`
+	TemplateID *uuid.UUID `gorm:"type:uuid" json:"templateId,omitempty"`
+	Name       string     `gorm:"not null" json:"name"`
+	CreatedAt  time.Time  `json:"createdAt"`
+
+	// Relations
+	Items []TicketChecklistItem `gorm:"foreignKey:ChecklistID;constraint:OnDelete:CASCADE" json:"items"`
+}
+
+func (TicketChecklist) TableName() string {
+	return "ticket_checklists"
+}
+
+type TicketChecklistItem struct {
+	ID          uuid.UUID         `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	ChecklistID uuid.UUID         `gorm:"type:uuid;not null;index" json:"checklistId"`
+	Position    int               `gorm:"not null" json:"position"`
+	Label       string            `gorm:"not null" json:"label"`
+	Type        ChecklistItemType `gorm:"type:varchar(20);not null;default:'CHECK'" json:"type"`
+	Required    bool              `gorm:"not null" json:"required"`
+	Unit        string            `json:"unit,omitempty"`
+
+	// Progress
+	Done          bool       `gorm:"not null;default:false" json:"done"`
+	Reading       *float64   `json:"reading,omitempty"`
+	AttachmentID  *uuid.UUID `gorm:"type:uuid" json:"attachmentId,omitempty"`
+	Note          string     `json:"note,omitempty"`
+	CompletedByID *uuid.UUID `gorm:"type:uuid" json:"completedById,omitempty"`
+	CompletedAt   *time.Time `json:"completedAt,omitempty"`
+
+	// Relations
+	Checklist   *TicketChecklist `gorm:"foreignKey:ChecklistID" json:"-"`
+	Attachment  *Attachment      `gorm:"foreignKey:AttachmentID" json:"attachment,omitempty"`
+	CompletedBy *User            `gorm:"foreignKey:CompletedByID" json:"completedBy,omitempty"`
+}
+
+func (TicketChecklistItem) TableName() string {
+	return "ticket_checklist_items"
+}
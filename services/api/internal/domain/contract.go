@@ -0,0 +1,47 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ContractType string
+
+const (
+	ContractWarranty ContractType = "WARRANTY"
+	ContractService  ContractType = "SERVICE"
+)
+
+// ServiceContract is a warranty or service contract covering an asset
+// between StartsAt and EndsAt. Faults on a covered asset go to the vendor
+// rather than our own technicians.
+type ServiceContract struct {
+	ID             uuid.UUID    `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	AssetID        uuid.UUID    `gorm:"type:uuid;not null;index" json:"assetId"`
+	Type           ContractType `gorm:"type:varchar(20);not null;default:'WARRANTY'" json:"type"`
+	Vendor         string       `gorm:"not null" json:"vendor"`
+	ContractNumber string       `json:"contractNumber,omitempty"`
+	Coverage       string       `gorm:"type:text" json:"coverage,omitempty"` // what is covered, e.g. "parts and labour"
+	StartsAt       time.Time    `gorm:"not null" json:"startsAt"`
+	EndsAt         time.Time    `gorm:"not null;index" json:"endsAt"`
+	ContactName    string       `json:"contactName,omitempty"`
+	ContactPhone   string       `json:"contactPhone,omitempty"`
+	ContactEmail   string       `json:"contactEmail,omitempty"`
+	Notes          string       `gorm:"type:text" json:"notes,omitempty"`
+	ReminderSentAt *time.Time   `json:"reminderSentAt,omitempty"` // expiry reminder; cleared when EndsAt changes
+	CreatedAt      time.Time    `json:"createdAt"`
+	UpdatedAt      time.Time    `json:"updatedAt"`
+
+	// Relations
+	Asset *Asset `gorm:"foreignKey:AssetID" json:"asset,omitempty"`
+}
+
+func (ServiceContract) TableName() string {
+	return "service_contracts"
+}
+
+// Covers reports whether the contract is in force at t.
+func (c *ServiceContract) Covers(t time.Time) bool {
+	return !t.Before(c.StartsAt) && t.Before(c.EndsAt)
+}
@@ -0,0 +1,31 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type CostApprovalStatus string
+
+const (
+	CostApprovalPending  CostApprovalStatus = "PENDING"
+	CostApprovalApproved CostApprovalStatus = "APPROVED"
+	CostApprovalRejected CostApprovalStatus = "REJECTED"
+)
+
+// ApprovalThreshold is the estimated cost above which a ticket needs
+// approval. Department (the requester's) and Category narrow it down; an
+// empty value matches any. The most specific threshold applies.
+type ApprovalThreshold struct {
+	ID         uuid.UUID      `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	Department string         `gorm:"uniqueIndex:idx_approval_threshold_scope;not null;default:''" json:"department"`
+	Category   TicketCategory `gorm:"type:varchar(20);uniqueIndex:idx_approval_threshold_scope;not null;default:''" json:"category"`
+	Amount     float64        `gorm:"type:numeric(12,2);not null" json:"amount"`
+	CreatedAt  time.Time      `json:"createdAt"`
+	UpdatedAt  time.Time      `json:"updatedAt"`
+}
+
+func (ApprovalThreshold) TableName() string {
+	return "approval_thresholds"
+}
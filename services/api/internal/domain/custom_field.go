@@ -0,0 +1,71 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type CustomFieldType string
+
+const (
+	FieldText        CustomFieldType = "TEXT"
+	FieldNumber      CustomFieldType = "NUMBER"
+	FieldDate        CustomFieldType = "DATE" // "2006-01-02"
+	FieldSelect      CustomFieldType = "SELECT"
+	FieldMultiSelect CustomFieldType = "MULTI_SELECT"
+	FieldBoolean     CustomFieldType = "BOOLEAN"
+	FieldUser        CustomFieldType = "USER" // user ID
+)
+
+func (t CustomFieldType) IsValid() bool {
+	switch t {
+	case FieldText, FieldNumber, FieldDate, FieldSelect, FieldMultiSelect, FieldBoolean, FieldUser:
+		return true
+	}
+	return false
+}
+
+// CustomField defines an extra field admins add to tickets, such as a room
+// phone or a cost code. Values are kept in Ticket.CustomFields under Key.
+type CustomField struct {
+	ID          uuid.UUID        `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	Key         string           `gorm:"uniqueIndex;not null" json:"key"` // fixed once created
+	Label       string           `gorm:"not null" json:"label"`
+	Type        CustomFieldType  `gorm:"type:varchar(20);not null" json:"type"` // fixed once created
+	Description string           `json:"description,omitempty"`
+	Categories  TicketCategories `gorm:"default:'[]'" json:"categories"` // empty for every category
+	Options     StringList       `gorm:"default:'[]'" json:"options"`    // choices of SELECT and MULTI_SELECT fields
+	Required    bool             `gorm:"default:false" json:"required"`
+	Active      bool             `gorm:"default:true" json:"active"` // inactive fields keep their values but take no new ones
+	SortOrder   int              `gorm:"default:0" json:"sortOrder"`
+	CreatedAt   time.Time        `json:"createdAt"`
+	UpdatedAt   time.Time        `json:"updatedAt"`
+}
+
+func (CustomField) TableName() string {
+	return "custom_fields"
+}
+
+// AppliesTo reports whether tickets of the category have the field.
+func (f *CustomField) AppliesTo(category TicketCategory) bool {
+	if len(f.Categories) == 0 {
+		return true
+	}
+	for _, c := range f.Categories {
+		if c == category {
+			return true
+		}
+	}
+	return false
+}
+
+// HasOption reports whether value is one of the field's choices.
+func (f *CustomField) HasOption(value string) bool {
+	for _, option := range f.Options {
+		if option == value {
+			return true
+		}
+	}
+	return false
+}
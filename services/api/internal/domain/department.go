@@ -0,0 +1,59 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Department is an organizational unit that maintenance costs are charged
+// to. Tickets are attributed to the requester's department unless
+// overridden.
+type Department struct {
+	ID            uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	Name          string    `gorm:"uniqueIndex;not null" json:"name"`
+	CostCenter    string    `gorm:"uniqueIndex:idx_department_cost_center,where:cost_center <> ''" json:"costCenter,omitempty"`
+	AnnualBudget  float64   `gorm:"type:numeric(14,2);default:0" json:"annualBudget"`
+	MonthlyBudget float64   `gorm:"type:numeric(14,2);default:0" json:"monthlyBudget"` // 0 spreads the annual budget evenly
+	CreatedAt     time.Time `json:"createdAt"`
+	UpdatedAt     time.Time `json:"updatedAt"`
+}
+
+func (Department) TableName() string {
+	return "departments"
+}
+
+// YearBudget is the budget for a whole year: the annual budget, or twelve
+// monthly budgets if only that is set.
+func (d *Department) YearBudget() float64 {
+	if d.AnnualBudget > 0 {
+		return d.AnnualBudget
+	}
+	return d.MonthlyBudget * 12
+}
+
+// MonthBudget is the budget for one month: the monthly budget, or a
+// twelfth of the annual budget if only that is set.
+func (d *Department) MonthBudget() float64 {
+	if d.MonthlyBudget > 0 {
+		return d.MonthlyBudget
+	}
+	return d.AnnualBudget / 12
+}
+
+// BudgetAlert records that a department was warned about reaching a
+// percentage of its budget for a period ("2026" or "2026-03"), so each
+// warning goes out once.
+type BudgetAlert struct {
+	ID           uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	DepartmentID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_budget_alert" json:"departmentId"`
+	Period       string    `gorm:"type:varchar(7);not null;uniqueIndex:idx_budget_alert" json:"period"`
+	Percent      int       `gorm:"not null;uniqueIndex:idx_budget_alert" json:"percent"`
+	Budget       float64   `gorm:"type:numeric(14,2)" json:"budget"`
+	Actual       float64   `gorm:"type:numeric(14,2)" json:"actual"`
+	CreatedAt    time.Time `json:"createdAt"`
+}
+
+func (BudgetAlert) TableName() string {
+	return "budget_alerts"
+}
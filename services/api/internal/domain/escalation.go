@@ -0,0 +1,62 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type EscalationCondition string
+
+const (
+	// ConditionUnassigned matches OPEN tickets nobody has been assigned to.
+	ConditionUnassigned EscalationCondition = "UNASSIGNED"
+	// ConditionNoActivity matches IN_PROGRESS tickets without a new comment.
+	ConditionNoActivity EscalationCondition = "NO_ACTIVITY"
+	// ConditionOverdue matches unresolved tickets past their due date.
+	ConditionOverdue EscalationCondition = "OVERDUE"
+)
+
+// EscalationRule fires when a ticket has met Condition for longer than
+// ThresholdMinutes. Priority and Category narrow the rule when set.
+type EscalationRule struct {
+	ID               uuid.UUID           `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	Name             string              `gorm:"not null" json:"name"`
+	Condition        EscalationCondition `gorm:"type:varchar(20);not null" json:"condition"`
+	ThresholdMinutes int                 `gorm:"not null" json:"thresholdMinutes"`
+	Priority         TicketPriority      `gorm:"type:varchar(20);default:''" json:"priority,omitempty"`
+	Category         TicketCategory      `gorm:"type:varchar(20);default:''" json:"category,omitempty"`
+
+	// Actions
+	BumpPriority bool       `gorm:"default:false" json:"bumpPriority"`
+	ReassignToID *uuid.UUID `gorm:"type:uuid" json:"reassignToId,omitempty"`
+	NotifyAdmins bool       `gorm:"default:true" json:"notifyAdmins"`
+
+	Active      bool      `gorm:"default:true" json:"active"`
+	CreatedByID uuid.UUID `gorm:"type:uuid;not null" json:"createdById"`
+	CreatedAt   time.Time `json:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+
+	// Relations
+	ReassignTo *User `gorm:"foreignKey:ReassignToID" json:"reassignTo,omitempty"`
+}
+
+func (EscalationRule) TableName() string {
+	return "escalation_rules"
+}
+
+// TicketEscalation records that a rule fired for a ticket. ConditionSince
+// is when the condition started to hold (e.g. the due date that was
+// missed) and identifies its occurrence, so a rule fires once per
+// occurrence even with several API instances scanning at the same time.
+type TicketEscalation struct {
+	ID             uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	TicketID       uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_ticket_escalation_once" json:"ticketId"`
+	RuleID         uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_ticket_escalation_once" json:"ruleId"`
+	ConditionSince time.Time `gorm:"not null;uniqueIndex:idx_ticket_escalation_once" json:"conditionSince"`
+	CreatedAt      time.Time `json:"createdAt"`
+}
+
+func (TicketEscalation) TableName() string {
+	return "ticket_escalations"
+}
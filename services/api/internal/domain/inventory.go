@@ -0,0 +1,101 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Part is a spare part kept in stock. Quantities on hand are never edited
+// directly; they follow from the StockMovement ledger.
+type Part struct {
+	ID          uuid.UUID      `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	SKU         string         `gorm:"column:sku;uniqueIndex:idx_part_sku,where:deleted_at IS NULL;not null" json:"sku"`
+	Name        string         `gorm:"not null" json:"name"`
+	Description string         `gorm:"type:text" json:"description,omitempty"`
+	Unit        string         `gorm:"not null;default:'pcs'" json:"unit"`
+	UnitCost    float64        `gorm:"type:numeric(12,2);default:0" json:"unitCost"`
+	MinStock    float64        `gorm:"type:numeric(12,3);default:0" json:"minStock"` // low-stock threshold across all stock locations
+	CreatedAt   time.Time      `json:"createdAt"`
+	UpdatedAt   time.Time      `json:"updatedAt"`
+	DeletedAt   gorm.DeletedAt `gorm:"index" json:"-"`
+
+	// OnHand is the total quantity across stock locations. Filled in on load.
+	OnHand float64 `gorm:"-" json:"onHand"`
+
+	// Relations
+	Stock []StockLevel `gorm:"foreignKey:PartID" json:"stock,omitempty"`
+}
+
+func (Part) TableName() string {
+	return "parts"
+}
+
+// IsLowStock reports whether the part has a threshold and is at or below it.
+func (p *Part) IsLowStock() bool {
+	return p.MinStock > 0 && p.OnHand <= p.MinStock
+}
+
+// StockLocation is a store room or van where parts are kept.
+type StockLocation struct {
+	ID          uuid.UUID  `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	Name        string     `gorm:"uniqueIndex;not null" json:"name"`
+	Description string     `json:"description,omitempty"`
+	LocationID  *uuid.UUID `gorm:"type:uuid" json:"locationId,omitempty"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	UpdatedAt   time.Time  `json:"updatedAt"`
+}
+
+func (StockLocation) TableName() string {
+	return "stock_locations"
+}
+
+// StockLevel is the running quantity of a part at a stock location, kept
+// in step with the movement ledger.
+type StockLevel struct {
+	PartID          uuid.UUID `gorm:"type:uuid;primaryKey" json:"partId"`
+	StockLocationID uuid.UUID `gorm:"type:uuid;primaryKey" json:"stockLocationId"`
+	Quantity        float64   `gorm:"type:numeric(12,3);not null;default:0" json:"quantity"`
+	UpdatedAt       time.Time `json:"updatedAt"`
+
+	// Relations
+	StockLocation *StockLocation `gorm:"foreignKey:StockLocationID" json:"stockLocation,omitempty"`
+}
+
+func (StockLevel) TableName() string {
+	return "stock_levels"
+}
+
+type StockMovementType string
+
+const (
+	MovementReceive StockMovementType = "RECEIVE"
+	MovementIssue   StockMovementType = "ISSUE"
+	MovementReturn  StockMovementType = "RETURN"
+	MovementAdjust  StockMovementType = "ADJUST"
+)
+
+// StockMovement is one ledger entry. Quantity is the signed change in stock:
+// positive for receipts and returns, negative for issues.
+type StockMovement struct {
+	ID              uuid.UUID         `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	PartID          uuid.UUID         `gorm:"type:uuid;not null;index" json:"partId"`
+	StockLocationID uuid.UUID         `gorm:"type:uuid;not null" json:"stockLocationId"`
+	Type            StockMovementType `gorm:"type:varchar(20);not null" json:"type"`
+	Quantity        float64           `gorm:"type:numeric(12,3);not null" json:"quantity"`
+	UnitCost        float64           `gorm:"type:numeric(12,2);default:0" json:"unitCost"`
+	TicketID        *uuid.UUID        `gorm:"type:uuid;index" json:"ticketId,omitempty"`
+	UserID          uuid.UUID         `gorm:"type:uuid;not null" json:"userId"`
+	Note            string            `json:"note,omitempty"`
+	CreatedAt       time.Time         `json:"createdAt"`
+
+	// Relations
+	Part          *Part          `gorm:"foreignKey:PartID" json:"part,omitempty"`
+	StockLocation *StockLocation `gorm:"foreignKey:StockLocationID" json:"stockLocation,omitempty"`
+	User          *User          `gorm:"foreignKey:UserID" json:"user,omitempty"`
+}
+
+func (StockMovement) TableName() string {
+	return "stock_movements"
+}
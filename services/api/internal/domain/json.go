@@ -0,0 +1,69 @@
+package domain
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+)
+
+// JSONMap is a free-form object stored in a jsonb column.
+type JSONMap map[string]interface{}
+
+func (JSONMap) GormDataType() string {
+	return "jsonb"
+}
+
+func (m JSONMap) Value() (driver.Value, error) {
+	if m == nil {
+		return "{}", nil
+	}
+	b, err := json.Marshal(m)
+	return string(b), err
+}
+
+func (m *JSONMap) Scan(value interface{}) error {
+	var b []byte
+	switch v := value.(type) {
+	case nil:
+		*m = nil
+		return nil
+	case []byte:
+		b = v
+	case string:
+		b = []byte(v)
+	default:
+		return fmt.Errorf("unsupported JSONMap source %T", value)
+	}
+	return json.Unmarshal(b, m)
+}
+
+// StringList is a list of strings stored in a jsonb column.
+type StringList []string
+
+func (StringList) GormDataType() string {
+	return "jsonb"
+}
+
+func (l StringList) Value() (driver.Value, error) {
+	if l == nil {
+		return "[]", nil
+	}
+	b, err := json.Marshal(l)
+	return string(b), err
+}
+
+func (l *StringList) Scan(value interface{}) error {
+	var b []byte
+	switch v := value.(type) {
+	case nil:
+		*l = nil
+		return nil
+	case []byte:
+		b = v
+	case string:
+		b = []byte(v)
+	default:
+		return fmt.Errorf("unsupported StringList source %T", value)
+	}
+	return json.Unmarshal(b, l)
+}
@@ -0,0 +1,60 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type LocationType string
+
+const (
+	LocationSite     LocationType = "SITE"
+	LocationBuilding LocationType = "BUILDING"
+	LocationFloor    LocationType = "FLOOR"
+	LocationRoom     LocationType = "ROOM"
+)
+
+// Depth returns the level of the location type in the tree, starting at 0
+// for a site.
+func (t LocationType) Depth() int {
+	switch t {
+	case LocationSite:
+		return 0
+	case LocationBuilding:
+		return 1
+	case LocationFloor:
+		return 2
+	case LocationRoom:
+		return 3
+	}
+	return -1
+}
+
+// Location is a node in the site → building → floor → room tree. Path is
+// the materialized path of ancestor IDs ending with the node's own ID
+// ("/<site>/<building>/"), so a subtree is everything whose path starts
+// with the node's path.
+type Location struct {
+	ID        uuid.UUID    `gorm:"type:uuid;primary_key" json:"id"`
+	Name      string       `gorm:"not null" json:"name"`
+	Code      string       `json:"code,omitempty"`
+	Type      LocationType `gorm:"type:varchar(20);not null" json:"type"`
+	ParentID  *uuid.UUID   `gorm:"type:uuid;index" json:"parentId,omitempty"`
+	Path      string       `gorm:"not null;index:idx_location_path,expression:path varchar_pattern_ops" json:"path"`
+	Depth     int          `gorm:"not null;default:0" json:"depth"`
+	CreatedAt time.Time    `json:"createdAt"`
+	UpdatedAt time.Time    `json:"updatedAt"`
+
+	// FullName is the names from the site down, e.g.
+	// "Main Campus / Building B / Floor 2". Filled in on load.
+	FullName string `gorm:"-" json:"fullName,omitempty"`
+
+	// Relations
+	Parent   *Location  `gorm:"foreignKey:ParentID" json:"-"`
+	Children []Location `gorm:"foreignKey:ParentID" json:"children,omitempty"`
+}
+
+func (Location) TableName() string {
+	return "locations"
+}
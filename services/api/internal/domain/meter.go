@@ -0,0 +1,135 @@
+package domain
+
+import (
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Meter tracks a measured quantity on an asset, such as run-hours or
+// discharge pressure. Readings on a cumulative meter may not go down.
+type Meter struct {
+	ID         uuid.UUID  `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	AssetID    uuid.UUID  `gorm:"type:uuid;not null;index" json:"assetId"`
+	Name       string     `gorm:"not null" json:"name"`
+	Unit       string     `json:"unit,omitempty"`
+	Cumulative bool       `gorm:"not null;default:false" json:"cumulative"`
+	LastValue  *float64   `json:"lastValue,omitempty"`
+	LastReadAt *time.Time `json:"lastReadAt,omitempty"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	UpdatedAt  time.Time  `json:"updatedAt"`
+
+	// Relations
+	Asset    *Asset         `gorm:"foreignKey:AssetID" json:"asset,omitempty"`
+	Rules    []MeterRule    `gorm:"foreignKey:MeterID;constraint:OnDelete:CASCADE" json:"rules,omitempty"`
+	Readings []MeterReading `gorm:"foreignKey:MeterID;constraint:OnDelete:CASCADE" json:"-"`
+}
+
+func (Meter) TableName() string {
+	return "meters"
+}
+
+type MeterReading struct {
+	ID           uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	MeterID      uuid.UUID `gorm:"type:uuid;not null;index:idx_meter_reading" json:"meterId"`
+	Value        float64   `gorm:"not null" json:"value"`
+	ReadAt       time.Time `gorm:"not null;index:idx_meter_reading" json:"readAt"`
+	RecordedByID uuid.UUID `gorm:"type:uuid;not null" json:"recordedById"`
+	CreatedAt    time.Time `json:"createdAt"`
+
+	// Relations
+	RecordedBy *User `gorm:"foreignKey:RecordedByID" json:"recordedBy,omitempty"`
+}
+
+func (MeterReading) TableName() string {
+	return "meter_readings"
+}
+
+type MeterRuleType string
+
+const (
+	MeterRuleThreshold MeterRuleType = "THRESHOLD" // reading compared against Value
+	MeterRuleDelta     MeterRuleType = "DELTA"     // every Value units since Baseline
+)
+
+type MeterOperator string
+
+const (
+	OperatorGT  MeterOperator = "GT"
+	OperatorGTE MeterOperator = "GTE"
+	OperatorLT  MeterOperator = "LT"
+	OperatorLTE MeterOperator = "LTE"
+)
+
+func (o MeterOperator) IsValid() bool {
+	switch o {
+	case OperatorGT, OperatorGTE, OperatorLT, OperatorLTE:
+		return true
+	}
+	return false
+}
+
+// MeterRule raises a ticket for condition-based maintenance when a new
+// reading meets it. A rule does not fire again while the last ticket it
+// raised is still open.
+type MeterRule struct {
+	ID       uuid.UUID     `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	MeterID  uuid.UUID     `gorm:"type:uuid;not null;index" json:"meterId"`
+	Name     string        `gorm:"not null" json:"name"`
+	Type     MeterRuleType `gorm:"type:varchar(20);not null" json:"type"`
+	Operator MeterOperator `gorm:"type:varchar(10)" json:"operator,omitempty"` // THRESHOLD only
+	Value    float64       `gorm:"not null" json:"value"`
+	// Baseline is the reading a DELTA rule's interval counts from. It
+	// advances by whole intervals each time the rule fires.
+	Baseline    *float64  `json:"baseline,omitempty"`
+	Active      bool      `gorm:"default:true" json:"active"`
+	CreatedByID uuid.UUID `gorm:"type:uuid;not null" json:"createdById"`
+	CreatedAt   time.Time `json:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+
+	// Template for the generated tickets
+	TicketTitle       string         `json:"ticketTitle,omitempty"`
+	TicketDescription string         `gorm:"type:text" json:"ticketDescription,omitempty"`
+	TicketPriority    TicketPriority `gorm:"type:varchar(20);default:'MEDIUM'" json:"ticketPriority"`
+	AssigneeID        *uuid.UUID     `gorm:"type:uuid" json:"assigneeId,omitempty"`
+
+	LastTicketID    *uuid.UUID `gorm:"type:uuid" json:"lastTicketId,omitempty"`
+	LastTriggeredAt *time.Time `json:"lastTriggeredAt,omitempty"`
+}
+
+func (MeterRule) TableName() string {
+	return "meter_rules"
+}
+
+// Fires reports whether a reading of value meets the rule. A DELTA rule
+// without a baseline never fires.
+func (r *MeterRule) Fires(value float64) bool {
+	switch r.Type {
+	case MeterRuleThreshold:
+		switch r.Operator {
+		case OperatorGT:
+			return value > r.Value
+		case OperatorGTE:
+			return value >= r.Value
+		case OperatorLT:
+			return value < r.Value
+		case OperatorLTE:
+			return value <= r.Value
+		}
+	case MeterRuleDelta:
+		return r.Baseline != nil && r.Value > 0 && value-*r.Baseline >= r.Value
+	}
+	return false
+}
+
+// Advance moves a DELTA rule's baseline forward by the whole intervals
+// value has covered, so the next ticket stays on the same cadence even if
+// readings skip past a multiple.
+func (r *MeterRule) Advance(value float64) {
+	if r.Type != MeterRuleDelta || r.Baseline == nil || r.Value <= 0 {
+		return
+	}
+	baseline := *r.Baseline + math.Floor((value-*r.Baseline)/r.Value)*r.Value
+	r.Baseline = &baseline
+}
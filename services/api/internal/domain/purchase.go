@@ -0,0 +1,90 @@
+package domain
+
+import (
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type PurchaseStatus string
+
+const (
+	PurchaseDraft     PurchaseStatus = "DRAFT"
+	PurchaseSubmitted PurchaseStatus = "SUBMITTED"
+	PurchaseApproved  PurchaseStatus = "APPROVED"
+	PurchaseOrdered   PurchaseStatus = "ORDERED"
+	PurchaseReceived  PurchaseStatus = "RECEIVED"
+	PurchaseCancelled PurchaseStatus = "CANCELLED"
+)
+
+// Outstanding reports whether goods are still awaited: the request has
+// been submitted but not yet received or cancelled. The ticket waits in
+// PENDING while it has outstanding requests.
+func (s PurchaseStatus) Outstanding() bool {
+	return s == PurchaseSubmitted || s == PurchaseApproved || s == PurchaseOrdered
+}
+
+// PurchaseRequest asks for goods that a ticket needs, typically parts that
+// are out of stock.
+type PurchaseRequest struct {
+	ID             uuid.UUID      `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	TicketID       uuid.UUID      `gorm:"type:uuid;not null;index" json:"ticketId"`
+	Supplier       string         `json:"supplier,omitempty"`
+	Status         PurchaseStatus `gorm:"type:varchar(20);not null;default:'DRAFT';index" json:"status"`
+	EstimatedCost  float64        `gorm:"type:numeric(12,2);default:0" json:"estimatedCost"` // sum of the lines
+	Notes          string         `gorm:"type:text" json:"notes,omitempty"`
+	OrderReference string         `json:"orderReference,omitempty"` // supplier's order or PO number
+	RequestedByID  uuid.UUID      `gorm:"type:uuid;not null" json:"requestedById"`
+	SubmittedAt    *time.Time     `json:"submittedAt,omitempty"`
+	ApprovedByID   *uuid.UUID     `gorm:"type:uuid" json:"approvedById,omitempty"`
+	ApprovedAt     *time.Time     `json:"approvedAt,omitempty"`
+	OrderedAt      *time.Time     `json:"orderedAt,omitempty"`
+	ReceivedByID   *uuid.UUID     `gorm:"type:uuid" json:"receivedById,omitempty"`
+	ReceivedAt     *time.Time     `json:"receivedAt,omitempty"`
+	CancelledAt    *time.Time     `json:"cancelledAt,omitempty"`
+	CreatedAt      time.Time      `json:"createdAt"`
+	UpdatedAt      time.Time      `json:"updatedAt"`
+
+	// Relations
+	Lines       []PurchaseRequestLine `gorm:"foreignKey:RequestID;constraint:OnDelete:CASCADE" json:"lines,omitempty"`
+	RequestedBy *User                 `gorm:"foreignKey:RequestedByID" json:"requestedBy,omitempty"`
+	ApprovedBy  *User                 `gorm:"foreignKey:ApprovedByID" json:"approvedBy,omitempty"`
+}
+
+func (PurchaseRequest) TableName() string {
+	return "purchase_requests"
+}
+
+// SumLines sets EstimatedCost from the lines.
+func (p *PurchaseRequest) SumLines() {
+	total := 0.0
+	for _, line := range p.Lines {
+		total += line.Total()
+	}
+	p.EstimatedCost = math.Round(total*100) / 100
+}
+
+// PurchaseRequestLine is one item on a request. Lines for a stocked part
+// carry its PartID so the goods can be received into inventory.
+type PurchaseRequestLine struct {
+	ID          uuid.UUID  `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	RequestID   uuid.UUID  `gorm:"type:uuid;not null;index" json:"requestId"`
+	Position    int        `gorm:"not null" json:"position"`
+	PartID      *uuid.UUID `gorm:"type:uuid;index" json:"partId,omitempty"`
+	Description string     `gorm:"not null" json:"description"`
+	Quantity    float64    `gorm:"type:numeric(12,3);not null" json:"quantity"`
+	Unit        string     `gorm:"not null;default:'pcs'" json:"unit"`
+	UnitCost    float64    `gorm:"type:numeric(12,2);default:0" json:"unitCost"` // estimated
+
+	// Relations
+	Part *Part `gorm:"foreignKey:PartID" json:"part,omitempty"`
+}
+
+func (PurchaseRequestLine) TableName() string {
+	return "purchase_request_lines"
+}
+
+func (l *PurchaseRequestLine) Total() float64 {
+	return l.Quantity * l.UnitCost
+}
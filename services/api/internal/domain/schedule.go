@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MaintenanceSchedule generates tickets for recurring work. Recurrence is
+// an RFC 5545 RRULE (FREQ=DAILY, WEEKLY or MONTHLY with INTERVAL, BYDAY,
+// BYMONTHDAY, COUNT and UNTIL) anchored at StartAt in Timezone. Each
+// occurrence's ticket is created LeadDays before it is due.
+type MaintenanceSchedule struct {
+	ID          uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	Name        string    `gorm:"not null" json:"name"`
+	RRule       string    `gorm:"column:rrule;not null" json:"rrule"`
+	StartAt     time.Time `gorm:"not null" json:"startAt"`
+	Timezone    string    `gorm:"not null;default:'Asia/Bangkok'" json:"timezone"`
+	LeadDays    int       `gorm:"not null;default:7" json:"leadDays"`
+	Active      bool      `gorm:"default:true" json:"active"`
+	CreatedByID uuid.UUID `gorm:"type:uuid;not null" json:"createdById"`
+	CreatedAt   time.Time `json:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+
+	// Template for the generated tickets
+	TicketTitle       string         `gorm:"not null" json:"ticketTitle"`
+	TicketDescription string         `gorm:"type:text" json:"ticketDescription"`
+	TicketCategory    TicketCategory `gorm:"type:varchar(20);default:'GENERAL'" json:"ticketCategory"`
+	TicketPriority    TicketPriority `gorm:"type:varchar(20);default:'MEDIUM'" json:"ticketPriority"`
+	TicketLocation    string         `json:"ticketLocation,omitempty"`
+	AssigneeID        *uuid.UUID     `gorm:"type:uuid" json:"assigneeId,omitempty"`
+
+	// Relations
+	Assignee *User `gorm:"foreignKey:AssigneeID" json:"assignee,omitempty"`
+}
+
+func (MaintenanceSchedule) TableName() string {
+	return "maintenance_schedules"
+}
+
+// ScheduleOccurrence records the ticket generated for one occurrence of a
+// schedule; the unique key stops an occurrence being generated twice.
+type ScheduleOccurrence struct {
+	ID         uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	ScheduleID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_schedule_occurrence" json:"scheduleId"`
+	OccursAt   time.Time `gorm:"not null;uniqueIndex:idx_schedule_occurrence" json:"occursAt"`
+	TicketID   uuid.UUID `gorm:"type:uuid;not null" json:"ticketId"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
+
+func (ScheduleOccurrence) TableName() string {
+	return "schedule_occurrences"
+}
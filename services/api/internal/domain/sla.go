@@ -0,0 +1,40 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// SLAPolicy sets the first-response and resolution targets for tickets of a
+// given priority. An empty Category matches every category; a policy for a
+// specific category takes precedence over it. Targets are measured in
+// working time on the policy's calendar, or the default calendar if none
+// is set.
+type SLAPolicy struct {
+	ID                uuid.UUID      `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	Name              string         `gorm:"not null" json:"name"`
+	Priority          TicketPriority `gorm:"type:varchar(20);not null;uniqueIndex:idx_sla_policy_key,where:deleted_at IS NULL" json:"priority"`
+	Category          TicketCategory `gorm:"type:varchar(20);not null;default:'';uniqueIndex:idx_sla_policy_key,where:deleted_at IS NULL" json:"category"`
+	ResponseMinutes   int            `gorm:"not null" json:"responseMinutes"`
+	ResolutionMinutes int            `gorm:"not null" json:"resolutionMinutes"`
+	CalendarID        *uuid.UUID     `gorm:"type:uuid" json:"calendarId,omitempty"`
+	CreatedAt         time.Time      `json:"createdAt"`
+	UpdatedAt         time.Time      `json:"updatedAt"`
+	DeletedAt         gorm.DeletedAt `gorm:"index" json:"-"`
+}
+
+func (SLAPolicy) TableName() string {
+	return "sla_policies"
+}
+
+// ResponseTarget is the time allowed before the first response.
+func (p *SLAPolicy) ResponseTarget() time.Duration {
+	return time.Duration(p.ResponseMinutes) * time.Minute
+}
+
+// ResolutionTarget is the time allowed to resolve the ticket.
+func (p *SLAPolicy) ResolutionTarget() time.Duration {
+	return time.Duration(p.ResolutionMinutes) * time.Minute
+}
@@ -0,0 +1,35 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DefaultTagColor is used for tags created without a color.
+const DefaultTagColor = "#6b7280"
+
+// Tag is a label curated by admins and put on tickets, for groupings finer
+// than the category such as "roof" or "night shift".
+type Tag struct {
+	ID          uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	Name        string    `gorm:"uniqueIndex;not null" json:"name"`
+	Color       string    `gorm:"type:varchar(7);not null;default:'#6b7280'" json:"color"` // "#rrggbb"
+	Description string    `json:"description,omitempty"`
+	CreatedAt   time.Time `json:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
+
+func (Tag) TableName() string {
+	return "tags"
+}
+
+// TicketTag is a row of the ticket_tags join table behind Ticket.Tags.
+type TicketTag struct {
+	TicketID uuid.UUID `gorm:"type:uuid;primaryKey" json:"ticketId"`
+	TagID    uuid.UUID `gorm:"type:uuid;primaryKey" json:"tagId"`
+}
+
+func (TicketTag) TableName() string {
+	return "ticket_tags"
+}
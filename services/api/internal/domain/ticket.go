@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 type TicketStatus string
@@ -14,8 +15,28 @@ const (
 	StatusPending    TicketStatus = "PENDING"
 	StatusResolved   TicketStatus = "RESOLVED"
 	StatusClosed     TicketStatus = "CLOSED"
+
+	// StatusAwaitingApproval holds a ticket whose cost estimate is over the
+	// approval threshold until an approver decides.
+	StatusAwaitingApproval TicketStatus = "AWAITING_APPROVAL"
 )
 
+// IsValid reports whether s is one of the known ticket statuses.
+func (s TicketStatus) IsValid() bool {
+	switch s {
+	case StatusOpen, StatusInProgress, StatusPending, StatusResolved, StatusClosed, StatusAwaitingApproval:
+		return true
+	}
+	return false
+}
+
+// HoldsSLA reports whether the SLA clock is paused while a ticket is in s.
+func (s TicketStatus) HoldsSLA() bool {
+	return s == StatusPending || s == StatusAwaitingApproval
+}
+
+// TicketPriority is the code of a Priority. The constants are the
+// priorities installed on first start; admins can add more.
 type TicketPriority string
 
 const (
@@ -25,6 +46,8 @@ const (
 	PriorityCritical TicketPriority = "CRITICAL"
 )
 
+// TicketCategory is the code of a Category. The constants are the
+// categories installed on first start; admins can add more.
 type TicketCategory string
 
 const (
@@ -44,25 +67,148 @@ type Ticket struct {
 	Priority     TicketPriority `gorm:"type:varchar(20);default:'MEDIUM'" json:"priority"`
 	Category     TicketCategory `gorm:"type:varchar(20);default:'GENERAL'" json:"category"`
 	Location     string         `json:"location,omitempty"`
+	LocationID   *uuid.UUID     `gorm:"type:uuid;index" json:"locationId,omitempty"`
 	CreatedByID  uuid.UUID      `gorm:"type:uuid;not null" json:"createdById"`
 	AssignedToID *uuid.UUID     `gorm:"type:uuid" json:"assignedToId,omitempty"`
+	ScheduleID   *uuid.UUID     `gorm:"type:uuid;index" json:"scheduleId,omitempty"`
+	AssetID      *uuid.UUID     `gorm:"type:uuid;index" json:"assetId,omitempty"`
+	VendorCost   float64        `gorm:"type:numeric(12,2);default:0" json:"vendorCost"`
+	PartsCost    float64        `gorm:"type:numeric(12,2);default:0" json:"partsCost"`
+	LaborCost    float64        `gorm:"type:numeric(12,2);default:0" json:"laborCost"`
 	DueDate      *time.Time     `json:"dueDate,omitempty"`
 	ResolvedAt   *time.Time     `json:"resolvedAt,omitempty"`
+	ClosedAt     *time.Time     `json:"closedAt,omitempty"`
 	CreatedAt    time.Time      `json:"createdAt"`
 	UpdatedAt    time.Time      `json:"updatedAt"`
+	DeletedAt    gorm.DeletedAt `gorm:"index" json:"-"`
+
+	// Contact details of a reporter without an account (QR tag reports)
+	ReporterName    string `json:"reporterName,omitempty"`
+	ReporterContact string `json:"reporterContact,omitempty"`
+
+	// Cost estimate; above the approval threshold it needs sign-off
+	EstimatedLaborCost  float64 `gorm:"type:numeric(12,2);default:0" json:"estimatedLaborCost"`
+	EstimatedPartsCost  float64 `gorm:"type:numeric(12,2);default:0" json:"estimatedPartsCost"`
+	EstimatedVendorCost float64 `gorm:"type:numeric(12,2);default:0" json:"estimatedVendorCost"`
+
+	// Cost approval
+	CostApproval         CostApprovalStatus `gorm:"type:varchar(20)" json:"costApproval,omitempty"`
+	ApprovedCost         float64            `gorm:"type:numeric(12,2);default:0" json:"approvedCost,omitempty"`
+	CostDecidedByID      *uuid.UUID         `gorm:"type:uuid" json:"costDecidedById,omitempty"`
+	CostDecidedAt        *time.Time         `json:"costDecidedAt,omitempty"`
+	CostDecisionReason   string             `json:"costDecisionReason,omitempty"`
+	StatusBeforeApproval TicketStatus       `gorm:"type:varchar(20)" json:"-"`
+
+	// Warranty or service contract covering the asset when the ticket was
+	// raised; the vendor should be called instead of a technician.
+	ContractID *uuid.UUID `gorm:"type:uuid" json:"contractId,omitempty"`
+
+	// Outside contractor the ticket is dispatched to instead of a technician
+	VendorID          *uuid.UUID `gorm:"type:uuid;index" json:"vendorId,omitempty"`
+	VendorAssignedAt  *time.Time `json:"vendorAssignedAt,omitempty"`
+	VendorRespondedAt *time.Time `json:"vendorRespondedAt,omitempty"` // first comment through their link
+
+	// Department the ticket's costs are charged to; the requester's unless
+	// overridden.
+	DepartmentID *uuid.UUID `gorm:"type:uuid;index" json:"departmentId,omitempty"`
+
+	// Parent ticket when this is one work item of a larger job
+	ParentID *uuid.UUID `gorm:"type:uuid;index" json:"parentId,omitempty"`
+
+	// Primary ticket this one was merged into and closed as a duplicate of
+	MergedIntoID *uuid.UUID `gorm:"type:uuid" json:"mergedIntoId,omitempty"`
+
+	// Values of the admin-defined custom fields, by field key
+	CustomFields JSONMap `gorm:"default:'{}';index:idx_ticket_custom_fields,type:gin" json:"customFields"`
+
+	// SLA
+	SLAPolicyID      *uuid.UUID `gorm:"column:sla_policy_id;type:uuid" json:"slaPolicyId,omitempty"`
+	ResponseDueAt    *time.Time `json:"responseDueAt,omitempty"`
+	FirstRespondedAt *time.Time `json:"firstRespondedAt,omitempty"`
+	SLAPausedAt      *time.Time `gorm:"column:sla_paused_at" json:"slaPausedAt,omitempty"`
+	SLAPausedSeconds int64      `gorm:"column:sla_paused_seconds;default:0" json:"slaPausedSeconds"`
+	// DueDateManual is set when DueDate was given by hand rather than
+	// taken from the SLA policy; a new policy then leaves it alone.
+	DueDateManual bool `gorm:"not null;default:false" json:"dueDateManual"`
+
+	// Derived from the deadlines above on load; not stored.
+	ResponseBreached   bool `gorm:"-" json:"responseBreached"`
+	ResolutionBreached bool `gorm:"-" json:"resolutionBreached"`
+
+	// Sums of the costs above. Filled in on load.
+	TotalCost     float64 `gorm:"-" json:"totalCost"`
+	EstimatedCost float64 `gorm:"-" json:"estimatedCost"`
+
+	// Rollup of the sub-tickets, filled in on load for tickets that have any.
+	Progress *TicketProgress `gorm:"-" json:"progress,omitempty"`
 
 	// Relations
-	CreatedBy   *User        `gorm:"foreignKey:CreatedByID" json:"createdBy,omitempty"`
-	AssignedTo  *User        `gorm:"foreignKey:AssignedToID" json:"assignedTo,omitempty"`
-	Comments    []Comment    `gorm:"foreignKey:TicketID" json:"comments,omitempty"`
-	Attachments []Attachment `gorm:"foreignKey:TicketID" json:"attachments,omitempty"`
-	Logs        []TicketLog  `gorm:"foreignKey:TicketID" json:"logs,omitempty"`
+	CreatedBy   *User             `gorm:"foreignKey:CreatedByID" json:"createdBy,omitempty"`
+	SLAPolicy   *SLAPolicy        `gorm:"foreignKey:SLAPolicyID" json:"slaPolicy,omitempty"`
+	AssignedTo  *User             `gorm:"foreignKey:AssignedToID" json:"assignedTo,omitempty"`
+	Asset       *Asset            `gorm:"foreignKey:AssetID" json:"asset,omitempty"`
+	Contract    *ServiceContract  `gorm:"foreignKey:ContractID" json:"contract,omitempty"`
+	Vendor      *Vendor           `gorm:"foreignKey:VendorID" json:"vendor,omitempty"`
+	Department  *Department       `gorm:"foreignKey:DepartmentID" json:"department,omitempty"`
+	LocationRef *Location         `gorm:"foreignKey:LocationID" json:"locationRef,omitempty"`
+	Comments    []Comment         `gorm:"foreignKey:TicketID" json:"comments,omitempty"`
+	Attachments []Attachment      `gorm:"foreignKey:TicketID" json:"attachments,omitempty"`
+	Logs        []TicketLog       `gorm:"foreignKey:TicketID" json:"logs,omitempty"`
+	Checklists  []TicketChecklist `gorm:"foreignKey:TicketID;constraint:OnDelete:CASCADE" json:"checklists,omitempty"`
+	Children    []Ticket          `gorm:"foreignKey:ParentID" json:"children,omitempty"`
+	Tags        []Tag             `gorm:"many2many:ticket_tags;constraint:OnDelete:CASCADE" json:"tags,omitempty"`
 }
 
 func (Ticket) TableName() string {
 	return "tickets"
 }
 
+// TicketProgress counts a ticket's direct sub-tickets and how many of them
+// are resolved or closed.
+type TicketProgress struct {
+	Total    int64 `json:"total"`
+	Resolved int64 `json:"resolved"`
+}
+
+// Complete reports whether every sub-ticket is resolved or closed.
+func (p TicketProgress) Complete() bool {
+	return p.Resolved >= p.Total
+}
+
+// AfterFind fills in the SLA breach flags for loaded tickets.
+func (t *Ticket) AfterFind(tx *gorm.DB) error {
+	t.EvaluateSLA(time.Now())
+	t.SumCosts()
+	return nil
+}
+
+// SumCosts fills in TotalCost and EstimatedCost.
+func (t *Ticket) SumCosts() {
+	t.TotalCost = t.LaborCost + t.PartsCost + t.VendorCost
+	t.EstimatedCost = t.EstimatedLaborCost + t.EstimatedPartsCost + t.EstimatedVendorCost
+}
+
+// EvaluateSLA sets ResponseBreached and ResolutionBreached as of now. A
+// deadline is measured against the moment its clock stopped: the first
+// response, resolution or closure, or the start of a pause.
+func (t *Ticket) EvaluateSLA(now time.Time) {
+	t.ResponseBreached = deadlineMissed(t.ResponseDueAt, now, t.FirstRespondedAt, t.SLAPausedAt)
+	t.ResolutionBreached = deadlineMissed(t.DueDate, now, t.ResolvedAt, t.ClosedAt, t.SLAPausedAt)
+}
+
+// deadlineMissed compares due against the first non-nil stop time, or now.
+func deadlineMissed(due *time.Time, now time.Time, stops ...*time.Time) bool {
+	if due == nil {
+		return false
+	}
+	for _, stop := range stops {
+		if stop != nil {
+			return stop.After(*due)
+		}
+	}
+	return now.After(*due)
+}
+
 type Comment struct {
 	ID        uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
 	Content   string    `gorm:"type:text;not null" json:"content"`
@@ -70,6 +216,9 @@ type Comment struct {
 	UserID    uuid.UUID `gorm:"type:uuid;not null" json:"userId"`
 	CreatedAt time.Time `json:"createdAt"`
 
+	// Set when posted by a vendor through their ticket link
+	VendorID *uuid.UUID `gorm:"type:uuid" json:"vendorId,omitempty"`
+
 	// Relations
 	User *User `gorm:"foreignKey:UserID" json:"user,omitempty"`
 }
@@ -91,6 +240,54 @@ type TicketLog struct {
 	User *User `gorm:"foreignKey:UserID" json:"user,omitempty"`
 }
 
+// Ticket log actions
+const (
+	LogActionCreated            = "CREATED"
+	LogActionTitleChanged       = "TITLE_CHANGED"
+	LogActionDescriptionChanged = "DESCRIPTION_CHANGED"
+	LogActionStatusChanged      = "STATUS_CHANGED"
+	LogActionPriorityChanged    = "PRIORITY_CHANGED"
+	LogActionCategoryChanged    = "CATEGORY_CHANGED"
+	LogActionLocationChanged    = "LOCATION_CHANGED"
+	LogActionAssigneeChanged    = "ASSIGNEE_CHANGED"
+	LogActionVendorChanged      = "VENDOR_CHANGED"
+	LogActionDepartmentChanged  = "DEPARTMENT_CHANGED"
+	LogActionParentChanged      = "PARENT_CHANGED"
+	LogActionSubTicketAdded     = "SUB_TICKET_ADDED"
+	LogActionLinkAdded          = "LINK_ADDED"
+	LogActionLinkRemoved        = "LINK_REMOVED"
+	LogActionMerged             = "MERGED"
+	LogActionMergedInto         = "MERGED_INTO"
+	LogActionTagAdded           = "TAG_ADDED"
+	LogActionTagRemoved         = "TAG_REMOVED"
+	LogActionCustomFieldChanged = "CUSTOM_FIELD_CHANGED"
+	LogActionDueDateChanged     = "DUE_DATE_CHANGED"
+	LogActionAssetChanged       = "ASSET_CHANGED"
+	LogActionCostChanged        = "COST_CHANGED"
+	LogActionEstimateChanged    = "ESTIMATE_CHANGED"
+	LogActionCostApproved       = "COST_APPROVED"
+	LogActionCostRejected       = "COST_REJECTED"
+	LogActionApprovalRequested  = "APPROVAL_REQUESTED"
+	LogActionApprovalCompleted  = "APPROVAL_COMPLETED"
+	LogActionChecklistAdded     = "CHECKLIST_ADDED"
+	LogActionChecklistRemoved   = "CHECKLIST_REMOVED"
+	LogActionChecklistItemDone  = "CHECKLIST_ITEM_DONE"
+	LogActionChecklistReopened  = "CHECKLIST_ITEM_REOPENED"
+	LogActionPartsUsed          = "PARTS_USED"
+	LogActionPartsReturned      = "PARTS_RETURNED"
+	LogActionPurchaseSubmitted  = "PURCHASE_SUBMITTED"
+	LogActionPurchaseReceived   = "PURCHASE_RECEIVED"
+	LogActionPurchaseCancelled  = "PURCHASE_CANCELLED"
+	LogActionTimeLogged         = "TIME_LOGGED"
+	LogActionTimeCorrected      = "TIME_CORRECTED"
+	LogActionTimeDeleted        = "TIME_DELETED"
+	LogActionCommentAdded       = "COMMENT_ADDED"
+	LogActionAttachmentAdded    = "ATTACHMENT_ADDED"
+	LogActionAttachmentRemoved  = "ATTACHMENT_REMOVED"
+	LogActionDeleted            = "DELETED"
+	LogActionEscalated          = "ESCALATED"
+)
+
 func (TicketLog) TableName() string {
 	return "ticket_logs"
 }
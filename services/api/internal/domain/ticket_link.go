@@ -0,0 +1,69 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type TicketLinkType string
+
+// Links are stored in one direction only; DUPLICATED_BY and BLOCKED_BY
+// are how a DUPLICATE_OF or BLOCKS link reads from the other ticket.
+const (
+	LinkDuplicateOf  TicketLinkType = "DUPLICATE_OF"
+	LinkDuplicatedBy TicketLinkType = "DUPLICATED_BY"
+	LinkBlocks       TicketLinkType = "BLOCKS"
+	LinkBlockedBy    TicketLinkType = "BLOCKED_BY"
+	LinkRelatedTo    TicketLinkType = "RELATED_TO"
+)
+
+// IsValid reports whether t is a link type, in either direction.
+func (t TicketLinkType) IsValid() bool {
+	switch t {
+	case LinkDuplicateOf, LinkDuplicatedBy, LinkBlocks, LinkBlockedBy, LinkRelatedTo:
+		return true
+	}
+	return false
+}
+
+// Inverse returns how a link of type t reads from the other end.
+func (t TicketLinkType) Inverse() TicketLinkType {
+	switch t {
+	case LinkDuplicateOf:
+		return LinkDuplicatedBy
+	case LinkDuplicatedBy:
+		return LinkDuplicateOf
+	case LinkBlocks:
+		return LinkBlockedBy
+	case LinkBlockedBy:
+		return LinkBlocks
+	default:
+		return t
+	}
+}
+
+// Stored reports whether links of type t are stored as is, rather than
+// as their inverse.
+func (t TicketLinkType) Stored() bool {
+	return t == LinkDuplicateOf || t == LinkBlocks || t == LinkRelatedTo
+}
+
+// TicketLink relates two tickets: the source is a duplicate of, blocks, or
+// is related to the target.
+type TicketLink struct {
+	ID          uuid.UUID      `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	SourceID    uuid.UUID      `gorm:"type:uuid;not null;uniqueIndex:idx_ticket_link" json:"sourceId"`
+	TargetID    uuid.UUID      `gorm:"type:uuid;not null;uniqueIndex:idx_ticket_link;index" json:"targetId"`
+	Type        TicketLinkType `gorm:"type:varchar(20);not null;uniqueIndex:idx_ticket_link" json:"type"`
+	CreatedByID uuid.UUID      `gorm:"type:uuid;not null" json:"createdById"`
+	CreatedAt   time.Time      `json:"createdAt"`
+
+	// Relations
+	Source *Ticket `gorm:"foreignKey:SourceID;constraint:OnDelete:CASCADE" json:"source,omitempty"`
+	Target *Ticket `gorm:"foreignKey:TargetID;constraint:OnDelete:CASCADE" json:"target,omitempty"`
+}
+
+func (TicketLink) TableName() string {
+	return "ticket_links"
+}
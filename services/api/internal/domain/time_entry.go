@@ -0,0 +1,60 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TimeEntry is time a user spent on a ticket, tracked with a timer or
+// entered afterwards. A running timer has no EndedAt; each user can have at
+// most one.
+type TimeEntry struct {
+	ID              uuid.UUID  `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	TicketID        uuid.UUID  `gorm:"type:uuid;not null;index" json:"ticketId"`
+	UserID          uuid.UUID  `gorm:"type:uuid;not null;index;uniqueIndex:idx_time_entry_running,where:ended_at IS NULL" json:"userId"`
+	StartedAt       time.Time  `gorm:"not null" json:"startedAt"`
+	EndedAt         *time.Time `json:"endedAt,omitempty"`
+	DurationSeconds int64      `gorm:"not null;default:0" json:"durationSeconds"`
+	Note            string     `gorm:"type:text" json:"note,omitempty"`
+	Billable        bool       `gorm:"not null" json:"billable"`
+	Manual          bool       `gorm:"not null" json:"manual"`
+	HourlyRate      float64    `gorm:"type:numeric(10,2);default:0" json:"hourlyRate"` // the user's rate when the entry was recorded
+	Cost            float64    `gorm:"type:numeric(12,2);default:0" json:"cost"`
+	CreatedAt       time.Time  `json:"createdAt"`
+	UpdatedAt       time.Time  `json:"updatedAt"`
+
+	// Set when an admin last corrected the entry; earlier corrections are
+	// in the ticket log.
+	CorrectedByID    *uuid.UUID `gorm:"type:uuid" json:"correctedById,omitempty"`
+	CorrectionReason string     `json:"correctionReason,omitempty"`
+
+	// Relations
+	User *User `gorm:"foreignKey:UserID" json:"user,omitempty"`
+}
+
+func (TimeEntry) TableName() string {
+	return "time_entries"
+}
+
+// Running reports whether the entry is a timer that has not been stopped.
+func (e *TimeEntry) Running() bool {
+	return e.EndedAt == nil
+}
+
+// Duration returns the length of the entry.
+func (e *TimeEntry) Duration() time.Duration {
+	return time.Duration(e.DurationSeconds) * time.Second
+}
+
+// LaborRate is the default hourly rate for a role, used for users who have
+// no rate of their own.
+type LaborRate struct {
+	Role       UserRole  `gorm:"type:varchar(20);primaryKey" json:"role"`
+	HourlyRate float64   `gorm:"type:numeric(10,2);not null" json:"hourlyRate"`
+	UpdatedAt  time.Time `json:"updatedAt"`
+}
+
+func (LaborRate) TableName() string {
+	return "labor_rates"
+}
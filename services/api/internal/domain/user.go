@@ -12,8 +12,18 @@ const (
 	RoleUser       UserRole = "USER"
 	RoleTechnician UserRole = "TECHNICIAN"
 	RoleAdmin      UserRole = "ADMIN"
+	RoleApprover   UserRole = "APPROVER" // signs off spending over the approval threshold
 )
 
+// IsValid reports whether r is one of the known roles.
+func (r UserRole) IsValid() bool {
+	switch r {
+	case RoleUser, RoleTechnician, RoleAdmin, RoleApprover:
+		return true
+	}
+	return false
+}
+
 type UserStatus string
 
 const (
@@ -21,18 +31,29 @@ const (
 	StatusInactive UserStatus = "inactive"
 )
 
+// PublicReporterEmail identifies the account that problem reports from
+// people without a login are filed under.
+const PublicReporterEmail = "public-reporter@maintenance-system.local"
+
 type User struct {
-	ID         uuid.UUID  `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
-	Email      string     `gorm:"uniqueIndex;not null" json:"email"`
-	Password   string     `gorm:"not null" json:"-"`
-	Name       string     `gorm:"not null" json:"name"`
-	Role       UserRole   `gorm:"type:varchar(20);default:'USER'" json:"role"`
-	Phone      string     `json:"phone,omitempty"`
-	Department string     `json:"department,omitempty"`
-	Avatar     string     `json:"avatar,omitempty"`
-	Status     UserStatus `gorm:"type:varchar(20);default:'active'" json:"status"`
-	CreatedAt  time.Time  `json:"createdAt"`
-	UpdatedAt  time.Time  `json:"updatedAt"`
+	ID             uuid.UUID  `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	Email          string     `gorm:"uniqueIndex;not null" json:"email"`
+	Password       string     `gorm:"not null" json:"-"`
+	Name           string     `gorm:"not null" json:"name"`
+	Role           UserRole   `gorm:"type:varchar(20);default:'USER'" json:"role"`
+	Phone          string     `json:"phone,omitempty"`
+	Department     string     `json:"department,omitempty"`
+	DepartmentHead bool       `gorm:"default:false" json:"departmentHead"` // decides department-head approval steps
+	Avatar         string     `json:"avatar,omitempty"`
+	Status         UserStatus `gorm:"type:varchar(20);default:'active'" json:"status"`
+	HourlyRate     *float64   `gorm:"type:numeric(10,2)" json:"hourlyRate,omitempty"` // overrides the role's labor rate
+	CreatedAt      time.Time  `json:"createdAt"`
+	UpdatedAt      time.Time  `json:"updatedAt"`
+
+	// DepartmentID links the user to a department; Department keeps its name,
+	// which approval thresholds and steps match on.
+	DepartmentID  *uuid.UUID  `gorm:"type:uuid;index" json:"departmentId,omitempty"`
+	DepartmentRef *Department `gorm:"foreignKey:DepartmentID" json:"departmentRef,omitempty"`
 
 	// Relations
 	CreatedTickets  []Ticket `gorm:"foreignKey:CreatedByID" json:"-"`
@@ -0,0 +1,107 @@
+package domain
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// VendorPortalEmail identifies the account that comments and uploads made
+// through vendor ticket links are filed under.
+const VendorPortalEmail = "vendor-portal@maintenance-system.local"
+
+// Vendor is an outside contractor, such as an elevator or fire-system
+// company, that tickets can be dispatched to. Vendors have no login; they
+// work through a tokenized link per ticket.
+type Vendor struct {
+	ID         uuid.UUID        `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	Name       string           `gorm:"uniqueIndex:idx_vendor_name,where:deleted_at IS NULL;not null" json:"name"`
+	Email      string           `json:"email,omitempty"`
+	Phone      string           `json:"phone,omitempty"`
+	Address    string           `gorm:"type:text" json:"address,omitempty"`
+	Categories TicketCategories `gorm:"default:'[]'" json:"categories"` // categories of work they take on
+	Notes      string           `gorm:"type:text" json:"notes,omitempty"`
+	Active     bool             `gorm:"default:true" json:"active"`
+	CreatedAt  time.Time        `json:"createdAt"`
+	UpdatedAt  time.Time        `json:"updatedAt"`
+	DeletedAt  gorm.DeletedAt   `gorm:"index" json:"-"`
+
+	// Relations
+	Contacts []VendorContact `gorm:"foreignKey:VendorID;constraint:OnDelete:CASCADE" json:"contacts,omitempty"`
+}
+
+func (Vendor) TableName() string {
+	return "vendors"
+}
+
+type VendorContact struct {
+	ID       uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	VendorID uuid.UUID `gorm:"type:uuid;not null;index" json:"vendorId"`
+	Name     string    `gorm:"not null" json:"name"`
+	Title    string    `json:"title,omitempty"`
+	Phone    string    `json:"phone,omitempty"`
+	Email    string    `json:"email,omitempty"`
+	Primary  bool      `gorm:"column:is_primary;not null;default:false" json:"primary"`
+}
+
+func (VendorContact) TableName() string {
+	return "vendor_contacts"
+}
+
+// VendorAccess is a link that lets a vendor view a ticket dispatched to
+// them, comment and upload files. Only a hash of the token is stored.
+type VendorAccess struct {
+	ID          uuid.UUID  `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	VendorID    uuid.UUID  `gorm:"type:uuid;not null;index" json:"vendorId"`
+	TicketID    uuid.UUID  `gorm:"type:uuid;not null;index" json:"ticketId"`
+	TokenHash   string     `gorm:"uniqueIndex;not null" json:"-"`
+	ExpiresAt   time.Time  `gorm:"not null" json:"expiresAt"`
+	RevokedAt   *time.Time `json:"revokedAt,omitempty"`
+	LastUsedAt  *time.Time `json:"lastUsedAt,omitempty"`
+	CreatedByID uuid.UUID  `gorm:"type:uuid;not null" json:"createdById"`
+	CreatedAt   time.Time  `json:"createdAt"`
+}
+
+func (VendorAccess) TableName() string {
+	return "vendor_accesses"
+}
+
+// Valid reports whether the link can be used at now.
+func (a *VendorAccess) Valid(now time.Time) bool {
+	return a.RevokedAt == nil && now.Before(a.ExpiresAt)
+}
+
+// TicketCategories is a list of ticket categories stored in a jsonb column.
+type TicketCategories []TicketCategory
+
+func (TicketCategories) GormDataType() string {
+	return "jsonb"
+}
+
+func (c TicketCategories) Value() (driver.Value, error) {
+	if c == nil {
+		return "[]", nil
+	}
+	b, err := json.Marshal(c)
+	return string(b), err
+}
+
+func (c *TicketCategories) Scan(value interface{}) error {
+	var b []byte
+	switch v := value.(type) {
+	case nil:
+		*c = nil
+		return nil
+	case []byte:
+		b = v
+	case string:
+		b = []byte(v)
+	default:
+		return fmt.Errorf("unsupported TicketCategories source %T", value)
+	}
+	return json.Unmarshal(b, c)
+}
@@ -0,0 +1,417 @@
+package handler
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/maintenance-system/api/internal/domain"
+	"github.com/maintenance-system/api/internal/repository"
+	"github.com/maintenance-system/api/internal/service"
+)
+
+type ApprovalHandler struct {
+	approvalService service.ApprovalService
+}
+
+func NewApprovalHandler(approvalService service.ApprovalService) *ApprovalHandler {
+	return &ApprovalHandler{approvalService: approvalService}
+}
+
+type ApprovalStepRequest struct {
+	// Steps sharing a stage run in parallel; stages run in order.
+	Stage        int    `json:"stage" binding:"required,min=1"`
+	Name         string `json:"name"`
+	ApproverType string `json:"approverType" binding:"required,oneof=USER ROLE DEPARTMENT_HEAD"`
+	ApproverID   string `json:"approverId" binding:"omitempty,uuid"`
+	ApproverRole string `json:"approverRole" binding:"omitempty,oneof=USER TECHNICIAN ADMIN APPROVER"`
+}
+
+type CreateApprovalWorkflowRequest struct {
+	Name              string                `json:"name" binding:"required"`
+	Description       string                `json:"description"`
+	ExpiresAfterHours int                   `json:"expiresAfterHours" binding:"min=0"`
+	BlockedStatuses   []string              `json:"blockedStatuses" binding:"dive,oneof=OPEN IN_PROGRESS PENDING AWAITING_APPROVAL RESOLVED CLOSED"`
+	Steps             []ApprovalStepRequest `json:"steps" binding:"required,min=1,dive"`
+}
+
+type UpdateApprovalWorkflowRequest struct {
+	Name              string  `json:"name"`
+	Description       *string `json:"description"`
+	ExpiresAfterHours *int    `json:"expiresAfterHours" binding:"omitempty,min=0"`
+	Active            *bool   `json:"active"`
+	// BlockedStatuses and Steps replace the current lists when present.
+	BlockedStatuses []string              `json:"blockedStatuses" binding:"omitempty,dive,oneof=OPEN IN_PROGRESS PENDING AWAITING_APPROVAL RESOLVED CLOSED"`
+	Steps           []ApprovalStepRequest `json:"steps" binding:"omitempty,dive"`
+}
+
+type CreateApprovalRequest struct {
+	WorkflowID  string `json:"workflowId" binding:"required,uuid"`
+	TicketID    string `json:"ticketId" binding:"omitempty,uuid"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+}
+
+type ApprovalDecisionRequest struct {
+	Comment string `json:"comment"`
+}
+
+type DelegateApprovalRequest struct {
+	UserID  string `json:"userId" binding:"required,uuid"`
+	Comment string `json:"comment"`
+}
+
+func approvalSteps(reqs []ApprovalStepRequest) []domain.ApprovalStep {
+	steps := make([]domain.ApprovalStep, 0, len(reqs))
+	for _, req := range reqs {
+		step := domain.ApprovalStep{
+			Stage:        req.Stage,
+			Name:         req.Name,
+			ApproverType: domain.ApproverType(req.ApproverType),
+			ApproverRole: domain.UserRole(req.ApproverRole),
+		}
+		if req.ApproverID != "" {
+			approverID := uuid.MustParse(req.ApproverID)
+			step.ApproverID = &approverID
+		}
+		steps = append(steps, step)
+	}
+	return steps
+}
+
+func ticketStatuses(values []string) domain.TicketStatuses {
+	statuses := make(domain.TicketStatuses, 0, len(values))
+	for _, v := range values {
+		statuses = append(statuses, domain.TicketStatus(v))
+	}
+	return statuses
+}
+
+func (h *ApprovalHandler) GetWorkflows(c *gin.Context) {
+	activeOnly, _ := strconv.ParseBool(c.Query("active"))
+
+	workflows, err := h.approvalService.GetWorkflows(activeOnly)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch approval workflows"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": workflows})
+}
+
+func (h *ApprovalHandler) GetWorkflow(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid workflow ID"})
+		return
+	}
+
+	workflow, err := h.approvalService.GetWorkflow(id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Approval workflow not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": workflow})
+}
+
+func (h *ApprovalHandler) CreateWorkflow(c *gin.Context) {
+	var req CreateApprovalWorkflowRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID := c.MustGet("userID").(uuid.UUID)
+
+	workflow := &domain.ApprovalWorkflow{
+		Name:              req.Name,
+		Description:       req.Description,
+		ExpiresAfterHours: req.ExpiresAfterHours,
+		BlockedStatuses:   ticketStatuses(req.BlockedStatuses),
+		Active:            true,
+		CreatedByID:       userID,
+		Steps:             approvalSteps(req.Steps),
+	}
+
+	if err := h.approvalService.CreateWorkflow(workflow); err != nil {
+		c.JSON(approvalErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"success": true, "data": workflow})
+}
+
+func (h *ApprovalHandler) UpdateWorkflow(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid workflow ID"})
+		return
+	}
+
+	var req UpdateApprovalWorkflowRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	updates := make(map[string]interface{})
+	if req.Name != "" {
+		updates["name"] = req.Name
+	}
+	if req.Description != nil {
+		updates["description"] = *req.Description
+	}
+	if req.ExpiresAfterHours != nil {
+		updates["expiresAfterHours"] = *req.ExpiresAfterHours
+	}
+	if req.Active != nil {
+		updates["active"] = *req.Active
+	}
+	if req.BlockedStatuses != nil {
+		updates["blockedStatuses"] = ticketStatuses(req.BlockedStatuses)
+	}
+	if req.Steps != nil {
+		updates["steps"] = approvalSteps(req.Steps)
+	}
+
+	workflow, err := h.approvalService.UpdateWorkflow(id, updates)
+	if err != nil {
+		c.JSON(approvalErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": workflow})
+}
+
+func (h *ApprovalHandler) DeleteWorkflow(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid workflow ID"})
+		return
+	}
+
+	if err := h.approvalService.DeleteWorkflow(id); err != nil {
+		c.JSON(approvalErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "message": "Approval workflow deleted"})
+}
+
+// GetAll lists approval requests. ?mine=true limits them to the caller's
+// own requests.
+func (h *ApprovalHandler) GetAll(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+
+	filter := repository.ApprovalFilter{
+		Status:     c.Query("status"),
+		WorkflowID: queryUUID(c, "workflowId"),
+		TicketID:   queryUUID(c, "ticketId"),
+		Page:       page,
+		Limit:      limit,
+	}
+	if mine, _ := strconv.ParseBool(c.Query("mine")); mine {
+		userID := c.MustGet("userID").(uuid.UUID)
+		filter.RequesterID = &userID
+	}
+
+	h.list(c, filter)
+}
+
+// GetByTicket lists the approval requests attached to a ticket.
+func (h *ApprovalHandler) GetByTicket(c *gin.Context) {
+	ticketID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ticket ID"})
+		return
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+
+	h.list(c, repository.ApprovalFilter{
+		Status:   c.Query("status"),
+		TicketID: &ticketID,
+		Page:     page,
+		Limit:    limit,
+	})
+}
+
+func (h *ApprovalHandler) list(c *gin.Context, filter repository.ApprovalFilter) {
+	requests, total, err := h.approvalService.GetRequests(filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch approval requests"})
+		return
+	}
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = 20
+	}
+	totalPages := (int(total) + limit - 1) / limit
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    requests,
+		"meta": gin.H{
+			"total":      total,
+			"page":       filter.Page,
+			"limit":      limit,
+			"totalPages": totalPages,
+		},
+	})
+}
+
+// Pending is the caller's inbox: requests waiting on their decision.
+func (h *ApprovalHandler) Pending(c *gin.Context) {
+	userID := c.MustGet("userID").(uuid.UUID)
+
+	requests, err := h.approvalService.Pending(userID)
+	if err != nil {
+		c.JSON(approvalErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": requests})
+}
+
+func (h *ApprovalHandler) GetByID(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid approval request ID"})
+		return
+	}
+
+	request, err := h.approvalService.GetRequest(id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Approval request not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": request})
+}
+
+func (h *ApprovalHandler) Create(c *gin.Context) {
+	var req CreateApprovalRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID := c.MustGet("userID").(uuid.UUID)
+
+	request := &domain.ApprovalRequest{
+		WorkflowID:  uuid.MustParse(req.WorkflowID),
+		RequesterID: userID,
+		Title:       req.Title,
+		Description: req.Description,
+	}
+	if req.TicketID != "" {
+		ticketID := uuid.MustParse(req.TicketID)
+		request.TicketID = &ticketID
+	}
+
+	if err := h.approvalService.Request(request); err != nil {
+		c.JSON(approvalErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"success": true, "data": request})
+}
+
+func (h *ApprovalHandler) Approve(c *gin.Context) {
+	h.decide(c, h.approvalService.Approve)
+}
+
+func (h *ApprovalHandler) Reject(c *gin.Context) {
+	h.decide(c, h.approvalService.Reject)
+}
+
+func (h *ApprovalHandler) decide(c *gin.Context, decide func(id, userID uuid.UUID, comment string) (*domain.ApprovalRequest, error)) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid approval request ID"})
+		return
+	}
+
+	var req ApprovalDecisionRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID := c.MustGet("userID").(uuid.UUID)
+
+	request, err := decide(id, userID, req.Comment)
+	if err != nil {
+		c.JSON(approvalErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": request})
+}
+
+func (h *ApprovalHandler) Delegate(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid approval request ID"})
+		return
+	}
+
+	var req DelegateApprovalRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID := c.MustGet("userID").(uuid.UUID)
+
+	request, err := h.approvalService.Delegate(id, userID, uuid.MustParse(req.UserID), req.Comment)
+	if err != nil {
+		c.JSON(approvalErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": request})
+}
+
+func (h *ApprovalHandler) Cancel(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid approval request ID"})
+		return
+	}
+
+	userID := c.MustGet("userID").(uuid.UUID)
+
+	request, err := h.approvalService.Cancel(id, userID)
+	if err != nil {
+		c.JSON(approvalErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": request})
+}
+
+func approvalErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrApprovalWorkflowNotFound), errors.Is(err, service.ErrApprovalNotFound),
+		errors.Is(err, service.ErrTicketNotFound), errors.Is(err, service.ErrUserNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, service.ErrApprovalWorkflowExists), errors.Is(err, service.ErrApprovalWorkflowInUse),
+		errors.Is(err, service.ErrApprovalClosed), errors.Is(err, service.ErrTicketClosed):
+		return http.StatusConflict
+	case errors.Is(err, service.ErrNotApprover), errors.Is(err, service.ErrApprovalForbidden),
+		errors.Is(err, service.ErrApprovalTicketForbidden):
+		return http.StatusForbidden
+	case errors.Is(err, service.ErrInvalidApprovalWorkflow), errors.Is(err, service.ErrInvalidApproval):
+		return http.StatusUnprocessableEntity
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,240 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/maintenance-system/api/internal/domain"
+	"github.com/maintenance-system/api/internal/repository"
+	"github.com/maintenance-system/api/internal/service"
+)
+
+type AssetHandler struct {
+	assetService service.AssetService
+}
+
+func NewAssetHandler(assetService service.AssetService) *AssetHandler {
+	return &AssetHandler{assetService: assetService}
+}
+
+type CreateAssetRequest struct {
+	Code              string                 `json:"code" binding:"required"`
+	Name              string                 `json:"name" binding:"required"`
+	Category          string                 `json:"category" binding:"omitempty,ticket_category"`
+	Make              string                 `json:"make"`
+	Model             string                 `json:"model"`
+	SerialNumber      string                 `json:"serialNumber"`
+	InstallDate       *time.Time             `json:"installDate"`
+	WarrantyExpiresAt *time.Time             `json:"warrantyExpiresAt"`
+	Location          string                 `json:"location"`
+	LocationID        string                 `json:"locationId" binding:"omitempty,uuid"`
+	Status            string                 `json:"status" binding:"omitempty,oneof=ACTIVE UNDER_MAINTENANCE OUT_OF_SERVICE RETIRED"`
+	Properties        map[string]interface{} `json:"properties"`
+}
+
+type UpdateAssetRequest struct {
+	Code              string                 `json:"code"`
+	Name              string                 `json:"name"`
+	Category          string                 `json:"category" binding:"omitempty,ticket_category"`
+	Make              *string                `json:"make"`
+	Model             *string                `json:"model"`
+	SerialNumber      *string                `json:"serialNumber"`
+	InstallDate       *time.Time             `json:"installDate"`
+	WarrantyExpiresAt *time.Time             `json:"warrantyExpiresAt"`
+	Location          *string                `json:"location"`
+	LocationID        string                 `json:"locationId" binding:"omitempty,uuid"`
+	Status            string                 `json:"status" binding:"omitempty,oneof=ACTIVE UNDER_MAINTENANCE OUT_OF_SERVICE RETIRED"`
+	Properties        map[string]interface{} `json:"properties"`
+}
+
+func (h *AssetHandler) GetAll(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	if limit <= 0 {
+		limit = 10
+	}
+
+	filter := repository.AssetFilter{
+		Status:     c.Query("status"),
+		Category:   c.Query("category"),
+		LocationID: queryUUID(c, "locationId"),
+		Search:     c.Query("search"),
+		Page:       page,
+		Limit:      limit,
+	}
+
+	assets, total, err := h.assetService.GetAll(filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch assets"})
+		return
+	}
+
+	totalPages := (int(total) + limit - 1) / limit
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    assets,
+		"meta": gin.H{
+			"total":      total,
+			"page":       page,
+			"limit":      limit,
+			"totalPages": totalPages,
+		},
+	})
+}
+
+func (h *AssetHandler) GetByID(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid asset ID"})
+		return
+	}
+
+	asset, err := h.assetService.GetByID(id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Asset not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": asset})
+}
+
+// GetHistory returns the asset with its past tickets, downtime and cost.
+func (h *AssetHandler) GetHistory(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid asset ID"})
+		return
+	}
+
+	detail, err := h.assetService.GetDetail(id)
+	if err != nil {
+		c.JSON(assetErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": detail})
+}
+
+func (h *AssetHandler) Create(c *gin.Context) {
+	var req CreateAssetRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	asset := &domain.Asset{
+		Code:              req.Code,
+		Name:              req.Name,
+		Category:          domain.TicketCategory(req.Category),
+		Make:              req.Make,
+		Model:             req.Model,
+		SerialNumber:      req.SerialNumber,
+		InstallDate:       req.InstallDate,
+		WarrantyExpiresAt: req.WarrantyExpiresAt,
+		Location:          req.Location,
+		Status:            domain.AssetStatus(req.Status),
+		Properties:        req.Properties,
+	}
+	if req.LocationID != "" {
+		locationID := uuid.MustParse(req.LocationID)
+		asset.LocationID = &locationID
+	}
+
+	if err := h.assetService.Create(asset); err != nil {
+		c.JSON(assetErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"success": true, "data": asset})
+}
+
+func (h *AssetHandler) Update(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid asset ID"})
+		return
+	}
+
+	var req UpdateAssetRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	updates := make(map[string]interface{})
+	if req.Code != "" {
+		updates["code"] = req.Code
+	}
+	if req.Name != "" {
+		updates["name"] = req.Name
+	}
+	if req.Category != "" {
+		updates["category"] = req.Category
+	}
+	if req.Make != nil {
+		updates["make"] = *req.Make
+	}
+	if req.Model != nil {
+		updates["model"] = *req.Model
+	}
+	if req.SerialNumber != nil {
+		updates["serialNumber"] = *req.SerialNumber
+	}
+	if req.InstallDate != nil {
+		updates["installDate"] = *req.InstallDate
+	}
+	if req.WarrantyExpiresAt != nil {
+		updates["warrantyExpiresAt"] = *req.WarrantyExpiresAt
+	}
+	if req.Location != nil {
+		updates["location"] = *req.Location
+	}
+	if req.LocationID != "" {
+		updates["locationId"] = uuid.MustParse(req.LocationID)
+	}
+	if req.Status != "" {
+		updates["status"] = req.Status
+	}
+	if req.Properties != nil {
+		updates["properties"] = req.Properties
+	}
+
+	asset, err := h.assetService.Update(id, updates)
+	if err != nil {
+		c.JSON(assetErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": asset})
+}
+
+func (h *AssetHandler) Delete(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid asset ID"})
+		return
+	}
+
+	if err := h.assetService.Delete(id); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete asset"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "message": "Asset deleted"})
+}
+
+func assetErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrAssetNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, service.ErrAssetCodeTaken):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,182 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/maintenance-system/api/internal/domain"
+	"github.com/maintenance-system/api/internal/service"
+)
+
+// AssetTagHandler serves asset QR tags and the "report a problem" flow
+// behind them.
+type AssetTagHandler struct {
+	tagService service.AssetTagService
+	captcha    service.CaptchaVerifier
+}
+
+func NewAssetTagHandler(tagService service.AssetTagService, captcha service.CaptchaVerifier) *AssetTagHandler {
+	return &AssetTagHandler{tagService: tagService, captcha: captcha}
+}
+
+type PublicReportRequest struct {
+	Title           string `json:"title" binding:"required,min=5"`
+	Description     string `json:"description" binding:"required,min=10"`
+	Priority        string `json:"priority" binding:"omitempty,ticket_priority"`
+	Category        string `json:"category" binding:"omitempty,ticket_category"`
+	ReporterName    string `json:"reporterName"`
+	ReporterContact string `json:"reporterContact"`
+	CaptchaToken    string `json:"captchaToken"`
+	// CustomFields sets the category's custom fields.
+	CustomFields map[string]interface{} `json:"customFields"`
+	// CreateAnyway and DuplicateOf answer a 409 as on ticket creation;
+	// they are ignored for anonymous reports.
+	CreateAnyway bool   `json:"createAnyway"`
+	DuplicateOf  string `json:"duplicateOf" binding:"omitempty,uuid"`
+}
+
+// QRCode renders an asset's tag (?format=png|svg, ?size=pixels for PNG).
+func (h *AssetTagHandler) QRCode(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid asset ID"})
+		return
+	}
+
+	size, _ := strconv.Atoi(c.DefaultQuery("size", "256"))
+
+	data, contentType, err := h.tagService.QRCode(id, c.DefaultQuery("format", "png"), size)
+	if err != nil {
+		c.JSON(assetTagErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Data(http.StatusOK, contentType, data)
+}
+
+// Labels renders a printable PDF sheet of tags for ?ids=<id>,<id>,...
+func (h *AssetTagHandler) Labels(c *gin.Context) {
+	var ids []uuid.UUID
+	for _, s := range strings.Split(c.Query("ids"), ",") {
+		if s == "" {
+			continue
+		}
+		id, err := uuid.Parse(s)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid asset ID"})
+			return
+		}
+		ids = append(ids, id)
+	}
+	if len(ids) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "No assets selected"})
+		return
+	}
+
+	pdf, err := h.tagService.LabelSheet(ids)
+	if err != nil {
+		c.JSON(assetTagErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Header("Content-Disposition", `inline; filename="asset-labels.pdf"`)
+	c.Data(http.StatusOK, "application/pdf", pdf)
+}
+
+// Resolve returns the summary of the asset a scanned tag points at.
+func (h *AssetTagHandler) Resolve(c *gin.Context) {
+	summary, err := h.tagService.Summary(c.Param("token"))
+	if err != nil {
+		c.JSON(assetTagErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": summary})
+}
+
+// Report files a ticket against a scanned asset. Signed-in users report
+// under their own account; anyone else must give a name and pass the
+// captcha. Signed-in users get 409 and the likely duplicates, as on ticket
+// creation; an anonymous report like an open ticket is added to it as a
+// comment and answered with 200.
+func (h *AssetTagHandler) Report(c *gin.Context) {
+	var req PublicReportRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ticket := &domain.Ticket{
+		Title:           req.Title,
+		Description:     req.Description,
+		Priority:        domain.TicketPriority(req.Priority),
+		Category:        domain.TicketCategory(req.Category),
+		ReporterName:    req.ReporterName,
+		ReporterContact: req.ReporterContact,
+	}
+
+	opts := service.ReportOptions{CustomFields: req.CustomFields}
+	if userID, ok := c.Get("userID"); ok {
+		ticket.CreatedByID = userID.(uuid.UUID)
+		opts.CreateAnyway = req.CreateAnyway
+		if req.DuplicateOf != "" {
+			id := uuid.MustParse(req.DuplicateOf)
+			opts.DuplicateOf = &id
+		}
+	} else {
+		if req.ReporterName == "" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Reporter name is required"})
+			return
+		}
+		if err := h.captcha.Verify(req.CaptchaToken, c.ClientIP()); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": service.ErrCaptchaFailed.Error()})
+			return
+		}
+	}
+
+	result, err := h.tagService.Report(c.Param("token"), ticket, opts)
+	if err != nil {
+		c.JSON(assetTagErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	switch {
+	case result.Duplicates != nil:
+		c.JSON(http.StatusConflict, gin.H{
+			"error":      "Similar open tickets already exist",
+			"duplicates": result.Duplicates,
+		})
+	case result.Comment != nil:
+		c.JSON(http.StatusOK, gin.H{"success": true, "data": gin.H{
+			"id":        result.Ticket.ID,
+			"title":     result.Ticket.Title,
+			"status":    result.Ticket.Status,
+			"commentId": result.Comment.ID,
+		}})
+	default:
+		c.JSON(http.StatusCreated, gin.H{"success": true, "data": gin.H{
+			"id":     ticket.ID,
+			"title":  ticket.Title,
+			"status": ticket.Status,
+		}})
+	}
+}
+
+func assetTagErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrInvalidAssetTag), errors.Is(err, service.ErrAssetNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, service.ErrAssetRetired):
+		return http.StatusGone
+	case errors.Is(err, service.ErrTicketNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, service.ErrInvalidCustomField), errors.Is(err, service.ErrInvalidCustomValue):
+		return http.StatusUnprocessableEntity
+	default:
+		return http.StatusInternalServerError
+	}
+}
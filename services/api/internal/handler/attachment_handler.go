@@ -12,19 +12,22 @@ import (
 	"github.com/google/uuid"
 	"github.com/maintenance-system/api/internal/domain"
 	"github.com/maintenance-system/api/internal/repository"
+	"github.com/maintenance-system/api/internal/service"
 )
 
 type AttachmentHandler struct {
 	attachmentRepo repository.AttachmentRepository
+	ticketService  service.TicketService
 	uploadDir      string
 }
 
-func NewAttachmentHandler(attachmentRepo repository.AttachmentRepository) *AttachmentHandler {
+func NewAttachmentHandler(attachmentRepo repository.AttachmentRepository, ticketService service.TicketService) *AttachmentHandler {
 	uploadDir := "./uploads"
 	// Create uploads directory if it doesn't exist
 	os.MkdirAll(uploadDir, 0755)
 	return &AttachmentHandler{
 		attachmentRepo: attachmentRepo,
+		ticketService:  ticketService,
 		uploadDir:      uploadDir,
 	}
 }
@@ -37,46 +40,18 @@ func (h *AttachmentHandler) Upload(c *gin.Context) {
 		return
 	}
 
-	file, header, err := c.Request.FormFile("file")
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "No file uploaded"})
-		return
-	}
-	defer file.Close()
-
-	// Generate unique filename
-	ext := filepath.Ext(header.Filename)
-	newFilename := fmt.Sprintf("%s_%d%s", uuid.New().String(), time.Now().Unix(), ext)
-	filePath := filepath.Join(h.uploadDir, newFilename)
-
-	// Create the file
-	dst, err := os.Create(filePath)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save file"})
-		return
-	}
-	defer dst.Close()
-
-	// Copy uploaded file to destination
-	if _, err := io.Copy(dst, file); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save file"})
+	attachment, filePath, ok := saveUpload(c, h.uploadDir)
+	if !ok {
 		return
 	}
+	attachment.TicketID = ticketID
 
-	// Create attachment record
-	attachment := &domain.Attachment{
-		Filename:  header.Filename,
-		URL:       "/uploads/" + newFilename,
-		Type:      header.Header.Get("Content-Type"),
-		Size:      header.Size,
-		TicketID:  ticketID,
-		CreatedAt: time.Now(),
-	}
+	userID := c.MustGet("userID").(uuid.UUID)
 
-	if err := h.attachmentRepo.Create(attachment); err != nil {
+	if err := h.ticketService.AddAttachment(attachment, userID); err != nil {
 		// Clean up file if database insert fails
 		os.Remove(filePath)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save attachment record"})
+		c.JSON(ticketErrorStatus(err), gin.H{"error": "Failed to save attachment record"})
 		return
 	}
 
@@ -101,16 +76,64 @@ func (h *AttachmentHandler) GetByTicketID(c *gin.Context) {
 }
 
 func (h *AttachmentHandler) Delete(c *gin.Context) {
+	ticketID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ticket ID"})
+		return
+	}
+
 	id, err := uuid.Parse(c.Param("attachmentId"))
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid attachment ID"})
 		return
 	}
 
-	if err := h.attachmentRepo.Delete(id); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete attachment"})
+	userID := c.MustGet("userID").(uuid.UUID)
+
+	if err := h.ticketService.DeleteAttachment(ticketID, id, userID); err != nil {
+		c.JSON(ticketErrorStatus(err), gin.H{"error": "Failed to delete attachment"})
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{"success": true, "message": "Attachment deleted"})
 }
+
+// saveUpload stores the request's "file" form field in dir and returns an
+// attachment record for it along with the saved path. On failure it has
+// already written the error response.
+func saveUpload(c *gin.Context, dir string) (*domain.Attachment, string, bool) {
+	file, header, err := c.Request.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "No file uploaded"})
+		return nil, "", false
+	}
+	defer file.Close()
+
+	// Generate unique filename
+	ext := filepath.Ext(header.Filename)
+	newFilename := fmt.Sprintf("%s_%d%s", uuid.New().String(), time.Now().Unix(), ext)
+	filePath := filepath.Join(dir, newFilename)
+
+	// Create the file
+	dst, err := os.Create(filePath)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save file"})
+		return nil, "", false
+	}
+	defer dst.Close()
+
+	// Copy uploaded file to destination
+	if _, err := io.Copy(dst, file); err != nil {
+		os.Remove(filePath)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save file"})
+		return nil, "", false
+	}
+
+	return &domain.Attachment{
+		Filename:  header.Filename,
+		URL:       "/uploads/" + newFilename,
+		Type:      header.Header.Get("Content-Type"),
+		Size:      header.Size,
+		CreatedAt: time.Now(),
+	}, filePath, true
+}
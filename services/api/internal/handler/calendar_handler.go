@@ -0,0 +1,320 @@
+package handler
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/maintenance-system/api/internal/domain"
+	"github.com/maintenance-system/api/internal/service"
+)
+
+type CalendarHandler struct {
+	calendarService service.CalendarService
+}
+
+func NewCalendarHandler(calendarService service.CalendarService) *CalendarHandler {
+	return &CalendarHandler{calendarService: calendarService}
+}
+
+type WorkingHoursRequest struct {
+	Weekday   int    `json:"weekday" binding:"min=0,max=6"`
+	StartTime string `json:"startTime" binding:"required"`
+	EndTime   string `json:"endTime" binding:"required"`
+}
+
+type CreateCalendarRequest struct {
+	Name      string                `json:"name" binding:"required"`
+	Timezone  string                `json:"timezone"`
+	IsDefault bool                  `json:"isDefault"`
+	Hours     []WorkingHoursRequest `json:"hours" binding:"dive"`
+}
+
+type UpdateCalendarRequest struct {
+	Name      string `json:"name"`
+	Timezone  string `json:"timezone"`
+	IsDefault *bool  `json:"isDefault"`
+}
+
+type SetHoursRequest struct {
+	Hours []WorkingHoursRequest `json:"hours" binding:"dive"`
+}
+
+type HolidayRequest struct {
+	Date string `json:"date" binding:"required"`
+	Name string `json:"name"`
+}
+
+func toWorkingHours(reqs []WorkingHoursRequest) []domain.WorkingHours {
+	hours := make([]domain.WorkingHours, 0, len(reqs))
+	for _, r := range reqs {
+		hours = append(hours, domain.WorkingHours{
+			Weekday:   time.Weekday(r.Weekday),
+			StartTime: r.StartTime,
+			EndTime:   r.EndTime,
+		})
+	}
+	return hours
+}
+
+func (h *CalendarHandler) GetAll(c *gin.Context) {
+	calendars, err := h.calendarService.GetAll()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch calendars"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": calendars})
+}
+
+func (h *CalendarHandler) GetByID(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid calendar ID"})
+		return
+	}
+
+	calendar, err := h.calendarService.GetByID(id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Calendar not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": calendar})
+}
+
+func (h *CalendarHandler) Create(c *gin.Context) {
+	var req CreateCalendarRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	calendar := &domain.WorkingCalendar{
+		Name:      req.Name,
+		Timezone:  req.Timezone,
+		IsDefault: req.IsDefault,
+		Hours:     toWorkingHours(req.Hours),
+	}
+
+	if err := h.calendarService.Create(calendar); err != nil {
+		c.JSON(calendarErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"success": true, "data": calendar})
+}
+
+func (h *CalendarHandler) Update(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid calendar ID"})
+		return
+	}
+
+	var req UpdateCalendarRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	updates := make(map[string]interface{})
+	if req.Name != "" {
+		updates["name"] = req.Name
+	}
+	if req.Timezone != "" {
+		updates["timezone"] = req.Timezone
+	}
+	if req.IsDefault != nil {
+		updates["isDefault"] = *req.IsDefault
+	}
+
+	calendar, err := h.calendarService.Update(id, updates)
+	if err != nil {
+		c.JSON(calendarErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": calendar})
+}
+
+func (h *CalendarHandler) Delete(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid calendar ID"})
+		return
+	}
+
+	if err := h.calendarService.Delete(id); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete calendar"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "message": "Calendar deleted"})
+}
+
+func (h *CalendarHandler) SetHours(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid calendar ID"})
+		return
+	}
+
+	var req SetHoursRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	calendar, err := h.calendarService.SetHours(id, toWorkingHours(req.Hours))
+	if err != nil {
+		c.JSON(calendarErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": calendar})
+}
+
+func (h *CalendarHandler) AddHoliday(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid calendar ID"})
+		return
+	}
+
+	var req HolidayRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	holiday := &domain.Holiday{CalendarID: id, Date: req.Date, Name: req.Name}
+	if err := h.calendarService.AddHoliday(holiday); err != nil {
+		c.JSON(calendarErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"success": true, "data": holiday})
+}
+
+func (h *CalendarHandler) DeleteHoliday(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid calendar ID"})
+		return
+	}
+
+	holidayID, err := uuid.Parse(c.Param("holidayId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid holiday ID"})
+		return
+	}
+
+	if err := h.calendarService.DeleteHoliday(id, holidayID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete holiday"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "message": "Holiday deleted"})
+}
+
+// ImportHolidays accepts an iCalendar file either as a multipart "file"
+// field or as the raw request body.
+func (h *CalendarHandler) ImportHolidays(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid calendar ID"})
+		return
+	}
+
+	var body io.Reader = c.Request.Body
+	if file, _, err := c.Request.FormFile("file"); err == nil {
+		defer file.Close()
+		body = file
+	}
+
+	holidays, err := h.calendarService.ImportHolidays(id, body)
+	if err != nil {
+		c.JSON(calendarErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": holidays})
+}
+
+// AddBusinessTime returns start plus duration of working time.
+func (h *CalendarHandler) AddBusinessTime(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid calendar ID"})
+		return
+	}
+
+	start, err := time.Parse(time.RFC3339, c.Query("start"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid start time"})
+		return
+	}
+
+	duration, err := time.ParseDuration(c.Query("duration"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid duration"})
+		return
+	}
+
+	end, err := h.calendarService.AddBusinessTime(&id, start, duration)
+	if err != nil {
+		c.JSON(calendarErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": gin.H{"start": start, "duration": duration.String(), "end": end}})
+}
+
+// BusinessTimeBetween returns the working time between from and to.
+func (h *CalendarHandler) BusinessTimeBetween(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid calendar ID"})
+		return
+	}
+
+	from, err := time.Parse(time.RFC3339, c.Query("from"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid from time"})
+		return
+	}
+
+	to, err := time.Parse(time.RFC3339, c.Query("to"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid to time"})
+		return
+	}
+
+	elapsed, err := h.calendarService.BusinessTimeBetween(&id, from, to)
+	if err != nil {
+		c.JSON(calendarErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": gin.H{
+		"from":     from,
+		"to":       to,
+		"duration": elapsed.String(),
+		"minutes":  int64(elapsed / time.Minute),
+	}})
+}
+
+func calendarErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrCalendarNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, service.ErrInvalidCalendar):
+		return http.StatusUnprocessableEntity
+	default:
+		return http.StatusInternalServerError
+	}
+}
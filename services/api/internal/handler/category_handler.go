@@ -0,0 +1,174 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/maintenance-system/api/internal/domain"
+	"github.com/maintenance-system/api/internal/service"
+)
+
+type CategoryHandler struct {
+	categoryService service.CategoryService
+}
+
+func NewCategoryHandler(categoryService service.CategoryService) *CategoryHandler {
+	return &CategoryHandler{categoryService: categoryService}
+}
+
+type CreateCategoryRequest struct {
+	Code              string     `json:"code" binding:"required"`
+	NameEN            string     `json:"nameEn" binding:"required"`
+	NameTH            string     `json:"nameTh"`
+	ParentID          *uuid.UUID `json:"parentId"`
+	Icon              string     `json:"icon"`
+	DefaultPriority   string     `json:"defaultPriority" binding:"omitempty,ticket_priority"`
+	DefaultAssigneeID *uuid.UUID `json:"defaultAssigneeId"`
+	SortOrder         int        `json:"sortOrder"`
+}
+
+type UpdateCategoryRequest struct {
+	NameEN            string     `json:"nameEn"`
+	NameTH            *string    `json:"nameTh"`
+	ParentID          *uuid.UUID `json:"parentId"`
+	Icon              *string    `json:"icon"`
+	DefaultPriority   *string    `json:"defaultPriority" binding:"omitempty,len=0|ticket_priority"`
+	DefaultAssigneeID *uuid.UUID `json:"defaultAssigneeId"`
+	Active            *bool      `json:"active"`
+	SortOrder         *int       `json:"sortOrder"`
+}
+
+func (h *CategoryHandler) GetAll(c *gin.Context) {
+	categories, err := h.categoryService.GetAll()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch categories"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": categories})
+}
+
+func (h *CategoryHandler) GetByID(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid category ID"})
+		return
+	}
+
+	category, err := h.categoryService.GetByID(id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Category not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": category})
+}
+
+func (h *CategoryHandler) Create(c *gin.Context) {
+	var req CreateCategoryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	category := &domain.Category{
+		Code:              domain.TicketCategory(req.Code),
+		NameEN:            req.NameEN,
+		NameTH:            req.NameTH,
+		ParentID:          req.ParentID,
+		Icon:              req.Icon,
+		DefaultPriority:   domain.TicketPriority(req.DefaultPriority),
+		DefaultAssigneeID: req.DefaultAssigneeID,
+		Active:            true,
+		SortOrder:         req.SortOrder,
+	}
+
+	if err := h.categoryService.Create(category); err != nil {
+		c.JSON(categoryErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"success": true, "data": category})
+}
+
+// Update changes a category. Its code is fixed; send parentId or
+// defaultAssigneeId as the nil UUID to clear them.
+func (h *CategoryHandler) Update(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid category ID"})
+		return
+	}
+
+	var req UpdateCategoryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	updates := make(map[string]interface{})
+	if req.NameEN != "" {
+		updates["nameEn"] = req.NameEN
+	}
+	if req.NameTH != nil {
+		updates["nameTh"] = *req.NameTH
+	}
+	if req.ParentID != nil {
+		updates["parentId"] = *req.ParentID
+	}
+	if req.Icon != nil {
+		updates["icon"] = *req.Icon
+	}
+	if req.DefaultPriority != nil {
+		updates["defaultPriority"] = *req.DefaultPriority
+	}
+	if req.DefaultAssigneeID != nil {
+		updates["defaultAssigneeId"] = *req.DefaultAssigneeID
+	}
+	if req.Active != nil {
+		updates["active"] = *req.Active
+	}
+	if req.SortOrder != nil {
+		updates["sortOrder"] = *req.SortOrder
+	}
+
+	category, err := h.categoryService.Update(id, updates)
+	if err != nil {
+		c.JSON(categoryErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": category})
+}
+
+// Delete removes a category nothing refers to. Categories in use can only
+// be deactivated, which hides them from new tickets.
+func (h *CategoryHandler) Delete(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid category ID"})
+		return
+	}
+
+	if err := h.categoryService.Delete(id); err != nil {
+		c.JSON(categoryErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "message": "Category deleted"})
+}
+
+func categoryErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrCategoryNotFound), errors.Is(err, service.ErrUserNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, service.ErrInvalidCategory):
+		return http.StatusUnprocessableEntity
+	case errors.Is(err, service.ErrCategoryExists), errors.Is(err, service.ErrCategoryInUse):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,304 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/maintenance-system/api/internal/domain"
+	"github.com/maintenance-system/api/internal/service"
+)
+
+type ChecklistHandler struct {
+	checklistService service.ChecklistService
+}
+
+func NewChecklistHandler(checklistService service.ChecklistService) *ChecklistHandler {
+	return &ChecklistHandler{checklistService: checklistService}
+}
+
+type ChecklistItemRequest struct {
+	Label    string `json:"label" binding:"required"`
+	Type     string `json:"type" binding:"omitempty,oneof=CHECK READING PHOTO"`
+	Required *bool  `json:"required"` // defaults to true
+	Unit     string `json:"unit"`
+}
+
+type CreateChecklistTemplateRequest struct {
+	Name        string `json:"name" binding:"required"`
+	Description string `json:"description"`
+	// Category and AssetCategory restrict which tickets the template is
+	// added to; empty matches any.
+	Category      string                 `json:"category" binding:"omitempty,ticket_category"`
+	AssetCategory string                 `json:"assetCategory" binding:"omitempty,ticket_category"`
+	Items         []ChecklistItemRequest `json:"items" binding:"required,min=1,dive"`
+}
+
+type UpdateChecklistTemplateRequest struct {
+	Name          string  `json:"name"`
+	Description   *string `json:"description"`
+	Category      *string `json:"category" binding:"omitempty,len=0|ticket_category"`
+	AssetCategory *string `json:"assetCategory" binding:"omitempty,len=0|ticket_category"`
+	Active        *bool   `json:"active"`
+	// Items replaces the current items when present.
+	Items []ChecklistItemRequest `json:"items" binding:"omitempty,dive"`
+}
+
+type AddChecklistRequest struct {
+	TemplateID string `json:"templateId" binding:"required,uuid"`
+}
+
+type UpdateChecklistItemRequest struct {
+	Done         *bool    `json:"done"`
+	Reading      *float64 `json:"reading"`
+	AttachmentID string   `json:"attachmentId" binding:"omitempty,uuid"` // photo of the item
+	Note         *string  `json:"note"`
+}
+
+func checklistItems(reqs []ChecklistItemRequest) []domain.ChecklistTemplateItem {
+	items := make([]domain.ChecklistTemplateItem, 0, len(reqs))
+	for _, req := range reqs {
+		item := domain.ChecklistTemplateItem{
+			Label:    req.Label,
+			Type:     domain.ChecklistItemType(req.Type),
+			Required: true,
+			Unit:     req.Unit,
+		}
+		if req.Required != nil {
+			item.Required = *req.Required
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+func (h *ChecklistHandler) GetTemplates(c *gin.Context) {
+	activeOnly, _ := strconv.ParseBool(c.Query("active"))
+
+	templates, err := h.checklistService.GetTemplates(activeOnly)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch checklist templates"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": templates})
+}
+
+func (h *ChecklistHandler) GetTemplate(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid template ID"})
+		return
+	}
+
+	template, err := h.checklistService.GetTemplate(id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Checklist template not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": template})
+}
+
+func (h *ChecklistHandler) CreateTemplate(c *gin.Context) {
+	var req CreateChecklistTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	template := &domain.ChecklistTemplate{
+		Name:          req.Name,
+		Description:   req.Description,
+		Category:      domain.TicketCategory(req.Category),
+		AssetCategory: domain.TicketCategory(req.AssetCategory),
+		Active:        true,
+		Items:         checklistItems(req.Items),
+	}
+
+	if err := h.checklistService.CreateTemplate(template); err != nil {
+		c.JSON(checklistErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"success": true, "data": template})
+}
+
+func (h *ChecklistHandler) UpdateTemplate(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid template ID"})
+		return
+	}
+
+	var req UpdateChecklistTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	updates := make(map[string]interface{})
+	if req.Name != "" {
+		updates["name"] = req.Name
+	}
+	if req.Description != nil {
+		updates["description"] = *req.Description
+	}
+	if req.Category != nil {
+		updates["category"] = *req.Category
+	}
+	if req.AssetCategory != nil {
+		updates["assetCategory"] = *req.AssetCategory
+	}
+	if req.Active != nil {
+		updates["active"] = *req.Active
+	}
+	if req.Items != nil {
+		updates["items"] = checklistItems(req.Items)
+	}
+
+	template, err := h.checklistService.UpdateTemplate(id, updates)
+	if err != nil {
+		c.JSON(checklistErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": template})
+}
+
+func (h *ChecklistHandler) DeleteTemplate(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid template ID"})
+		return
+	}
+
+	if err := h.checklistService.DeleteTemplate(id); err != nil {
+		c.JSON(checklistErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "message": "Checklist template deleted"})
+}
+
+func (h *ChecklistHandler) GetTicketChecklists(c *gin.Context) {
+	ticketID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ticket ID"})
+		return
+	}
+
+	checklists, err := h.checklistService.GetTicketChecklists(ticketID)
+	if err != nil {
+		c.JSON(checklistErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": checklists})
+}
+
+func (h *ChecklistHandler) AddToTicket(c *gin.Context) {
+	ticketID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ticket ID"})
+		return
+	}
+
+	var req AddChecklistRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID := c.MustGet("userID").(uuid.UUID)
+
+	checklist, err := h.checklistService.AddToTicket(ticketID, uuid.MustParse(req.TemplateID), userID)
+	if err != nil {
+		c.JSON(checklistErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"success": true, "data": checklist})
+}
+
+func (h *ChecklistHandler) RemoveFromTicket(c *gin.Context) {
+	ticketID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ticket ID"})
+		return
+	}
+	checklistID, err := uuid.Parse(c.Param("checklistId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid checklist ID"})
+		return
+	}
+
+	userID := c.MustGet("userID").(uuid.UUID)
+
+	if err := h.checklistService.RemoveFromTicket(ticketID, checklistID, userID); err != nil {
+		c.JSON(checklistErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "message": "Checklist removed"})
+}
+
+func (h *ChecklistHandler) UpdateItem(c *gin.Context) {
+	ticketID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ticket ID"})
+		return
+	}
+	itemID, err := uuid.Parse(c.Param("itemId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid item ID"})
+		return
+	}
+
+	var req UpdateChecklistItemRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	updates := make(map[string]interface{})
+	if req.Done != nil {
+		updates["done"] = *req.Done
+	}
+	if req.Reading != nil {
+		updates["reading"] = *req.Reading
+	}
+	if req.AttachmentID != "" {
+		updates["attachmentId"] = uuid.MustParse(req.AttachmentID)
+	}
+	if req.Note != nil {
+		updates["note"] = *req.Note
+	}
+
+	userID := c.MustGet("userID").(uuid.UUID)
+
+	item, err := h.checklistService.UpdateItem(ticketID, itemID, userID, updates)
+	if err != nil {
+		c.JSON(checklistErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": item})
+}
+
+func checklistErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrChecklistTemplateNotFound), errors.Is(err, service.ErrChecklistNotFound),
+		errors.Is(err, service.ErrChecklistItemNotFound), errors.Is(err, service.ErrTicketNotFound),
+		errors.Is(err, service.ErrAttachmentNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, service.ErrTicketClosed), errors.Is(err, service.ErrChecklistIncomplete):
+		return http.StatusConflict
+	case errors.Is(err, service.ErrInvalidChecklistTemplate), errors.Is(err, service.ErrInvalidChecklistItem):
+		return http.StatusUnprocessableEntity
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,223 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/maintenance-system/api/internal/domain"
+	"github.com/maintenance-system/api/internal/repository"
+	"github.com/maintenance-system/api/internal/service"
+)
+
+type ContractHandler struct {
+	contractService service.ContractService
+}
+
+func NewContractHandler(contractService service.ContractService) *ContractHandler {
+	return &ContractHandler{contractService: contractService}
+}
+
+type CreateContractRequest struct {
+	AssetID        string    `json:"assetId" binding:"required,uuid"`
+	Type           string    `json:"type" binding:"omitempty,oneof=WARRANTY SERVICE"`
+	Vendor         string    `json:"vendor" binding:"required"`
+	ContractNumber string    `json:"contractNumber"`
+	Coverage       string    `json:"coverage"`
+	StartsAt       time.Time `json:"startsAt" binding:"required"`
+	EndsAt         time.Time `json:"endsAt" binding:"required"`
+	ContactName    string    `json:"contactName"`
+	ContactPhone   string    `json:"contactPhone"`
+	ContactEmail   string    `json:"contactEmail" binding:"omitempty,email"`
+	Notes          string    `json:"notes"`
+}
+
+type UpdateContractRequest struct {
+	Type           string     `json:"type" binding:"omitempty,oneof=WARRANTY SERVICE"`
+	Vendor         string     `json:"vendor"`
+	ContractNumber *string    `json:"contractNumber"`
+	Coverage       *string    `json:"coverage"`
+	StartsAt       *time.Time `json:"startsAt"`
+	EndsAt         *time.Time `json:"endsAt"`
+	ContactName    *string    `json:"contactName"`
+	ContactPhone   *string    `json:"contactPhone"`
+	ContactEmail   *string    `json:"contactEmail" binding:"omitempty,email"`
+	Notes          *string    `json:"notes"`
+}
+
+func (h *ContractHandler) GetAll(c *gin.Context) {
+	filter := repository.ContractFilter{
+		AssetID: queryUUID(c, "assetId"),
+		Type:    c.Query("type"),
+	}
+	if active := queryBool(c, "active"); active != nil && *active {
+		now := time.Now()
+		filter.ActiveAt = &now
+	}
+
+	contracts, err := h.contractService.GetAll(filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch contracts"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": contracts})
+}
+
+func (h *ContractHandler) GetByAsset(c *gin.Context) {
+	assetID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid asset ID"})
+		return
+	}
+
+	contracts, err := h.contractService.GetAll(repository.ContractFilter{AssetID: &assetID})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch contracts"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": contracts})
+}
+
+// Expiring reports the contracts ending within the next ?days= days
+// (default 30).
+func (h *ContractHandler) Expiring(c *gin.Context) {
+	days, _ := strconv.Atoi(c.DefaultQuery("days", "30"))
+
+	contracts, err := h.contractService.Expiring(days, time.Now())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch expiring contracts"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": contracts})
+}
+
+func (h *ContractHandler) GetByID(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid contract ID"})
+		return
+	}
+
+	contract, err := h.contractService.GetByID(id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Contract not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": contract})
+}
+
+func (h *ContractHandler) Create(c *gin.Context) {
+	var req CreateContractRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	contract := &domain.ServiceContract{
+		AssetID:        uuid.MustParse(req.AssetID),
+		Type:           domain.ContractType(req.Type),
+		Vendor:         req.Vendor,
+		ContractNumber: req.ContractNumber,
+		Coverage:       req.Coverage,
+		StartsAt:       req.StartsAt,
+		EndsAt:         req.EndsAt,
+		ContactName:    req.ContactName,
+		ContactPhone:   req.ContactPhone,
+		ContactEmail:   req.ContactEmail,
+		Notes:          req.Notes,
+	}
+
+	if err := h.contractService.Create(contract); err != nil {
+		c.JSON(contractErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"success": true, "data": contract})
+}
+
+func (h *ContractHandler) Update(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid contract ID"})
+		return
+	}
+
+	var req UpdateContractRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	updates := make(map[string]interface{})
+	if req.Type != "" {
+		updates["type"] = req.Type
+	}
+	if req.Vendor != "" {
+		updates["vendor"] = req.Vendor
+	}
+	if req.ContractNumber != nil {
+		updates["contractNumber"] = *req.ContractNumber
+	}
+	if req.Coverage != nil {
+		updates["coverage"] = *req.Coverage
+	}
+	if req.StartsAt != nil {
+		updates["startsAt"] = *req.StartsAt
+	}
+	if req.EndsAt != nil {
+		updates["endsAt"] = *req.EndsAt
+	}
+	if req.ContactName != nil {
+		updates["contactName"] = *req.ContactName
+	}
+	if req.ContactPhone != nil {
+		updates["contactPhone"] = *req.ContactPhone
+	}
+	if req.ContactEmail != nil {
+		updates["contactEmail"] = *req.ContactEmail
+	}
+	if req.Notes != nil {
+		updates["notes"] = *req.Notes
+	}
+
+	contract, err := h.contractService.Update(id, updates)
+	if err != nil {
+		c.JSON(contractErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": contract})
+}
+
+func (h *ContractHandler) Delete(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid contract ID"})
+		return
+	}
+
+	if err := h.contractService.Delete(id); err != nil {
+		c.JSON(contractErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "message": "Contract deleted"})
+}
+
+func contractErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrContractNotFound), errors.Is(err, service.ErrAssetNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, service.ErrInvalidContract):
+		return http.StatusUnprocessableEntity
+	default:
+		return http.StatusInternalServerError
+	}
+}
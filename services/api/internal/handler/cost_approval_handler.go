@@ -0,0 +1,140 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/maintenance-system/api/internal/domain"
+	"github.com/maintenance-system/api/internal/service"
+)
+
+type CostApprovalHandler struct {
+	costApprovalService service.CostApprovalService
+}
+
+func NewCostApprovalHandler(costApprovalService service.CostApprovalService) *CostApprovalHandler {
+	return &CostApprovalHandler{costApprovalService: costApprovalService}
+}
+
+type CreateApprovalThresholdRequest struct {
+	// Department and Category narrow the threshold down; empty matches any.
+	Department string  `json:"department"`
+	Category   string  `json:"category" binding:"omitempty,ticket_category"`
+	Amount     float64 `json:"amount" binding:"min=0"`
+}
+
+type UpdateApprovalThresholdRequest struct {
+	Department *string  `json:"department"`
+	Category   *string  `json:"category" binding:"omitempty,len=0|ticket_category"`
+	Amount     *float64 `json:"amount" binding:"omitempty,min=0"`
+}
+
+func (h *CostApprovalHandler) GetAll(c *gin.Context) {
+	thresholds, err := h.costApprovalService.GetAll()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch approval thresholds"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": thresholds})
+}
+
+func (h *CostApprovalHandler) GetByID(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid approval threshold ID"})
+		return
+	}
+
+	threshold, err := h.costApprovalService.GetByID(id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Approval threshold not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": threshold})
+}
+
+func (h *CostApprovalHandler) Create(c *gin.Context) {
+	var req CreateApprovalThresholdRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	threshold := &domain.ApprovalThreshold{
+		Department: req.Department,
+		Category:   domain.TicketCategory(req.Category),
+		Amount:     req.Amount,
+	}
+
+	if err := h.costApprovalService.Create(threshold); err != nil {
+		c.JSON(costApprovalErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"success": true, "data": threshold})
+}
+
+func (h *CostApprovalHandler) Update(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid approval threshold ID"})
+		return
+	}
+
+	var req UpdateApprovalThresholdRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	updates := make(map[string]interface{})
+	if req.Department != nil {
+		updates["department"] = *req.Department
+	}
+	if req.Category != nil {
+		updates["category"] = *req.Category
+	}
+	if req.Amount != nil {
+		updates["amount"] = *req.Amount
+	}
+
+	threshold, err := h.costApprovalService.Update(id, updates)
+	if err != nil {
+		c.JSON(costApprovalErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": threshold})
+}
+
+func (h *CostApprovalHandler) Delete(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid approval threshold ID"})
+		return
+	}
+
+	if err := h.costApprovalService.Delete(id); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete approval threshold"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "message": "Approval threshold deleted"})
+}
+
+func costApprovalErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrApprovalThresholdNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, service.ErrApprovalThresholdExists):
+		return http.StatusConflict
+	case errors.Is(err, service.ErrInvalidApprovalThreshold):
+		return http.StatusUnprocessableEntity
+	default:
+		return http.StatusInternalServerError
+	}
+}
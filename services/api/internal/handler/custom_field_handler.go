@@ -0,0 +1,173 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/maintenance-system/api/internal/domain"
+	"github.com/maintenance-system/api/internal/service"
+)
+
+type CustomFieldHandler struct {
+	customFieldService service.CustomFieldService
+}
+
+func NewCustomFieldHandler(customFieldService service.CustomFieldService) *CustomFieldHandler {
+	return &CustomFieldHandler{customFieldService: customFieldService}
+}
+
+type CreateCustomFieldRequest struct {
+	Key         string   `json:"key" binding:"required"`
+	Label       string   `json:"label" binding:"required"`
+	Type        string   `json:"type" binding:"required,oneof=TEXT NUMBER DATE SELECT MULTI_SELECT BOOLEAN USER"`
+	Description string   `json:"description"`
+	Categories  []string `json:"categories" binding:"dive,ticket_category"`
+	Options     []string `json:"options"`
+	Required    bool     `json:"required"`
+	SortOrder   int      `json:"sortOrder"`
+}
+
+type UpdateCustomFieldRequest struct {
+	Label       string    `json:"label"`
+	Description *string   `json:"description"`
+	Categories  *[]string `json:"categories" binding:"omitempty,dive,ticket_category"`
+	Options     *[]string `json:"options"`
+	Required    *bool     `json:"required"`
+	Active      *bool     `json:"active"`
+	SortOrder   *int      `json:"sortOrder"`
+}
+
+// GetAll lists the custom fields; with ?category= only the active fields
+// that tickets of that category have.
+func (h *CustomFieldHandler) GetAll(c *gin.Context) {
+	fields, err := h.customFieldService.GetAll(domain.TicketCategory(c.Query("category")))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch custom fields"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": fields})
+}
+
+func (h *CustomFieldHandler) GetByID(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid custom field ID"})
+		return
+	}
+
+	field, err := h.customFieldService.GetByID(id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Custom field not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": field})
+}
+
+func (h *CustomFieldHandler) Create(c *gin.Context) {
+	var req CreateCustomFieldRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	field := &domain.CustomField{
+		Key:         req.Key,
+		Label:       req.Label,
+		Type:        domain.CustomFieldType(req.Type),
+		Description: req.Description,
+		Categories:  ticketCategories(req.Categories),
+		Options:     domain.StringList(req.Options),
+		Required:    req.Required,
+		Active:      true,
+		SortOrder:   req.SortOrder,
+	}
+
+	if err := h.customFieldService.Create(field); err != nil {
+		c.JSON(customFieldErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"success": true, "data": field})
+}
+
+// Update changes a custom field. Its key and type are fixed.
+func (h *CustomFieldHandler) Update(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid custom field ID"})
+		return
+	}
+
+	var req UpdateCustomFieldRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	updates := make(map[string]interface{})
+	if req.Label != "" {
+		updates["label"] = req.Label
+	}
+	if req.Description != nil {
+		updates["description"] = *req.Description
+	}
+	if req.Categories != nil {
+		updates["categories"] = ticketCategories(*req.Categories)
+	}
+	if req.Options != nil {
+		updates["options"] = domain.StringList(*req.Options)
+	}
+	if req.Required != nil {
+		updates["required"] = *req.Required
+	}
+	if req.Active != nil {
+		updates["active"] = *req.Active
+	}
+	if req.SortOrder != nil {
+		updates["sortOrder"] = *req.SortOrder
+	}
+
+	field, err := h.customFieldService.Update(id, updates)
+	if err != nil {
+		c.JSON(customFieldErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": field})
+}
+
+// Delete removes the custom field and its values from every ticket. To
+// keep the values, deactivate the field instead.
+func (h *CustomFieldHandler) Delete(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid custom field ID"})
+		return
+	}
+
+	if err := h.customFieldService.Delete(id); err != nil {
+		c.JSON(customFieldErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "message": "Custom field deleted"})
+}
+
+func customFieldErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrCustomFieldNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, service.ErrInvalidCustomFilter):
+		return http.StatusBadRequest
+	case errors.Is(err, service.ErrInvalidCustomField), errors.Is(err, service.ErrInvalidCustomValue):
+		return http.StatusUnprocessableEntity
+	case errors.Is(err, service.ErrCustomFieldExists):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
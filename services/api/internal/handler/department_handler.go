@@ -0,0 +1,199 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/maintenance-system/api/internal/domain"
+	"github.com/maintenance-system/api/internal/service"
+)
+
+type DepartmentHandler struct {
+	departmentService service.DepartmentService
+}
+
+func NewDepartmentHandler(departmentService service.DepartmentService) *DepartmentHandler {
+	return &DepartmentHandler{departmentService: departmentService}
+}
+
+type CreateDepartmentRequest struct {
+	Name          string  `json:"name" binding:"required"`
+	CostCenter    string  `json:"costCenter"`
+	AnnualBudget  float64 `json:"annualBudget" binding:"min=0"`
+	MonthlyBudget float64 `json:"monthlyBudget" binding:"min=0"`
+}
+
+type UpdateDepartmentRequest struct {
+	Name          string   `json:"name"`
+	CostCenter    *string  `json:"costCenter"`
+	AnnualBudget  *float64 `json:"annualBudget" binding:"omitempty,min=0"`
+	MonthlyBudget *float64 `json:"monthlyBudget" binding:"omitempty,min=0"`
+}
+
+func (h *DepartmentHandler) GetAll(c *gin.Context) {
+	departments, err := h.departmentService.GetAll()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch departments"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": departments})
+}
+
+func (h *DepartmentHandler) GetByID(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid department ID"})
+		return
+	}
+
+	department, err := h.departmentService.GetByID(id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Department not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": department})
+}
+
+func (h *DepartmentHandler) Create(c *gin.Context) {
+	var req CreateDepartmentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	department := &domain.Department{
+		Name:          req.Name,
+		CostCenter:    req.CostCenter,
+		AnnualBudget:  req.AnnualBudget,
+		MonthlyBudget: req.MonthlyBudget,
+	}
+
+	if err := h.departmentService.Create(department); err != nil {
+		c.JSON(departmentErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"success": true, "data": department})
+}
+
+// Update changes the department. Renaming it renames the department on its
+// users and approval thresholds too.
+func (h *DepartmentHandler) Update(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid department ID"})
+		return
+	}
+
+	var req UpdateDepartmentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	updates := make(map[string]interface{})
+	if req.Name != "" {
+		updates["name"] = req.Name
+	}
+	if req.CostCenter != nil {
+		updates["costCenter"] = *req.CostCenter
+	}
+	if req.AnnualBudget != nil {
+		updates["annualBudget"] = *req.AnnualBudget
+	}
+	if req.MonthlyBudget != nil {
+		updates["monthlyBudget"] = *req.MonthlyBudget
+	}
+
+	department, err := h.departmentService.Update(id, updates)
+	if err != nil {
+		c.JSON(departmentErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": department})
+}
+
+func (h *DepartmentHandler) Delete(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid department ID"})
+		return
+	}
+
+	if err := h.departmentService.Delete(id); err != nil {
+		c.JSON(departmentErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "message": "Department deleted"})
+}
+
+// BudgetReport compares every department's budget with its spending for
+// ?year= (default this year), month by month.
+func (h *DepartmentHandler) BudgetReport(c *gin.Context) {
+	year, ok := queryYear(c)
+	if !ok {
+		return
+	}
+
+	report, err := h.departmentService.BudgetReport(year)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to build budget report"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": report})
+}
+
+func (h *DepartmentHandler) Budget(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid department ID"})
+		return
+	}
+	year, ok := queryYear(c)
+	if !ok {
+		return
+	}
+
+	budget, err := h.departmentService.Budget(id, year)
+	if err != nil {
+		c.JSON(departmentErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": budget})
+}
+
+func queryYear(c *gin.Context) (int, bool) {
+	raw := c.Query("year")
+	if raw == "" {
+		return time.Now().Year(), true
+	}
+	year, err := strconv.Atoi(raw)
+	if err != nil || year < 2000 || year > 9999 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid year"})
+		return 0, false
+	}
+	return year, true
+}
+
+func departmentErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrDepartmentNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, service.ErrInvalidDepartment):
+		return http.StatusUnprocessableEntity
+	case errors.Is(err, service.ErrDepartmentExists), errors.Is(err, service.ErrDepartmentInUse):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
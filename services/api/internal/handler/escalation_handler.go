@@ -0,0 +1,167 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/maintenance-system/api/internal/domain"
+	"github.com/maintenance-system/api/internal/service"
+)
+
+type EscalationHandler struct {
+	escalationService service.EscalationService
+}
+
+func NewEscalationHandler(escalationService service.EscalationService) *EscalationHandler {
+	return &EscalationHandler{escalationService: escalationService}
+}
+
+type CreateEscalationRuleRequest struct {
+	Name             string `json:"name" binding:"required"`
+	Condition        string `json:"condition" binding:"required,oneof=UNASSIGNED NO_ACTIVITY OVERDUE"`
+	ThresholdMinutes int    `json:"thresholdMinutes" binding:"min=0"`
+	Priority         string `json:"priority" binding:"omitempty,ticket_priority"`
+	Category         string `json:"category" binding:"omitempty,ticket_category"`
+	BumpPriority     bool   `json:"bumpPriority"`
+	ReassignToID     string `json:"reassignToId" binding:"omitempty,uuid"`
+	NotifyAdmins     *bool  `json:"notifyAdmins"`
+}
+
+type UpdateEscalationRuleRequest struct {
+	Name             string `json:"name"`
+	ThresholdMinutes *int   `json:"thresholdMinutes" binding:"omitempty,min=0"`
+	Priority         string `json:"priority" binding:"omitempty,ticket_priority"`
+	Category         string `json:"category" binding:"omitempty,ticket_category"`
+	BumpPriority     *bool  `json:"bumpPriority"`
+	ReassignToID     string `json:"reassignToId" binding:"omitempty,uuid"`
+	NotifyAdmins     *bool  `json:"notifyAdmins"`
+	Active           *bool  `json:"active"`
+}
+
+func (h *EscalationHandler) GetAll(c *gin.Context) {
+	rules, err := h.escalationService.GetRules()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch escalation rules"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": rules})
+}
+
+func (h *EscalationHandler) GetByID(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid escalation rule ID"})
+		return
+	}
+
+	rule, err := h.escalationService.GetRule(id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Escalation rule not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": rule})
+}
+
+func (h *EscalationHandler) Create(c *gin.Context) {
+	var req CreateEscalationRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID := c.MustGet("userID").(uuid.UUID)
+
+	rule := &domain.EscalationRule{
+		Name:             req.Name,
+		Condition:        domain.EscalationCondition(req.Condition),
+		ThresholdMinutes: req.ThresholdMinutes,
+		Priority:         domain.TicketPriority(req.Priority),
+		Category:         domain.TicketCategory(req.Category),
+		BumpPriority:     req.BumpPriority,
+		NotifyAdmins:     req.NotifyAdmins == nil || *req.NotifyAdmins,
+		Active:           true,
+		CreatedByID:      userID,
+	}
+	if req.ReassignToID != "" {
+		reassignTo := uuid.MustParse(req.ReassignToID)
+		rule.ReassignToID = &reassignTo
+	}
+
+	if err := h.escalationService.CreateRule(rule); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create escalation rule"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"success": true, "data": rule})
+}
+
+func (h *EscalationHandler) Update(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid escalation rule ID"})
+		return
+	}
+
+	var req UpdateEscalationRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	updates := make(map[string]interface{})
+	if req.Name != "" {
+		updates["name"] = req.Name
+	}
+	if req.ThresholdMinutes != nil {
+		updates["thresholdMinutes"] = *req.ThresholdMinutes
+	}
+	if req.Priority != "" {
+		updates["priority"] = req.Priority
+	}
+	if req.Category != "" {
+		updates["category"] = req.Category
+	}
+	if req.BumpPriority != nil {
+		updates["bumpPriority"] = *req.BumpPriority
+	}
+	if req.ReassignToID != "" {
+		updates["reassignToId"] = uuid.MustParse(req.ReassignToID)
+	}
+	if req.NotifyAdmins != nil {
+		updates["notifyAdmins"] = *req.NotifyAdmins
+	}
+	if req.Active != nil {
+		updates["active"] = *req.Active
+	}
+
+	rule, err := h.escalationService.UpdateRule(id, updates)
+	if err != nil {
+		if errors.Is(err, service.ErrEscalationRuleNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": rule})
+}
+
+func (h *EscalationHandler) Delete(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid escalation rule ID"})
+		return
+	}
+
+	if err := h.escalationService.DeleteRule(id); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete escalation rule"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "message": "Escalation rule deleted"})
+}
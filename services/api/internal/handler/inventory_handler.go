@@ -0,0 +1,438 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/maintenance-system/api/internal/domain"
+	"github.com/maintenance-system/api/internal/repository"
+	"github.com/maintenance-system/api/internal/service"
+)
+
+type InventoryHandler struct {
+	inventoryService service.InventoryService
+}
+
+func NewInventoryHandler(inventoryService service.InventoryService) *InventoryHandler {
+	return &InventoryHandler{inventoryService: inventoryService}
+}
+
+type CreatePartRequest struct {
+	SKU         string  `json:"sku" binding:"required"`
+	Name        string  `json:"name" binding:"required"`
+	Description string  `json:"description"`
+	Unit        string  `json:"unit"`
+	UnitCost    float64 `json:"unitCost" binding:"min=0"`
+	MinStock    float64 `json:"minStock" binding:"min=0"`
+}
+
+type UpdatePartRequest struct {
+	SKU         string   `json:"sku"`
+	Name        string   `json:"name"`
+	Description *string  `json:"description"`
+	Unit        string   `json:"unit"`
+	UnitCost    *float64 `json:"unitCost" binding:"omitempty,min=0"`
+	MinStock    *float64 `json:"minStock" binding:"omitempty,min=0"`
+}
+
+type StockLocationRequest struct {
+	Name        string  `json:"name"`
+	Description *string `json:"description"`
+	LocationID  string  `json:"locationId" binding:"omitempty,uuid"`
+}
+
+type ReceiveStockRequest struct {
+	StockLocationID string  `json:"stockLocationId" binding:"required,uuid"`
+	Quantity        float64 `json:"quantity" binding:"required,gt=0"`
+	UnitCost        float64 `json:"unitCost" binding:"min=0"`
+	Note            string  `json:"note"`
+}
+
+type AdjustStockRequest struct {
+	StockLocationID string  `json:"stockLocationId" binding:"required,uuid"`
+	Delta           float64 `json:"delta" binding:"required"`
+	Note            string  `json:"note" binding:"required"`
+}
+
+type TicketPartRequest struct {
+	PartID          string  `json:"partId" binding:"required,uuid"`
+	StockLocationID string  `json:"stockLocationId" binding:"required,uuid"`
+	Quantity        float64 `json:"quantity" binding:"required,gt=0"`
+	Note            string  `json:"note"`
+}
+
+func (h *InventoryHandler) GetParts(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	if limit <= 0 {
+		limit = 10
+	}
+	lowStock, _ := strconv.ParseBool(c.Query("lowStock"))
+
+	filter := repository.PartFilter{
+		Search:   c.Query("search"),
+		LowStock: lowStock,
+		Page:     page,
+		Limit:    limit,
+	}
+
+	parts, total, err := h.inventoryService.GetParts(filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch parts"})
+		return
+	}
+
+	totalPages := (int(total) + limit - 1) / limit
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    parts,
+		"meta": gin.H{
+			"total":      total,
+			"page":       page,
+			"limit":      limit,
+			"totalPages": totalPages,
+		},
+	})
+}
+
+func (h *InventoryHandler) GetPart(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid part ID"})
+		return
+	}
+
+	part, err := h.inventoryService.GetPart(id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Part not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": part})
+}
+
+func (h *InventoryHandler) CreatePart(c *gin.Context) {
+	var req CreatePartRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	part := &domain.Part{
+		SKU:         req.SKU,
+		Name:        req.Name,
+		Description: req.Description,
+		Unit:        req.Unit,
+		UnitCost:    req.UnitCost,
+		MinStock:    req.MinStock,
+	}
+
+	if err := h.inventoryService.CreatePart(part); err != nil {
+		c.JSON(inventoryErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"success": true, "data": part})
+}
+
+func (h *InventoryHandler) UpdatePart(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid part ID"})
+		return
+	}
+
+	var req UpdatePartRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	updates := make(map[string]interface{})
+	if req.SKU != "" {
+		updates["sku"] = req.SKU
+	}
+	if req.Name != "" {
+		updates["name"] = req.Name
+	}
+	if req.Description != nil {
+		updates["description"] = *req.Description
+	}
+	if req.Unit != "" {
+		updates["unit"] = req.Unit
+	}
+	if req.UnitCost != nil {
+		updates["unitCost"] = *req.UnitCost
+	}
+	if req.MinStock != nil {
+		updates["minStock"] = *req.MinStock
+	}
+
+	part, err := h.inventoryService.UpdatePart(id, updates)
+	if err != nil {
+		c.JSON(inventoryErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": part})
+}
+
+func (h *InventoryHandler) DeletePart(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid part ID"})
+		return
+	}
+
+	if err := h.inventoryService.DeletePart(id); err != nil {
+		c.JSON(inventoryErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "message": "Part deleted"})
+}
+
+// GetMovements lists the part's stock ledger, newest first.
+func (h *InventoryHandler) GetMovements(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid part ID"})
+		return
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if limit <= 0 {
+		limit = 20
+	}
+
+	filter := repository.MovementFilter{
+		PartID:          &id,
+		StockLocationID: queryUUID(c, "stockLocationId"),
+		TicketID:        queryUUID(c, "ticketId"),
+		Type:            c.Query("type"),
+		Page:            page,
+		Limit:           limit,
+	}
+
+	movements, total, err := h.inventoryService.GetMovements(filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch stock movements"})
+		return
+	}
+
+	totalPages := (int(total) + limit - 1) / limit
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    movements,
+		"meta": gin.H{
+			"total":      total,
+			"page":       page,
+			"limit":      limit,
+			"totalPages": totalPages,
+		},
+	})
+}
+
+func (h *InventoryHandler) Receive(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid part ID"})
+		return
+	}
+
+	var req ReceiveStockRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID := c.MustGet("userID").(uuid.UUID)
+
+	movement, err := h.inventoryService.Receive(id, uuid.MustParse(req.StockLocationID), req.Quantity, req.UnitCost, userID, req.Note)
+	if err != nil {
+		c.JSON(inventoryErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"success": true, "data": movement})
+}
+
+func (h *InventoryHandler) Adjust(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid part ID"})
+		return
+	}
+
+	var req AdjustStockRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID := c.MustGet("userID").(uuid.UUID)
+
+	movement, err := h.inventoryService.Adjust(id, uuid.MustParse(req.StockLocationID), req.Delta, userID, req.Note)
+	if err != nil {
+		c.JSON(inventoryErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"success": true, "data": movement})
+}
+
+func (h *InventoryHandler) GetStockLocations(c *gin.Context) {
+	locations, err := h.inventoryService.GetStockLocations()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch stock locations"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": locations})
+}
+
+func (h *InventoryHandler) CreateStockLocation(c *gin.Context) {
+	var req StockLocationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	location := &domain.StockLocation{Name: req.Name}
+	if req.Description != nil {
+		location.Description = *req.Description
+	}
+	if req.LocationID != "" {
+		locationID := uuid.MustParse(req.LocationID)
+		location.LocationID = &locationID
+	}
+
+	if err := h.inventoryService.CreateStockLocation(location); err != nil {
+		c.JSON(inventoryErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"success": true, "data": location})
+}
+
+func (h *InventoryHandler) UpdateStockLocation(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid stock location ID"})
+		return
+	}
+
+	var req StockLocationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	updates := make(map[string]interface{})
+	if req.Name != "" {
+		updates["name"] = req.Name
+	}
+	if req.Description != nil {
+		updates["description"] = *req.Description
+	}
+	if req.LocationID != "" {
+		updates["locationId"] = uuid.MustParse(req.LocationID)
+	}
+
+	location, err := h.inventoryService.UpdateStockLocation(id, updates)
+	if err != nil {
+		c.JSON(inventoryErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": location})
+}
+
+func (h *InventoryHandler) DeleteStockLocation(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid stock location ID"})
+		return
+	}
+
+	if err := h.inventoryService.DeleteStockLocation(id); err != nil {
+		c.JSON(inventoryErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "message": "Stock location deleted"})
+}
+
+// GetTicketParts lists the parts used on a ticket, net of returns.
+func (h *InventoryHandler) GetTicketParts(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ticket ID"})
+		return
+	}
+
+	parts, err := h.inventoryService.GetTicketParts(id)
+	if err != nil {
+		c.JSON(inventoryErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": parts})
+}
+
+// IssueToTicket records parts consumed on a ticket.
+func (h *InventoryHandler) IssueToTicket(c *gin.Context) {
+	h.ticketMovement(c, h.inventoryService.IssueToTicket)
+}
+
+// ReturnFromTicket puts unused parts from a ticket back into stock.
+func (h *InventoryHandler) ReturnFromTicket(c *gin.Context) {
+	h.ticketMovement(c, h.inventoryService.ReturnFromTicket)
+}
+
+func (h *InventoryHandler) ticketMovement(c *gin.Context, move func(ticketID, partID, stockLocationID uuid.UUID, quantity float64, userID uuid.UUID, note string) (*domain.StockMovement, error)) {
+	ticketID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ticket ID"})
+		return
+	}
+
+	var req TicketPartRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID := c.MustGet("userID").(uuid.UUID)
+
+	movement, err := move(ticketID, uuid.MustParse(req.PartID), uuid.MustParse(req.StockLocationID), req.Quantity, userID, req.Note)
+	if err != nil {
+		c.JSON(inventoryErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"success": true, "data": movement})
+}
+
+func inventoryErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrPartNotFound), errors.Is(err, service.ErrStockLocationNotFound),
+		errors.Is(err, service.ErrTicketNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, service.ErrPartSKUTaken), errors.Is(err, service.ErrStockLocationTaken),
+		errors.Is(err, service.ErrPartInStock), errors.Is(err, service.ErrStockLocationInUse),
+		errors.Is(err, service.ErrInsufficientStock), errors.Is(err, service.ErrTicketClosed):
+		return http.StatusConflict
+	case errors.Is(err, service.ErrInvalidPart), errors.Is(err, service.ErrInvalidStockLocation),
+		errors.Is(err, service.ErrInvalidMovement):
+		return http.StatusUnprocessableEntity
+	default:
+		return http.StatusInternalServerError
+	}
+}
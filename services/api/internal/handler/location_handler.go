@@ -0,0 +1,178 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/maintenance-system/api/internal/domain"
+	"github.com/maintenance-system/api/internal/service"
+)
+
+type LocationHandler struct {
+	locationService service.LocationService
+}
+
+func NewLocationHandler(locationService service.LocationService) *LocationHandler {
+	return &LocationHandler{locationService: locationService}
+}
+
+type CreateLocationRequest struct {
+	Name     string `json:"name" binding:"required"`
+	Code     string `json:"code"`
+	Type     string `json:"type" binding:"required,oneof=SITE BUILDING FLOOR ROOM"`
+	ParentID string `json:"parentId" binding:"omitempty,uuid"`
+}
+
+type UpdateLocationRequest struct {
+	Name string  `json:"name"`
+	Code *string `json:"code"`
+	Type string  `json:"type" binding:"omitempty,oneof=SITE BUILDING FLOOR ROOM"`
+	// ParentID moves the location; an empty string moves it to the top.
+	ParentID *string `json:"parentId"`
+}
+
+func (h *LocationHandler) GetAll(c *gin.Context) {
+	var locations []domain.Location
+	var err error
+	if tree, _ := strconv.ParseBool(c.Query("tree")); tree {
+		locations, err = h.locationService.GetTree()
+	} else {
+		locations, err = h.locationService.GetAll()
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch locations"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": locations})
+}
+
+func (h *LocationHandler) GetByID(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid location ID"})
+		return
+	}
+
+	location, err := h.locationService.GetByID(id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Location not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": location})
+}
+
+func (h *LocationHandler) Create(c *gin.Context) {
+	var req CreateLocationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	location := &domain.Location{
+		Name: req.Name,
+		Code: req.Code,
+		Type: domain.LocationType(req.Type),
+	}
+	if req.ParentID != "" {
+		parentID := uuid.MustParse(req.ParentID)
+		location.ParentID = &parentID
+	}
+
+	if err := h.locationService.Create(location); err != nil {
+		c.JSON(locationErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"success": true, "data": location})
+}
+
+func (h *LocationHandler) Update(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid location ID"})
+		return
+	}
+
+	var req UpdateLocationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	updates := make(map[string]interface{})
+	if req.Name != "" {
+		updates["name"] = req.Name
+	}
+	if req.Code != nil {
+		updates["code"] = *req.Code
+	}
+	if req.Type != "" {
+		updates["type"] = req.Type
+	}
+	if req.ParentID != nil {
+		parentID := uuid.Nil
+		if *req.ParentID != "" {
+			if parentID, err = uuid.Parse(*req.ParentID); err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid parent ID"})
+				return
+			}
+		}
+		updates["parentId"] = parentID
+	}
+
+	location, err := h.locationService.Update(id, updates)
+	if err != nil {
+		c.JSON(locationErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": location})
+}
+
+func (h *LocationHandler) Delete(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid location ID"})
+		return
+	}
+
+	if err := h.locationService.Delete(id); err != nil {
+		c.JSON(locationErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "message": "Location deleted"})
+}
+
+// MigrateLegacy maps the free-text locations of existing tickets and assets
+// onto the tree and reports the ones it could not match. Pass ?dryRun=true
+// to see the report without changing anything.
+func (h *LocationHandler) MigrateLegacy(c *gin.Context) {
+	dryRun, _ := strconv.ParseBool(c.Query("dryRun"))
+
+	report, err := h.locationService.MigrateLegacy(dryRun)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to migrate locations"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": report})
+}
+
+func locationErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrLocationNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, service.ErrInvalidLocation):
+		return http.StatusUnprocessableEntity
+	case errors.Is(err, service.ErrLocationInUse):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,372 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/maintenance-system/api/internal/domain"
+	"github.com/maintenance-system/api/internal/service"
+)
+
+type MeterHandler struct {
+	meterService service.MeterService
+}
+
+func NewMeterHandler(meterService service.MeterService) *MeterHandler {
+	return &MeterHandler{meterService: meterService}
+}
+
+type CreateMeterRequest struct {
+	Name       string `json:"name" binding:"required"`
+	Unit       string `json:"unit"`
+	Cumulative bool   `json:"cumulative"` // readings may not go down, e.g. run-hours
+}
+
+type UpdateMeterRequest struct {
+	Name       string  `json:"name"`
+	Unit       *string `json:"unit"`
+	Cumulative *bool   `json:"cumulative"`
+}
+
+type RecordReadingRequest struct {
+	Value  *float64   `json:"value" binding:"required"`
+	ReadAt *time.Time `json:"readAt"`
+}
+
+type CreateMeterRuleRequest struct {
+	Name     string `json:"name" binding:"required"`
+	Type     string `json:"type" binding:"required,oneof=THRESHOLD DELTA"`
+	Operator string `json:"operator" binding:"omitempty,oneof=GT GTE LT LTE"`
+	// Value is the limit of a THRESHOLD rule or the interval of a DELTA
+	// rule. Baseline is where a DELTA rule's interval counts from; it
+	// defaults to the meter's last reading.
+	Value             *float64 `json:"value" binding:"required"`
+	Baseline          *float64 `json:"baseline"`
+	TicketTitle       string   `json:"ticketTitle"`
+	TicketDescription string   `json:"ticketDescription"`
+	TicketPriority    string   `json:"ticketPriority" binding:"omitempty,ticket_priority"`
+	AssigneeID        string   `json:"assigneeId" binding:"omitempty,uuid"`
+}
+
+type UpdateMeterRuleRequest struct {
+	Name              string   `json:"name"`
+	Operator          string   `json:"operator" binding:"omitempty,oneof=GT GTE LT LTE"`
+	Value             *float64 `json:"value"`
+	Baseline          *float64 `json:"baseline"`
+	Active            *bool    `json:"active"`
+	TicketTitle       *string  `json:"ticketTitle"`
+	TicketDescription *string  `json:"ticketDescription"`
+	TicketPriority    string   `json:"ticketPriority" binding:"omitempty,ticket_priority"`
+	AssigneeID        string   `json:"assigneeId" binding:"omitempty,uuid"`
+}
+
+func (h *MeterHandler) GetByAsset(c *gin.Context) {
+	assetID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid asset ID"})
+		return
+	}
+
+	meters, err := h.meterService.GetByAsset(assetID)
+	if err != nil {
+		c.JSON(meterErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": meters})
+}
+
+func (h *MeterHandler) GetByID(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid meter ID"})
+		return
+	}
+
+	meter, err := h.meterService.GetByID(id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Meter not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": meter})
+}
+
+func (h *MeterHandler) Create(c *gin.Context) {
+	assetID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid asset ID"})
+		return
+	}
+
+	var req CreateMeterRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	meter := &domain.Meter{
+		AssetID:    assetID,
+		Name:       req.Name,
+		Unit:       req.Unit,
+		Cumulative: req.Cumulative,
+	}
+
+	if err := h.meterService.Create(meter); err != nil {
+		c.JSON(meterErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"success": true, "data": meter})
+}
+
+func (h *MeterHandler) Update(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid meter ID"})
+		return
+	}
+
+	var req UpdateMeterRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	updates := make(map[string]interface{})
+	if req.Name != "" {
+		updates["name"] = req.Name
+	}
+	if req.Unit != nil {
+		updates["unit"] = *req.Unit
+	}
+	if req.Cumulative != nil {
+		updates["cumulative"] = *req.Cumulative
+	}
+
+	meter, err := h.meterService.Update(id, updates)
+	if err != nil {
+		c.JSON(meterErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": meter})
+}
+
+func (h *MeterHandler) Delete(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid meter ID"})
+		return
+	}
+
+	if err := h.meterService.Delete(id); err != nil {
+		c.JSON(meterErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "message": "Meter deleted"})
+}
+
+func (h *MeterHandler) GetReadings(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid meter ID"})
+		return
+	}
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "100"))
+
+	readings, err := h.meterService.GetReadings(id, limit)
+	if err != nil {
+		c.JSON(meterErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": readings})
+}
+
+func (h *MeterHandler) RecordReading(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid meter ID"})
+		return
+	}
+
+	var req RecordReadingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID := c.MustGet("userID").(uuid.UUID)
+
+	reading := &domain.MeterReading{
+		MeterID:      id,
+		Value:        *req.Value,
+		RecordedByID: userID,
+	}
+	if req.ReadAt != nil {
+		reading.ReadAt = *req.ReadAt
+	}
+
+	if err := h.meterService.Record(reading); err != nil {
+		c.JSON(meterErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"success": true, "data": reading})
+}
+
+// ImportReadings records readings from an uploaded CSV file.
+func (h *MeterHandler) ImportReadings(c *gin.Context) {
+	file, _, err := c.Request.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "No file uploaded"})
+		return
+	}
+	defer file.Close()
+
+	userID := c.MustGet("userID").(uuid.UUID)
+
+	result, err := h.meterService.Import(file, userID)
+	if err != nil {
+		c.JSON(meterErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": result})
+}
+
+func (h *MeterHandler) CreateRule(c *gin.Context) {
+	meterID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid meter ID"})
+		return
+	}
+
+	var req CreateMeterRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID := c.MustGet("userID").(uuid.UUID)
+
+	rule := &domain.MeterRule{
+		MeterID:           meterID,
+		Name:              req.Name,
+		Type:              domain.MeterRuleType(req.Type),
+		Operator:          domain.MeterOperator(req.Operator),
+		Value:             *req.Value,
+		Baseline:          req.Baseline,
+		Active:            true,
+		CreatedByID:       userID,
+		TicketTitle:       req.TicketTitle,
+		TicketDescription: req.TicketDescription,
+		TicketPriority:    domain.TicketPriority(req.TicketPriority),
+	}
+	if req.AssigneeID != "" {
+		assigneeID := uuid.MustParse(req.AssigneeID)
+		rule.AssigneeID = &assigneeID
+	}
+
+	if err := h.meterService.CreateRule(rule); err != nil {
+		c.JSON(meterErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"success": true, "data": rule})
+}
+
+func (h *MeterHandler) UpdateRule(c *gin.Context) {
+	meterID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid meter ID"})
+		return
+	}
+	ruleID, err := uuid.Parse(c.Param("ruleId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid rule ID"})
+		return
+	}
+
+	var req UpdateMeterRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	updates := make(map[string]interface{})
+	if req.Name != "" {
+		updates["name"] = req.Name
+	}
+	if req.Operator != "" {
+		updates["operator"] = req.Operator
+	}
+	if req.Value != nil {
+		updates["value"] = *req.Value
+	}
+	if req.Baseline != nil {
+		updates["baseline"] = *req.Baseline
+	}
+	if req.Active != nil {
+		updates["active"] = *req.Active
+	}
+	if req.TicketTitle != nil {
+		updates["ticketTitle"] = *req.TicketTitle
+	}
+	if req.TicketDescription != nil {
+		updates["ticketDescription"] = *req.TicketDescription
+	}
+	if req.TicketPriority != "" {
+		updates["ticketPriority"] = req.TicketPriority
+	}
+	if req.AssigneeID != "" {
+		updates["assigneeId"] = uuid.MustParse(req.AssigneeID)
+	}
+
+	rule, err := h.meterService.UpdateRule(meterID, ruleID, updates)
+	if err != nil {
+		c.JSON(meterErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": rule})
+}
+
+func (h *MeterHandler) DeleteRule(c *gin.Context) {
+	meterID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid meter ID"})
+		return
+	}
+	ruleID, err := uuid.Parse(c.Param("ruleId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid rule ID"})
+		return
+	}
+
+	if err := h.meterService.DeleteRule(meterID, ruleID); err != nil {
+		c.JSON(meterErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "message": "Meter rule deleted"})
+}
+
+func meterErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrMeterNotFound), errors.Is(err, service.ErrMeterRuleNotFound),
+		errors.Is(err, service.ErrAssetNotFound), errors.Is(err, service.ErrUserNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, service.ErrInvalidMeter), errors.Is(err, service.ErrInvalidMeterRule),
+		errors.Is(err, service.ErrInvalidReading), errors.Is(err, service.ErrInvalidImport):
+		return http.StatusUnprocessableEntity
+	default:
+		return http.StatusInternalServerError
+	}
+}
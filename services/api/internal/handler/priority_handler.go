@@ -0,0 +1,163 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/maintenance-system/api/internal/domain"
+	"github.com/maintenance-system/api/internal/service"
+)
+
+type PriorityHandler struct {
+	priorityService service.PriorityService
+}
+
+func NewPriorityHandler(priorityService service.PriorityService) *PriorityHandler {
+	return &PriorityHandler{priorityService: priorityService}
+}
+
+type CreatePriorityRequest struct {
+	Code        string     `json:"code" binding:"required"`
+	NameEN      string     `json:"nameEn" binding:"required"`
+	NameTH      string     `json:"nameTh"`
+	Rank        int        `json:"rank"`
+	Color       string     `json:"color"`
+	SLAPolicyID *uuid.UUID `json:"slaPolicyId"`
+}
+
+type UpdatePriorityRequest struct {
+	NameEN      string     `json:"nameEn"`
+	NameTH      *string    `json:"nameTh"`
+	Rank        *int       `json:"rank"`
+	Color       *string    `json:"color"`
+	SLAPolicyID *uuid.UUID `json:"slaPolicyId"`
+	Active      *bool      `json:"active"`
+}
+
+// GetAll lists the priorities from least to most urgent.
+func (h *PriorityHandler) GetAll(c *gin.Context) {
+	priorities, err := h.priorityService.GetAll()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch priorities"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": priorities})
+}
+
+func (h *PriorityHandler) GetByID(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid priority ID"})
+		return
+	}
+
+	priority, err := h.priorityService.GetByID(id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Priority not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": priority})
+}
+
+func (h *PriorityHandler) Create(c *gin.Context) {
+	var req CreatePriorityRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	priority := &domain.Priority{
+		Code:        domain.TicketPriority(req.Code),
+		NameEN:      req.NameEN,
+		NameTH:      req.NameTH,
+		Rank:        req.Rank,
+		Color:       req.Color,
+		SLAPolicyID: req.SLAPolicyID,
+		Active:      true,
+	}
+
+	if err := h.priorityService.Create(priority); err != nil {
+		c.JSON(priorityErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"success": true, "data": priority})
+}
+
+// Update changes a priority. Its code is fixed; send slaPolicyId as the nil
+// UUID to unlink the SLA policy.
+func (h *PriorityHandler) Update(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid priority ID"})
+		return
+	}
+
+	var req UpdatePriorityRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	updates := make(map[string]interface{})
+	if req.NameEN != "" {
+		updates["nameEn"] = req.NameEN
+	}
+	if req.NameTH != nil {
+		updates["nameTh"] = *req.NameTH
+	}
+	if req.Rank != nil {
+		updates["rank"] = *req.Rank
+	}
+	if req.Color != nil {
+		updates["color"] = *req.Color
+	}
+	if req.SLAPolicyID != nil {
+		updates["slaPolicyId"] = *req.SLAPolicyID
+	}
+	if req.Active != nil {
+		updates["active"] = *req.Active
+	}
+
+	priority, err := h.priorityService.Update(id, updates)
+	if err != nil {
+		c.JSON(priorityErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": priority})
+}
+
+// Delete removes a priority nothing refers to. Priorities in use can only
+// be deactivated, which hides them from new tickets.
+func (h *PriorityHandler) Delete(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid priority ID"})
+		return
+	}
+
+	if err := h.priorityService.Delete(id); err != nil {
+		c.JSON(priorityErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "message": "Priority deleted"})
+}
+
+func priorityErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrPriorityNotFound), errors.Is(err, service.ErrSLAPolicyNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, service.ErrInvalidPriority):
+		return http.StatusUnprocessableEntity
+	case errors.Is(err, service.ErrPriorityExists), errors.Is(err, service.ErrPriorityInUse):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,295 @@
+package handler
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/maintenance-system/api/internal/domain"
+	"github.com/maintenance-system/api/internal/repository"
+	"github.com/maintenance-system/api/internal/service"
+)
+
+type PurchaseHandler struct {
+	purchaseService service.PurchaseService
+}
+
+func NewPurchaseHandler(purchaseService service.PurchaseService) *PurchaseHandler {
+	return &PurchaseHandler{purchaseService: purchaseService}
+}
+
+type PurchaseLineRequest struct {
+	PartID      string  `json:"partId" binding:"omitempty,uuid"`
+	Description string  `json:"description"`
+	Quantity    float64 `json:"quantity" binding:"required,gt=0"`
+	Unit        string  `json:"unit"`
+	UnitCost    float64 `json:"unitCost" binding:"min=0"`
+}
+
+type CreatePurchaseRequest struct {
+	Supplier string                `json:"supplier"`
+	Notes    string                `json:"notes"`
+	Lines    []PurchaseLineRequest `json:"lines" binding:"dive"`
+}
+
+type UpdatePurchaseRequest struct {
+	Supplier *string                `json:"supplier"`
+	Notes    *string                `json:"notes"`
+	Lines    *[]PurchaseLineRequest `json:"lines" binding:"omitempty,dive"`
+}
+
+type OrderPurchaseRequest struct {
+	OrderReference string `json:"orderReference"`
+}
+
+type ReceivePurchaseRequest struct {
+	StockLocationID string `json:"stockLocationId" binding:"omitempty,uuid"`
+}
+
+func (h *PurchaseHandler) GetAll(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+
+	h.list(c, repository.PurchaseFilter{
+		Status:   c.Query("status"),
+		TicketID: queryUUID(c, "ticketId"),
+		Supplier: c.Query("supplier"),
+		Page:     page,
+		Limit:    limit,
+	})
+}
+
+func (h *PurchaseHandler) GetByTicket(c *gin.Context) {
+	ticketID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ticket ID"})
+		return
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+
+	h.list(c, repository.PurchaseFilter{
+		Status:   c.Query("status"),
+		TicketID: &ticketID,
+		Page:     page,
+		Limit:    limit,
+	})
+}
+
+func (h *PurchaseHandler) list(c *gin.Context, filter repository.PurchaseFilter) {
+	requests, total, err := h.purchaseService.GetAll(filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch purchase requests"})
+		return
+	}
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = 20
+	}
+	totalPages := (int(total) + limit - 1) / limit
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    requests,
+		"meta": gin.H{
+			"total":      total,
+			"page":       filter.Page,
+			"limit":      limit,
+			"totalPages": totalPages,
+		},
+	})
+}
+
+func (h *PurchaseHandler) GetByID(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid purchase request ID"})
+		return
+	}
+
+	request, err := h.purchaseService.GetByID(id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Purchase request not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": request})
+}
+
+func (h *PurchaseHandler) Create(c *gin.Context) {
+	ticketID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ticket ID"})
+		return
+	}
+
+	var req CreatePurchaseRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	request := &domain.PurchaseRequest{
+		TicketID:      ticketID,
+		Supplier:      req.Supplier,
+		Notes:         req.Notes,
+		RequestedByID: c.MustGet("userID").(uuid.UUID),
+		Lines:         purchaseLines(req.Lines),
+	}
+
+	if err := h.purchaseService.Create(request); err != nil {
+		c.JSON(purchaseErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"success": true, "data": request})
+}
+
+func (h *PurchaseHandler) Update(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid purchase request ID"})
+		return
+	}
+
+	var req UpdatePurchaseRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	updates := make(map[string]interface{})
+	if req.Supplier != nil {
+		updates["supplier"] = *req.Supplier
+	}
+	if req.Notes != nil {
+		updates["notes"] = *req.Notes
+	}
+	if req.Lines != nil {
+		updates["lines"] = purchaseLines(*req.Lines)
+	}
+
+	request, err := h.purchaseService.Update(id, updates)
+	if err != nil {
+		c.JSON(purchaseErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": request})
+}
+
+func (h *PurchaseHandler) Delete(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid purchase request ID"})
+		return
+	}
+
+	if err := h.purchaseService.Delete(id); err != nil {
+		c.JSON(purchaseErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "message": "Purchase request deleted"})
+}
+
+func (h *PurchaseHandler) Submit(c *gin.Context) {
+	h.act(c, h.purchaseService.Submit)
+}
+
+func (h *PurchaseHandler) Approve(c *gin.Context) {
+	h.act(c, h.purchaseService.Approve)
+}
+
+func (h *PurchaseHandler) Cancel(c *gin.Context) {
+	h.act(c, h.purchaseService.Cancel)
+}
+
+func (h *PurchaseHandler) Order(c *gin.Context) {
+	var req OrderPurchaseRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.act(c, func(id, userID uuid.UUID) (*domain.PurchaseRequest, error) {
+		return h.purchaseService.Order(id, userID, req.OrderReference)
+	})
+}
+
+// Receive records the goods as arrived. Give a stockLocationId to put
+// part lines into stock there.
+func (h *PurchaseHandler) Receive(c *gin.Context) {
+	var req ReceivePurchaseRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var stockLocationID *uuid.UUID
+	if req.StockLocationID != "" {
+		id := uuid.MustParse(req.StockLocationID)
+		stockLocationID = &id
+	}
+
+	h.act(c, func(id, userID uuid.UUID) (*domain.PurchaseRequest, error) {
+		return h.purchaseService.Receive(id, userID, stockLocationID)
+	})
+}
+
+// act runs a status change on the request in the :id parameter.
+func (h *PurchaseHandler) act(c *gin.Context, fn func(id, userID uuid.UUID) (*domain.PurchaseRequest, error)) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid purchase request ID"})
+		return
+	}
+
+	userID := c.MustGet("userID").(uuid.UUID)
+
+	request, err := fn(id, userID)
+	if err != nil {
+		c.JSON(purchaseErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": request})
+}
+
+func purchaseLines(reqs []PurchaseLineRequest) []domain.PurchaseRequestLine {
+	lines := make([]domain.PurchaseRequestLine, 0, len(reqs))
+	for _, req := range reqs {
+		line := domain.PurchaseRequestLine{
+			Description: req.Description,
+			Quantity:    req.Quantity,
+			Unit:        req.Unit,
+			UnitCost:    req.UnitCost,
+		}
+		if req.PartID != "" {
+			partID := uuid.MustParse(req.PartID)
+			line.PartID = &partID
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+func purchaseErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrPurchaseNotFound), errors.Is(err, service.ErrTicketNotFound),
+		errors.Is(err, service.ErrPartNotFound), errors.Is(err, service.ErrStockLocationNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, service.ErrInvalidPurchase):
+		return http.StatusUnprocessableEntity
+	case errors.Is(err, service.ErrPurchaseNotDraft), errors.Is(err, service.ErrPurchaseTransition),
+		errors.Is(err, service.ErrTicketClosed):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
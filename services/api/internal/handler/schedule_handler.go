@@ -0,0 +1,237 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/maintenance-system/api/internal/domain"
+	"github.com/maintenance-system/api/internal/service"
+)
+
+type ScheduleHandler struct {
+	scheduleService service.ScheduleService
+}
+
+func NewScheduleHandler(scheduleService service.ScheduleService) *ScheduleHandler {
+	return &ScheduleHandler{scheduleService: scheduleService}
+}
+
+type CreateScheduleRequest struct {
+	Name              string    `json:"name" binding:"required"`
+	RRule             string    `json:"rrule" binding:"required"`
+	StartAt           time.Time `json:"startAt" binding:"required"`
+	Timezone          string    `json:"timezone"`
+	LeadDays          *int      `json:"leadDays" binding:"omitempty,min=0"`
+	TicketTitle       string    `json:"ticketTitle" binding:"required"`
+	TicketDescription string    `json:"ticketDescription"`
+	TicketCategory    string    `json:"ticketCategory" binding:"omitempty,ticket_category"`
+	TicketPriority    string    `json:"ticketPriority" binding:"omitempty,ticket_priority"`
+	TicketLocation    string    `json:"ticketLocation"`
+	AssigneeID        string    `json:"assigneeId" binding:"omitempty,uuid"`
+}
+
+type UpdateScheduleRequest struct {
+	Name              string     `json:"name"`
+	RRule             string     `json:"rrule"`
+	StartAt           *time.Time `json:"startAt"`
+	Timezone          string     `json:"timezone"`
+	LeadDays          *int       `json:"leadDays" binding:"omitempty,min=0"`
+	Active            *bool      `json:"active"`
+	TicketTitle       string     `json:"ticketTitle"`
+	TicketDescription *string    `json:"ticketDescription"`
+	TicketCategory    string     `json:"ticketCategory" binding:"omitempty,ticket_category"`
+	TicketPriority    string     `json:"ticketPriority" binding:"omitempty,ticket_priority"`
+	TicketLocation    *string    `json:"ticketLocation"`
+	AssigneeID        string     `json:"assigneeId" binding:"omitempty,uuid"`
+}
+
+func (h *ScheduleHandler) GetAll(c *gin.Context) {
+	schedules, err := h.scheduleService.GetAll()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch schedules"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": schedules})
+}
+
+func (h *ScheduleHandler) GetByID(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid schedule ID"})
+		return
+	}
+
+	schedule, err := h.scheduleService.GetByID(id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Schedule not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": schedule})
+}
+
+func (h *ScheduleHandler) Create(c *gin.Context) {
+	var req CreateScheduleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID := c.MustGet("userID").(uuid.UUID)
+
+	schedule := &domain.MaintenanceSchedule{
+		Name:              req.Name,
+		RRule:             req.RRule,
+		StartAt:           req.StartAt,
+		Timezone:          req.Timezone,
+		LeadDays:          7,
+		Active:            true,
+		CreatedByID:       userID,
+		TicketTitle:       req.TicketTitle,
+		TicketDescription: req.TicketDescription,
+		TicketCategory:    domain.TicketCategory(req.TicketCategory),
+		TicketPriority:    domain.TicketPriority(req.TicketPriority),
+		TicketLocation:    req.TicketLocation,
+	}
+	if req.LeadDays != nil {
+		schedule.LeadDays = *req.LeadDays
+	}
+	if req.AssigneeID != "" {
+		assigneeID := uuid.MustParse(req.AssigneeID)
+		schedule.AssigneeID = &assigneeID
+	}
+
+	if err := h.scheduleService.Create(schedule); err != nil {
+		c.JSON(scheduleErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"success": true, "data": schedule})
+}
+
+func (h *ScheduleHandler) Update(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid schedule ID"})
+		return
+	}
+
+	var req UpdateScheduleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	updates := make(map[string]interface{})
+	if req.Name != "" {
+		updates["name"] = req.Name
+	}
+	if req.RRule != "" {
+		updates["rrule"] = req.RRule
+	}
+	if req.StartAt != nil {
+		updates["startAt"] = *req.StartAt
+	}
+	if req.Timezone != "" {
+		updates["timezone"] = req.Timezone
+	}
+	if req.LeadDays != nil {
+		updates["leadDays"] = *req.LeadDays
+	}
+	if req.Active != nil {
+		updates["active"] = *req.Active
+	}
+	if req.TicketTitle != "" {
+		updates["ticketTitle"] = req.TicketTitle
+	}
+	if req.TicketDescription != nil {
+		updates["ticketDescription"] = *req.TicketDescription
+	}
+	if req.TicketCategory != "" {
+		updates["ticketCategory"] = req.TicketCategory
+	}
+	if req.TicketPriority != "" {
+		updates["ticketPriority"] = req.TicketPriority
+	}
+	if req.TicketLocation != nil {
+		updates["ticketLocation"] = *req.TicketLocation
+	}
+	if req.AssigneeID != "" {
+		updates["assigneeId"] = uuid.MustParse(req.AssigneeID)
+	}
+
+	schedule, err := h.scheduleService.Update(id, updates)
+	if err != nil {
+		c.JSON(scheduleErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": schedule})
+}
+
+func (h *ScheduleHandler) Delete(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid schedule ID"})
+		return
+	}
+
+	if err := h.scheduleService.Delete(id); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete schedule"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "message": "Schedule deleted"})
+}
+
+// Preview returns the next occurrences of a schedule (?count=N, default 10).
+func (h *ScheduleHandler) Preview(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid schedule ID"})
+		return
+	}
+
+	count, _ := strconv.Atoi(c.DefaultQuery("count", "10"))
+
+	occurrences, err := h.scheduleService.Preview(id, count, time.Now())
+	if err != nil {
+		c.JSON(scheduleErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": occurrences})
+}
+
+// GetOccurrences lists the occurrences already generated and their tickets.
+func (h *ScheduleHandler) GetOccurrences(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid schedule ID"})
+		return
+	}
+
+	occurrences, err := h.scheduleService.GetOccurrences(id)
+	if err != nil {
+		c.JSON(scheduleErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": occurrences})
+}
+
+func scheduleErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrScheduleNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, service.ErrInvalidSchedule):
+		return http.StatusUnprocessableEntity
+	default:
+		return http.StatusInternalServerError
+	}
+}
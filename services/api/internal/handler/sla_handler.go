@@ -0,0 +1,143 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/maintenance-system/api/internal/domain"
+	"github.com/maintenance-system/api/internal/service"
+)
+
+type SLAHandler struct {
+	slaService service.SLAService
+}
+
+func NewSLAHandler(slaService service.SLAService) *SLAHandler {
+	return &SLAHandler{slaService: slaService}
+}
+
+type CreateSLAPolicyRequest struct {
+	Name              string `json:"name" binding:"required"`
+	Priority          string `json:"priority" binding:"required,ticket_priority"`
+	Category          string `json:"category" binding:"omitempty,ticket_category"`
+	ResponseMinutes   int    `json:"responseMinutes" binding:"required,min=1"`
+	ResolutionMinutes int    `json:"resolutionMinutes" binding:"required,min=1"`
+	CalendarID        string `json:"calendarId" binding:"omitempty,uuid"`
+}
+
+type UpdateSLAPolicyRequest struct {
+	Name              string `json:"name"`
+	ResponseMinutes   int    `json:"responseMinutes" binding:"omitempty,min=1"`
+	ResolutionMinutes int    `json:"resolutionMinutes" binding:"omitempty,min=1"`
+	CalendarID        string `json:"calendarId" binding:"omitempty,uuid"`
+}
+
+func (h *SLAHandler) GetAll(c *gin.Context) {
+	policies, err := h.slaService.GetAll()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch SLA policies"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": policies})
+}
+
+func (h *SLAHandler) GetByID(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid SLA policy ID"})
+		return
+	}
+
+	policy, err := h.slaService.GetByID(id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "SLA policy not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": policy})
+}
+
+func (h *SLAHandler) Create(c *gin.Context) {
+	var req CreateSLAPolicyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	policy := &domain.SLAPolicy{
+		Name:              req.Name,
+		Priority:          domain.TicketPriority(req.Priority),
+		Category:          domain.TicketCategory(req.Category),
+		ResponseMinutes:   req.ResponseMinutes,
+		ResolutionMinutes: req.ResolutionMinutes,
+	}
+	if req.CalendarID != "" {
+		calendarID := uuid.MustParse(req.CalendarID)
+		policy.CalendarID = &calendarID
+	}
+
+	if err := h.slaService.Create(policy); err != nil {
+		c.JSON(http.StatusConflict, gin.H{"error": "An SLA policy for this priority and category already exists"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"success": true, "data": policy})
+}
+
+func (h *SLAHandler) Update(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid SLA policy ID"})
+		return
+	}
+
+	var req UpdateSLAPolicyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	updates := make(map[string]interface{})
+	if req.Name != "" {
+		updates["name"] = req.Name
+	}
+	if req.ResponseMinutes != 0 {
+		updates["responseMinutes"] = req.ResponseMinutes
+	}
+	if req.ResolutionMinutes != 0 {
+		updates["resolutionMinutes"] = req.ResolutionMinutes
+	}
+	if req.CalendarID != "" {
+		updates["calendarId"] = uuid.MustParse(req.CalendarID)
+	}
+
+	policy, err := h.slaService.Update(id, updates)
+	if err != nil {
+		if errors.Is(err, service.ErrSLAPolicyNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": policy})
+}
+
+func (h *SLAHandler) Delete(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid SLA policy ID"})
+		return
+	}
+
+	if err := h.slaService.Delete(id); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete SLA policy"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "message": "SLA policy deleted"})
+}
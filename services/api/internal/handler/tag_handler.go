@@ -0,0 +1,268 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/maintenance-system/api/internal/domain"
+	"github.com/maintenance-system/api/internal/service"
+)
+
+type TagHandler struct {
+	tagService service.TagService
+}
+
+func NewTagHandler(tagService service.TagService) *TagHandler {
+	return &TagHandler{tagService: tagService}
+}
+
+type CreateTagRequest struct {
+	Name        string `json:"name" binding:"required"`
+	Color       string `json:"color"`
+	Description string `json:"description"`
+}
+
+type UpdateTagRequest struct {
+	Name        string  `json:"name"`
+	Color       string  `json:"color"`
+	Description *string `json:"description"`
+}
+
+type MergeTagsRequest struct {
+	SourceIDs []string `json:"sourceIds" binding:"required,min=1,dive,uuid"`
+}
+
+type TicketTagsRequest struct {
+	TagIDs []string `json:"tagIds" binding:"required,min=1,dive,uuid"`
+}
+
+type BulkTagRequest struct {
+	TicketIDs []string `json:"ticketIds" binding:"required,min=1,max=500,dive,uuid"`
+	Add       []string `json:"add" binding:"dive,uuid"`
+	Remove    []string `json:"remove" binding:"dive,uuid"`
+}
+
+func (h *TagHandler) GetAll(c *gin.Context) {
+	tags, err := h.tagService.GetAll()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch tags"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": tags})
+}
+
+func (h *TagHandler) GetByID(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid tag ID"})
+		return
+	}
+
+	tag, err := h.tagService.GetByID(id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Tag not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": tag})
+}
+
+func (h *TagHandler) Create(c *gin.Context) {
+	var req CreateTagRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	tag := &domain.Tag{
+		Name:        req.Name,
+		Color:       req.Color,
+		Description: req.Description,
+	}
+
+	if err := h.tagService.Create(tag); err != nil {
+		c.JSON(tagErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"success": true, "data": tag})
+}
+
+// Update changes the tag. A rename applies to every ticket with the tag.
+func (h *TagHandler) Update(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid tag ID"})
+		return
+	}
+
+	var req UpdateTagRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	updates := make(map[string]interface{})
+	if req.Name != "" {
+		updates["name"] = req.Name
+	}
+	if req.Color != "" {
+		updates["color"] = req.Color
+	}
+	if req.Description != nil {
+		updates["description"] = *req.Description
+	}
+
+	tag, err := h.tagService.Update(id, updates)
+	if err != nil {
+		c.JSON(tagErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": tag})
+}
+
+func (h *TagHandler) Delete(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid tag ID"})
+		return
+	}
+
+	if err := h.tagService.Delete(id); err != nil {
+		c.JSON(tagErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "message": "Tag deleted"})
+}
+
+// Merge folds the tags in sourceIds into the tag in :id.
+func (h *TagHandler) Merge(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid tag ID"})
+		return
+	}
+
+	var req MergeTagsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID := c.MustGet("userID").(uuid.UUID)
+
+	tag, err := h.tagService.Merge(id, parseUUIDs(req.SourceIDs), userID)
+	if err != nil {
+		c.JSON(tagErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": tag})
+}
+
+// AddToTicket puts the tags in the body on the ticket in :id and responds
+// with the ticket's tags.
+func (h *TagHandler) AddToTicket(c *gin.Context) {
+	ticketID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ticket ID"})
+		return
+	}
+
+	var req TicketTagsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.retagTicket(c, ticketID, parseUUIDs(req.TagIDs), nil)
+}
+
+// RemoveFromTicket takes the tag in :tagId off the ticket in :id and
+// responds with the ticket's remaining tags.
+func (h *TagHandler) RemoveFromTicket(c *gin.Context) {
+	ticketID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ticket ID"})
+		return
+	}
+	tagID, err := uuid.Parse(c.Param("tagId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid tag ID"})
+		return
+	}
+
+	h.retagTicket(c, ticketID, nil, []uuid.UUID{tagID})
+}
+
+func (h *TagHandler) retagTicket(c *gin.Context, ticketID uuid.UUID, add, remove []uuid.UUID) {
+	userID := c.MustGet("userID").(uuid.UUID)
+
+	// Retag skips tickets that do not exist; a single ticket should 404.
+	if _, err := h.tagService.TicketTags(ticketID); err != nil {
+		c.JSON(tagErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	if _, err := h.tagService.Retag([]uuid.UUID{ticketID}, add, remove, userID); err != nil {
+		c.JSON(tagErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	tags, err := h.tagService.TicketTags(ticketID)
+	if err != nil {
+		c.JSON(tagErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	if tags == nil {
+		tags = []domain.Tag{}
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": tags})
+}
+
+// Bulk adds and removes tags on many tickets at once. Tickets that do not
+// exist are skipped.
+func (h *TagHandler) Bulk(c *gin.Context) {
+	var req BulkTagRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID := c.MustGet("userID").(uuid.UUID)
+
+	change, err := h.tagService.Retag(parseUUIDs(req.TicketIDs), parseUUIDs(req.Add), parseUUIDs(req.Remove), userID)
+	if err != nil {
+		c.JSON(tagErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": change})
+}
+
+// parseUUIDs parses IDs that binding has already checked.
+func parseUUIDs(raw []string) []uuid.UUID {
+	ids := make([]uuid.UUID, len(raw))
+	for i, s := range raw {
+		ids[i] = uuid.MustParse(s)
+	}
+	return ids
+}
+
+func tagErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrTagNotFound), errors.Is(err, service.ErrTicketNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, service.ErrInvalidTag):
+		return http.StatusUnprocessableEntity
+	case errors.Is(err, service.ErrTagExists):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
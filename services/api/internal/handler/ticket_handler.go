@@ -1,8 +1,12 @@
 package handler
 
 import (
+	"errors"
+	"io"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -12,26 +16,73 @@ import (
 )
 
 type TicketHandler struct {
-	ticketService service.TicketService
+	ticketService      service.TicketService
+	duplicateService   service.DuplicateService
+	customFieldService service.CustomFieldService
 }
 
-func NewTicketHandler(ticketService service.TicketService) *TicketHandler {
-	return &TicketHandler{ticketService: ticketService}
+func NewTicketHandler(ticketService service.TicketService, duplicateService service.DuplicateService, customFieldService service.CustomFieldService) *TicketHandler {
+	return &TicketHandler{
+		ticketService:      ticketService,
+		duplicateService:   duplicateService,
+		customFieldService: customFieldService,
+	}
 }
 
 type CreateTicketRequest struct {
 	Title       string `json:"title" binding:"required,min=5"`
 	Description string `json:"description" binding:"required,min=10"`
-	Priority    string `json:"priority" binding:"required,oneof=LOW MEDIUM HIGH CRITICAL"`
-	Category    string `json:"category" binding:"required,oneof=ELECTRICAL PLUMBING HVAC IT GENERAL OTHER"`
+	Priority    string `json:"priority" binding:"omitempty,ticket_priority"`
+	Category    string `json:"category" binding:"required,ticket_category"`
 	Location    string `json:"location"`
+	LocationID  string `json:"locationId" binding:"omitempty,uuid"`
+	AssetID     string `json:"assetId" binding:"omitempty,uuid"`
+
+	// DepartmentID charges the ticket to a department other than the
+	// requester's.
+	DepartmentID string `json:"departmentId" binding:"omitempty,uuid"`
+
+	// CreateAnyway skips the duplicate check. DuplicateOf adds the request
+	// as a comment on that existing ticket instead of creating a new one.
+	CreateAnyway bool   `json:"createAnyway"`
+	DuplicateOf  string `json:"duplicateOf" binding:"omitempty,uuid"`
+
+	// Values of the custom fields defined for the category, by key
+	CustomFields map[string]interface{} `json:"customFields"`
+
+	EstimatedLaborCost  float64 `json:"estimatedLaborCost" binding:"min=0"`
+	EstimatedPartsCost  float64 `json:"estimatedPartsCost" binding:"min=0"`
+	EstimatedVendorCost float64 `json:"estimatedVendorCost" binding:"min=0"`
 }
 
 type UpdateTicketRequest struct {
-	Title       string `json:"title"`
-	Description string `json:"description"`
-	Status      string `json:"status"`
-	Priority    string `json:"priority"`
+	Title       string     `json:"title"`
+	Description string     `json:"description"`
+	Status      string     `json:"status"`
+	Priority    string     `json:"priority" binding:"omitempty,ticket_priority"`
+	Category    string     `json:"category" binding:"omitempty,ticket_category"`
+	Location    string     `json:"location"`
+	DueDate     *time.Time `json:"dueDate"`
+	LocationID  string     `json:"locationId" binding:"omitempty,uuid"`
+	AssetID     string     `json:"assetId" binding:"omitempty,uuid"`
+	VendorCost  *float64   `json:"vendorCost" binding:"omitempty,min=0"`
+	// RecomputeDueDate drops a hand-set due date in favour of the SLA
+	// resolution target.
+	RecomputeDueDate bool `json:"recomputeDueDate"`
+
+	DepartmentID string `json:"departmentId" binding:"omitempty,uuid"`
+	// ParentID makes the ticket a sub-ticket of another.
+	ParentID string `json:"parentId" binding:"omitempty,uuid"`
+	// CustomFields sets the fields given; a null value clears one.
+	CustomFields map[string]interface{} `json:"customFields"`
+
+	EstimatedLaborCost  *float64 `json:"estimatedLaborCost" binding:"omitempty,min=0"`
+	EstimatedPartsCost  *float64 `json:"estimatedPartsCost" binding:"omitempty,min=0"`
+	EstimatedVendorCost *float64 `json:"estimatedVendorCost" binding:"omitempty,min=0"`
+}
+
+type CostDecisionRequest struct {
+	Reason string `json:"reason"`
 }
 
 type AssignRequest struct {
@@ -42,6 +93,10 @@ type CommentRequest struct {
 	Content string `json:"content" binding:"required,min=1"`
 }
 
+// Create raises a ticket. Unless the request sets createAnyway, it is
+// refused with 409 and the likely duplicates when similar open tickets
+// exist; the client can then resend with createAnyway, or with duplicateOf
+// to comment on one of them instead.
 func (h *TicketHandler) Create(c *gin.Context) {
 	var req CreateTicketRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -51,6 +106,108 @@ func (h *TicketHandler) Create(c *gin.Context) {
 
 	userID := c.MustGet("userID").(uuid.UUID)
 
+	if req.DuplicateOf != "" {
+		content := req.Title + "\n\n" + req.Description
+		comment, err := h.ticketService.AddComment(uuid.MustParse(req.DuplicateOf), userID, content)
+		if err != nil {
+			c.JSON(ticketErrorStatus(err), gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"success": true, "data": comment})
+		return
+	}
+
+	ticket := newTicket(req, userID)
+	if !h.applyCustomFields(c, ticket, req.CustomFields) {
+		return
+	}
+
+	if !req.CreateAnyway {
+		duplicates, err := h.duplicateService.Find(ticket, time.Now())
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check for duplicate tickets"})
+			return
+		}
+		if len(duplicates) > 0 {
+			c.JSON(http.StatusConflict, gin.H{
+				"error":      "Similar open tickets already exist",
+				"duplicates": duplicates,
+			})
+			return
+		}
+	}
+
+	if err := h.ticketService.Create(ticket); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create ticket"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"success": true, "data": ticket})
+}
+
+// CheckDuplicates lists the open tickets that the ticket in the body would
+// likely duplicate, without creating it.
+func (h *TicketHandler) CheckDuplicates(c *gin.Context) {
+	var req CreateTicketRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ticket := newTicket(req, c.MustGet("userID").(uuid.UUID))
+
+	duplicates, err := h.duplicateService.Find(ticket, time.Now())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check for duplicate tickets"})
+		return
+	}
+	if duplicates == nil {
+		duplicates = []service.DuplicateCandidate{}
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": duplicates})
+}
+
+// CreateChild creates a sub-ticket of the ticket in :id.
+func (h *TicketHandler) CreateChild(c *gin.Context) {
+	parentID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ticket ID"})
+		return
+	}
+
+	var req CreateTicketRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ticket := newTicket(req, c.MustGet("userID").(uuid.UUID))
+	if !h.applyCustomFields(c, ticket, req.CustomFields) {
+		return
+	}
+
+	if err := h.ticketService.CreateChild(parentID, ticket); err != nil {
+		c.JSON(ticketErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"success": true, "data": ticket})
+}
+
+// applyCustomFields validates the custom field values of a new ticket and
+// sets them on it, responding with the error if they are invalid.
+func (h *TicketHandler) applyCustomFields(c *gin.Context, ticket *domain.Ticket, values map[string]interface{}) bool {
+	fields, err := h.customFieldService.Validate(ticket.Category, values, nil)
+	if err != nil {
+		c.JSON(customFieldErrorStatus(err), gin.H{"error": err.Error()})
+		return false
+	}
+	ticket.CustomFields = fields
+	return true
+}
+
+func newTicket(req CreateTicketRequest, userID uuid.UUID) *domain.Ticket {
 	ticket := &domain.Ticket{
 		Title:       req.Title,
 		Description: req.Description,
@@ -58,14 +215,24 @@ func (h *TicketHandler) Create(c *gin.Context) {
 		Category:    domain.TicketCategory(req.Category),
 		Location:    req.Location,
 		CreatedByID: userID,
-	}
 
-	if err := h.ticketService.Create(ticket); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create ticket"})
-		return
+		EstimatedLaborCost:  req.EstimatedLaborCost,
+		EstimatedPartsCost:  req.EstimatedPartsCost,
+		EstimatedVendorCost: req.EstimatedVendorCost,
 	}
-
-	c.JSON(http.StatusCreated, gin.H{"success": true, "data": ticket})
+	if req.LocationID != "" {
+		locationID := uuid.MustParse(req.LocationID)
+		ticket.LocationID = &locationID
+	}
+	if req.AssetID != "" {
+		assetID := uuid.MustParse(req.AssetID)
+		ticket.AssetID = &assetID
+	}
+	if req.DepartmentID != "" {
+		departmentID := uuid.MustParse(req.DepartmentID)
+		ticket.DepartmentID = &departmentID
+	}
+	return ticket
 }
 
 func (h *TicketHandler) GetByID(c *gin.Context) {
@@ -75,7 +242,13 @@ func (h *TicketHandler) GetByID(c *gin.Context) {
 		return
 	}
 
-	ticket, err := h.ticketService.GetByID(id)
+	// ?children=true nests the whole tree of sub-tickets under the ticket.
+	var opts []repository.FindOption
+	if children := queryBool(c, "children"); children != nil && *children {
+		opts = append(opts, repository.WithChildTree())
+	}
+
+	ticket, err := h.ticketService.GetByID(id, opts...)
 	if err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Ticket not found"})
 		return
@@ -89,20 +262,91 @@ func (h *TicketHandler) GetAll(c *gin.Context) {
 	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
 
 	filter := repository.TicketFilter{
+		Status:             c.Query("status"),
+		Priority:           c.Query("priority"),
+		Category:           c.Query("category"),
+		Search:             c.Query("search"),
+		AssetID:            queryUUID(c, "assetId"),
+		LocationID:         queryUUID(c, "locationId"),
+		DepartmentID:       queryUUID(c, "departmentId"),
+		ParentID:           queryUUID(c, "parentId"),
+		ResponseBreached:   queryBool(c, "responseBreached"),
+		ResolutionBreached: queryBool(c, "resolutionBreached"),
+		Page:               page,
+		Limit:              limit,
+
+		TagsAny:  queryUUIDs(c, "tags"),
+		TagsAll:  queryUUIDs(c, "tagsAll"),
+		TagsNone: queryUUIDs(c, "tagsNone"),
+	}
+	if !h.customFieldQuery(c, &filter) {
+		return
+	}
+
+	h.list(c, filter)
+}
+
+// GetChildren lists the direct sub-tickets of the ticket in :id.
+func (h *TicketHandler) GetChildren(c *gin.Context) {
+	parentID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ticket ID"})
+		return
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
+
+	h.list(c, repository.TicketFilter{
 		Status:   c.Query("status"),
-		Priority: c.Query("priority"),
-		Category: c.Query("category"),
-		Search:   c.Query("search"),
+		ParentID: &parentID,
 		Page:     page,
 		Limit:    limit,
+	})
+}
+
+// customFieldQuery adds the custom field conditions and sort in the query
+// string to the filter: cf.<key>=<op>:<value> for each condition, and
+// sort=cf.<key> with order=asc or desc. It responds with 400 if any is
+// invalid.
+func (h *TicketHandler) customFieldQuery(c *gin.Context, filter *repository.TicketFilter) bool {
+	for param, values := range c.Request.URL.Query() {
+		key, ok := strings.CutPrefix(param, "cf.")
+		if !ok {
+			continue
+		}
+		for _, expr := range values {
+			field, err := h.customFieldService.Filter(key, expr)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return false
+			}
+			filter.CustomFields = append(filter.CustomFields, field)
+		}
 	}
 
+	if key, ok := strings.CutPrefix(c.Query("sort"), "cf."); ok {
+		sort, err := h.customFieldService.Sort(key, strings.EqualFold(c.Query("order"), "desc"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return false
+		}
+		filter.SortBy = sort
+	}
+	return true
+}
+
+func (h *TicketHandler) list(c *gin.Context, filter repository.TicketFilter) {
 	tickets, total, err := h.ticketService.GetAll(filter)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch tickets"})
 		return
 	}
 
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = 10
+	}
 	totalPages := (int(total) + limit - 1) / limit
 
 	c.JSON(http.StatusOK, gin.H{
@@ -110,7 +354,7 @@ func (h *TicketHandler) GetAll(c *gin.Context) {
 		"data":    tickets,
 		"meta": gin.H{
 			"total":      total,
-			"page":       page,
+			"page":       filter.Page,
 			"limit":      limit,
 			"totalPages": totalPages,
 		},
@@ -145,10 +389,65 @@ func (h *TicketHandler) Update(c *gin.Context) {
 	if req.Priority != "" {
 		updates["priority"] = req.Priority
 	}
+	if req.Category != "" {
+		updates["category"] = req.Category
+	}
+	if req.Location != "" {
+		updates["location"] = req.Location
+	}
+	if req.DueDate != nil {
+		updates["dueDate"] = *req.DueDate
+	}
+	if req.RecomputeDueDate {
+		updates["recomputeDueDate"] = true
+	}
+	if req.LocationID != "" {
+		updates["locationId"] = uuid.MustParse(req.LocationID)
+	}
+	if req.AssetID != "" {
+		updates["assetId"] = uuid.MustParse(req.AssetID)
+	}
+	if req.DepartmentID != "" {
+		updates["departmentId"] = uuid.MustParse(req.DepartmentID)
+	}
+	if req.ParentID != "" {
+		updates["parentId"] = uuid.MustParse(req.ParentID)
+	}
+	if req.VendorCost != nil {
+		updates["vendorCost"] = *req.VendorCost
+	}
+	if req.EstimatedLaborCost != nil {
+		updates["estimatedLaborCost"] = *req.EstimatedLaborCost
+	}
+	if req.EstimatedPartsCost != nil {
+		updates["estimatedPartsCost"] = *req.EstimatedPartsCost
+	}
+	if req.EstimatedVendorCost != nil {
+		updates["estimatedVendorCost"] = *req.EstimatedVendorCost
+	}
+	// A new category can drop fields or make others required, so the
+	// values are checked again whenever either changes.
+	if req.CustomFields != nil || req.Category != "" {
+		current, err := h.ticketService.GetByID(id)
+		if err != nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Ticket not found"})
+			return
+		}
+		category := current.Category
+		if req.Category != "" {
+			category = domain.TicketCategory(req.Category)
+		}
+		fields, err := h.customFieldService.Validate(category, req.CustomFields, current.CustomFields)
+		if err != nil {
+			c.JSON(customFieldErrorStatus(err), gin.H{"error": err.Error()})
+			return
+		}
+		updates["customFields"] = fields
+	}
 
 	ticket, err := h.ticketService.Update(id, updates, userID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(ticketErrorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 
@@ -162,8 +461,10 @@ func (h *TicketHandler) Delete(c *gin.Context) {
 		return
 	}
 
-	if err := h.ticketService.Delete(id); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete ticket"})
+	userID := c.MustGet("userID").(uuid.UUID)
+
+	if err := h.ticketService.Delete(id, userID); err != nil {
+		c.JSON(ticketErrorStatus(err), gin.H{"error": "Failed to delete ticket"})
 		return
 	}
 
@@ -192,7 +493,7 @@ func (h *TicketHandler) Assign(c *gin.Context) {
 	userID := c.MustGet("userID").(uuid.UUID)
 
 	if err := h.ticketService.AssignTechnician(ticketID, techID, userID); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(ticketErrorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 
@@ -216,7 +517,7 @@ func (h *TicketHandler) AddComment(c *gin.Context) {
 
 	comment, err := h.ticketService.AddComment(ticketID, userID, req.Content)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(ticketErrorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 
@@ -264,3 +565,99 @@ func (h *TicketHandler) GetLogs(c *gin.Context) {
 
 	c.JSON(http.StatusOK, gin.H{"success": true, "data": logs})
 }
+
+// ApproveCost signs off the ticket's cost estimate.
+func (h *TicketHandler) ApproveCost(c *gin.Context) {
+	h.decideCost(c, true)
+}
+
+// RejectCost turns the ticket's cost estimate down; a reason is required.
+func (h *TicketHandler) RejectCost(c *gin.Context) {
+	h.decideCost(c, false)
+}
+
+func (h *TicketHandler) decideCost(c *gin.Context, approve bool) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ticket ID"})
+		return
+	}
+
+	var req CostDecisionRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if !approve && req.Reason == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "A reason is required to reject"})
+		return
+	}
+
+	userID := c.MustGet("userID").(uuid.UUID)
+
+	var ticket *domain.Ticket
+	if approve {
+		ticket, err = h.ticketService.ApproveCost(id, userID, req.Reason)
+	} else {
+		ticket, err = h.ticketService.RejectCost(id, userID, req.Reason)
+	}
+	if err != nil {
+		c.JSON(ticketErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": ticket})
+}
+
+// ticketErrorStatus maps ticket service errors to an HTTP status code.
+func ticketErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrTicketNotFound), errors.Is(err, service.ErrUserNotFound),
+		errors.Is(err, service.ErrAttachmentNotFound), errors.Is(err, service.ErrAssetNotFound),
+		errors.Is(err, service.ErrLinkNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, service.ErrInvalidStatus), errors.Is(err, service.ErrInvalidLink),
+		errors.Is(err, service.ErrInvalidMerge):
+		return http.StatusUnprocessableEntity
+	case errors.Is(err, service.ErrInvalidTransition), errors.Is(err, service.ErrNotAwaitingApproval),
+		errors.Is(err, service.ErrCostRejected), errors.Is(err, service.ErrApprovalRequired),
+		errors.Is(err, service.ErrChecklistIncomplete), errors.Is(err, service.ErrOpenSubTickets),
+		errors.Is(err, service.ErrTicketClosed), errors.Is(err, service.ErrInvalidParent),
+		errors.Is(err, service.ErrTicketBlocked), errors.Is(err, service.ErrLinkExists):
+		return http.StatusConflict
+	case errors.Is(err, service.ErrTransitionForbidden), errors.Is(err, service.ErrCostApprovalForbidden),
+		errors.Is(err, service.ErrCostFieldsForbidden):
+		return http.StatusForbidden
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// queryBool returns the boolean query parameter key, or nil if it is absent
+// or not a valid boolean.
+func queryBool(c *gin.Context, key string) *bool {
+	v, err := strconv.ParseBool(c.Query(key))
+	if err != nil {
+		return nil
+	}
+	return &v
+}
+
+func queryUUID(c *gin.Context, key string) *uuid.UUID {
+	v, err := uuid.Parse(c.Query(key))
+	if err != nil {
+		return nil
+	}
+	return &v
+}
+
+// queryUUIDs reads a comma-separated list of IDs, skipping invalid ones.
+func queryUUIDs(c *gin.Context, key string) []uuid.UUID {
+	var ids []uuid.UUID
+	for _, raw := range strings.Split(c.Query(key), ",") {
+		if id, err := uuid.Parse(strings.TrimSpace(raw)); err == nil {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
@@ -0,0 +1,110 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/maintenance-system/api/internal/domain"
+)
+
+type LinkTicketRequest struct {
+	Type     string `json:"type" binding:"required,oneof=DUPLICATE_OF DUPLICATED_BY BLOCKS BLOCKED_BY RELATED_TO"`
+	TicketID string `json:"ticketId" binding:"required,uuid"`
+}
+
+type MergeTicketsRequest struct {
+	DuplicateIDs []string `json:"duplicateIds" binding:"required,min=1,dive,uuid"`
+}
+
+func (h *TicketHandler) GetLinks(c *gin.Context) {
+	ticketID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ticket ID"})
+		return
+	}
+
+	links, err := h.ticketService.GetLinks(ticketID)
+	if err != nil {
+		c.JSON(ticketErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": links})
+}
+
+func (h *TicketHandler) AddLink(c *gin.Context) {
+	ticketID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ticket ID"})
+		return
+	}
+
+	var req LinkTicketRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID := c.MustGet("userID").(uuid.UUID)
+
+	link, err := h.ticketService.AddLink(ticketID, uuid.MustParse(req.TicketID), domain.TicketLinkType(req.Type), userID)
+	if err != nil {
+		c.JSON(ticketErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"success": true, "data": link})
+}
+
+func (h *TicketHandler) RemoveLink(c *gin.Context) {
+	ticketID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ticket ID"})
+		return
+	}
+	linkID, err := uuid.Parse(c.Param("linkId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid link ID"})
+		return
+	}
+
+	userID := c.MustGet("userID").(uuid.UUID)
+
+	if err := h.ticketService.RemoveLink(ticketID, linkID, userID); err != nil {
+		c.JSON(ticketErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "message": "Link removed"})
+}
+
+// Merge folds the duplicateIds into the ticket in :id and closes them.
+func (h *TicketHandler) Merge(c *gin.Context) {
+	primaryID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ticket ID"})
+		return
+	}
+
+	var req MergeTicketsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	duplicateIDs := make([]uuid.UUID, len(req.DuplicateIDs))
+	for i, id := range req.DuplicateIDs {
+		duplicateIDs[i] = uuid.MustParse(id)
+	}
+
+	userID := c.MustGet("userID").(uuid.UUID)
+
+	ticket, err := h.ticketService.Merge(primaryID, duplicateIDs, userID)
+	if err != nil {
+		c.JSON(ticketErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": ticket})
+}
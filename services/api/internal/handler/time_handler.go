@@ -0,0 +1,301 @@
+package handler
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/maintenance-system/api/internal/domain"
+	"github.com/maintenance-system/api/internal/repository"
+	"github.com/maintenance-system/api/internal/service"
+)
+
+type TimeHandler struct {
+	timeService service.TimeService
+}
+
+func NewTimeHandler(timeService service.TimeService) *TimeHandler {
+	return &TimeHandler{timeService: timeService}
+}
+
+type StartTimerRequest struct {
+	Note     string `json:"note"`
+	Billable *bool  `json:"billable"`
+}
+
+type CreateTimeEntryRequest struct {
+	StartedAt       time.Time `json:"startedAt" binding:"required"`
+	DurationMinutes int       `json:"durationMinutes" binding:"required,min=1"`
+	Note            string    `json:"note"`
+	Billable        *bool     `json:"billable"`
+	// UserID records the time for another user (admins only).
+	UserID string `json:"userId" binding:"omitempty,uuid"`
+}
+
+type CorrectTimeEntryRequest struct {
+	StartedAt       *time.Time `json:"startedAt"`
+	DurationMinutes *int       `json:"durationMinutes" binding:"omitempty,min=1"`
+	Note            *string    `json:"note"`
+	Billable        *bool      `json:"billable"`
+	Reason          string     `json:"reason" binding:"required"`
+}
+
+type DeleteTimeEntryRequest struct {
+	Reason string `json:"reason" binding:"required"`
+}
+
+type LaborRateRequest struct {
+	HourlyRate *float64 `json:"hourlyRate" binding:"required,min=0"`
+}
+
+// GetTicketTime returns the ticket's time entries with their totals.
+func (h *TimeHandler) GetTicketTime(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ticket ID"})
+		return
+	}
+
+	result, err := h.timeService.GetTicketTime(id)
+	if err != nil {
+		c.JSON(timeErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": result})
+}
+
+func (h *TimeHandler) Start(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ticket ID"})
+		return
+	}
+
+	var req StartTimerRequest
+	// The body is optional.
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID := c.MustGet("userID").(uuid.UUID)
+
+	entry, err := h.timeService.Start(id, userID, req.Note, req.Billable == nil || *req.Billable, time.Now())
+	if err != nil {
+		c.JSON(timeErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"success": true, "data": entry})
+}
+
+// GetCurrent returns the current user's running timer, or null.
+func (h *TimeHandler) GetCurrent(c *gin.Context) {
+	userID := c.MustGet("userID").(uuid.UUID)
+
+	entry, err := h.timeService.GetRunning(userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch timer"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": entry})
+}
+
+func (h *TimeHandler) Stop(c *gin.Context) {
+	userID := c.MustGet("userID").(uuid.UUID)
+
+	entry, err := h.timeService.Stop(userID, time.Now())
+	if err != nil {
+		c.JSON(timeErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": entry})
+}
+
+func (h *TimeHandler) Create(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ticket ID"})
+		return
+	}
+
+	var req CreateTimeEntryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID := c.MustGet("userID").(uuid.UUID)
+
+	entry := &domain.TimeEntry{
+		TicketID:        id,
+		StartedAt:       req.StartedAt,
+		DurationSeconds: int64(req.DurationMinutes) * 60,
+		Note:            req.Note,
+		Billable:        req.Billable == nil || *req.Billable,
+	}
+	if req.UserID != "" {
+		entry.UserID = uuid.MustParse(req.UserID)
+	}
+
+	if err := h.timeService.AddEntry(entry, userID); err != nil {
+		c.JSON(timeErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"success": true, "data": entry})
+}
+
+// Correct lets an admin fix an entry; the reason is kept in the ticket log.
+func (h *TimeHandler) Correct(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid time entry ID"})
+		return
+	}
+
+	var req CorrectTimeEntryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	updates := make(map[string]interface{})
+	if req.StartedAt != nil {
+		updates["startedAt"] = *req.StartedAt
+	}
+	if req.DurationMinutes != nil {
+		updates["durationSeconds"] = int64(*req.DurationMinutes) * 60
+	}
+	if req.Note != nil {
+		updates["note"] = *req.Note
+	}
+	if req.Billable != nil {
+		updates["billable"] = *req.Billable
+	}
+
+	adminID := c.MustGet("userID").(uuid.UUID)
+
+	entry, err := h.timeService.Correct(id, updates, adminID, req.Reason)
+	if err != nil {
+		c.JSON(timeErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": entry})
+}
+
+func (h *TimeHandler) Delete(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid time entry ID"})
+		return
+	}
+
+	var req DeleteTimeEntryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	adminID := c.MustGet("userID").(uuid.UUID)
+
+	if err := h.timeService.Delete(id, adminID, req.Reason); err != nil {
+		c.JSON(timeErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "message": "Time entry deleted"})
+}
+
+// Summary totals time and labor cost per technician, department or ticket
+// (?groupBy=technician|department|ticket, optional RFC 3339 ?from= and ?to=).
+func (h *TimeHandler) Summary(c *gin.Context) {
+	filter := repository.TimeSummaryFilter{
+		GroupBy:  c.Query("groupBy"),
+		UserID:   queryUUID(c, "userId"),
+		TicketID: queryUUID(c, "ticketId"),
+	}
+	if v := c.Query("from"); v != "" {
+		from, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid from time"})
+			return
+		}
+		filter.From = &from
+	}
+	if v := c.Query("to"); v != "" {
+		to, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid to time"})
+			return
+		}
+		filter.To = &to
+	}
+
+	summary, err := h.timeService.Summary(filter)
+	if err != nil {
+		c.JSON(timeErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": summary})
+}
+
+func (h *TimeHandler) GetRates(c *gin.Context) {
+	rates, err := h.timeService.GetRates()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch labor rates"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": rates})
+}
+
+// SetRate sets the default hourly rate for a role.
+func (h *TimeHandler) SetRate(c *gin.Context) {
+	role := domain.UserRole(c.Param("role"))
+	switch role {
+	case domain.RoleUser, domain.RoleTechnician, domain.RoleAdmin:
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid role"})
+		return
+	}
+
+	var req LaborRateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	rate, err := h.timeService.SetRate(role, *req.HourlyRate)
+	if err != nil {
+		c.JSON(timeErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": rate})
+}
+
+func timeErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrTimeEntryNotFound), errors.Is(err, service.ErrTicketNotFound),
+		errors.Is(err, service.ErrUserNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, service.ErrTimerRunning), errors.Is(err, service.ErrNoRunningTimer),
+		errors.Is(err, service.ErrTicketClosed):
+		return http.StatusConflict
+	case errors.Is(err, service.ErrTimeEntryForbidden):
+		return http.StatusForbidden
+	case errors.Is(err, service.ErrInvalidTimeEntry):
+		return http.StatusUnprocessableEntity
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
@@ -23,10 +24,16 @@ type UpdateUserRequest struct {
 	Phone      string `json:"phone"`
 	Department string `json:"department"`
 	Status     string `json:"status"`
+	// HourlyRate overrides the role's labor rate for this user.
+	HourlyRate *float64 `json:"hourlyRate" binding:"omitempty,min=0"`
+	// DepartmentHead lets the user decide department-head approval steps.
+	DepartmentHead *bool `json:"departmentHead"`
+	// DepartmentID links the user to a department, taking its name.
+	DepartmentID string `json:"departmentId" binding:"omitempty,uuid"`
 }
 
 type UpdateRoleRequest struct {
-	Role string `json:"role" binding:"required,oneof=USER TECHNICIAN ADMIN"`
+	Role string `json:"role" binding:"required,oneof=USER TECHNICIAN ADMIN APPROVER"`
 }
 
 func (h *UserHandler) GetAll(c *gin.Context) {
@@ -95,10 +102,23 @@ func (h *UserHandler) Update(c *gin.Context) {
 	if req.Status != "" {
 		updates["status"] = req.Status
 	}
+	if req.HourlyRate != nil {
+		updates["hourlyRate"] = *req.HourlyRate
+	}
+	if req.DepartmentHead != nil {
+		updates["departmentHead"] = *req.DepartmentHead
+	}
+	if req.DepartmentID != "" {
+		updates["departmentId"] = uuid.MustParse(req.DepartmentID)
+	}
 
 	user, err := h.userService.Update(id, updates)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		status := http.StatusInternalServerError
+		if errors.Is(err, service.ErrDepartmentNotFound) {
+			status = http.StatusNotFound
+		}
+		c.JSON(status, gin.H{"error": err.Error()})
 		return
 	}
 
@@ -0,0 +1,28 @@
+package handler
+
+import (
+	"fmt"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"github.com/maintenance-system/api/internal/domain"
+	"github.com/maintenance-system/api/internal/service"
+)
+
+// RegisterValidators adds the binding tags that check request fields
+// against the categories and priorities admins have set up:
+// ticket_category and ticket_priority accept active codes only.
+func RegisterValidators(categories service.CategoryService, priorities service.PriorityService) error {
+	v, ok := binding.Validator.Engine().(*validator.Validate)
+	if !ok {
+		return fmt.Errorf("unexpected validator engine %T", binding.Validator.Engine())
+	}
+	if err := v.RegisterValidation("ticket_category", func(fl validator.FieldLevel) bool {
+		return categories.IsActive(domain.TicketCategory(fl.Field().String()))
+	}); err != nil {
+		return err
+	}
+	return v.RegisterValidation("ticket_priority", func(fl validator.FieldLevel) bool {
+		return priorities.IsActive(domain.TicketPriority(fl.Field().String()))
+	})
+}
@@ -0,0 +1,390 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/maintenance-system/api/internal/domain"
+	"github.com/maintenance-system/api/internal/repository"
+	"github.com/maintenance-system/api/internal/service"
+)
+
+type VendorHandler struct {
+	vendorService service.VendorService
+	uploadDir     string
+	maxUpload     int64
+}
+
+// NewVendorHandler serves vendor management and the vendor portal. Portal
+// uploads are capped at maxUpload bytes.
+func NewVendorHandler(vendorService service.VendorService, maxUpload int64) *VendorHandler {
+	return &VendorHandler{vendorService: vendorService, uploadDir: "./uploads", maxUpload: maxUpload}
+}
+
+// portalUploadTypes are the content types a vendor may upload, sniffed
+// from the file itself rather than taken from the client.
+var portalUploadTypes = []string{"image/", "application/pdf"}
+
+type VendorContactRequest struct {
+	Name    string `json:"name" binding:"required"`
+	Title   string `json:"title"`
+	Phone   string `json:"phone"`
+	Email   string `json:"email" binding:"omitempty,email"`
+	Primary bool   `json:"primary"`
+}
+
+type CreateVendorRequest struct {
+	Name       string                 `json:"name" binding:"required"`
+	Email      string                 `json:"email" binding:"omitempty,email"`
+	Phone      string                 `json:"phone"`
+	Address    string                 `json:"address"`
+	Categories []string               `json:"categories" binding:"dive,ticket_category"`
+	Notes      string                 `json:"notes"`
+	Contacts   []VendorContactRequest `json:"contacts" binding:"dive"`
+}
+
+type UpdateVendorRequest struct {
+	Name       string                  `json:"name"`
+	Email      *string                 `json:"email" binding:"omitempty,email"`
+	Phone      *string                 `json:"phone"`
+	Address    *string                 `json:"address"`
+	Categories *[]string               `json:"categories" binding:"omitempty,dive,ticket_category"`
+	Notes      *string                 `json:"notes"`
+	Active     *bool                   `json:"active"`
+	Contacts   *[]VendorContactRequest `json:"contacts" binding:"omitempty,dive"`
+}
+
+type DispatchVendorRequest struct {
+	VendorID string `json:"vendorId" binding:"required,uuid"`
+}
+
+type VendorCommentRequest struct {
+	Content string `json:"content" binding:"required"`
+}
+
+func (h *VendorHandler) GetAll(c *gin.Context) {
+	filter := repository.VendorFilter{
+		Search:   c.Query("search"),
+		Category: c.Query("category"),
+	}
+	if active := queryBool(c, "active"); active != nil && *active {
+		filter.ActiveOnly = true
+	}
+
+	vendors, err := h.vendorService.GetAll(filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch vendors"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": vendors})
+}
+
+func (h *VendorHandler) GetByID(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid vendor ID"})
+		return
+	}
+
+	vendor, err := h.vendorService.GetByID(id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Vendor not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": vendor})
+}
+
+func (h *VendorHandler) Create(c *gin.Context) {
+	var req CreateVendorRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	vendor := &domain.Vendor{
+		Name:       req.Name,
+		Email:      req.Email,
+		Phone:      req.Phone,
+		Address:    req.Address,
+		Categories: ticketCategories(req.Categories),
+		Notes:      req.Notes,
+		Active:     true,
+		Contacts:   vendorContacts(req.Contacts),
+	}
+
+	if err := h.vendorService.Create(vendor); err != nil {
+		c.JSON(vendorErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"success": true, "data": vendor})
+}
+
+func (h *VendorHandler) Update(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid vendor ID"})
+		return
+	}
+
+	var req UpdateVendorRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	updates := make(map[string]interface{})
+	if req.Name != "" {
+		updates["name"] = req.Name
+	}
+	if req.Email != nil {
+		updates["email"] = *req.Email
+	}
+	if req.Phone != nil {
+		updates["phone"] = *req.Phone
+	}
+	if req.Address != nil {
+		updates["address"] = *req.Address
+	}
+	if req.Categories != nil {
+		updates["categories"] = ticketCategories(*req.Categories)
+	}
+	if req.Notes != nil {
+		updates["notes"] = *req.Notes
+	}
+	if req.Active != nil {
+		updates["active"] = *req.Active
+	}
+	if req.Contacts != nil {
+		updates["contacts"] = vendorContacts(*req.Contacts)
+	}
+
+	vendor, err := h.vendorService.Update(id, updates)
+	if err != nil {
+		c.JSON(vendorErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": vendor})
+}
+
+func (h *VendorHandler) Delete(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid vendor ID"})
+		return
+	}
+
+	if err := h.vendorService.Delete(id); err != nil {
+		c.JSON(vendorErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "message": "Vendor deleted"})
+}
+
+// Stats reports per-vendor response time, resolution time and cost for
+// tickets dispatched between ?from= and ?to= (RFC 3339).
+func (h *VendorHandler) Stats(c *gin.Context) {
+	filter := repository.VendorStatsFilter{
+		VendorID: queryUUID(c, "vendorId"),
+	}
+	if v := c.Query("from"); v != "" {
+		from, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid from time"})
+			return
+		}
+		filter.From = &from
+	}
+	if v := c.Query("to"); v != "" {
+		to, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid to time"})
+			return
+		}
+		filter.To = &to
+	}
+
+	stats, err := h.vendorService.Stats(filter)
+	if err != nil {
+		c.JSON(vendorErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": stats})
+}
+
+// Dispatch assigns a ticket to a vendor and returns the link sent to them.
+func (h *VendorHandler) Dispatch(c *gin.Context) {
+	ticketID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ticket ID"})
+		return
+	}
+
+	var req DispatchVendorRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID := c.MustGet("userID").(uuid.UUID)
+
+	dispatch, err := h.vendorService.Dispatch(ticketID, uuid.MustParse(req.VendorID), userID)
+	if err != nil {
+		c.JSON(vendorErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": dispatch})
+}
+
+func (h *VendorHandler) RevokeLinks(c *gin.Context) {
+	ticketID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ticket ID"})
+		return
+	}
+
+	if err := h.vendorService.RevokeLinks(ticketID); err != nil {
+		c.JSON(vendorErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "message": "Vendor links revoked"})
+}
+
+// PortalTicket shows a vendor the ticket behind their link.
+func (h *VendorHandler) PortalTicket(c *gin.Context) {
+	view, err := h.vendorService.PortalTicket(c.Param("token"))
+	if err != nil {
+		c.JSON(vendorErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": view})
+}
+
+func (h *VendorHandler) PortalComment(c *gin.Context) {
+	var req VendorCommentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	comment, err := h.vendorService.PortalComment(c.Param("token"), req.Content)
+	if err != nil {
+		c.JSON(vendorErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"success": true, "data": comment})
+}
+
+// PortalAttachment stores a photo or PDF from the vendor. The link is
+// checked before the upload is read, so nothing reaches the disk for a bad
+// token.
+func (h *VendorHandler) PortalAttachment(c *gin.Context) {
+	token := c.Param("token")
+	if err := h.vendorService.CheckLink(token); err != nil {
+		c.JSON(vendorErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	if !h.checkPortalUpload(c) {
+		return
+	}
+
+	attachment, filePath, ok := saveUpload(c, h.uploadDir)
+	if !ok {
+		return
+	}
+
+	if err := h.vendorService.PortalAttachment(token, attachment); err != nil {
+		// Clean up file if the link is bad or the insert fails
+		os.Remove(filePath)
+		c.JSON(vendorErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"success": true, "data": attachment})
+}
+
+// checkPortalUpload limits the request body to the upload cap and checks
+// the file's size and sniffed type. On failure it has already written the
+// error response.
+func (h *VendorHandler) checkPortalUpload(c *gin.Context) bool {
+	// Leave room for the multipart headers around the file.
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, h.maxUpload+64*1024)
+	file, header, err := c.Request.FormFile("file")
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": fmt.Sprintf("File is larger than %d bytes", h.maxUpload)})
+			return false
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": "No file uploaded"})
+		return false
+	}
+	defer file.Close()
+
+	if header.Size > h.maxUpload {
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": fmt.Sprintf("File is larger than %d bytes", h.maxUpload)})
+		return false
+	}
+	head := make([]byte, 512)
+	n, _ := file.Read(head)
+	contentType := http.DetectContentType(head[:n])
+	for _, allowed := range portalUploadTypes {
+		if strings.HasPrefix(contentType, allowed) {
+			return true
+		}
+	}
+	c.JSON(http.StatusUnsupportedMediaType, gin.H{"error": "Only images and PDF files can be uploaded"})
+	return false
+}
+
+func ticketCategories(categories []string) domain.TicketCategories {
+	result := make(domain.TicketCategories, 0, len(categories))
+	for _, category := range categories {
+		result = append(result, domain.TicketCategory(category))
+	}
+	return result
+}
+
+func vendorContacts(reqs []VendorContactRequest) []domain.VendorContact {
+	contacts := make([]domain.VendorContact, 0, len(reqs))
+	for _, req := range reqs {
+		contacts = append(contacts, domain.VendorContact{
+			Name:    req.Name,
+			Title:   req.Title,
+			Phone:   req.Phone,
+			Email:   req.Email,
+			Primary: req.Primary,
+		})
+	}
+	return contacts
+}
+
+func vendorErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrVendorNotFound), errors.Is(err, service.ErrTicketNotFound),
+		errors.Is(err, service.ErrVendorLinkInvalid):
+		return http.StatusNotFound
+	case errors.Is(err, service.ErrInvalidVendor):
+		return http.StatusUnprocessableEntity
+	case errors.Is(err, service.ErrVendorExists), errors.Is(err, service.ErrVendorInactive),
+		errors.Is(err, service.ErrTicketClosed), errors.Is(err, service.ErrInvalidTransition):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,62 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RateLimit allows each client IP at most limit requests per window. Counts
+// are kept in memory, so with several API instances the effective limit is
+// per instance. The client IP comes from X-Forwarded-For only when the
+// request arrives through one of the router's trusted proxies.
+func RateLimit(limit int, window time.Duration) gin.HandlerFunc {
+	type counter struct {
+		count int
+		reset time.Time
+	}
+	var (
+		mu       sync.Mutex
+		counters = make(map[string]*counter)
+		sweepAt  time.Time
+	)
+
+	return func(c *gin.Context) {
+		if limit <= 0 {
+			c.Next()
+			return
+		}
+
+		now := time.Now()
+		ip := c.ClientIP()
+
+		mu.Lock()
+		if now.After(sweepAt) {
+			for key, ctr := range counters {
+				if now.After(ctr.reset) {
+					delete(counters, key)
+				}
+			}
+			sweepAt = now.Add(window)
+		}
+		ctr, ok := counters[ip]
+		if !ok || now.After(ctr.reset) {
+			ctr = &counter{reset: now.Add(window)}
+			counters[ip] = ctr
+		}
+		ctr.count++
+		exceeded := ctr.count > limit
+		retryAfter := ctr.reset.Sub(now)
+		mu.Unlock()
+
+		if exceeded {
+			c.Header("Retry-After", strconv.Itoa(int(retryAfter.Seconds())+1))
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{"error": "Too many requests, please try again later"})
+			return
+		}
+		c.Next()
+	}
+}
@@ -34,6 +34,11 @@ func RequireAdmin() gin.HandlerFunc {
 	return RequireRole(domain.RoleAdmin)
 }
 
+// RequireApprover allows approvers and admins
+func RequireApprover() gin.HandlerFunc {
+	return RequireRole(domain.RoleApprover, domain.RoleAdmin)
+}
+
 // RequireTechnician allows technicians and admins
 func RequireTechnician() gin.HandlerFunc {
 	return RequireRole(domain.RoleTechnician, domain.RoleAdmin)
@@ -0,0 +1,212 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/maintenance-system/api/internal/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type approvalRepository struct {
+	db *gorm.DB
+}
+
+func NewApprovalRepository(db *gorm.DB) ApprovalRepository {
+	return &approvalRepository{db: db}
+}
+
+func orderSteps(db *gorm.DB) *gorm.DB {
+	return db.Order("stage, id")
+}
+
+func (r *approvalRepository) CreateWorkflow(workflow *domain.ApprovalWorkflow) error {
+	return r.db.Omit("Steps.Approver").Create(workflow).Error
+}
+
+func (r *approvalRepository) FindWorkflowByID(id uuid.UUID) (*domain.ApprovalWorkflow, error) {
+	var workflow domain.ApprovalWorkflow
+	if err := r.db.
+		Preload("Steps", orderSteps).
+		Preload("Steps.Approver").
+		First(&workflow, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &workflow, nil
+}
+
+func (r *approvalRepository) FindWorkflowByName(name string) (*domain.ApprovalWorkflow, error) {
+	var workflow domain.ApprovalWorkflow
+	if err := r.db.First(&workflow, "name = ?", name).Error; err != nil {
+		return nil, err
+	}
+	return &workflow, nil
+}
+
+func (r *approvalRepository) FindWorkflows(activeOnly bool) ([]domain.ApprovalWorkflow, error) {
+	var workflows []domain.ApprovalWorkflow
+	query := r.db.Preload("Steps", orderSteps).Preload("Steps.Approver").Order("name")
+	if activeOnly {
+		query = query.Where("active = ?", true)
+	}
+	err := query.Find(&workflows).Error
+	return workflows, err
+}
+
+func (r *approvalRepository) UpdateWorkflow(workflow *domain.ApprovalWorkflow, replaceSteps bool) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Save(workflow).Error; err != nil {
+			return err
+		}
+		if !replaceSteps {
+			return nil
+		}
+		if err := tx.Where("workflow_id = ?", workflow.ID).Delete(&domain.ApprovalStep{}).Error; err != nil {
+			return err
+		}
+		if len(workflow.Steps) == 0 {
+			return nil
+		}
+		for i := range workflow.Steps {
+			workflow.Steps[i].WorkflowID = workflow.ID
+		}
+		return tx.Omit("Approver").Create(&workflow.Steps).Error
+	})
+}
+
+func (r *approvalRepository) DeleteWorkflow(id uuid.UUID) error {
+	return r.db.Delete(&domain.ApprovalWorkflow{}, "id = ?", id).Error
+}
+
+func (r *approvalRepository) CountRequests(workflowID uuid.UUID) (int64, error) {
+	var count int64
+	err := r.db.Model(&domain.ApprovalRequest{}).Where("workflow_id = ?", workflowID).Count(&count).Error
+	return count, err
+}
+
+func (r *approvalRepository) CreateRequest(request *domain.ApprovalRequest) error {
+	return r.db.Omit("Workflow", "Requester", "Tasks.Approver", "Tasks.DecidedBy").Create(request).Error
+}
+
+func (r *approvalRepository) FindRequestByID(id uuid.UUID) (*domain.ApprovalRequest, error) {
+	var request domain.ApprovalRequest
+	if err := r.preloadRequest(r.db).First(&request, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &request, nil
+}
+
+func (r *approvalRepository) LockRequest(id uuid.UUID) (*domain.ApprovalRequest, error) {
+	var request domain.ApprovalRequest
+	if err := r.db.
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Preload("Tasks", orderSteps).
+		First(&request, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &request, nil
+}
+
+func (r *approvalRepository) preloadRequest(query *gorm.DB) *gorm.DB {
+	return query.
+		Preload("Workflow").
+		Preload("Requester").
+		Preload("Tasks", orderSteps).
+		Preload("Tasks.Approver").
+		Preload("Tasks.DecidedBy")
+}
+
+func (r *approvalRepository) FindRequests(filter ApprovalFilter) ([]domain.ApprovalRequest, int64, error) {
+	var requests []domain.ApprovalRequest
+	var total int64
+
+	query := r.db.Model(&domain.ApprovalRequest{})
+
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.WorkflowID != nil {
+		query = query.Where("workflow_id = ?", filter.WorkflowID)
+	}
+	if filter.TicketID != nil {
+		query = query.Where("ticket_id = ?", filter.TicketID)
+	}
+	if filter.RequesterID != nil {
+		query = query.Where("requester_id = ?", filter.RequesterID)
+	}
+
+	query.Count(&total)
+
+	if filter.Limit <= 0 {
+		filter.Limit = 20
+	}
+	if filter.Page <= 0 {
+		filter.Page = 1
+	}
+	offset := (filter.Page - 1) * filter.Limit
+
+	if err := r.preloadRequest(query).
+		Offset(offset).
+		Limit(filter.Limit).
+		Order("created_at DESC").
+		Find(&requests).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return requests, total, nil
+}
+
+// FindPendingFor returns the pending requests with a task of their current
+// stage that the user can decide, oldest first. The user's own requests
+// are left out.
+func (r *approvalRepository) FindPendingFor(user *domain.User) ([]domain.ApprovalRequest, error) {
+	var requests []domain.ApprovalRequest
+	approver := r.db.
+		Where("approver_id = ?", user.ID).
+		Or("approver_id IS NULL AND approver_role = ?", user.Role)
+	if user.DepartmentHead && user.Department != "" {
+		approver = approver.Or("approver_id IS NULL AND approver_department = ?", user.Department)
+	}
+	tasks := r.db.Model(&domain.ApprovalTask{}).
+		Select("request_id").
+		Where("status = ?", domain.TaskPending).
+		Where(approver)
+
+	err := r.preloadRequest(r.db).
+		Where("status = ? AND requester_id <> ? AND id IN (?)", domain.ApprovalPending, user.ID, tasks).
+		Order("created_at").
+		Find(&requests).Error
+	return requests, err
+}
+
+func (r *approvalRepository) FindPendingByTicket(ticketID uuid.UUID) ([]domain.ApprovalRequest, error) {
+	var requests []domain.ApprovalRequest
+	err := r.db.
+		Where("ticket_id = ? AND status = ?", ticketID, domain.ApprovalPending).
+		Order("created_at").
+		Find(&requests).Error
+	return requests, err
+}
+
+func (r *approvalRepository) FindExpired(now time.Time) ([]domain.ApprovalRequest, error) {
+	var requests []domain.ApprovalRequest
+	err := r.db.
+		Where("status = ? AND expires_at <= ?", domain.ApprovalPending, now).
+		Order("expires_at").
+		Find(&requests).Error
+	return requests, err
+}
+
+func (r *approvalRepository) UpdateRequest(request *domain.ApprovalRequest) error {
+	return r.db.Omit(clause.Associations).Save(request).Error
+}
+
+func (r *approvalRepository) UpdateTasks(tasks []domain.ApprovalTask) error {
+	for i := range tasks {
+		if err := r.db.Omit(clause.Associations).Save(&tasks[i]).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
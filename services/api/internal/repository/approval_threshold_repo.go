@@ -0,0 +1,57 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/maintenance-system/api/internal/domain"
+	"gorm.io/gorm"
+)
+
+type approvalThresholdRepository struct {
+	db *gorm.DB
+}
+
+func NewApprovalThresholdRepository(db *gorm.DB) ApprovalThresholdRepository {
+	return &approvalThresholdRepository{db: db}
+}
+
+func (r *approvalThresholdRepository) Create(threshold *domain.ApprovalThreshold) error {
+	return r.db.Create(threshold).Error
+}
+
+func (r *approvalThresholdRepository) FindByID(id uuid.UUID) (*domain.ApprovalThreshold, error) {
+	var threshold domain.ApprovalThreshold
+	if err := r.db.First(&threshold, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &threshold, nil
+}
+
+func (r *approvalThresholdRepository) FindAll() ([]domain.ApprovalThreshold, error) {
+	var thresholds []domain.ApprovalThreshold
+	err := r.db.Order("department, category").Find(&thresholds).Error
+	return thresholds, err
+}
+
+func (r *approvalThresholdRepository) FindMatch(department string, category domain.TicketCategory) (*domain.ApprovalThreshold, error) {
+	var threshold domain.ApprovalThreshold
+	if err := r.db.
+		Where("(department = ? OR department = '') AND (category = ? OR category = '')", department, category).
+		Order("department DESC, category DESC").
+		First(&threshold).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &threshold, nil
+}
+
+func (r *approvalThresholdRepository) Update(threshold *domain.ApprovalThreshold) error {
+	return r.db.Save(threshold).Error
+}
+
+func (r *approvalThresholdRepository) Delete(id uuid.UUID) error {
+	return r.db.Delete(&domain.ApprovalThreshold{}, "id = ?", id).Error
+}
@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"github.com/google/uuid"
+	"github.com/maintenance-system/api/internal/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type assetRepository struct {
+	db *gorm.DB
+}
+
+func NewAssetRepository(db *gorm.DB) AssetRepository {
+	return &assetRepository{db: db}
+}
+
+func (r *assetRepository) Create(asset *domain.Asset) error {
+	return r.db.Create(asset).Error
+}
+
+func (r *assetRepository) FindByID(id uuid.UUID) (*domain.Asset, error) {
+	var asset domain.Asset
+	if err := r.db.Preload("LocationRef").First(&asset, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &asset, nil
+}
+
+func (r *assetRepository) FindByCode(code string) (*domain.Asset, error) {
+	var asset domain.Asset
+	if err := r.db.First(&asset, "code = ?", code).Error; err != nil {
+		return nil, err
+	}
+	return &asset, nil
+}
+
+// FindByIDs returns the assets with the given IDs, ordered by code.
+func (r *assetRepository) FindByIDs(ids []uuid.UUID) ([]domain.Asset, error) {
+	var assets []domain.Asset
+	err := r.db.Where("id IN ?", ids).Order("code").Find(&assets).Error
+	return assets, err
+}
+
+func (r *assetRepository) FindAll(filter AssetFilter) ([]domain.Asset, int64, error) {
+	var assets []domain.Asset
+	var total int64
+
+	query := r.db.Model(&domain.Asset{})
+
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.Category != "" {
+		query = query.Where("category = ?", filter.Category)
+	}
+	if filter.LocationID != nil {
+		query = query.Where("location_id IN ("+subtreeSQL+")", filter.LocationID)
+	}
+	if filter.Search != "" {
+		search := "%" + filter.Search + "%"
+		query = query.Where("code ILIKE ? OR name ILIKE ? OR serial_number ILIKE ?", search, search, search)
+	}
+
+	query.Count(&total)
+
+	if filter.Limit <= 0 {
+		filter.Limit = 10
+	}
+	if filter.Page <= 0 {
+		filter.Page = 1
+	}
+	offset := (filter.Page - 1) * filter.Limit
+
+	if err := query.Offset(offset).Limit(filter.Limit).Order("code").Find(&assets).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return assets, total, nil
+}
+
+func (r *assetRepository) Update(asset *domain.Asset) error {
+	return r.db.Omit(clause.Associations).Save(asset).Error
+}
+
+func (r *assetRepository) Delete(id uuid.UUID) error {
+	return r.db.Delete(&domain.Asset{}, "id = ?", id).Error
+}
+
+// FindTickets returns every ticket raised against the asset, newest first.
+func (r *assetRepository) FindTickets(assetID uuid.UUID) ([]domain.Ticket, error) {
+	var tickets []domain.Ticket
+	err := r.db.
+		Preload("AssignedTo").
+		Where("asset_id = ?", assetID).
+		Order("created_at DESC").
+		Find(&tickets).Error
+	return tickets, err
+}
@@ -18,6 +18,14 @@ func (r *attachmentRepository) Create(attachment *domain.Attachment) error {
 	return r.db.Create(attachment).Error
 }
 
+func (r *attachmentRepository) FindByID(id uuid.UUID) (*domain.Attachment, error) {
+	var attachment domain.Attachment
+	if err := r.db.First(&attachment, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &attachment, nil
+}
+
 func (r *attachmentRepository) FindByTicketID(ticketID uuid.UUID) ([]domain.Attachment, error) {
 	var attachments []domain.Attachment
 	err := r.db.Where("ticket_id = ?", ticketID).Order("created_at DESC").Find(&attachments).Error
@@ -27,3 +35,9 @@ func (r *attachmentRepository) FindByTicketID(ticketID uuid.UUID) ([]domain.Atta
 func (r *attachmentRepository) Delete(id uuid.UUID) error {
 	return r.db.Delete(&domain.Attachment{}, id).Error
 }
+
+func (r *attachmentRepository) Reassign(fromTicketID, toTicketID uuid.UUID) error {
+	return r.db.Model(&domain.Attachment{}).
+		Where("ticket_id = ?", fromTicketID).
+		Update("ticket_id", toTicketID).Error
+}
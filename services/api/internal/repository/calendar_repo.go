@@ -0,0 +1,104 @@
+package repository
+
+import (
+	"github.com/google/uuid"
+	"github.com/maintenance-system/api/internal/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type calendarRepository struct {
+	db *gorm.DB
+}
+
+func NewCalendarRepository(db *gorm.DB) CalendarRepository {
+	return &calendarRepository{db: db}
+}
+
+func (r *calendarRepository) Create(calendar *domain.WorkingCalendar) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(calendar).Error; err != nil {
+			return err
+		}
+		return clearOtherDefaults(tx, calendar)
+	})
+}
+
+func (r *calendarRepository) FindByID(id uuid.UUID) (*domain.WorkingCalendar, error) {
+	var calendar domain.WorkingCalendar
+	if err := r.preload().First(&calendar, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &calendar, nil
+}
+
+func (r *calendarRepository) FindDefault() (*domain.WorkingCalendar, error) {
+	var calendar domain.WorkingCalendar
+	if err := r.preload().First(&calendar, "is_default = ?", true).Error; err != nil {
+		return nil, err
+	}
+	return &calendar, nil
+}
+
+func (r *calendarRepository) FindAll() ([]domain.WorkingCalendar, error) {
+	var calendars []domain.WorkingCalendar
+	err := r.preload().Order("name").Find(&calendars).Error
+	return calendars, err
+}
+
+func (r *calendarRepository) preload() *gorm.DB {
+	return r.db.
+		Preload("Hours", func(db *gorm.DB) *gorm.DB {
+			return db.Order("weekday, start_time")
+		}).
+		Preload("Holidays", func(db *gorm.DB) *gorm.DB {
+			return db.Order("date")
+		})
+}
+
+func (r *calendarRepository) Update(calendar *domain.WorkingCalendar) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Save(calendar).Error; err != nil {
+			return err
+		}
+		return clearOtherDefaults(tx, calendar)
+	})
+}
+
+// clearOtherDefaults keeps at most one calendar marked as the default.
+func clearOtherDefaults(tx *gorm.DB, calendar *domain.WorkingCalendar) error {
+	if !calendar.IsDefault {
+		return nil
+	}
+	return tx.Model(&domain.WorkingCalendar{}).
+		Where("id <> ?", calendar.ID).
+		Update("is_default", false).Error
+}
+
+func (r *calendarRepository) Delete(id uuid.UUID) error {
+	return r.db.Delete(&domain.WorkingCalendar{}, "id = ?", id).Error
+}
+
+func (r *calendarRepository) ReplaceHours(calendarID uuid.UUID, hours []domain.WorkingHours) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("calendar_id = ?", calendarID).Delete(&domain.WorkingHours{}).Error; err != nil {
+			return err
+		}
+		if len(hours) == 0 {
+			return nil
+		}
+		return tx.Create(&hours).Error
+	})
+}
+
+// AddHolidays inserts holidays, skipping dates the calendar already has.
+func (r *calendarRepository) AddHolidays(holidays []domain.Holiday) error {
+	if len(holidays) == 0 {
+		return nil
+	}
+	return r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&holidays).Error
+}
+
+func (r *calendarRepository) DeleteHoliday(calendarID, holidayID uuid.UUID) error {
+	return r.db.Where("calendar_id = ?", calendarID).Delete(&domain.Holiday{}, "id = ?", holidayID).Error
+}
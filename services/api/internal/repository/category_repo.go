@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"database/sql"
+
+	"github.com/google/uuid"
+	"github.com/maintenance-system/api/internal/domain"
+	"gorm.io/gorm"
+)
+
+// categoryUsageSQL counts the rows that store a category code (@code) and
+// the subcategories of the category (@id).
+const categoryUsageSQL = `SELECT
+	(SELECT COUNT(*) FROM tickets WHERE category = @code) +
+	(SELECT COUNT(*) FROM sla_policies WHERE category = @code) +
+	(SELECT COUNT(*) FROM escalation_rules WHERE category = @code) +
+	(SELECT COUNT(*) FROM approval_thresholds WHERE category = @code) +
+	(SELECT COUNT(*) FROM maintenance_schedules WHERE ticket_category = @code) +
+	(SELECT COUNT(*) FROM assets WHERE category = @code) +
+	(SELECT COUNT(*) FROM checklist_templates WHERE category = @code OR asset_category = @code) +
+	(SELECT COUNT(*) FROM vendors WHERE categories @> jsonb_build_array(@code::text)) +
+	(SELECT COUNT(*) FROM custom_fields WHERE categories @> jsonb_build_array(@code::text)) +
+	(SELECT COUNT(*) FROM ticket_categories WHERE parent_id = @id)`
+
+type categoryRepository struct {
+	db *gorm.DB
+}
+
+func NewCategoryRepository(db *gorm.DB) CategoryRepository {
+	return &categoryRepository{db: db}
+}
+
+func (r *categoryRepository) Create(category *domain.Category) error {
+	return r.db.Create(category).Error
+}
+
+func (r *categoryRepository) FindByID(id uuid.UUID) (*domain.Category, error) {
+	var category domain.Category
+	if err := r.db.Preload("DefaultAssignee").First(&category, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &category, nil
+}
+
+func (r *categoryRepository) FindByCode(code domain.TicketCategory) (*domain.Category, error) {
+	var category domain.Category
+	if err := r.db.First(&category, "code = ?", code).Error; err != nil {
+		return nil, err
+	}
+	return &category, nil
+}
+
+func (r *categoryRepository) FindAll() ([]domain.Category, error) {
+	var categories []domain.Category
+	err := r.db.Preload("DefaultAssignee").Order("sort_order, name_en").Find(&categories).Error
+	return categories, err
+}
+
+func (r *categoryRepository) Update(category *domain.Category) error {
+	return r.db.Omit("DefaultAssignee").Save(category).Error
+}
+
+func (r *categoryRepository) Delete(id uuid.UUID) error {
+	return r.db.Delete(&domain.Category{}, "id = ?", id).Error
+}
+
+func (r *categoryRepository) CountUsage(category *domain.Category) (int64, error) {
+	var count int64
+	err := r.db.Raw(categoryUsageSQL,
+		sql.Named("code", string(category.Code)), sql.Named("id", category.ID)).Scan(&count).Error
+	return count, err
+}
@@ -0,0 +1,128 @@
+package repository
+
+import (
+	"github.com/google/uuid"
+	"github.com/maintenance-system/api/internal/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type checklistRepository struct {
+	db *gorm.DB
+}
+
+func NewChecklistRepository(db *gorm.DB) ChecklistRepository {
+	return &checklistRepository{db: db}
+}
+
+func orderItems(db *gorm.DB) *gorm.DB {
+	return db.Order("position")
+}
+
+func (r *checklistRepository) CreateTemplate(template *domain.ChecklistTemplate) error {
+	return r.db.Create(template).Error
+}
+
+func (r *checklistRepository) FindTemplateByID(id uuid.UUID) (*domain.ChecklistTemplate, error) {
+	var template domain.ChecklistTemplate
+	if err := r.db.Preload("Items", orderItems).First(&template, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &template, nil
+}
+
+func (r *checklistRepository) FindTemplates(activeOnly bool) ([]domain.ChecklistTemplate, error) {
+	var templates []domain.ChecklistTemplate
+	query := r.db.Preload("Items", orderItems).Order("name")
+	if activeOnly {
+		query = query.Where("active = ?", true)
+	}
+	err := query.Find(&templates).Error
+	return templates, err
+}
+
+func (r *checklistRepository) FindMatchingTemplates(category, assetCategory domain.TicketCategory) ([]domain.ChecklistTemplate, error) {
+	var templates []domain.ChecklistTemplate
+	err := r.db.
+		Preload("Items", orderItems).
+		Where("active = ?", true).
+		Where("category = '' OR category = ?", category).
+		Where("asset_category = '' OR asset_category = ?", assetCategory).
+		Order("name").
+		Find(&templates).Error
+	return templates, err
+}
+
+func (r *checklistRepository) UpdateTemplate(template *domain.ChecklistTemplate, replaceItems bool) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Save(template).Error; err != nil {
+			return err
+		}
+		if !replaceItems {
+			return nil
+		}
+		if err := tx.Where("template_id = ?", template.ID).Delete(&domain.ChecklistTemplateItem{}).Error; err != nil {
+			return err
+		}
+		if len(template.Items) == 0 {
+			return nil
+		}
+		for i := range template.Items {
+			template.Items[i].TemplateID = template.ID
+		}
+		return tx.Create(&template.Items).Error
+	})
+}
+
+func (r *checklistRepository) DeleteTemplate(id uuid.UUID) error {
+	return r.db.Delete(&domain.ChecklistTemplate{}, "id = ?", id).Error
+}
+
+func (r *checklistRepository) CreateChecklist(checklist *domain.TicketChecklist) error {
+	return r.db.Create(checklist).Error
+}
+
+func (r *checklistRepository) FindChecklistByID(id uuid.UUID) (*domain.TicketChecklist, error) {
+	var checklist domain.TicketChecklist
+	if err := r.db.Preload("Items", orderItems).First(&checklist, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &checklist, nil
+}
+
+func (r *checklistRepository) FindByTicketID(ticketID uuid.UUID) ([]domain.TicketChecklist, error) {
+	var checklists []domain.TicketChecklist
+	err := r.db.
+		Preload("Items", orderItems).
+		Preload("Items.Attachment").
+		Preload("Items.CompletedBy").
+		Where("ticket_id = ?", ticketID).
+		Order("created_at, name").
+		Find(&checklists).Error
+	return checklists, err
+}
+
+func (r *checklistRepository) DeleteChecklist(id uuid.UUID) error {
+	return r.db.Delete(&domain.TicketChecklist{}, "id = ?", id).Error
+}
+
+func (r *checklistRepository) FindItemByID(id uuid.UUID) (*domain.TicketChecklistItem, error) {
+	var item domain.TicketChecklistItem
+	if err := r.db.Preload("Checklist").First(&item, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+func (r *checklistRepository) UpdateItem(item *domain.TicketChecklistItem) error {
+	return r.db.Omit(clause.Associations).Save(item).Error
+}
+
+func (r *checklistRepository) CountIncomplete(ticketID uuid.UUID) (int64, error) {
+	var count int64
+	err := r.db.Model(&domain.TicketChecklistItem{}).
+		Joins("JOIN ticket_checklists ON ticket_checklists.id = ticket_checklist_items.checklist_id").
+		Where("ticket_checklists.ticket_id = ? AND ticket_checklist_items.required AND NOT ticket_checklist_items.done", ticketID).
+		Count(&count).Error
+	return count, err
+}
@@ -0,0 +1,107 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/maintenance-system/api/internal/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type contractRepository struct {
+	db *gorm.DB
+}
+
+func NewContractRepository(db *gorm.DB) ContractRepository {
+	return &contractRepository{db: db}
+}
+
+func (r *contractRepository) Create(contract *domain.ServiceContract) error {
+	return r.db.Omit(clause.Associations).Create(contract).Error
+}
+
+func (r *contractRepository) FindByID(id uuid.UUID) (*domain.ServiceContract, error) {
+	var contract domain.ServiceContract
+	if err := r.db.Preload("Asset").First(&contract, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &contract, nil
+}
+
+func (r *contractRepository) FindAll(filter ContractFilter) ([]domain.ServiceContract, error) {
+	var contracts []domain.ServiceContract
+	query := r.db.Preload("Asset")
+
+	if filter.AssetID != nil {
+		query = query.Where("asset_id = ?", filter.AssetID)
+	}
+	if filter.Type != "" {
+		query = query.Where("type = ?", filter.Type)
+	}
+	if filter.ActiveAt != nil {
+		query = query.Where("starts_at <= ? AND ends_at > ?", filter.ActiveAt, filter.ActiveAt)
+	}
+
+	err := query.Order("ends_at").Find(&contracts).Error
+	return contracts, err
+}
+
+func (r *contractRepository) FindCovering(assetID uuid.UUID, at time.Time) (*domain.ServiceContract, error) {
+	var contract domain.ServiceContract
+	if err := r.db.
+		Where("asset_id = ? AND starts_at <= ? AND ends_at > ?", assetID, at, at).
+		Order("ends_at DESC").
+		First(&contract).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &contract, nil
+}
+
+func (r *contractRepository) FindExpiring(from, to time.Time) ([]domain.ServiceContract, error) {
+	var contracts []domain.ServiceContract
+	err := r.db.
+		Preload("Asset").
+		Where("ends_at > ? AND ends_at <= ?", from, to).
+		Order("ends_at").
+		Find(&contracts).Error
+	return contracts, err
+}
+
+func (r *contractRepository) FindDueReminders(from, to time.Time) ([]domain.ServiceContract, error) {
+	var contracts []domain.ServiceContract
+	err := r.db.
+		Preload("Asset").
+		Where("ends_at > ? AND ends_at <= ? AND reminder_sent_at IS NULL", from, to).
+		Order("ends_at").
+		Find(&contracts).Error
+	return contracts, err
+}
+
+func (r *contractRepository) ClaimReminder(id uuid.UUID, at time.Time) (bool, error) {
+	result := r.db.Model(&domain.ServiceContract{}).
+		Where("id = ? AND reminder_sent_at IS NULL", id).
+		Update("reminder_sent_at", at)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r *contractRepository) ReleaseReminder(id uuid.UUID, at time.Time) error {
+	return r.db.Model(&domain.ServiceContract{}).
+		Where("id = ? AND reminder_sent_at = ?", id, at).
+		Update("reminder_sent_at", nil).Error
+}
+
+func (r *contractRepository) Update(contract *domain.ServiceContract) error {
+	return r.db.Omit(clause.Associations).Save(contract).Error
+}
+
+func (r *contractRepository) Delete(id uuid.UUID) error {
+	return r.db.Delete(&domain.ServiceContract{}, "id = ?", id).Error
+}
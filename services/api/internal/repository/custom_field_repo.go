@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"github.com/google/uuid"
+	"github.com/maintenance-system/api/internal/domain"
+	"gorm.io/gorm"
+)
+
+type customFieldRepository struct {
+	db *gorm.DB
+}
+
+func NewCustomFieldRepository(db *gorm.DB) CustomFieldRepository {
+	return &customFieldRepository{db: db}
+}
+
+func (r *customFieldRepository) Create(field *domain.CustomField) error {
+	return r.db.Create(field).Error
+}
+
+func (r *customFieldRepository) FindByID(id uuid.UUID) (*domain.CustomField, error) {
+	var field domain.CustomField
+	if err := r.db.First(&field, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &field, nil
+}
+
+func (r *customFieldRepository) FindByKey(key string) (*domain.CustomField, error) {
+	var field domain.CustomField
+	if err := r.db.First(&field, "key = ?", key).Error; err != nil {
+		return nil, err
+	}
+	return &field, nil
+}
+
+func (r *customFieldRepository) FindAll() ([]domain.CustomField, error) {
+	var fields []domain.CustomField
+	err := r.db.Order("sort_order, label").Find(&fields).Error
+	return fields, err
+}
+
+func (r *customFieldRepository) Update(field *domain.CustomField) error {
+	return r.db.Save(field).Error
+}
+
+func (r *customFieldRepository) Delete(field *domain.CustomField) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Model(&domain.Ticket{}).
+			Where("jsonb_exists(custom_fields, ?)", field.Key).
+			UpdateColumn("custom_fields", gorm.Expr("custom_fields - ?", field.Key)).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&domain.CustomField{}, "id = ?", field.ID).Error
+	})
+}
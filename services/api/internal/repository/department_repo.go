@@ -0,0 +1,125 @@
+package repository
+
+import (
+	"github.com/google/uuid"
+	"github.com/maintenance-system/api/internal/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type departmentRepository struct {
+	db *gorm.DB
+}
+
+func NewDepartmentRepository(db *gorm.DB) DepartmentRepository {
+	return &departmentRepository{db: db}
+}
+
+func (r *departmentRepository) Create(department *domain.Department) error {
+	return r.db.Create(department).Error
+}
+
+func (r *departmentRepository) FindByID(id uuid.UUID) (*domain.Department, error) {
+	var department domain.Department
+	if err := r.db.First(&department, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &department, nil
+}
+
+func (r *departmentRepository) FindByName(name string) (*domain.Department, error) {
+	var department domain.Department
+	if err := r.db.First(&department, "name = ?", name).Error; err != nil {
+		return nil, err
+	}
+	return &department, nil
+}
+
+func (r *departmentRepository) FindAll() ([]domain.Department, error) {
+	var departments []domain.Department
+	err := r.db.Order("name").Find(&departments).Error
+	return departments, err
+}
+
+func (r *departmentRepository) Update(department *domain.Department, previousName string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(department).Error; err != nil {
+			return err
+		}
+		if previousName == "" || previousName == department.Name {
+			return nil
+		}
+		if err := tx.Model(&domain.User{}).
+			Where("department_id = ? OR (department_id IS NULL AND department = ?)", department.ID, previousName).
+			Updates(map[string]interface{}{"department": department.Name, "department_id": department.ID}).Error; err != nil {
+			return err
+		}
+		return tx.Model(&domain.ApprovalThreshold{}).
+			Where("department = ?", previousName).
+			Update("department", department.Name).Error
+	})
+}
+
+func (r *departmentRepository) Delete(id uuid.UUID) error {
+	return r.db.Delete(&domain.Department{}, "id = ?", id).Error
+}
+
+func (r *departmentRepository) CountReferences(id uuid.UUID) (int64, error) {
+	var count int64
+	err := r.db.Raw(`SELECT
+		(SELECT COUNT(*) FROM users WHERE department_id = ?) +
+		(SELECT COUNT(*) FROM tickets WHERE department_id = ?)`, id, id).Scan(&count).Error
+	return count, err
+}
+
+// ticketCosts lists every cost booked on a live ticket with when it was
+// incurred: labor when the time entry ended, parts when they were issued or
+// returned. Vendor costs and goods bought for the ticket carry no date of
+// their own; they count when the ticket was resolved, or created while it
+// is still open. Each ticket also counts once, with no cost, when created.
+const ticketCosts = `
+	SELECT t.department_id, e.ended_at AS at, e.cost AS cost, 0 AS tickets
+	FROM time_entries e JOIN tickets t ON t.id = e.ticket_id
+	WHERE t.deleted_at IS NULL AND e.ended_at IS NOT NULL
+	UNION ALL
+	SELECT t.department_id, m.created_at, ROUND(-m.quantity * m.unit_cost, 2), 0
+	FROM stock_movements m JOIN tickets t ON t.id = m.ticket_id
+	WHERE t.deleted_at IS NULL AND m.type IN ?
+	UNION ALL
+	SELECT t.department_id, COALESCE(t.resolved_at, t.created_at),
+		t.vendor_cost + t.parts_cost - COALESCE((
+			SELECT SUM(ROUND(-m.quantity * m.unit_cost, 2))
+			FROM stock_movements m
+			WHERE m.ticket_id = t.id AND m.type IN ?), 0), 0
+	FROM tickets t
+	WHERE t.deleted_at IS NULL
+	UNION ALL
+	SELECT t.department_id, t.created_at, 0, 1
+	FROM tickets t
+	WHERE t.deleted_at IS NULL`
+
+func (r *departmentRepository) Spending(filter SpendingFilter) ([]DepartmentSpending, error) {
+	issued := []domain.StockMovementType{domain.MovementIssue, domain.MovementReturn}
+	query := r.db.Table("("+ticketCosts+") AS costs", issued, issued).
+		Where("department_id IS NOT NULL AND at >= ? AND at < ?", filter.From, filter.To)
+
+	if filter.DepartmentID != nil {
+		query = query.Where("department_id = ?", filter.DepartmentID)
+	}
+
+	var spending []DepartmentSpending
+	err := query.
+		Select(`department_id,
+			to_char(at, 'YYYY-MM') AS month,
+			SUM(tickets) AS tickets,
+			COALESCE(SUM(cost), 0) AS cost`).
+		Group("department_id, month").
+		Order("month").
+		Scan(&spending).Error
+	return spending, err
+}
+
+func (r *departmentRepository) CreateAlert(alert *domain.BudgetAlert) (bool, error) {
+	result := r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(alert)
+	return result.RowsAffected > 0, result.Error
+}
@@ -0,0 +1,143 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/maintenance-system/api/internal/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// EscalationCandidate is a ticket that meets a rule's condition. Since is
+// when the condition started to hold, and identifies its occurrence.
+type EscalationCandidate struct {
+	Ticket domain.Ticket
+	Since  time.Time
+}
+
+type escalationRepository struct {
+	db *gorm.DB
+}
+
+func NewEscalationRepository(db *gorm.DB) EscalationRepository {
+	return &escalationRepository{db: db}
+}
+
+func (r *escalationRepository) CreateRule(rule *domain.EscalationRule) error {
+	return r.db.Create(rule).Error
+}
+
+func (r *escalationRepository) FindRuleByID(id uuid.UUID) (*domain.EscalationRule, error) {
+	var rule domain.EscalationRule
+	if err := r.db.Preload("ReassignTo").First(&rule, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &rule, nil
+}
+
+func (r *escalationRepository) FindRules(activeOnly bool) ([]domain.EscalationRule, error) {
+	var rules []domain.EscalationRule
+	query := r.db.Preload("ReassignTo").Order("created_at")
+	if activeOnly {
+		query = query.Where("active = ?", true)
+	}
+	err := query.Find(&rules).Error
+	return rules, err
+}
+
+func (r *escalationRepository) UpdateRule(rule *domain.EscalationRule) error {
+	return r.db.Omit(clause.Associations).Save(rule).Error
+}
+
+func (r *escalationRepository) DeleteRule(id uuid.UUID) error {
+	return r.db.Delete(&domain.EscalationRule{}, "id = ?", id).Error
+}
+
+// sinceExpr is the SQL for when each condition started to hold.
+var sinceExpr = map[domain.EscalationCondition]string{
+	domain.ConditionUnassigned: "tickets.created_at",
+	domain.ConditionNoActivity: "COALESCE((SELECT MAX(comments.created_at) FROM comments WHERE comments.ticket_id = tickets.id), tickets.first_responded_at, tickets.created_at)",
+	domain.ConditionOverdue:    "tickets.due_date",
+}
+
+func (r *escalationRepository) FindCandidates(rule *domain.EscalationRule, now time.Time) ([]EscalationCandidate, error) {
+	since, ok := sinceExpr[rule.Condition]
+	if !ok {
+		return nil, nil
+	}
+
+	query := r.db.Model(&domain.Ticket{})
+	switch rule.Condition {
+	case domain.ConditionUnassigned:
+		query = query.Where("tickets.status = ? AND tickets.assigned_to_id IS NULL", domain.StatusOpen)
+	case domain.ConditionNoActivity:
+		query = query.Where("tickets.status = ?", domain.StatusInProgress)
+	case domain.ConditionOverdue:
+		query = query.Where("tickets.due_date IS NOT NULL AND tickets.sla_paused_at IS NULL AND tickets.status NOT IN ?",
+			[]domain.TicketStatus{domain.StatusResolved, domain.StatusClosed})
+	}
+	if rule.Priority != "" {
+		query = query.Where("tickets.priority = ?", rule.Priority)
+	}
+	if rule.Category != "" {
+		query = query.Where("tickets.category = ?", rule.Category)
+	}
+
+	cutoff := now.Add(-time.Duration(rule.ThresholdMinutes) * time.Minute)
+	query = query.
+		Where(since+" < ?", cutoff).
+		Where("NOT EXISTS (SELECT 1 FROM ticket_escalations e WHERE e.ticket_id = tickets.id AND e.rule_id = ? AND e.condition_since = "+since+")", rule.ID)
+
+	var rows []struct {
+		ID    uuid.UUID
+		Since time.Time
+	}
+	if err := query.Select("tickets.id, " + since + " AS since").Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]uuid.UUID, 0, len(rows))
+	for _, row := range rows {
+		ids = append(ids, row.ID)
+	}
+	var tickets []domain.Ticket
+	if err := r.db.Where("id IN ?", ids).Find(&tickets).Error; err != nil {
+		return nil, err
+	}
+	byID := make(map[uuid.UUID]domain.Ticket, len(tickets))
+	for _, t := range tickets {
+		byID[t.ID] = t
+	}
+
+	candidates := make([]EscalationCandidate, 0, len(rows))
+	for _, row := range rows {
+		if t, ok := byID[row.ID]; ok {
+			candidates = append(candidates, EscalationCandidate{Ticket: t, Since: row.Since})
+		}
+	}
+	return candidates, nil
+}
+
+func (r *escalationRepository) ConditionSince(condition domain.EscalationCondition, ticketID uuid.UUID) (*time.Time, error) {
+	since, ok := sinceExpr[condition]
+	if !ok {
+		return nil, nil
+	}
+	var at *time.Time
+	err := r.db.Model(&domain.Ticket{}).
+		Select(since).
+		Where("tickets.id = ?", ticketID).
+		Scan(&at).Error
+	return at, err
+}
+
+// Claim records an escalation. It returns false if the same occurrence was
+// already recorded, e.g. by another API instance.
+func (r *escalationRepository) Claim(escalation *domain.TicketEscalation) (bool, error) {
+	result := r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(escalation)
+	return result.RowsAffected > 0, result.Error
+}
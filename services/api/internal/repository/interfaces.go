@@ -1,6 +1,8 @@
 package repository
 
 import (
+	"time"
+
 	"github.com/google/uuid"
 	"github.com/maintenance-system/api/internal/domain"
 )
@@ -10,45 +12,684 @@ type UserRepository interface {
 	FindByID(id uuid.UUID) (*domain.User, error)
 	FindByEmail(email string) (*domain.User, error)
 	FindAll(page, limit int) ([]domain.User, int64, error)
+	FindByRole(role domain.UserRole) ([]domain.User, error)
+	// FindDepartmentHeads returns the active heads of the department.
+	FindDepartmentHeads(department string) ([]domain.User, error)
 	Update(user *domain.User) error
 	Delete(id uuid.UUID) error
 }
 
 type TicketRepository interface {
 	Create(ticket *domain.Ticket) error
-	FindByID(id uuid.UUID) (*domain.Ticket, error)
+	FindByID(id uuid.UUID, opts ...FindOption) (*domain.Ticket, error)
 	FindAll(filter TicketFilter) ([]domain.Ticket, int64, error)
+	// FindDuplicateCandidates returns open tickets that could be the same
+	// job as a new one.
+	FindDuplicateCandidates(criteria DuplicateCriteria) ([]domain.Ticket, error)
+	// Progress rolls up the sub-tickets of each of the tickets. Tickets
+	// without sub-tickets are left out.
+	Progress(ids []uuid.UUID) (map[uuid.UUID]domain.TicketProgress, error)
 	Update(ticket *domain.Ticket) error
+	// AddPartsCost adds delta to the ticket's parts cost.
+	AddPartsCost(id uuid.UUID, delta float64) error
+	// AddLaborCost adds delta to the ticket's labor cost.
+	AddLaborCost(id uuid.UUID, delta float64) error
 	Delete(id uuid.UUID) error
-	GetStats() (map[string]int64, error)
+	GetStats() (*TicketStats, error)
 }
 
 type TicketFilter struct {
-	Status       string
-	Priority     string
-	Category     string
-	AssignedToID *uuid.UUID
-	CreatedByID  *uuid.UUID
-	Search       string
-	Page         int
-	Limit        int
+	Status             string
+	Priority           string
+	Category           string
+	AssignedToID       *uuid.UUID
+	AssetID            *uuid.UUID
+	LocationID         *uuid.UUID // anywhere under this location
+	CreatedByID        *uuid.UUID
+	DepartmentID       *uuid.UUID
+	ParentID           *uuid.UUID // direct sub-tickets of this ticket
+	Search             string
+	ResponseBreached   *bool
+	ResolutionBreached *bool
+	Page               int
+	Limit              int
+
+	// Tag queries; each one given narrows the result further.
+	TagsAny  []uuid.UUID // at least one of these tags
+	TagsAll  []uuid.UUID // every one of these tags
+	TagsNone []uuid.UUID // none of these tags
+
+	// Custom field conditions, all of which must hold, and a custom field
+	// to sort by instead of creation time.
+	CustomFields []CustomFieldFilter
+	SortBy       *CustomFieldSort
+}
+
+// Custom field filter operators. Lt, Lte, Gt and Gte apply to number and
+// date fields; Contains matches part of a text field or one choice of a
+// multi-select field; Exists takes a bool.
+const (
+	FieldOpEq       = "eq"
+	FieldOpNe       = "ne"
+	FieldOpLt       = "lt"
+	FieldOpLte      = "lte"
+	FieldOpGt       = "gt"
+	FieldOpGte      = "gte"
+	FieldOpContains = "contains"
+	FieldOpExists   = "exists"
+)
+
+// CustomFieldFilter is a condition on a custom field's value. Value is
+// already of the field's type: float64 for numbers, bool for booleans and
+// exists, string otherwise.
+type CustomFieldFilter struct {
+	Key   string
+	Type  domain.CustomFieldType
+	Op    string
+	Value interface{}
+}
+
+// CustomFieldSort orders tickets by a custom field; tickets without a
+// value come last either way.
+type CustomFieldSort struct {
+	Key  string
+	Type domain.CustomFieldType
+	Desc bool
+}
+
+// TicketStats counts tickets by status, and by tag across all statuses.
+type TicketStats struct {
+	Total      int64      `json:"total"`
+	Open       int64      `json:"open"`
+	InProgress int64      `json:"inProgress"`
+	Resolved   int64      `json:"resolved"`
+	Tags       []TagCount `json:"tags"`
+}
+
+// DuplicateCriteria narrows duplicate candidates to open tickets of the
+// category created since Since, at the same asset, location or free-text
+// location if any is given.
+type DuplicateCriteria struct {
+	Category   domain.TicketCategory
+	AssetID    *uuid.UUID
+	LocationID *uuid.UUID
+	Location   string
+	Since      time.Time
+	Limit      int
+}
+
+// FindOption adjusts what TicketRepository.FindByID loads.
+type FindOption func(*findOptions)
+
+type findOptions struct {
+	childTree bool
+	forUpdate bool
+}
+
+// ForUpdate locks the ticket row until the transaction ends. Only
+// meaningful with a repository bound to a transaction.
+func ForUpdate() FindOption {
+	return func(o *findOptions) {
+		o.forUpdate = true
+	}
+}
+
+// WithChildTree loads the ticket's sub-tickets into Children, each with
+// their own sub-tickets, all the way down.
+func WithChildTree() FindOption {
+	return func(o *findOptions) {
+		o.childTree = true
+	}
 }
 
 type CommentRepository interface {
 	Create(comment *domain.Comment) error
 	FindByTicketID(ticketID uuid.UUID) ([]domain.Comment, error)
 	Delete(id uuid.UUID) error
+	// Reassign moves every comment on one ticket to another.
+	Reassign(fromTicketID, toTicketID uuid.UUID) error
 }
 
 type AttachmentRepository interface {
 	Create(attachment *domain.Attachment) error
+	FindByID(id uuid.UUID) (*domain.Attachment, error)
 	FindByTicketID(ticketID uuid.UUID) ([]domain.Attachment, error)
 	Delete(id uuid.UUID) error
+	// Reassign moves every attachment on one ticket to another.
+	Reassign(fromTicketID, toTicketID uuid.UUID) error
+}
+
+type CategoryRepository interface {
+	Create(category *domain.Category) error
+	FindByID(id uuid.UUID) (*domain.Category, error)
+	FindByCode(code domain.TicketCategory) (*domain.Category, error)
+	// FindAll returns the categories in display order.
+	FindAll() ([]domain.Category, error)
+	Update(category *domain.Category) error
+	Delete(id uuid.UUID) error
+	// CountUsage counts the records that store the code, and the
+	// category's subcategories.
+	CountUsage(category *domain.Category) (int64, error)
+}
+
+type PriorityRepository interface {
+	Create(priority *domain.Priority) error
+	FindByID(id uuid.UUID) (*domain.Priority, error)
+	FindByCode(code domain.TicketPriority) (*domain.Priority, error)
+	// FindAll returns the priorities from least to most urgent.
+	FindAll() ([]domain.Priority, error)
+	// FindNext returns the active priority one step more urgent than rank.
+	FindNext(rank int) (*domain.Priority, error)
+	Update(priority *domain.Priority) error
+	Delete(id uuid.UUID) error
+	// CountUsage counts the records that store the code.
+	CountUsage(code domain.TicketPriority) (int64, error)
+}
+
+type CustomFieldRepository interface {
+	Create(field *domain.CustomField) error
+	FindByID(id uuid.UUID) (*domain.CustomField, error)
+	FindByKey(key string) (*domain.CustomField, error)
+	// FindAll returns the fields in display order.
+	FindAll() ([]domain.CustomField, error)
+	Update(field *domain.CustomField) error
+	// Delete removes the field and its values from every ticket.
+	Delete(field *domain.CustomField) error
+}
+
+type TagRepository interface {
+	Create(tag *domain.Tag) error
+	FindByID(id uuid.UUID) (*domain.Tag, error)
+	FindByIDs(ids []uuid.UUID) ([]domain.Tag, error)
+	// FindByName matches the name case-insensitively.
+	FindByName(name string) (*domain.Tag, error)
+	FindAll() ([]domain.Tag, error)
+	Update(tag *domain.Tag) error
+	// Delete removes the tag from every ticket with it.
+	Delete(id uuid.UUID) error
+	// Merge moves the tickets tagged with any of the sources to the target
+	// and deletes the sources, in one transaction. It returns the pairs
+	// added with the target and the source pairs removed.
+	Merge(sourceIDs []uuid.UUID, targetID uuid.UUID) (added, removed []domain.TicketTag, err error)
+	// Tag puts every tag on every ticket that exists and does not have it
+	// yet, and returns the pairs that were added.
+	Tag(ticketIDs, tagIDs []uuid.UUID) ([]domain.TicketTag, error)
+	// Untag takes the tags off the tickets and returns the pairs removed.
+	Untag(ticketIDs, tagIDs []uuid.UUID) ([]domain.TicketTag, error)
+	// Counts returns how many tickets carry each tag, most used first.
+	Counts() ([]TagCount, error)
+}
+
+type TagCount struct {
+	ID      uuid.UUID `json:"id"`
+	Name    string    `json:"name"`
+	Color   string    `json:"color"`
+	Tickets int64     `json:"tickets"`
+}
+
+type TicketLinkRepository interface {
+	Create(link *domain.TicketLink) error
+	FindByID(id uuid.UUID) (*domain.TicketLink, error)
+	// FindByTicket returns the links from and to the ticket, with both
+	// tickets loaded.
+	FindByTicket(ticketID uuid.UUID) ([]domain.TicketLink, error)
+	// Exists reports whether a link of the type runs from source to target.
+	Exists(sourceID, targetID uuid.UUID, linkType domain.TicketLinkType) (bool, error)
+	// Reaches reports whether a chain of links of the type runs from one
+	// ticket to the other.
+	Reaches(fromID, toID uuid.UUID, linkType domain.TicketLinkType) (bool, error)
+	Delete(id uuid.UUID) error
+	// CountOpenBlockers counts the tickets blocking this one that are not
+	// resolved or closed.
+	CountOpenBlockers(ticketID uuid.UUID) (int64, error)
 }
 
 type NotificationRepository interface {
 	Create(notification *domain.Notification) error
-	FindByUserID(userID uuid.UUID) ([]domain.Notification, error)
-	MarkAsRead(id uuid.UUID) error
+	CreateBatch(notifications []domain.Notification) error
+	FindByUserID(userID uuid.UUID, unreadOnly bool, limit int) ([]domain.Notification, error)
+	CountUnread(userID uuid.UUID) (int64, error)
+	// MarkAsRead marks one of the user's notifications as read.
+	MarkAsRead(id, userID uuid.UUID) error
 	MarkAllAsRead(userID uuid.UUID) error
 }
+
+type InventoryRepository interface {
+	CreatePart(part *domain.Part) error
+	FindPartByID(id uuid.UUID) (*domain.Part, error)
+	FindPartBySKU(sku string) (*domain.Part, error)
+	FindParts(filter PartFilter) ([]domain.Part, int64, error)
+	UpdatePart(part *domain.Part) error
+	DeletePart(id uuid.UUID) error
+	// OnHand returns the part's total quantity across stock locations.
+	OnHand(partID uuid.UUID) (float64, error)
+
+	CreateStockLocation(location *domain.StockLocation) error
+	FindStockLocationByID(id uuid.UUID) (*domain.StockLocation, error)
+	FindStockLocations() ([]domain.StockLocation, error)
+	UpdateStockLocation(location *domain.StockLocation) error
+	DeleteStockLocation(id uuid.UUID) error
+	// CountMovements returns how many ledger entries refer to the location.
+	CountMovements(locationID uuid.UUID) (int64, error)
+
+	// ApplyMovement records the movement and applies it to the stock level.
+	// It returns false, recording nothing, if the movement would take the
+	// stock level below zero.
+	ApplyMovement(movement *domain.StockMovement) (bool, error)
+	FindMovements(filter MovementFilter) ([]domain.StockMovement, int64, error)
+	// FindTicketParts returns the net quantity and cost of each part issued
+	// to the ticket, after returns.
+	FindTicketParts(ticketID uuid.UUID) ([]TicketPart, error)
+}
+
+type PartFilter struct {
+	Search   string
+	LowStock bool
+	Page     int
+	Limit    int
+}
+
+type MovementFilter struct {
+	PartID          *uuid.UUID
+	StockLocationID *uuid.UUID
+	TicketID        *uuid.UUID
+	Type            string
+	Page            int
+	Limit           int
+}
+
+// TicketPart is a part's net usage on a ticket.
+type TicketPart struct {
+	PartID          uuid.UUID `json:"partId"`
+	SKU             string    `json:"sku"`
+	Name            string    `json:"name"`
+	Unit            string    `json:"unit"`
+	StockLocationID uuid.UUID `json:"stockLocationId"`
+	Quantity        float64   `json:"quantity"`
+	Cost            float64   `json:"cost"`
+}
+
+type TimeEntryRepository interface {
+	Create(entry *domain.TimeEntry) error
+	// StartTimer creates a running entry. It returns false, creating
+	// nothing, if the user already has a running timer.
+	StartTimer(entry *domain.TimeEntry) (bool, error)
+	FindByID(id uuid.UUID) (*domain.TimeEntry, error)
+	// Lock returns the entry, without its user, locked for update until
+	// the surrounding transaction ends.
+	Lock(id uuid.UUID) (*domain.TimeEntry, error)
+	FindByTicketID(ticketID uuid.UUID) ([]domain.TimeEntry, error)
+	// FindRunning returns the user's running timer.
+	FindRunning(userID uuid.UUID) (*domain.TimeEntry, error)
+	// StopTimer saves the entry's end, duration and cost if it is still
+	// running. It returns false, changing nothing, if it was already
+	// stopped.
+	StopTimer(entry *domain.TimeEntry) (bool, error)
+	Update(entry *domain.TimeEntry) error
+	Delete(id uuid.UUID) error
+	// Summarize totals finished entries per technician, department or
+	// ticket.
+	Summarize(filter TimeSummaryFilter) ([]TimeSummary, error)
+
+	FindRates() ([]domain.LaborRate, error)
+	FindRate(role domain.UserRole) (*domain.LaborRate, error)
+	SaveRate(rate *domain.LaborRate) error
+}
+
+// Time summary groupings.
+const (
+	GroupByTechnician = "technician"
+	GroupByDepartment = "department"
+	GroupByTicket     = "ticket"
+)
+
+type TimeSummaryFilter struct {
+	GroupBy  string
+	From     *time.Time
+	To       *time.Time
+	UserID   *uuid.UUID
+	TicketID *uuid.UUID
+}
+
+// TimeSummary is the time and labor cost of one group of entries. Key is
+// the user or ticket ID, or the department name.
+type TimeSummary struct {
+	Key             string  `json:"key"`
+	Name            string  `json:"name"`
+	Entries         int64   `json:"entries"`
+	Seconds         int64   `json:"seconds"`
+	BillableSeconds int64   `json:"billableSeconds"`
+	LaborCost       float64 `json:"laborCost"`
+}
+
+type SLAPolicyRepository interface {
+	Create(policy *domain.SLAPolicy) error
+	FindByID(id uuid.UUID) (*domain.SLAPolicy, error)
+	FindAll() ([]domain.SLAPolicy, error)
+	// FindMatch returns the policy for priority and category, falling back
+	// to the priority's catch-all policy and then to the policy linked to
+	// the priority, or nil if none applies.
+	FindMatch(priority domain.TicketPriority, category domain.TicketCategory) (*domain.SLAPolicy, error)
+	Update(policy *domain.SLAPolicy) error
+	Delete(id uuid.UUID) error
+}
+
+type ApprovalThresholdRepository interface {
+	Create(threshold *domain.ApprovalThreshold) error
+	FindByID(id uuid.UUID) (*domain.ApprovalThreshold, error)
+	FindAll() ([]domain.ApprovalThreshold, error)
+	// FindMatch returns the most specific threshold for the department and
+	// category, preferring a department match over a category match, or
+	// nil if none applies.
+	FindMatch(department string, category domain.TicketCategory) (*domain.ApprovalThreshold, error)
+	Update(threshold *domain.ApprovalThreshold) error
+	Delete(id uuid.UUID) error
+}
+
+type ApprovalRepository interface {
+	CreateWorkflow(workflow *domain.ApprovalWorkflow) error
+	FindWorkflowByID(id uuid.UUID) (*domain.ApprovalWorkflow, error)
+	FindWorkflowByName(name string) (*domain.ApprovalWorkflow, error)
+	FindWorkflows(activeOnly bool) ([]domain.ApprovalWorkflow, error)
+	// UpdateWorkflow saves the workflow and, if replaceSteps is set,
+	// replaces its steps with workflow.Steps.
+	UpdateWorkflow(workflow *domain.ApprovalWorkflow, replaceSteps bool) error
+	DeleteWorkflow(id uuid.UUID) error
+	// CountRequests returns how many requests were made with the workflow.
+	CountRequests(workflowID uuid.UUID) (int64, error)
+
+	// CreateRequest creates the request together with its tasks.
+	CreateRequest(request *domain.ApprovalRequest) error
+	FindRequestByID(id uuid.UUID) (*domain.ApprovalRequest, error)
+	// LockRequest loads the request and its tasks, locking the request row
+	// until the surrounding transaction ends.
+	LockRequest(id uuid.UUID) (*domain.ApprovalRequest, error)
+	FindRequests(filter ApprovalFilter) ([]domain.ApprovalRequest, int64, error)
+	// FindPendingFor returns the pending requests with a task the user can
+	// decide now, leaving out the user's own.
+	FindPendingFor(user *domain.User) ([]domain.ApprovalRequest, error)
+	FindPendingByTicket(ticketID uuid.UUID) ([]domain.ApprovalRequest, error)
+	// FindExpired returns the pending requests whose expiry has passed.
+	FindExpired(now time.Time) ([]domain.ApprovalRequest, error)
+	UpdateRequest(request *domain.ApprovalRequest) error
+	UpdateTasks(tasks []domain.ApprovalTask) error
+}
+
+type ApprovalFilter struct {
+	Status      string
+	WorkflowID  *uuid.UUID
+	TicketID    *uuid.UUID
+	RequesterID *uuid.UUID
+	Page        int
+	Limit       int
+}
+
+type ChecklistRepository interface {
+	CreateTemplate(template *domain.ChecklistTemplate) error
+	FindTemplateByID(id uuid.UUID) (*domain.ChecklistTemplate, error)
+	FindTemplates(activeOnly bool) ([]domain.ChecklistTemplate, error)
+	// FindMatchingTemplates returns the active templates for a ticket in
+	// category against an asset in assetCategory (empty without an asset).
+	FindMatchingTemplates(category, assetCategory domain.TicketCategory) ([]domain.ChecklistTemplate, error)
+	// UpdateTemplate saves the template and, if replaceItems is set,
+	// replaces its items with template.Items.
+	UpdateTemplate(template *domain.ChecklistTemplate, replaceItems bool) error
+	DeleteTemplate(id uuid.UUID) error
+
+	// CreateChecklist creates the ticket checklist together with its items.
+	CreateChecklist(checklist *domain.TicketChecklist) error
+	FindChecklistByID(id uuid.UUID) (*domain.TicketChecklist, error)
+	FindByTicketID(ticketID uuid.UUID) ([]domain.TicketChecklist, error)
+	DeleteChecklist(id uuid.UUID) error
+	// FindItemByID returns the item with its checklist.
+	FindItemByID(id uuid.UUID) (*domain.TicketChecklistItem, error)
+	UpdateItem(item *domain.TicketChecklistItem) error
+	// CountIncomplete returns how many required items on the ticket's
+	// checklists are not done.
+	CountIncomplete(ticketID uuid.UUID) (int64, error)
+}
+
+type MeterRepository interface {
+	Create(meter *domain.Meter) error
+	// FindByID returns the meter with its asset and rules.
+	FindByID(id uuid.UUID) (*domain.Meter, error)
+	FindByAsset(assetID uuid.UUID) ([]domain.Meter, error)
+	// Lock returns the meter, without associations, locked for update
+	// until the surrounding transaction ends.
+	Lock(id uuid.UUID) (*domain.Meter, error)
+	// Update saves the meter's settings; the last reading is only changed
+	// through SetLastReading.
+	Update(meter *domain.Meter) error
+	SetLastReading(id uuid.UUID, value float64, at time.Time) error
+	Delete(id uuid.UUID) error
+
+	CreateReading(reading *domain.MeterReading) error
+	// FindReadings returns the meter's latest readings, newest first.
+	FindReadings(meterID uuid.UUID, limit int) ([]domain.MeterReading, error)
+
+	CreateRule(rule *domain.MeterRule) error
+	FindRuleByID(id uuid.UUID) (*domain.MeterRule, error)
+	// LockRule returns the rule locked for update until the surrounding
+	// transaction ends.
+	LockRule(id uuid.UUID) (*domain.MeterRule, error)
+	UpdateRule(rule *domain.MeterRule) error
+	DeleteRule(id uuid.UUID) error
+}
+
+type CalendarRepository interface {
+	Create(calendar *domain.WorkingCalendar) error
+	FindByID(id uuid.UUID) (*domain.WorkingCalendar, error)
+	FindDefault() (*domain.WorkingCalendar, error)
+	FindAll() ([]domain.WorkingCalendar, error)
+	Update(calendar *domain.WorkingCalendar) error
+	Delete(id uuid.UUID) error
+	ReplaceHours(calendarID uuid.UUID, hours []domain.WorkingHours) error
+	AddHolidays(holidays []domain.Holiday) error
+	DeleteHoliday(calendarID, holidayID uuid.UUID) error
+}
+
+type EscalationRepository interface {
+	CreateRule(rule *domain.EscalationRule) error
+	FindRuleByID(id uuid.UUID) (*domain.EscalationRule, error)
+	FindRules(activeOnly bool) ([]domain.EscalationRule, error)
+	UpdateRule(rule *domain.EscalationRule) error
+	DeleteRule(id uuid.UUID) error
+	FindCandidates(rule *domain.EscalationRule, now time.Time) ([]EscalationCandidate, error)
+	// ConditionSince returns when the condition started to hold for the
+	// ticket as FindCandidates computes it, e.g. its last activity for
+	// NO_ACTIVITY, or nil if that cannot be told.
+	ConditionSince(condition domain.EscalationCondition, ticketID uuid.UUID) (*time.Time, error)
+	Claim(escalation *domain.TicketEscalation) (bool, error)
+}
+
+type ContractFilter struct {
+	AssetID  *uuid.UUID
+	Type     string
+	ActiveAt *time.Time // in force at this time
+}
+
+type ContractRepository interface {
+	Create(contract *domain.ServiceContract) error
+	FindByID(id uuid.UUID) (*domain.ServiceContract, error)
+	FindAll(filter ContractFilter) ([]domain.ServiceContract, error)
+	// FindCovering returns the contract in force on the asset at the given
+	// time, preferring the one that runs longest, or nil if there is none.
+	FindCovering(assetID uuid.UUID, at time.Time) (*domain.ServiceContract, error)
+	// FindExpiring returns the contracts ending after from and by to,
+	// soonest first.
+	FindExpiring(from, to time.Time) ([]domain.ServiceContract, error)
+	// FindDueReminders is FindExpiring limited to contracts not yet
+	// reminded about.
+	FindDueReminders(from, to time.Time) ([]domain.ServiceContract, error)
+	// ClaimReminder marks the contract as reminded at the given time. It
+	// returns false, changing nothing, if a reminder was already sent.
+	ClaimReminder(id uuid.UUID, at time.Time) (bool, error)
+	// ReleaseReminder undoes a ClaimReminder made at the given time.
+	ReleaseReminder(id uuid.UUID, at time.Time) error
+	Update(contract *domain.ServiceContract) error
+	Delete(id uuid.UUID) error
+}
+
+type VendorFilter struct {
+	Search     string
+	Category   string // vendors taking on this category of work
+	ActiveOnly bool
+}
+
+type VendorStatsFilter struct {
+	From     *time.Time // by when tickets were dispatched
+	To       *time.Time
+	VendorID *uuid.UUID
+}
+
+// VendorStats is how a vendor performed on the tickets dispatched to it.
+// Response and resolution are average hours from dispatch, over the
+// tickets that got that far; costs are the tickets' total costs.
+type VendorStats struct {
+	VendorID           string   `json:"vendorId"`
+	Name               string   `json:"name"`
+	Tickets            int64    `json:"tickets"`
+	Resolved           int64    `json:"resolved"`
+	AvgResponseHours   *float64 `json:"avgResponseHours"`
+	AvgResolutionHours *float64 `json:"avgResolutionHours"`
+	TotalCost          float64  `json:"totalCost"`
+	AverageCost        float64  `json:"averageCost"`
+}
+
+type VendorRepository interface {
+	Create(vendor *domain.Vendor) error
+	FindByID(id uuid.UUID) (*domain.Vendor, error)
+	FindByName(name string) (*domain.Vendor, error)
+	FindAll(filter VendorFilter) ([]domain.Vendor, error)
+	// Update saves the vendor and, if replaceContacts is set, replaces its
+	// contacts with vendor.Contacts.
+	Update(vendor *domain.Vendor, replaceContacts bool) error
+	Delete(id uuid.UUID) error
+
+	CreateAccess(access *domain.VendorAccess) error
+	FindAccessByHash(tokenHash string) (*domain.VendorAccess, error)
+	// RevokeAccess revokes every live link to the ticket.
+	RevokeAccess(ticketID uuid.UUID, now time.Time) error
+	TouchAccess(id uuid.UUID, now time.Time) error
+
+	Stats(filter VendorStatsFilter) ([]VendorStats, error)
+}
+
+type DepartmentRepository interface {
+	Create(department *domain.Department) error
+	FindByID(id uuid.UUID) (*domain.Department, error)
+	FindByName(name string) (*domain.Department, error)
+	FindAll() ([]domain.Department, error)
+	// Update saves the department. If it was renamed from previousName, the
+	// users and approval thresholds that name it are renamed too.
+	Update(department *domain.Department, previousName string) error
+	Delete(id uuid.UUID) error
+	// CountReferences returns how many users and tickets point at the
+	// department.
+	CountReferences(id uuid.UUID) (int64, error)
+
+	// Spending sums ticket costs per department and the month they were
+	// incurred in.
+	Spending(filter SpendingFilter) ([]DepartmentSpending, error)
+	// CreateAlert records the alert unless one for the same department,
+	// period and percent exists, and reports whether it was new.
+	CreateAlert(alert *domain.BudgetAlert) (bool, error)
+}
+
+type SpendingFilter struct {
+	From         time.Time // by when costs were incurred
+	To           time.Time
+	DepartmentID *uuid.UUID
+}
+
+// DepartmentSpending is what a department's tickets cost in a month
+// ("2026-03") and how many of them were created in it.
+type DepartmentSpending struct {
+	DepartmentID uuid.UUID `json:"departmentId"`
+	Month        string    `json:"month"`
+	Tickets      int64     `json:"tickets"`
+	Cost         float64   `json:"cost"`
+}
+
+type ScheduleRepository interface {
+	Create(schedule *domain.MaintenanceSchedule) error
+	FindByID(id uuid.UUID) (*domain.MaintenanceSchedule, error)
+	FindAll(activeOnly bool) ([]domain.MaintenanceSchedule, error)
+	Update(schedule *domain.MaintenanceSchedule) error
+	Delete(id uuid.UUID) error
+	FindOccurrences(scheduleID uuid.UUID) ([]domain.ScheduleOccurrence, error)
+	// LastOccurrence returns the time of the latest occurrence generated
+	// for the schedule, or nil if there is none.
+	LastOccurrence(scheduleID uuid.UUID) (*time.Time, error)
+	ClaimOccurrence(occurrence *domain.ScheduleOccurrence) (bool, error)
+}
+
+type AssetRepository interface {
+	Create(asset *domain.Asset) error
+	FindByID(id uuid.UUID) (*domain.Asset, error)
+	FindByCode(code string) (*domain.Asset, error)
+	FindByIDs(ids []uuid.UUID) ([]domain.Asset, error)
+	FindAll(filter AssetFilter) ([]domain.Asset, int64, error)
+	Update(asset *domain.Asset) error
+	Delete(id uuid.UUID) error
+	FindTickets(assetID uuid.UUID) ([]domain.Ticket, error)
+}
+
+type AssetFilter struct {
+	Status     string
+	Category   string
+	LocationID *uuid.UUID // anywhere under this location
+	Search     string
+	Page       int
+	Limit      int
+}
+
+type PurchaseFilter struct {
+	Status   string
+	TicketID *uuid.UUID
+	Supplier string
+	Page     int
+	Limit    int
+}
+
+type PurchaseRepository interface {
+	Create(request *domain.PurchaseRequest) error
+	FindByID(id uuid.UUID) (*domain.PurchaseRequest, error)
+	// Lock loads the request like FindByID, locking its row until the
+	// transaction ends. Only meaningful inside a transaction.
+	Lock(id uuid.UUID) (*domain.PurchaseRequest, error)
+	FindAll(filter PurchaseFilter) ([]domain.PurchaseRequest, int64, error)
+	// Update saves the request, replacing its lines if replaceLines is set.
+	Update(request *domain.PurchaseRequest, replaceLines bool) error
+	Delete(id uuid.UUID) error
+	// CountOutstanding returns how many of the ticket's requests, other
+	// than except, are still awaiting goods.
+	CountOutstanding(ticketID, except uuid.UUID) (int64, error)
+}
+
+type LocationRepository interface {
+	Create(location *domain.Location) error
+	FindByID(id uuid.UUID) (*domain.Location, error)
+	FindAll() ([]domain.Location, error)
+	Update(location *domain.Location) error
+	// Move re-parents the location and rewrites the paths of its subtree.
+	Move(location *domain.Location, parent *domain.Location) error
+	Delete(id uuid.UUID) error
+	// CountReferences returns how many child locations, tickets and assets
+	// point at the location.
+	CountReferences(id uuid.UUID) (int64, error)
+
+	// FindLegacyLocations returns the distinct free-text locations of
+	// tickets and assets that have no LocationID yet.
+	FindLegacyLocations() ([]LegacyLocation, error)
+	// AssignLegacyLocation sets LocationID on the tickets and assets whose
+	// free-text location is text.
+	AssignLegacyLocation(text string, locationID uuid.UUID) (tickets, assets int64, err error)
+}
+
+// LegacyLocation is a free-text location and how often it is used.
+type LegacyLocation struct {
+	Text    string `json:"text"`
+	Tickets int64  `json:"tickets"`
+	Assets  int64  `json:"assets"`
+}
@@ -0,0 +1,240 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/maintenance-system/api/internal/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// onHandSQL is the total stock of the part in the current row.
+const onHandSQL = "COALESCE((SELECT SUM(quantity) FROM stock_levels WHERE stock_levels.part_id = parts.id), 0)"
+
+type inventoryRepository struct {
+	db *gorm.DB
+}
+
+func NewInventoryRepository(db *gorm.DB) InventoryRepository {
+	return &inventoryRepository{db: db}
+}
+
+func (r *inventoryRepository) CreatePart(part *domain.Part) error {
+	return r.db.Omit(clause.Associations).Create(part).Error
+}
+
+func (r *inventoryRepository) FindPartByID(id uuid.UUID) (*domain.Part, error) {
+	var part domain.Part
+	if err := r.db.Preload("Stock.StockLocation").First(&part, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	fillOnHand(&part)
+	return &part, nil
+}
+
+func (r *inventoryRepository) FindPartBySKU(sku string) (*domain.Part, error) {
+	var part domain.Part
+	if err := r.db.First(&part, "sku = ?", sku).Error; err != nil {
+		return nil, err
+	}
+	return &part, nil
+}
+
+func (r *inventoryRepository) FindParts(filter PartFilter) ([]domain.Part, int64, error) {
+	var parts []domain.Part
+	var total int64
+
+	query := r.db.Model(&domain.Part{})
+
+	if filter.Search != "" {
+		search := "%" + filter.Search + "%"
+		query = query.Where("sku ILIKE ? OR name ILIKE ?", search, search)
+	}
+	if filter.LowStock {
+		query = query.Where("min_stock > 0 AND " + onHandSQL + " <= min_stock")
+	}
+
+	query.Count(&total)
+
+	if filter.Limit <= 0 {
+		filter.Limit = 10
+	}
+	if filter.Page <= 0 {
+		filter.Page = 1
+	}
+	offset := (filter.Page - 1) * filter.Limit
+
+	if err := query.
+		Preload("Stock.StockLocation").
+		Offset(offset).
+		Limit(filter.Limit).
+		Order("sku").
+		Find(&parts).Error; err != nil {
+		return nil, 0, err
+	}
+	for i := range parts {
+		fillOnHand(&parts[i])
+	}
+
+	return parts, total, nil
+}
+
+func fillOnHand(part *domain.Part) {
+	part.OnHand = 0
+	for _, level := range part.Stock {
+		part.OnHand += level.Quantity
+	}
+}
+
+func (r *inventoryRepository) UpdatePart(part *domain.Part) error {
+	return r.db.Omit(clause.Associations).Save(part).Error
+}
+
+func (r *inventoryRepository) DeletePart(id uuid.UUID) error {
+	return r.db.Delete(&domain.Part{}, "id = ?", id).Error
+}
+
+func (r *inventoryRepository) OnHand(partID uuid.UUID) (float64, error) {
+	var onHand float64
+	err := r.db.Model(&domain.StockLevel{}).
+		Select("COALESCE(SUM(quantity), 0)").
+		Where("part_id = ?", partID).
+		Scan(&onHand).Error
+	return onHand, err
+}
+
+func (r *inventoryRepository) CreateStockLocation(location *domain.StockLocation) error {
+	return r.db.Create(location).Error
+}
+
+func (r *inventoryRepository) FindStockLocationByID(id uuid.UUID) (*domain.StockLocation, error) {
+	var location domain.StockLocation
+	if err := r.db.First(&location, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &location, nil
+}
+
+func (r *inventoryRepository) FindStockLocations() ([]domain.StockLocation, error) {
+	var locations []domain.StockLocation
+	err := r.db.Order("name").Find(&locations).Error
+	return locations, err
+}
+
+func (r *inventoryRepository) UpdateStockLocation(location *domain.StockLocation) error {
+	return r.db.Save(location).Error
+}
+
+func (r *inventoryRepository) DeleteStockLocation(id uuid.UUID) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&domain.StockLevel{}, "stock_location_id = ?", id).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&domain.StockLocation{}, "id = ?", id).Error
+	})
+}
+
+func (r *inventoryRepository) CountMovements(locationID uuid.UUID) (int64, error) {
+	var count int64
+	err := r.db.Model(&domain.StockMovement{}).
+		Where("stock_location_id = ?", locationID).
+		Count(&count).Error
+	return count, err
+}
+
+func (r *inventoryRepository) ApplyMovement(movement *domain.StockMovement) (bool, error) {
+	now := time.Now()
+
+	var result *gorm.DB
+	if movement.Quantity >= 0 {
+		result = r.db.Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "part_id"}, {Name: "stock_location_id"}},
+			DoUpdates: clause.Assignments(map[string]interface{}{
+				"quantity":   gorm.Expr("stock_levels.quantity + ?", movement.Quantity),
+				"updated_at": now,
+			}),
+		}).Create(&domain.StockLevel{
+			PartID:          movement.PartID,
+			StockLocationID: movement.StockLocationID,
+			Quantity:        movement.Quantity,
+			UpdatedAt:       now,
+		})
+	} else {
+		// The guard in the WHERE clause makes concurrent issues of the last
+		// units fail instead of driving the level negative.
+		result = r.db.Model(&domain.StockLevel{}).
+			Where("part_id = ? AND stock_location_id = ? AND quantity >= ?",
+				movement.PartID, movement.StockLocationID, -movement.Quantity).
+			Updates(map[string]interface{}{
+				"quantity":   gorm.Expr("quantity + ?", movement.Quantity),
+				"updated_at": now,
+			})
+	}
+	if result.Error != nil {
+		return false, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return false, nil
+	}
+
+	return true, r.db.Omit(clause.Associations).Create(movement).Error
+}
+
+func (r *inventoryRepository) FindMovements(filter MovementFilter) ([]domain.StockMovement, int64, error) {
+	var movements []domain.StockMovement
+	var total int64
+
+	query := r.db.Model(&domain.StockMovement{})
+
+	if filter.PartID != nil {
+		query = query.Where("part_id = ?", filter.PartID)
+	}
+	if filter.StockLocationID != nil {
+		query = query.Where("stock_location_id = ?", filter.StockLocationID)
+	}
+	if filter.TicketID != nil {
+		query = query.Where("ticket_id = ?", filter.TicketID)
+	}
+	if filter.Type != "" {
+		query = query.Where("type = ?", filter.Type)
+	}
+
+	query.Count(&total)
+
+	if filter.Limit <= 0 {
+		filter.Limit = 20
+	}
+	if filter.Page <= 0 {
+		filter.Page = 1
+	}
+	offset := (filter.Page - 1) * filter.Limit
+
+	if err := query.
+		Preload("Part", func(db *gorm.DB) *gorm.DB { return db.Unscoped() }).
+		Preload("StockLocation").
+		Preload("User").
+		Offset(offset).
+		Limit(filter.Limit).
+		Order("created_at DESC").
+		Find(&movements).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return movements, total, nil
+}
+
+func (r *inventoryRepository) FindTicketParts(ticketID uuid.UUID) ([]TicketPart, error) {
+	var parts []TicketPart
+	err := r.db.Raw(`
+		SELECT m.part_id, p.sku, p.name, p.unit, m.stock_location_id,
+			-SUM(m.quantity) AS quantity,
+			-SUM(m.quantity * m.unit_cost) AS cost
+		FROM stock_movements m
+		JOIN parts p ON p.id = m.part_id
+		WHERE m.ticket_id = ?
+		GROUP BY m.part_id, p.sku, p.name, p.unit, m.stock_location_id
+		HAVING SUM(m.quantity) <> 0
+		ORDER BY p.sku`, ticketID).Scan(&parts).Error
+	return parts, err
+}
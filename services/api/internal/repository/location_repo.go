@@ -0,0 +1,136 @@
+package repository
+
+import (
+	"github.com/google/uuid"
+	"github.com/maintenance-system/api/internal/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// subtreeSQL selects the IDs of a location and all its descendants.
+const subtreeSQL = "SELECT id FROM locations WHERE path LIKE (SELECT path FROM locations WHERE id = ?) || '%'"
+
+type locationRepository struct {
+	db *gorm.DB
+}
+
+func NewLocationRepository(db *gorm.DB) LocationRepository {
+	return &locationRepository{db: db}
+}
+
+func (r *locationRepository) Create(location *domain.Location) error {
+	if location.ID == uuid.Nil {
+		location.ID = uuid.New()
+	}
+	location.Path = "/" + location.ID.String() + "/"
+	location.Depth = 0
+	if location.ParentID != nil {
+		var parent domain.Location
+		if err := r.db.First(&parent, "id = ?", location.ParentID).Error; err != nil {
+			return err
+		}
+		location.Path = parent.Path + location.ID.String() + "/"
+		location.Depth = parent.Depth + 1
+	}
+	return r.db.Create(location).Error
+}
+
+func (r *locationRepository) FindByID(id uuid.UUID) (*domain.Location, error) {
+	var location domain.Location
+	if err := r.db.First(&location, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &location, nil
+}
+
+// FindAll returns every location in depth-first order.
+func (r *locationRepository) FindAll() ([]domain.Location, error) {
+	var locations []domain.Location
+	err := r.db.Order("path").Find(&locations).Error
+	return locations, err
+}
+
+func (r *locationRepository) Update(location *domain.Location) error {
+	return r.db.Omit(clause.Associations, "parent_id", "path", "depth").Save(location).Error
+}
+
+func (r *locationRepository) Move(location *domain.Location, parent *domain.Location) error {
+	oldPath := location.Path
+	newPath := "/" + location.ID.String() + "/"
+	depth := 0
+	var parentID *uuid.UUID
+	if parent != nil {
+		newPath = parent.Path + location.ID.String() + "/"
+		depth = parent.Depth + 1
+		parentID = &parent.ID
+	}
+	delta := depth - location.Depth
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&domain.Location{}).
+			Where("id = ?", location.ID).
+			Update("parent_id", parentID).Error; err != nil {
+			return err
+		}
+		return tx.Exec(
+			"UPDATE locations SET path = ? || substr(path, ?), depth = depth + ?, updated_at = NOW() WHERE path LIKE ? || '%'",
+			newPath, len(oldPath)+1, delta, oldPath,
+		).Error
+	})
+	if err != nil {
+		return err
+	}
+
+	location.ParentID = parentID
+	location.Path = newPath
+	location.Depth = depth
+	return nil
+}
+
+func (r *locationRepository) Delete(id uuid.UUID) error {
+	return r.db.Delete(&domain.Location{}, "id = ?", id).Error
+}
+
+func (r *locationRepository) CountReferences(id uuid.UUID) (int64, error) {
+	var count int64
+	err := r.db.Raw(`SELECT
+		(SELECT COUNT(*) FROM locations WHERE parent_id = ?) +
+		(SELECT COUNT(*) FROM tickets WHERE location_id = ?) +
+		(SELECT COUNT(*) FROM assets WHERE location_id = ?)`, id, id, id).Scan(&count).Error
+	return count, err
+}
+
+func (r *locationRepository) FindLegacyLocations() ([]LegacyLocation, error) {
+	var rows []LegacyLocation
+	err := r.db.Raw(`SELECT text, SUM(tickets) AS tickets, SUM(assets) AS assets FROM (
+		SELECT location AS text, COUNT(*) AS tickets, 0 AS assets FROM tickets
+			WHERE location_id IS NULL AND location <> '' AND deleted_at IS NULL GROUP BY location
+		UNION ALL
+		SELECT location AS text, 0 AS tickets, COUNT(*) AS assets FROM assets
+			WHERE location_id IS NULL AND location <> '' AND deleted_at IS NULL GROUP BY location
+	) legacy GROUP BY text ORDER BY text`).Scan(&rows).Error
+	return rows, err
+}
+
+func (r *locationRepository) AssignLegacyLocation(text string, locationID uuid.UUID) (int64, int64, error) {
+	var tickets, assets int64
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&domain.Ticket{}).
+			Where("location_id IS NULL AND location = ?", text).
+			UpdateColumn("location_id", locationID)
+		if result.Error != nil {
+			return result.Error
+		}
+		tickets = result.RowsAffected
+
+		result = tx.Model(&domain.Asset{}).
+			Where("location_id IS NULL AND location = ?", text).
+			UpdateColumn("location_id", locationID)
+		if result.Error != nil {
+			return result.Error
+		}
+		assets = result.RowsAffected
+		return nil
+	})
+	return tickets, assets, err
+}
@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/maintenance-system/api/internal/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type meterRepository struct {
+	db *gorm.DB
+}
+
+func NewMeterRepository(db *gorm.DB) MeterRepository {
+	return &meterRepository{db: db}
+}
+
+func orderRules(db *gorm.DB) *gorm.DB {
+	return db.Order("created_at")
+}
+
+func (r *meterRepository) Create(meter *domain.Meter) error {
+	return r.db.Omit(clause.Associations).Create(meter).Error
+}
+
+func (r *meterRepository) FindByID(id uuid.UUID) (*domain.Meter, error) {
+	var meter domain.Meter
+	if err := r.db.
+		Preload("Asset").
+		Preload("Rules", orderRules).
+		First(&meter, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &meter, nil
+}
+
+func (r *meterRepository) FindByAsset(assetID uuid.UUID) ([]domain.Meter, error) {
+	var meters []domain.Meter
+	err := r.db.
+		Preload("Rules", orderRules).
+		Where("asset_id = ?", assetID).
+		Order("name").
+		Find(&meters).Error
+	return meters, err
+}
+
+func (r *meterRepository) Lock(id uuid.UUID) (*domain.Meter, error) {
+	var meter domain.Meter
+	if err := r.db.
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		First(&meter, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &meter, nil
+}
+
+func (r *meterRepository) Update(meter *domain.Meter) error {
+	return r.db.Omit(clause.Associations, "LastValue", "LastReadAt").Save(meter).Error
+}
+
+func (r *meterRepository) SetLastReading(id uuid.UUID, value float64, at time.Time) error {
+	return r.db.Model(&domain.Meter{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"last_value":   value,
+			"last_read_at": at,
+		}).Error
+}
+
+func (r *meterRepository) Delete(id uuid.UUID) error {
+	return r.db.Delete(&domain.Meter{}, "id = ?", id).Error
+}
+
+func (r *meterRepository) CreateReading(reading *domain.MeterReading) error {
+	return r.db.Omit(clause.Associations).Create(reading).Error
+}
+
+func (r *meterRepository) FindReadings(meterID uuid.UUID, limit int) ([]domain.MeterReading, error) {
+	var readings []domain.MeterReading
+	err := r.db.
+		Preload("RecordedBy").
+		Where("meter_id = ?", meterID).
+		Order("read_at DESC").
+		Limit(limit).
+		Find(&readings).Error
+	return readings, err
+}
+
+func (r *meterRepository) CreateRule(rule *domain.MeterRule) error {
+	return r.db.Create(rule).Error
+}
+
+func (r *meterRepository) FindRuleByID(id uuid.UUID) (*domain.MeterRule, error) {
+	var rule domain.MeterRule
+	if err := r.db.First(&rule, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &rule, nil
+}
+
+func (r *meterRepository) LockRule(id uuid.UUID) (*domain.MeterRule, error) {
+	var rule domain.MeterRule
+	if err := r.db.
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		First(&rule, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &rule, nil
+}
+
+func (r *meterRepository) UpdateRule(rule *domain.MeterRule) error {
+	return r.db.Save(rule).Error
+}
+
+func (r *meterRepository) DeleteRule(id uuid.UUID) error {
+	return r.db.Delete(&domain.MeterRule{}, "id = ?", id).Error
+}
@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"database/sql"
+
+	"github.com/google/uuid"
+	"github.com/maintenance-system/api/internal/domain"
+	"gorm.io/gorm"
+)
+
+// priorityUsageSQL counts the rows that store a priority code (@code).
+const priorityUsageSQL = `SELECT
+	(SELECT COUNT(*) FROM tickets WHERE priority = @code) +
+	(SELECT COUNT(*) FROM sla_policies WHERE priority = @code) +
+	(SELECT COUNT(*) FROM escalation_rules WHERE priority = @code) +
+	(SELECT COUNT(*) FROM maintenance_schedules WHERE ticket_priority = @code) +
+	(SELECT COUNT(*) FROM meter_rules WHERE ticket_priority = @code) +
+	(SELECT COUNT(*) FROM ticket_categories WHERE default_priority = @code)`
+
+type priorityRepository struct {
+	db *gorm.DB
+}
+
+func NewPriorityRepository(db *gorm.DB) PriorityRepository {
+	return &priorityRepository{db: db}
+}
+
+func (r *priorityRepository) Create(priority *domain.Priority) error {
+	return r.db.Create(priority).Error
+}
+
+func (r *priorityRepository) FindByID(id uuid.UUID) (*domain.Priority, error) {
+	var priority domain.Priority
+	if err := r.db.Preload("SLAPolicy").First(&priority, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &priority, nil
+}
+
+func (r *priorityRepository) FindByCode(code domain.TicketPriority) (*domain.Priority, error) {
+	var priority domain.Priority
+	if err := r.db.First(&priority, "code = ?", code).Error; err != nil {
+		return nil, err
+	}
+	return &priority, nil
+}
+
+func (r *priorityRepository) FindAll() ([]domain.Priority, error) {
+	var priorities []domain.Priority
+	err := r.db.Preload("SLAPolicy").Order("rank, code").Find(&priorities).Error
+	return priorities, err
+}
+
+func (r *priorityRepository) FindNext(rank int) (*domain.Priority, error) {
+	var priority domain.Priority
+	if err := r.db.
+		Where("active AND rank > ?", rank).
+		Order("rank").
+		First(&priority).Error; err != nil {
+		return nil, err
+	}
+	return &priority, nil
+}
+
+func (r *priorityRepository) Update(priority *domain.Priority) error {
+	return r.db.Omit("SLAPolicy").Save(priority).Error
+}
+
+func (r *priorityRepository) Delete(id uuid.UUID) error {
+	return r.db.Delete(&domain.Priority{}, "id = ?", id).Error
+}
+
+func (r *priorityRepository) CountUsage(code domain.TicketPriority) (int64, error) {
+	var count int64
+	err := r.db.Raw(priorityUsageSQL, sql.Named("code", string(code))).Scan(&count).Error
+	return count, err
+}
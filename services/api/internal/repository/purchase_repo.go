@@ -0,0 +1,121 @@
+package repository
+
+import (
+	"github.com/google/uuid"
+	"github.com/maintenance-system/api/internal/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type purchaseRepository struct {
+	db *gorm.DB
+}
+
+func NewPurchaseRepository(db *gorm.DB) PurchaseRepository {
+	return &purchaseRepository{db: db}
+}
+
+func orderLines(db *gorm.DB) *gorm.DB {
+	return db.Order("position")
+}
+
+func (r *purchaseRepository) preload(db *gorm.DB) *gorm.DB {
+	return db.
+		Preload("Lines", orderLines).
+		Preload("Lines.Part").
+		Preload("RequestedBy").
+		Preload("ApprovedBy")
+}
+
+func (r *purchaseRepository) Create(request *domain.PurchaseRequest) error {
+	return r.db.Create(request).Error
+}
+
+func (r *purchaseRepository) FindByID(id uuid.UUID) (*domain.PurchaseRequest, error) {
+	var request domain.PurchaseRequest
+	if err := r.preload(r.db).First(&request, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &request, nil
+}
+
+func (r *purchaseRepository) Lock(id uuid.UUID) (*domain.PurchaseRequest, error) {
+	var request domain.PurchaseRequest
+	if err := r.preload(r.db.Clauses(clause.Locking{Strength: "UPDATE"})).
+		First(&request, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &request, nil
+}
+
+func (r *purchaseRepository) FindAll(filter PurchaseFilter) ([]domain.PurchaseRequest, int64, error) {
+	var requests []domain.PurchaseRequest
+	var total int64
+
+	query := r.db.Model(&domain.PurchaseRequest{})
+
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.TicketID != nil {
+		query = query.Where("ticket_id = ?", filter.TicketID)
+	}
+	if filter.Supplier != "" {
+		query = query.Where("supplier ILIKE ?", "%"+filter.Supplier+"%")
+	}
+
+	query.Count(&total)
+
+	if filter.Limit <= 0 {
+		filter.Limit = 20
+	}
+	if filter.Page <= 0 {
+		filter.Page = 1
+	}
+	offset := (filter.Page - 1) * filter.Limit
+
+	if err := r.preload(query).
+		Offset(offset).
+		Limit(filter.Limit).
+		Order("created_at DESC").
+		Find(&requests).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return requests, total, nil
+}
+
+func (r *purchaseRepository) Update(request *domain.PurchaseRequest, replaceLines bool) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Save(request).Error; err != nil {
+			return err
+		}
+		if !replaceLines {
+			return nil
+		}
+		if err := tx.Where("request_id = ?", request.ID).Delete(&domain.PurchaseRequestLine{}).Error; err != nil {
+			return err
+		}
+		if len(request.Lines) == 0 {
+			return nil
+		}
+		for i := range request.Lines {
+			request.Lines[i].ID = uuid.Nil
+			request.Lines[i].RequestID = request.ID
+		}
+		return tx.Omit(clause.Associations).Create(&request.Lines).Error
+	})
+}
+
+func (r *purchaseRepository) Delete(id uuid.UUID) error {
+	return r.db.Delete(&domain.PurchaseRequest{}, "id = ?", id).Error
+}
+
+func (r *purchaseRepository) CountOutstanding(ticketID, except uuid.UUID) (int64, error) {
+	var count int64
+	err := r.db.Model(&domain.PurchaseRequest{}).
+		Where("ticket_id = ? AND id <> ?", ticketID, except).
+		Where("status IN ?", []domain.PurchaseStatus{domain.PurchaseSubmitted, domain.PurchaseApproved, domain.PurchaseOrdered}).
+		Count(&count).Error
+	return count, err
+}
@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/maintenance-system/api/internal/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type scheduleRepository struct {
+	db *gorm.DB
+}
+
+func NewScheduleRepository(db *gorm.DB) ScheduleRepository {
+	return &scheduleRepository{db: db}
+}
+
+func (r *scheduleRepository) Create(schedule *domain.MaintenanceSchedule) error {
+	return r.db.Create(schedule).Error
+}
+
+func (r *scheduleRepository) FindByID(id uuid.UUID) (*domain.MaintenanceSchedule, error) {
+	var schedule domain.MaintenanceSchedule
+	if err := r.db.Preload("Assignee").First(&schedule, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &schedule, nil
+}
+
+func (r *scheduleRepository) FindAll(activeOnly bool) ([]domain.MaintenanceSchedule, error) {
+	var schedules []domain.MaintenanceSchedule
+	query := r.db.Preload("Assignee").Order("created_at")
+	if activeOnly {
+		query = query.Where("active = ?", true)
+	}
+	err := query.Find(&schedules).Error
+	return schedules, err
+}
+
+func (r *scheduleRepository) Update(schedule *domain.MaintenanceSchedule) error {
+	return r.db.Omit(clause.Associations).Save(schedule).Error
+}
+
+func (r *scheduleRepository) Delete(id uuid.UUID) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&domain.ScheduleOccurrence{}, "schedule_id = ?", id).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&domain.MaintenanceSchedule{}, "id = ?", id).Error
+	})
+}
+
+func (r *scheduleRepository) FindOccurrences(scheduleID uuid.UUID) ([]domain.ScheduleOccurrence, error) {
+	var occurrences []domain.ScheduleOccurrence
+	err := r.db.Where("schedule_id = ?", scheduleID).Order("occurs_at DESC").Find(&occurrences).Error
+	return occurrences, err
+}
+
+func (r *scheduleRepository) LastOccurrence(scheduleID uuid.UUID) (*time.Time, error) {
+	var last *time.Time
+	err := r.db.Model(&domain.ScheduleOccurrence{}).
+		Select("MAX(occurs_at)").
+		Where("schedule_id = ?", scheduleID).
+		Scan(&last).Error
+	return last, err
+}
+
+// ClaimOccurrence records the ticket generated for an occurrence. It
+// returns false if the occurrence was already generated, e.g. by another
+// API instance.
+func (r *scheduleRepository) ClaimOccurrence(occurrence *domain.ScheduleOccurrence) (bool, error) {
+	result := r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(occurrence)
+	return result.RowsAffected > 0, result.Error
+}
@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/maintenance-system/api/internal/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type slaPolicyRepository struct {
+	db *gorm.DB
+}
+
+func NewSLAPolicyRepository(db *gorm.DB) SLAPolicyRepository {
+	return &slaPolicyRepository{db: db}
+}
+
+func (r *slaPolicyRepository) Create(policy *domain.SLAPolicy) error {
+	return r.db.Create(policy).Error
+}
+
+func (r *slaPolicyRepository) FindByID(id uuid.UUID) (*domain.SLAPolicy, error) {
+	var policy domain.SLAPolicy
+	if err := r.db.First(&policy, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &policy, nil
+}
+
+func (r *slaPolicyRepository) FindAll() ([]domain.SLAPolicy, error) {
+	var policies []domain.SLAPolicy
+	err := r.db.Order("priority, category").Find(&policies).Error
+	return policies, err
+}
+
+// FindMatch returns the policy for priority and category, falling back to
+// the priority's catch-all policy (empty category) and then to the policy
+// linked to the priority. It returns nil if no policy applies.
+func (r *slaPolicyRepository) FindMatch(priority domain.TicketPriority, category domain.TicketCategory) (*domain.SLAPolicy, error) {
+	var policy domain.SLAPolicy
+	if err := r.db.
+		Where("(priority = @priority AND (category = @category OR category = '')) OR "+
+			"id = (SELECT sla_policy_id FROM ticket_priorities WHERE code = @priority)",
+			sql.Named("priority", priority), sql.Named("category", category)).
+		Clauses(clause.OrderBy{Expression: clause.Expr{
+			SQL:                "CASE WHEN priority = ? AND category = ? THEN 0 WHEN priority = ? THEN 1 ELSE 2 END",
+			Vars:               []interface{}{priority, category, priority},
+			WithoutParentheses: true,
+		}}).
+		Take(&policy).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &policy, nil
+}
+
+func (r *slaPolicyRepository) Update(policy *domain.SLAPolicy) error {
+	return r.db.Save(policy).Error
+}
+
+func (r *slaPolicyRepository) Delete(id uuid.UUID) error {
+	return r.db.Delete(&domain.SLAPolicy{}, "id = ?", id).Error
+}
@@ -0,0 +1,107 @@
+package repository
+
+import (
+	"github.com/google/uuid"
+	"github.com/maintenance-system/api/internal/domain"
+	"gorm.io/gorm"
+)
+
+type tagRepository struct {
+	db *gorm.DB
+}
+
+func NewTagRepository(db *gorm.DB) TagRepository {
+	return &tagRepository{db: db}
+}
+
+func (r *tagRepository) Create(tag *domain.Tag) error {
+	return r.db.Create(tag).Error
+}
+
+func (r *tagRepository) FindByID(id uuid.UUID) (*domain.Tag, error) {
+	var tag domain.Tag
+	if err := r.db.First(&tag, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &tag, nil
+}
+
+func (r *tagRepository) FindByIDs(ids []uuid.UUID) ([]domain.Tag, error) {
+	var tags []domain.Tag
+	err := r.db.Where("id IN ?", ids).Order("name").Find(&tags).Error
+	return tags, err
+}
+
+func (r *tagRepository) FindByName(name string) (*domain.Tag, error) {
+	var tag domain.Tag
+	if err := r.db.First(&tag, "LOWER(name) = LOWER(?)", name).Error; err != nil {
+		return nil, err
+	}
+	return &tag, nil
+}
+
+func (r *tagRepository) FindAll() ([]domain.Tag, error) {
+	var tags []domain.Tag
+	err := r.db.Order("name").Find(&tags).Error
+	return tags, err
+}
+
+func (r *tagRepository) Update(tag *domain.Tag) error {
+	return r.db.Save(tag).Error
+}
+
+func (r *tagRepository) Delete(id uuid.UUID) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&domain.TicketTag{}, "tag_id = ?", id).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&domain.Tag{}, "id = ?", id).Error
+	})
+}
+
+func (r *tagRepository) Merge(sourceIDs []uuid.UUID, targetID uuid.UUID) (added, removed []domain.TicketTag, err error) {
+	err = r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Raw(`INSERT INTO ticket_tags (ticket_id, tag_id)
+			SELECT DISTINCT ticket_id, ? FROM ticket_tags WHERE tag_id IN ?
+			ON CONFLICT DO NOTHING
+			RETURNING ticket_id, tag_id`, targetID, sourceIDs).Scan(&added).Error; err != nil {
+			return err
+		}
+		if err := tx.Raw(`DELETE FROM ticket_tags WHERE tag_id IN ?
+			RETURNING ticket_id, tag_id`, sourceIDs).Scan(&removed).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&domain.Tag{}, "id IN ?", sourceIDs).Error
+	})
+	return added, removed, err
+}
+
+func (r *tagRepository) Tag(ticketIDs, tagIDs []uuid.UUID) ([]domain.TicketTag, error) {
+	var added []domain.TicketTag
+	err := r.db.Raw(`INSERT INTO ticket_tags (ticket_id, tag_id)
+		SELECT t.id, g.id FROM tickets t CROSS JOIN tags g
+		WHERE t.id IN ? AND t.deleted_at IS NULL AND g.id IN ?
+		ON CONFLICT DO NOTHING
+		RETURNING ticket_id, tag_id`, ticketIDs, tagIDs).Scan(&added).Error
+	return added, err
+}
+
+func (r *tagRepository) Untag(ticketIDs, tagIDs []uuid.UUID) ([]domain.TicketTag, error) {
+	var removed []domain.TicketTag
+	err := r.db.Raw(`DELETE FROM ticket_tags
+		WHERE ticket_id IN ? AND tag_id IN ?
+		RETURNING ticket_id, tag_id`, ticketIDs, tagIDs).Scan(&removed).Error
+	return removed, err
+}
+
+func (r *tagRepository) Counts() ([]TagCount, error) {
+	var counts []TagCount
+	err := r.db.Table("tags g").
+		Select("g.id, g.name, g.color, COUNT(t.id) AS tickets").
+		Joins("LEFT JOIN ticket_tags tt ON tt.tag_id = g.id").
+		Joins("LEFT JOIN tickets t ON t.id = tt.ticket_id AND t.deleted_at IS NULL").
+		Group("g.id, g.name, g.color").
+		Order("tickets DESC, g.name").
+		Scan(&counts).Error
+	return counts, err
+}
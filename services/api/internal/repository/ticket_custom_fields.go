@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/maintenance-system/api/internal/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// fieldComparisons maps the ordering operators to SQL.
+var fieldComparisons = map[string]string{
+	FieldOpLt:  "<",
+	FieldOpLte: "<=",
+	FieldOpGt:  ">",
+	FieldOpGte: ">=",
+}
+
+// whereCustomField narrows the query to tickets whose custom field meets
+// the filter. Equality and multi-select matches use jsonb containment so
+// the GIN index on custom_fields serves them.
+func whereCustomField(query *gorm.DB, filter CustomFieldFilter) *gorm.DB {
+	switch filter.Op {
+	case FieldOpEq:
+		return query.Where("custom_fields @> ?::jsonb", containsJSON(filter.Key, filter.Value))
+	case FieldOpNe:
+		return query.Where("NOT (custom_fields @> ?::jsonb)", containsJSON(filter.Key, filter.Value))
+	case FieldOpContains:
+		if filter.Type == domain.FieldMultiSelect {
+			return query.Where("custom_fields @> ?::jsonb", containsJSON(filter.Key, []interface{}{filter.Value}))
+		}
+		return query.Where("custom_fields->>? ILIKE ?", filter.Key, "%"+fmt.Sprint(filter.Value)+"%")
+	case FieldOpExists:
+		if exists, _ := filter.Value.(bool); !exists {
+			return query.Where("NOT jsonb_exists(custom_fields, ?)", filter.Key)
+		}
+		return query.Where("jsonb_exists(custom_fields, ?)", filter.Key)
+	}
+	if op, ok := fieldComparisons[filter.Op]; ok {
+		return query.Where(customFieldSQL(filter.Type)+" "+op+" ?", filter.Key, filter.Value)
+	}
+	return query
+}
+
+// ticketOrder sorts by the custom field if given, newest first otherwise
+// and among equal values.
+func ticketOrder(sort *CustomFieldSort) clause.OrderBy {
+	if sort == nil {
+		return clause.OrderBy{Columns: []clause.OrderByColumn{{Column: clause.Column{Name: "created_at"}, Desc: true}}}
+	}
+	direction := "ASC"
+	if sort.Desc {
+		direction = "DESC"
+	}
+	return clause.OrderBy{Expression: clause.Expr{
+		SQL:                customFieldSQL(sort.Type) + " " + direction + " NULLS LAST, created_at DESC",
+		Vars:               []interface{}{sort.Key},
+		WithoutParentheses: true,
+	}}
+}
+
+// customFieldSQL reads a custom field, cast so that numbers and dates
+// compare as such. The field key is its one parameter.
+func customFieldSQL(fieldType domain.CustomFieldType) string {
+	switch fieldType {
+	case domain.FieldNumber:
+		return "(custom_fields->>?)::numeric"
+	case domain.FieldDate:
+		return "(custom_fields->>?)::date"
+	case domain.FieldBoolean:
+		return "(custom_fields->>?)::boolean"
+	default:
+		return "(custom_fields->>?)"
+	}
+}
+
+func containsJSON(key string, value interface{}) string {
+	b, _ := json.Marshal(map[string]interface{}{key: value})
+	return string(b)
+}
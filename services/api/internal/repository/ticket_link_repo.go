@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"github.com/google/uuid"
+	"github.com/maintenance-system/api/internal/domain"
+	"gorm.io/gorm"
+)
+
+type ticketLinkRepository struct {
+	db *gorm.DB
+}
+
+func NewTicketLinkRepository(db *gorm.DB) TicketLinkRepository {
+	return &ticketLinkRepository{db: db}
+}
+
+func (r *ticketLinkRepository) Create(link *domain.TicketLink) error {
+	return r.db.Create(link).Error
+}
+
+func (r *ticketLinkRepository) FindByID(id uuid.UUID) (*domain.TicketLink, error) {
+	var link domain.TicketLink
+	if err := r.db.First(&link, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &link, nil
+}
+
+func (r *ticketLinkRepository) FindByTicket(ticketID uuid.UUID) ([]domain.TicketLink, error) {
+	var links []domain.TicketLink
+	err := r.db.
+		Preload("Source").
+		Preload("Target").
+		Where("source_id = ? OR target_id = ?", ticketID, ticketID).
+		Order("created_at").
+		Find(&links).Error
+	return links, err
+}
+
+func (r *ticketLinkRepository) Exists(sourceID, targetID uuid.UUID, linkType domain.TicketLinkType) (bool, error) {
+	var count int64
+	err := r.db.Model(&domain.TicketLink{}).
+		Where("source_id = ? AND target_id = ? AND type = ?", sourceID, targetID, linkType).
+		Count(&count).Error
+	return count > 0, err
+}
+
+func (r *ticketLinkRepository) Delete(id uuid.UUID) error {
+	return r.db.Delete(&domain.TicketLink{}, "id = ?", id).Error
+}
+
+func (r *ticketLinkRepository) Reaches(fromID, toID uuid.UUID, linkType domain.TicketLinkType) (bool, error) {
+	var found bool
+	err := r.db.Raw(`WITH RECURSIVE reach(id) AS (
+			SELECT target_id FROM ticket_links WHERE source_id = ? AND type = ?
+			UNION
+			SELECT l.target_id FROM ticket_links l JOIN reach ON l.source_id = reach.id WHERE l.type = ?
+		)
+		SELECT EXISTS (SELECT 1 FROM reach WHERE id = ?)`,
+		fromID, linkType, linkType, toID).
+		Scan(&found).Error
+	return found, err
+}
+
+func (r *ticketLinkRepository) CountOpenBlockers(ticketID uuid.UUID) (int64, error) {
+	var count int64
+	err := r.db.Table("ticket_links l").
+		Joins("JOIN tickets t ON t.id = l.source_id").
+		Where("l.target_id = ? AND l.type = ?", ticketID, domain.LinkBlocks).
+		Where("t.deleted_at IS NULL AND t.status NOT IN ?",
+			[]domain.TicketStatus{domain.StatusResolved, domain.StatusClosed}).
+		Count(&count).Error
+	return count, err
+}
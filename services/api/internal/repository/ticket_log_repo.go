@@ -8,7 +8,10 @@ import (
 
 type TicketLogRepository interface {
 	Create(log *domain.TicketLog) error
+	CreateBatch(logs []domain.TicketLog) error
 	FindByTicketID(ticketID uuid.UUID) ([]domain.TicketLog, error)
+	// Reassign moves every log entry of one ticket to another.
+	Reassign(fromTicketID, toTicketID uuid.UUID) error
 }
 
 type ticketLogRepository struct {
@@ -23,6 +26,13 @@ func (r *ticketLogRepository) Create(log *domain.TicketLog) error {
 	return r.db.Create(log).Error
 }
 
+func (r *ticketLogRepository) CreateBatch(logs []domain.TicketLog) error {
+	if len(logs) == 0 {
+		return nil
+	}
+	return r.db.Create(&logs).Error
+}
+
 func (r *ticketLogRepository) FindByTicketID(ticketID uuid.UUID) ([]domain.TicketLog, error) {
 	var logs []domain.TicketLog
 	err := r.db.Where("ticket_id = ?", ticketID).
@@ -31,3 +41,9 @@ func (r *ticketLogRepository) FindByTicketID(ticketID uuid.UUID) ([]domain.Ticke
 		Find(&logs).Error
 	return logs, err
 }
+
+func (r *ticketLogRepository) Reassign(fromTicketID, toTicketID uuid.UUID) error {
+	return r.db.Model(&domain.TicketLog{}).
+		Where("ticket_id = ?", fromTicketID).
+		Update("ticket_id", toTicketID).Error
+}
@@ -1,9 +1,18 @@
 package repository
 
 import (
+	"database/sql"
+
 	"github.com/google/uuid"
 	"github.com/maintenance-system/api/internal/domain"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// SQL counterparts of domain.Ticket.EvaluateSLA, used for filtering.
+const (
+	responseBreachedSQL   = "response_due_at IS NOT NULL AND COALESCE(first_responded_at, sla_paused_at, NOW()) > response_due_at"
+	resolutionBreachedSQL = "due_date IS NOT NULL AND COALESCE(resolved_at, closed_at, sla_paused_at, NOW()) > due_date"
 )
 
 type ticketRepository struct {
@@ -18,19 +27,135 @@ func (r *ticketRepository) Create(ticket *domain.Ticket) error {
 	return r.db.Create(ticket).Error
 }
 
-func (r *ticketRepository) FindByID(id uuid.UUID) (*domain.Ticket, error) {
+func (r *ticketRepository) FindByID(id uuid.UUID, opts ...FindOption) (*domain.Ticket, error) {
+	var options findOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	query := r.db
+	if options.forUpdate {
+		query = query.Clauses(clause.Locking{Strength: "UPDATE"})
+	}
+
 	var ticket domain.Ticket
-	if err := r.db.
+	if err := query.
 		Preload("CreatedBy").
 		Preload("AssignedTo").
+		Preload("SLAPolicy").
+		Preload("Asset").
+		Preload("Contract").
+		Preload("Vendor").
+		Preload("Department").
+		Preload("LocationRef").
+		Preload("Tags", orderTags).
 		Preload("Comments.User").
 		Preload("Attachments").
+		Preload("Checklists", func(db *gorm.DB) *gorm.DB { return db.Order("created_at, name") }).
+		Preload("Checklists.Items", orderItems).
 		First(&ticket, "id = ?", id).Error; err != nil {
 		return nil, err
 	}
+
+	if options.childTree {
+		if err := r.loadChildTree(&ticket); err != nil {
+			return nil, err
+		}
+		return &ticket, nil
+	}
+	if err := r.fillProgress([]*domain.Ticket{&ticket}); err != nil {
+		return nil, err
+	}
 	return &ticket, nil
 }
 
+// descendantsSQL selects the IDs of every ticket below the given one.
+// UNION rather than UNION ALL stops at a cycle.
+const descendantsSQL = `WITH RECURSIVE tree AS (
+	SELECT id FROM tickets WHERE parent_id = @id AND deleted_at IS NULL
+	UNION
+	SELECT t.id FROM tickets t JOIN tree ON t.parent_id = tree.id WHERE t.deleted_at IS NULL
+) SELECT id FROM tree WHERE id <> @id`
+
+// loadChildTree fetches all of the ticket's descendants in one query and
+// nests them under their parents.
+func (r *ticketRepository) loadChildTree(ticket *domain.Ticket) error {
+	var descendants []domain.Ticket
+	if err := r.db.
+		Preload("AssignedTo").
+		Where("id IN ("+descendantsSQL+")", sql.Named("id", ticket.ID)).
+		Order("created_at").
+		Find(&descendants).Error; err != nil {
+		return err
+	}
+
+	byParent := make(map[uuid.UUID][]domain.Ticket)
+	for _, t := range descendants {
+		byParent[*t.ParentID] = append(byParent[*t.ParentID], t)
+	}
+	attachChildren(ticket, byParent)
+	return nil
+}
+
+func attachChildren(ticket *domain.Ticket, byParent map[uuid.UUID][]domain.Ticket) {
+	ticket.Children = byParent[ticket.ID]
+	if len(ticket.Children) == 0 {
+		return
+	}
+	progress := domain.TicketProgress{Total: int64(len(ticket.Children))}
+	for i := range ticket.Children {
+		child := &ticket.Children[i]
+		attachChildren(child, byParent)
+		if child.Status == domain.StatusResolved || child.Status == domain.StatusClosed {
+			progress.Resolved++
+		}
+	}
+	ticket.Progress = &progress
+}
+
+func (r *ticketRepository) Progress(ids []uuid.UUID) (map[uuid.UUID]domain.TicketProgress, error) {
+	progress := make(map[uuid.UUID]domain.TicketProgress)
+	if len(ids) == 0 {
+		return progress, nil
+	}
+
+	var rows []struct {
+		ParentID uuid.UUID
+		Total    int64
+		Resolved int64
+	}
+	if err := r.db.Model(&domain.Ticket{}).
+		Select("parent_id, COUNT(*) AS total, COUNT(*) FILTER (WHERE status IN ?) AS resolved",
+			[]domain.TicketStatus{domain.StatusResolved, domain.StatusClosed}).
+		Where("parent_id IN ?", ids).
+		Group("parent_id").
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		progress[row.ParentID] = domain.TicketProgress{Total: row.Total, Resolved: row.Resolved}
+	}
+	return progress, nil
+}
+
+// fillProgress sets Progress on those of the tickets that have sub-tickets.
+func (r *ticketRepository) fillProgress(tickets []*domain.Ticket) error {
+	ids := make([]uuid.UUID, len(tickets))
+	for i, t := range tickets {
+		ids[i] = t.ID
+	}
+	progress, err := r.Progress(ids)
+	if err != nil {
+		return err
+	}
+	for _, t := range tickets {
+		if p, ok := progress[t.ID]; ok {
+			t.Progress = &p
+		}
+	}
+	return nil
+}
+
 func (r *ticketRepository) FindAll(filter TicketFilter) ([]domain.Ticket, int64, error) {
 	var tickets []domain.Ticket
 	var total int64
@@ -50,13 +175,44 @@ func (r *ticketRepository) FindAll(filter TicketFilter) ([]domain.Ticket, int64,
 	if filter.AssignedToID != nil {
 		query = query.Where("assigned_to_id = ?", filter.AssignedToID)
 	}
+	if filter.LocationID != nil {
+		query = query.Where("location_id IN ("+subtreeSQL+")", filter.LocationID)
+	}
+	if filter.AssetID != nil {
+		query = query.Where("asset_id = ?", filter.AssetID)
+	}
 	if filter.CreatedByID != nil {
 		query = query.Where("created_by_id = ?", filter.CreatedByID)
 	}
+	if filter.DepartmentID != nil {
+		query = query.Where("department_id = ?", filter.DepartmentID)
+	}
+	if filter.ParentID != nil {
+		query = query.Where("parent_id = ?", filter.ParentID)
+	}
+	if len(filter.TagsAny) > 0 {
+		query = query.Where("id IN (SELECT ticket_id FROM ticket_tags WHERE tag_id IN ?)", filter.TagsAny)
+	}
+	if tags := distinctIDs(filter.TagsAll); len(tags) > 0 {
+		query = query.Where(`id IN (SELECT ticket_id FROM ticket_tags WHERE tag_id IN ?
+			GROUP BY ticket_id HAVING COUNT(*) = ?)`, tags, len(tags))
+	}
+	if len(filter.TagsNone) > 0 {
+		query = query.Where("id NOT IN (SELECT ticket_id FROM ticket_tags WHERE tag_id IN ?)", filter.TagsNone)
+	}
 	if filter.Search != "" {
 		search := "%" + filter.Search + "%"
 		query = query.Where("title ILIKE ? OR description ILIKE ?", search, search)
 	}
+	if filter.ResponseBreached != nil {
+		query = whereFlag(query, responseBreachedSQL, *filter.ResponseBreached)
+	}
+	if filter.ResolutionBreached != nil {
+		query = whereFlag(query, resolutionBreachedSQL, *filter.ResolutionBreached)
+	}
+	for _, field := range filter.CustomFields {
+		query = whereCustomField(query, field)
+	}
 
 	query.Count(&total)
 
@@ -72,46 +228,132 @@ func (r *ticketRepository) FindAll(filter TicketFilter) ([]domain.Ticket, int64,
 	if err := query.
 		Preload("CreatedBy").
 		Preload("AssignedTo").
+		Preload("Tags", orderTags).
 		Offset(offset).
 		Limit(filter.Limit).
-		Order("created_at DESC").
+		Clauses(ticketOrder(filter.SortBy)).
 		Find(&tickets).Error; err != nil {
 		return nil, 0, err
 	}
 
+	page := make([]*domain.Ticket, len(tickets))
+	for i := range tickets {
+		page[i] = &tickets[i]
+	}
+	if err := r.fillProgress(page); err != nil {
+		return nil, 0, err
+	}
+
 	return tickets, total, nil
 }
 
+func (r *ticketRepository) FindDuplicateCandidates(criteria DuplicateCriteria) ([]domain.Ticket, error) {
+	query := r.db.Model(&domain.Ticket{}).
+		Where("status NOT IN ?", []domain.TicketStatus{domain.StatusResolved, domain.StatusClosed}).
+		Where("category = ? AND created_at >= ?", criteria.Category, criteria.Since)
+
+	place := r.db.Where("1 = 0")
+	narrowed := false
+	if criteria.AssetID != nil {
+		place = place.Or("asset_id = ?", criteria.AssetID)
+		narrowed = true
+	}
+	if criteria.LocationID != nil {
+		place = place.Or("location_id = ?", criteria.LocationID)
+		narrowed = true
+	}
+	if criteria.Location != "" {
+		place = place.Or("LOWER(location) = LOWER(?)", criteria.Location)
+		narrowed = true
+	}
+	if narrowed {
+		query = query.Where(place)
+	}
+
+	if criteria.Limit <= 0 {
+		criteria.Limit = 200
+	}
+
+	var tickets []domain.Ticket
+	err := query.
+		Preload("CreatedBy").
+		Preload("AssignedTo").
+		Order("created_at DESC").
+		Limit(criteria.Limit).
+		Find(&tickets).Error
+	return tickets, err
+}
+
+// whereFlag filters on a boolean SQL condition being true or false.
+func whereFlag(query *gorm.DB, condition string, want bool) *gorm.DB {
+	if want {
+		return query.Where(condition)
+	}
+	return query.Where("NOT (" + condition + ")")
+}
+
 func (r *ticketRepository) Update(ticket *domain.Ticket) error {
-	return r.db.Save(ticket).Error
+	// Preloaded relations are read-only here; saving them would overwrite
+	// foreign keys such as AssignedToID with the stale association.
+	// Parts and labor costs are only changed through AddPartsCost and
+	// AddLaborCost.
+	return r.db.Omit(clause.Associations, "parts_cost", "labor_cost").Save(ticket).Error
+}
+
+func (r *ticketRepository) AddPartsCost(id uuid.UUID, delta float64) error {
+	return r.db.Model(&domain.Ticket{}).Where("id = ?", id).
+		Update("parts_cost", gorm.Expr("parts_cost + ?", delta)).Error
+}
+
+func (r *ticketRepository) AddLaborCost(id uuid.UUID, delta float64) error {
+	return r.db.Model(&domain.Ticket{}).Where("id = ?", id).
+		Update("labor_cost", gorm.Expr("labor_cost + ?", delta)).Error
 }
 
 func (r *ticketRepository) Delete(id uuid.UUID) error {
 	return r.db.Delete(&domain.Ticket{}, "id = ?", id).Error
 }
 
-func (r *ticketRepository) GetStats() (map[string]int64, error) {
-	var total, open, inProgress, resolved int64
+func (r *ticketRepository) GetStats() (*TicketStats, error) {
+	var stats TicketStats
 
-	if err := r.db.Model(&domain.Ticket{}).Count(&total).Error; err != nil {
+	if err := r.db.Model(&domain.Ticket{}).Count(&stats.Total).Error; err != nil {
 		return nil, err
 	}
-	if err := r.db.Model(&domain.Ticket{}).Where("status = ?", "OPEN").Count(&open).Error; err != nil {
+	if err := r.db.Model(&domain.Ticket{}).Where("status = ?", "OPEN").Count(&stats.Open).Error; err != nil {
 		return nil, err
 	}
-	if err := r.db.Model(&domain.Ticket{}).Where("status = ?", "IN_PROGRESS").Count(&inProgress).Error; err != nil {
+	if err := r.db.Model(&domain.Ticket{}).Where("status = ?", "IN_PROGRESS").Count(&stats.InProgress).Error; err != nil {
 		return nil, err
 	}
-	if err := r.db.Model(&domain.Ticket{}).Where("status = ?", "RESOLVED").Count(&resolved).Error; err != nil {
+	if err := r.db.Model(&domain.Ticket{}).Where("status = ?", "RESOLVED").Count(&stats.Resolved).Error; err != nil {
 		return nil, err
 	}
 
-	return map[string]int64{
-		"total":      total,
-		"open":       open,
-		"inProgress": inProgress,
-		"resolved":   resolved,
-	}, nil
+	tags, err := NewTagRepository(r.db).Counts()
+	if err != nil {
+		return nil, err
+	}
+	stats.Tags = tags
+
+	return &stats, nil
+}
+
+func orderTags(db *gorm.DB) *gorm.DB {
+	return db.Order("name")
+}
+
+// distinctIDs drops repeated IDs, keeping the first of each.
+func distinctIDs(ids []uuid.UUID) []uuid.UUID {
+	seen := make(map[uuid.UUID]bool, len(ids))
+	var out []uuid.UUID
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			out = append(out, id)
+		}
+	}
+	return out
 }
 
 // Comment Repository
@@ -142,3 +384,9 @@ func (r *commentRepository) FindByTicketID(ticketID uuid.UUID) ([]domain.Comment
 func (r *commentRepository) Delete(id uuid.UUID) error {
 	return r.db.Delete(&domain.Comment{}, "id = ?", id).Error
 }
+
+func (r *commentRepository) Reassign(fromTicketID, toTicketID uuid.UUID) error {
+	return r.db.Model(&domain.Comment{}).
+		Where("ticket_id = ?", fromTicketID).
+		Update("ticket_id", toTicketID).Error
+}
@@ -0,0 +1,147 @@
+package repository
+
+import (
+	"github.com/google/uuid"
+	"github.com/maintenance-system/api/internal/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type timeEntryRepository struct {
+	db *gorm.DB
+}
+
+func NewTimeEntryRepository(db *gorm.DB) TimeEntryRepository {
+	return &timeEntryRepository{db: db}
+}
+
+func (r *timeEntryRepository) Create(entry *domain.TimeEntry) error {
+	return r.db.Omit(clause.Associations).Create(entry).Error
+}
+
+func (r *timeEntryRepository) StartTimer(entry *domain.TimeEntry) (bool, error) {
+	// idx_time_entry_running allows one entry without ended_at per user.
+	result := r.db.Omit(clause.Associations).Clauses(clause.OnConflict{DoNothing: true}).Create(entry)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r *timeEntryRepository) FindByID(id uuid.UUID) (*domain.TimeEntry, error) {
+	var entry domain.TimeEntry
+	if err := r.db.Preload("User").First(&entry, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+func (r *timeEntryRepository) Lock(id uuid.UUID) (*domain.TimeEntry, error) {
+	var entry domain.TimeEntry
+	if err := r.db.
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		First(&entry, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+func (r *timeEntryRepository) FindByTicketID(ticketID uuid.UUID) ([]domain.TimeEntry, error) {
+	var entries []domain.TimeEntry
+	err := r.db.Preload("User").
+		Where("ticket_id = ?", ticketID).
+		Order("started_at DESC").
+		Find(&entries).Error
+	return entries, err
+}
+
+func (r *timeEntryRepository) FindRunning(userID uuid.UUID) (*domain.TimeEntry, error) {
+	var entry domain.TimeEntry
+	if err := r.db.First(&entry, "user_id = ? AND ended_at IS NULL", userID).Error; err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+func (r *timeEntryRepository) StopTimer(entry *domain.TimeEntry) (bool, error) {
+	result := r.db.Model(&domain.TimeEntry{}).
+		Where("id = ? AND ended_at IS NULL", entry.ID).
+		Updates(map[string]interface{}{
+			"ended_at":         entry.EndedAt,
+			"duration_seconds": entry.DurationSeconds,
+			"cost":             entry.Cost,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r *timeEntryRepository) Update(entry *domain.TimeEntry) error {
+	return r.db.Omit(clause.Associations).Save(entry).Error
+}
+
+func (r *timeEntryRepository) Delete(id uuid.UUID) error {
+	return r.db.Delete(&domain.TimeEntry{}, "id = ?", id).Error
+}
+
+func (r *timeEntryRepository) Summarize(filter TimeSummaryFilter) ([]TimeSummary, error) {
+	query := r.db.Table("time_entries te").
+		Joins("JOIN users u ON u.id = te.user_id").
+		Where("te.ended_at IS NOT NULL")
+
+	if filter.From != nil {
+		query = query.Where("te.started_at >= ?", filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("te.started_at < ?", filter.To)
+	}
+	if filter.UserID != nil {
+		query = query.Where("te.user_id = ?", filter.UserID)
+	}
+	if filter.TicketID != nil {
+		query = query.Where("te.ticket_id = ?", filter.TicketID)
+	}
+
+	var key, name string
+	switch filter.GroupBy {
+	case GroupByDepartment:
+		key = "COALESCE(u.department, '')"
+		name = key
+	case GroupByTicket:
+		query = query.Joins("JOIN tickets t ON t.id = te.ticket_id")
+		key, name = "t.id::text", "t.title"
+	default:
+		key, name = "u.id::text", "u.name"
+	}
+
+	var summaries []TimeSummary
+	err := query.
+		Select(key + ` AS key, ` + name + ` AS name,
+			COUNT(*) AS entries,
+			COALESCE(SUM(te.duration_seconds), 0) AS seconds,
+			COALESCE(SUM(CASE WHEN te.billable THEN te.duration_seconds ELSE 0 END), 0) AS billable_seconds,
+			COALESCE(SUM(te.cost), 0) AS labor_cost`).
+		Group(key + ", " + name).
+		Order("labor_cost DESC, seconds DESC").
+		Scan(&summaries).Error
+	return summaries, err
+}
+
+func (r *timeEntryRepository) FindRates() ([]domain.LaborRate, error) {
+	var rates []domain.LaborRate
+	err := r.db.Order("role").Find(&rates).Error
+	return rates, err
+}
+
+func (r *timeEntryRepository) FindRate(role domain.UserRole) (*domain.LaborRate, error) {
+	var rate domain.LaborRate
+	if err := r.db.First(&rate, "role = ?", role).Error; err != nil {
+		return nil, err
+	}
+	return &rate, nil
+}
+
+func (r *timeEntryRepository) SaveRate(rate *domain.LaborRate) error {
+	return r.db.Save(rate).Error
+}
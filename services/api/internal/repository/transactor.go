@@ -0,0 +1,58 @@
+package repository
+
+import "gorm.io/gorm"
+
+// Repositories groups the repositories that can take part in a transaction.
+type Repositories struct {
+	Tickets     TicketRepository
+	Comments    CommentRepository
+	TicketLogs  TicketLogRepository
+	Attachments AttachmentRepository
+	Escalations EscalationRepository
+	Schedules   ScheduleRepository
+	Inventory   InventoryRepository
+	TimeEntries TimeEntryRepository
+	Approvals   ApprovalRepository
+	Checklists  ChecklistRepository
+	Meters      MeterRepository
+	Purchases   PurchaseRepository
+	Links       TicketLinkRepository
+	Tags        TagRepository
+	Vendors     VendorRepository
+}
+
+// Transactor runs fn with repositories bound to a single database
+// transaction. The transaction is rolled back if fn returns an error.
+type Transactor interface {
+	WithinTransaction(fn func(repos Repositories) error) error
+}
+
+type gormTransactor struct {
+	db *gorm.DB
+}
+
+func NewTransactor(db *gorm.DB) Transactor {
+	return &gormTransactor{db: db}
+}
+
+func (t *gormTransactor) WithinTransaction(fn func(repos Repositories) error) error {
+	return t.db.Transaction(func(tx *gorm.DB) error {
+		return fn(Repositories{
+			Tickets:     NewTicketRepository(tx),
+			Comments:    NewCommentRepository(tx),
+			TicketLogs:  NewTicketLogRepository(tx),
+			Attachments: NewAttachmentRepository(tx),
+			Escalations: NewEscalationRepository(tx),
+			Schedules:   NewScheduleRepository(tx),
+			Inventory:   NewInventoryRepository(tx),
+			TimeEntries: NewTimeEntryRepository(tx),
+			Approvals:   NewApprovalRepository(tx),
+			Checklists:  NewChecklistRepository(tx),
+			Meters:      NewMeterRepository(tx),
+			Purchases:   NewPurchaseRepository(tx),
+			Links:       NewTicketLinkRepository(tx),
+			Tags:        NewTagRepository(tx),
+			Vendors:     NewVendorRepository(tx),
+		})
+	})
+}
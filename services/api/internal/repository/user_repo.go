@@ -48,6 +48,18 @@ func (r *userRepository) FindAll(page, limit int) ([]domain.User, int64, error)
 	return users, total, nil
 }
 
+func (r *userRepository) FindByRole(role domain.UserRole) ([]domain.User, error) {
+	var users []domain.User
+	err := r.db.Where("role = ? AND status = ?", role, domain.StatusActive).Find(&users).Error
+	return users, err
+}
+
+func (r *userRepository) FindDepartmentHeads(department string) ([]domain.User, error) {
+	var users []domain.User
+	err := r.db.Where("department = ? AND department_head = ? AND status = ?", department, true, domain.StatusActive).Find(&users).Error
+	return users, err
+}
+
 func (r *userRepository) Update(user *domain.User) error {
 	return r.db.Save(user).Error
 }
@@ -0,0 +1,139 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/maintenance-system/api/internal/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type vendorRepository struct {
+	db *gorm.DB
+}
+
+func NewVendorRepository(db *gorm.DB) VendorRepository {
+	return &vendorRepository{db: db}
+}
+
+func orderContacts(db *gorm.DB) *gorm.DB {
+	return db.Order("is_primary DESC, name")
+}
+
+func (r *vendorRepository) Create(vendor *domain.Vendor) error {
+	return r.db.Create(vendor).Error
+}
+
+func (r *vendorRepository) FindByID(id uuid.UUID) (*domain.Vendor, error) {
+	var vendor domain.Vendor
+	if err := r.db.Preload("Contacts", orderContacts).First(&vendor, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &vendor, nil
+}
+
+func (r *vendorRepository) FindByName(name string) (*domain.Vendor, error) {
+	var vendor domain.Vendor
+	if err := r.db.First(&vendor, "name = ?", name).Error; err != nil {
+		return nil, err
+	}
+	return &vendor, nil
+}
+
+func (r *vendorRepository) FindAll(filter VendorFilter) ([]domain.Vendor, error) {
+	var vendors []domain.Vendor
+	query := r.db.Preload("Contacts", orderContacts)
+
+	if filter.Search != "" {
+		query = query.Where("name ILIKE ?", "%"+filter.Search+"%")
+	}
+	if filter.Category != "" {
+		query = query.Where("categories @> ?", `["`+filter.Category+`"]`)
+	}
+	if filter.ActiveOnly {
+		query = query.Where("active = ?", true)
+	}
+
+	err := query.Order("name").Find(&vendors).Error
+	return vendors, err
+}
+
+func (r *vendorRepository) Update(vendor *domain.Vendor, replaceContacts bool) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Save(vendor).Error; err != nil {
+			return err
+		}
+		if !replaceContacts {
+			return nil
+		}
+		if err := tx.Where("vendor_id = ?", vendor.ID).Delete(&domain.VendorContact{}).Error; err != nil {
+			return err
+		}
+		if len(vendor.Contacts) == 0 {
+			return nil
+		}
+		for i := range vendor.Contacts {
+			vendor.Contacts[i].VendorID = vendor.ID
+		}
+		return tx.Create(&vendor.Contacts).Error
+	})
+}
+
+func (r *vendorRepository) Delete(id uuid.UUID) error {
+	return r.db.Delete(&domain.Vendor{}, "id = ?", id).Error
+}
+
+func (r *vendorRepository) CreateAccess(access *domain.VendorAccess) error {
+	return r.db.Create(access).Error
+}
+
+func (r *vendorRepository) FindAccessByHash(tokenHash string) (*domain.VendorAccess, error) {
+	var access domain.VendorAccess
+	if err := r.db.First(&access, "token_hash = ?", tokenHash).Error; err != nil {
+		return nil, err
+	}
+	return &access, nil
+}
+
+func (r *vendorRepository) RevokeAccess(ticketID uuid.UUID, now time.Time) error {
+	return r.db.Model(&domain.VendorAccess{}).
+		Where("ticket_id = ? AND revoked_at IS NULL", ticketID).
+		Update("revoked_at", now).Error
+}
+
+func (r *vendorRepository) TouchAccess(id uuid.UUID, now time.Time) error {
+	return r.db.Model(&domain.VendorAccess{}).
+		Where("id = ?", id).
+		Update("last_used_at", now).Error
+}
+
+func (r *vendorRepository) Stats(filter VendorStatsFilter) ([]VendorStats, error) {
+	query := r.db.Table("tickets t").
+		Joins("JOIN vendors v ON v.id = t.vendor_id").
+		Where("t.deleted_at IS NULL")
+
+	if filter.From != nil {
+		query = query.Where("t.vendor_assigned_at >= ?", filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("t.vendor_assigned_at < ?", filter.To)
+	}
+	if filter.VendorID != nil {
+		query = query.Where("t.vendor_id = ?", filter.VendorID)
+	}
+
+	var stats []VendorStats
+	err := query.
+		Select(`v.id::text AS vendor_id, v.name AS name,
+			COUNT(*) AS tickets,
+			COUNT(t.resolved_at) AS resolved,
+			AVG(EXTRACT(EPOCH FROM t.vendor_responded_at - t.vendor_assigned_at) / 3600) AS avg_response_hours,
+			AVG(EXTRACT(EPOCH FROM t.resolved_at - t.vendor_assigned_at) / 3600) AS avg_resolution_hours,
+			COALESCE(SUM(t.vendor_cost + t.parts_cost + t.labor_cost), 0) AS total_cost,
+			COALESCE(AVG(t.vendor_cost + t.parts_cost + t.labor_cost), 0) AS average_cost`).
+		Group("v.id, v.name").
+		Order("tickets DESC, name").
+		Scan(&stats).Error
+	return stats, err
+}
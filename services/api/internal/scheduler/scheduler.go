@@ -0,0 +1,62 @@
+package scheduler
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// Job is a task run periodically in the background.
+type Job struct {
+	Name     string
+	Interval time.Duration
+	Run      func(ctx context.Context, now time.Time) error
+}
+
+// Scheduler runs jobs on their own tickers until its context is cancelled.
+// Each API instance runs its own scheduler, so jobs must be safe to run
+// concurrently on several instances.
+type Scheduler struct {
+	jobs []Job
+}
+
+func New(jobs ...Job) *Scheduler {
+	return &Scheduler{jobs: jobs}
+}
+
+func (s *Scheduler) Start(ctx context.Context) {
+	for _, job := range s.jobs {
+		if job.Interval <= 0 {
+			log.Printf("Scheduler: job %s disabled", job.Name)
+			continue
+		}
+		go s.loop(ctx, job)
+	}
+}
+
+func (s *Scheduler) loop(ctx context.Context, job Job) {
+	ticker := time.NewTicker(job.Interval)
+	defer ticker.Stop()
+
+	log.Printf("Scheduler: job %s every %s", job.Name, job.Interval)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			s.run(ctx, job, now)
+		}
+	}
+}
+
+func (s *Scheduler) run(ctx context.Context, job Job, now time.Time) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("Scheduler: job %s panicked: %v", job.Name, r)
+		}
+	}()
+
+	if err := job.Run(ctx, now); err != nil {
+		log.Printf("Scheduler: job %s failed: %v", job.Name, err)
+	}
+}
@@ -0,0 +1,697 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/maintenance-system/api/internal/domain"
+	"github.com/maintenance-system/api/internal/repository"
+	"github.com/maintenance-system/api/internal/websocket"
+)
+
+var (
+	ErrApprovalWorkflowNotFound = errors.New("approval workflow not found")
+	ErrApprovalWorkflowExists   = errors.New("an approval workflow with this name already exists")
+	ErrApprovalWorkflowInUse    = errors.New("approval workflow has requests; deactivate it instead")
+	ErrInvalidApprovalWorkflow  = errors.New("invalid approval workflow")
+	ErrApprovalNotFound         = errors.New("approval request not found")
+	ErrInvalidApproval          = errors.New("invalid approval request")
+	ErrApprovalClosed           = errors.New("approval request is no longer pending")
+	ErrNotApprover              = errors.New("no pending approval task for this user")
+	ErrApprovalForbidden        = errors.New("not permitted to cancel this approval request")
+	ErrApprovalTicketForbidden  = errors.New("not permitted to request approval on this ticket")
+	ErrApprovalRequired         = errors.New("a pending approval blocks this status change")
+)
+
+type ApprovalService interface {
+	GetWorkflows(activeOnly bool) ([]domain.ApprovalWorkflow, error)
+	GetWorkflow(id uuid.UUID) (*domain.ApprovalWorkflow, error)
+	CreateWorkflow(workflow *domain.ApprovalWorkflow) error
+	// UpdateWorkflow applies updates; a "steps" entry replaces the steps.
+	// Requests already made keep the tasks they started with.
+	UpdateWorkflow(id uuid.UUID, updates map[string]interface{}) (*domain.ApprovalWorkflow, error)
+	DeleteWorkflow(id uuid.UUID) error
+
+	GetRequests(filter repository.ApprovalFilter) ([]domain.ApprovalRequest, int64, error)
+	GetRequest(id uuid.UUID) (*domain.ApprovalRequest, error)
+	// Request starts a request, turning the workflow's steps into tasks.
+	// A request on a ticket needs a requester who may work on the ticket.
+	Request(request *domain.ApprovalRequest) error
+	// Pending returns the requests waiting on a decision from the user.
+	Pending(userID uuid.UUID) ([]domain.ApprovalRequest, error)
+	Approve(id, userID uuid.UUID, comment string) (*domain.ApprovalRequest, error)
+	Reject(id, userID uuid.UUID, comment string) (*domain.ApprovalRequest, error)
+	// Delegate hands the user's pending task over to another user.
+	Delegate(id, userID, delegateID uuid.UUID, comment string) (*domain.ApprovalRequest, error)
+	// Cancel withdraws a pending request. Only the requester or an admin
+	// may cancel.
+	Cancel(id, userID uuid.UUID) (*domain.ApprovalRequest, error)
+	// ExpireDue expires the pending requests past their deadline and
+	// returns how many were expired.
+	ExpireDue(ctx context.Context, now time.Time) (int, error)
+
+	// CheckTransition returns an ErrApprovalRequired error if a pending
+	// request on the ticket blocks moving it to status to.
+	CheckTransition(ticketID uuid.UUID, to domain.TicketStatus) error
+}
+
+type approvalService struct {
+	repo       repository.ApprovalRepository
+	ticketRepo repository.TicketRepository
+	userRepo   repository.UserRepository
+	tx         repository.Transactor
+	notifier   NotificationService
+	hub        *websocket.Hub
+}
+
+func NewApprovalService(repo repository.ApprovalRepository, ticketRepo repository.TicketRepository, userRepo repository.UserRepository, tx repository.Transactor, notifier NotificationService, hub *websocket.Hub) ApprovalService {
+	return &approvalService{
+		repo:       repo,
+		ticketRepo: ticketRepo,
+		userRepo:   userRepo,
+		tx:         tx,
+		notifier:   notifier,
+		hub:        hub,
+	}
+}
+
+func (s *approvalService) GetWorkflows(activeOnly bool) ([]domain.ApprovalWorkflow, error) {
+	return s.repo.FindWorkflows(activeOnly)
+}
+
+func (s *approvalService) GetWorkflow(id uuid.UUID) (*domain.ApprovalWorkflow, error) {
+	workflow, err := s.repo.FindWorkflowByID(id)
+	if err != nil {
+		return nil, ErrApprovalWorkflowNotFound
+	}
+	return workflow, nil
+}
+
+func (s *approvalService) CreateWorkflow(workflow *domain.ApprovalWorkflow) error {
+	if err := s.validateWorkflow(workflow); err != nil {
+		return err
+	}
+	if _, err := s.repo.FindWorkflowByName(workflow.Name); err == nil {
+		return ErrApprovalWorkflowExists
+	}
+	if err := s.repo.CreateWorkflow(workflow); err != nil {
+		return err
+	}
+	created, err := s.repo.FindWorkflowByID(workflow.ID)
+	if err != nil {
+		return err
+	}
+	*workflow = *created
+	return nil
+}
+
+func (s *approvalService) UpdateWorkflow(id uuid.UUID, updates map[string]interface{}) (*domain.ApprovalWorkflow, error) {
+	workflow, err := s.repo.FindWorkflowByID(id)
+	if err != nil {
+		return nil, ErrApprovalWorkflowNotFound
+	}
+
+	if name, ok := updates["name"].(string); ok && name != workflow.Name {
+		if _, err := s.repo.FindWorkflowByName(name); err == nil {
+			return nil, ErrApprovalWorkflowExists
+		}
+		workflow.Name = name
+	}
+	if description, ok := updates["description"].(string); ok {
+		workflow.Description = description
+	}
+	if hours, ok := updates["expiresAfterHours"].(int); ok {
+		workflow.ExpiresAfterHours = hours
+	}
+	if statuses, ok := updates["blockedStatuses"].(domain.TicketStatuses); ok {
+		workflow.BlockedStatuses = statuses
+	}
+	if active, ok := updates["active"].(bool); ok {
+		workflow.Active = active
+	}
+	steps, replaceSteps := updates["steps"].([]domain.ApprovalStep)
+	if replaceSteps {
+		workflow.Steps = steps
+	}
+
+	if err := s.validateWorkflow(workflow); err != nil {
+		return nil, err
+	}
+	if err := s.repo.UpdateWorkflow(workflow, replaceSteps); err != nil {
+		return nil, err
+	}
+	return s.repo.FindWorkflowByID(id)
+}
+
+func (s *approvalService) DeleteWorkflow(id uuid.UUID) error {
+	if _, err := s.repo.FindWorkflowByID(id); err != nil {
+		return ErrApprovalWorkflowNotFound
+	}
+	n, err := s.repo.CountRequests(id)
+	if err != nil {
+		return err
+	}
+	if n > 0 {
+		return ErrApprovalWorkflowInUse
+	}
+	return s.repo.DeleteWorkflow(id)
+}
+
+func (s *approvalService) validateWorkflow(workflow *domain.ApprovalWorkflow) error {
+	if workflow.Name == "" {
+		return invalidApprovalWorkflow(errors.New("name is required"))
+	}
+	if workflow.ExpiresAfterHours < 0 {
+		return invalidApprovalWorkflow(errors.New("expiry cannot be negative"))
+	}
+	for _, status := range workflow.BlockedStatuses {
+		if !status.IsValid() {
+			return invalidApprovalWorkflow(fmt.Errorf("unknown ticket status %q", status))
+		}
+	}
+	if len(workflow.Steps) == 0 {
+		return invalidApprovalWorkflow(errors.New("at least one step is required"))
+	}
+
+	for i := range workflow.Steps {
+		step := &workflow.Steps[i]
+		if step.Stage < 1 {
+			return invalidApprovalWorkflow(fmt.Errorf("step %d: stage must be at least 1", i+1))
+		}
+		switch step.ApproverType {
+		case domain.ApproverUser:
+			if step.ApproverID == nil {
+				return invalidApprovalWorkflow(fmt.Errorf("step %d: approver is required", i+1))
+			}
+			if _, err := s.userRepo.FindByID(*step.ApproverID); err != nil {
+				return invalidApprovalWorkflow(fmt.Errorf("step %d: approver not found", i+1))
+			}
+			step.ApproverRole = ""
+		case domain.ApproverRole:
+			if !step.ApproverRole.IsValid() {
+				return invalidApprovalWorkflow(fmt.Errorf("step %d: unknown role %q", i+1, step.ApproverRole))
+			}
+			step.ApproverID = nil
+		case domain.ApproverDepartmentHead:
+			step.ApproverID = nil
+			step.ApproverRole = ""
+		default:
+			return invalidApprovalWorkflow(fmt.Errorf("step %d: unknown approver type %q", i+1, step.ApproverType))
+		}
+		step.Approver = nil
+	}
+	return nil
+}
+
+func (s *approvalService) GetRequests(filter repository.ApprovalFilter) ([]domain.ApprovalRequest, int64, error) {
+	return s.repo.FindRequests(filter)
+}
+
+func (s *approvalService) GetRequest(id uuid.UUID) (*domain.ApprovalRequest, error) {
+	request, err := s.repo.FindRequestByID(id)
+	if err != nil {
+		return nil, ErrApprovalNotFound
+	}
+	return request, nil
+}
+
+func (s *approvalService) Request(request *domain.ApprovalRequest) error {
+	workflow, err := s.repo.FindWorkflowByID(request.WorkflowID)
+	if err != nil {
+		return ErrApprovalWorkflowNotFound
+	}
+	if !workflow.Active {
+		return invalidApproval(errors.New("workflow is inactive"))
+	}
+	requester, err := s.userRepo.FindByID(request.RequesterID)
+	if err != nil {
+		return ErrUserNotFound
+	}
+	if request.TicketID != nil {
+		ticket, err := s.ticketRepo.FindByID(*request.TicketID)
+		if err != nil {
+			return ErrTicketNotFound
+		}
+		if !canWorkOn(ticket, requester) {
+			return ErrApprovalTicketForbidden
+		}
+		if ticket.Status == domain.StatusClosed {
+			return ErrTicketClosed
+		}
+	}
+	if request.Title == "" {
+		request.Title = workflow.Name
+	}
+
+	tasks := make([]domain.ApprovalTask, 0, len(workflow.Steps))
+	for _, step := range workflow.Steps {
+		task := domain.ApprovalTask{
+			Stage:  step.Stage,
+			Name:   step.Name,
+			Status: domain.TaskWaiting,
+		}
+		switch step.ApproverType {
+		case domain.ApproverUser:
+			if step.ApproverID != nil && *step.ApproverID == requester.ID {
+				return invalidApproval(errors.New("the requester cannot approve their own request"))
+			}
+			task.ApproverID = step.ApproverID
+		case domain.ApproverRole:
+			task.ApproverRole = step.ApproverRole
+		case domain.ApproverDepartmentHead:
+			heads, err := s.userRepo.FindDepartmentHeads(requester.Department)
+			if err != nil {
+				return err
+			}
+			if requester.Department == "" || !hasOtherUser(heads, requester.ID) {
+				return invalidApproval(errors.New("the requester's department has no other head to approve"))
+			}
+			task.ApproverDepartment = requester.Department
+		}
+		tasks = append(tasks, task)
+	}
+
+	now := time.Now()
+	request.Status = domain.ApprovalPending
+	request.Tasks = tasks
+	request.Stage = 0
+	request.BlockedStatuses = workflow.BlockedStatuses
+	request.ExpiresAt = nil
+	request.CompletedAt = nil
+	if workflow.ExpiresAfterHours > 0 {
+		expiresAt := now.Add(time.Duration(workflow.ExpiresAfterHours) * time.Hour)
+		request.ExpiresAt = &expiresAt
+	}
+	advanceStage(request, now)
+
+	err = s.tx.WithinTransaction(func(r repository.Repositories) error {
+		if err := r.Approvals.CreateRequest(request); err != nil {
+			return err
+		}
+		if request.TicketID == nil {
+			return nil
+		}
+		entry := newTicketLog(*request.TicketID, request.RequesterID, domain.LogActionApprovalRequested, "", request.Title)
+		return r.TicketLogs.Create(&entry)
+	})
+	if err != nil {
+		return err
+	}
+
+	s.notifyApprovers(request, request.Stage)
+	if s.hub != nil {
+		s.hub.Broadcast("approval:created", request)
+	}
+	return nil
+}
+
+func (s *approvalService) Pending(userID uuid.UUID) ([]domain.ApprovalRequest, error) {
+	user, err := s.userRepo.FindByID(userID)
+	if err != nil {
+		return nil, ErrUserNotFound
+	}
+	return s.repo.FindPendingFor(user)
+}
+
+func (s *approvalService) Approve(id, userID uuid.UUID, comment string) (*domain.ApprovalRequest, error) {
+	return s.decide(id, userID, domain.TaskApproved, comment)
+}
+
+func (s *approvalService) Reject(id, userID uuid.UUID, comment string) (*domain.ApprovalRequest, error) {
+	return s.decide(id, userID, domain.TaskRejected, comment)
+}
+
+// decide records the user's decision on their task in the current stage.
+// A rejection ends the request; the last approval of a stage starts the
+// next one, or approves the request if it was the last stage.
+func (s *approvalService) decide(id, userID uuid.UUID, decision domain.ApprovalTaskStatus, comment string) (*domain.ApprovalRequest, error) {
+	user, err := s.userRepo.FindByID(userID)
+	if err != nil {
+		return nil, ErrUserNotFound
+	}
+
+	stage := 0
+	err = s.tx.WithinTransaction(func(r repository.Repositories) error {
+		request, err := r.Approvals.LockRequest(id)
+		if err != nil {
+			return ErrApprovalNotFound
+		}
+		if request.Status != domain.ApprovalPending {
+			return ErrApprovalClosed
+		}
+		task := decidableTask(request, user)
+		if task == nil {
+			return ErrNotApprover
+		}
+
+		now := time.Now()
+		task.Status = decision
+		task.DecidedByID = &userID
+		task.DecidedAt = &now
+		task.Comment = comment
+
+		if decision == domain.TaskRejected {
+			finishRequest(request, domain.ApprovalRejected, now)
+		} else if stageApproved(request) {
+			advanceStage(request, now)
+			if request.Status == domain.ApprovalPending {
+				stage = request.Stage
+			}
+		}
+		return s.save(r, request, userID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	request, err := s.repo.FindRequestByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if stage != 0 {
+		s.notifyApprovers(request, stage)
+	}
+	s.notifyOutcome(request)
+	if s.hub != nil {
+		s.hub.Broadcast("approval:updated", request)
+	}
+	return request, nil
+}
+
+func (s *approvalService) Delegate(id, userID, delegateID uuid.UUID, comment string) (*domain.ApprovalRequest, error) {
+	user, err := s.userRepo.FindByID(userID)
+	if err != nil {
+		return nil, ErrUserNotFound
+	}
+	delegate, err := s.userRepo.FindByID(delegateID)
+	if err != nil || delegate.Status != domain.StatusActive {
+		return nil, invalidApproval(errors.New("delegate not found"))
+	}
+	if delegateID == userID {
+		return nil, invalidApproval(errors.New("cannot delegate to yourself"))
+	}
+
+	err = s.tx.WithinTransaction(func(r repository.Repositories) error {
+		request, err := r.Approvals.LockRequest(id)
+		if err != nil {
+			return ErrApprovalNotFound
+		}
+		if request.Status != domain.ApprovalPending {
+			return ErrApprovalClosed
+		}
+		task := decidableTask(request, user)
+		if task == nil {
+			return ErrNotApprover
+		}
+		if delegateID == request.RequesterID {
+			return invalidApproval(errors.New("cannot delegate to the requester"))
+		}
+
+		task.DelegatedFromID = &userID
+		task.ApproverID = &delegateID
+		task.ApproverRole = ""
+		task.ApproverDepartment = ""
+		task.Comment = comment
+		return r.Approvals.UpdateTasks([]domain.ApprovalTask{*task})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	request, err := s.repo.FindRequestByID(id)
+	if err != nil {
+		return nil, err
+	}
+	s.notify([]uuid.UUID{delegateID}, request, "Approval delegated to you",
+		fmt.Sprintf("%s asked you to decide on %q", user.Name, request.Title))
+	if s.hub != nil {
+		s.hub.Broadcast("approval:updated", request)
+	}
+	return request, nil
+}
+
+func (s *approvalService) Cancel(id, userID uuid.UUID) (*domain.ApprovalRequest, error) {
+	user, err := s.userRepo.FindByID(userID)
+	if err != nil {
+		return nil, ErrUserNotFound
+	}
+
+	err = s.tx.WithinTransaction(func(r repository.Repositories) error {
+		request, err := r.Approvals.LockRequest(id)
+		if err != nil {
+			return ErrApprovalNotFound
+		}
+		if request.RequesterID != userID && user.Role != domain.RoleAdmin {
+			return ErrApprovalForbidden
+		}
+		if request.Status != domain.ApprovalPending {
+			return ErrApprovalClosed
+		}
+		finishRequest(request, domain.ApprovalCancelled, time.Now())
+		return s.save(r, request, userID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	request, err := s.repo.FindRequestByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if s.hub != nil {
+		s.hub.Broadcast("approval:updated", request)
+	}
+	return request, nil
+}
+
+func (s *approvalService) ExpireDue(ctx context.Context, now time.Time) (int, error) {
+	due, err := s.repo.FindExpired(now)
+	if err != nil {
+		return 0, err
+	}
+
+	expired := 0
+	for _, d := range due {
+		if ctx.Err() != nil {
+			return expired, ctx.Err()
+		}
+		ok := false
+		err := s.tx.WithinTransaction(func(r repository.Repositories) error {
+			request, err := r.Approvals.LockRequest(d.ID)
+			if err != nil {
+				return err
+			}
+			// Decided or cancelled since it was listed.
+			if request.Status != domain.ApprovalPending {
+				return nil
+			}
+			finishRequest(request, domain.ApprovalExpired, now)
+			ok = true
+			return s.save(r, request, request.RequesterID)
+		})
+		if err != nil {
+			log.Printf("Approval request %s: %v", d.ID, err)
+			continue
+		}
+		if !ok {
+			continue
+		}
+		expired++
+
+		if request, err := s.repo.FindRequestByID(d.ID); err == nil {
+			s.notifyOutcome(request)
+			if s.hub != nil {
+				s.hub.Broadcast("approval:updated", request)
+			}
+		}
+	}
+	return expired, nil
+}
+
+func (s *approvalService) CheckTransition(ticketID uuid.UUID, to domain.TicketStatus) error {
+	requests, err := s.repo.FindPendingByTicket(ticketID)
+	if err != nil {
+		return err
+	}
+	for _, request := range requests {
+		if request.BlockedStatuses.Contains(to) {
+			return fmt.Errorf("%w: %s", ErrApprovalRequired, request.Title)
+		}
+	}
+	return nil
+}
+
+// save writes the request and its tasks, and logs the outcome on the
+// ticket once the request is finished.
+func (s *approvalService) save(r repository.Repositories, request *domain.ApprovalRequest, userID uuid.UUID) error {
+	request.UpdatedAt = time.Now()
+	if err := r.Approvals.UpdateTasks(request.Tasks); err != nil {
+		return err
+	}
+	if err := r.Approvals.UpdateRequest(request); err != nil {
+		return err
+	}
+	if request.TicketID == nil || request.Status == domain.ApprovalPending {
+		return nil
+	}
+	entry := newTicketLog(*request.TicketID, userID, domain.LogActionApprovalCompleted, request.Title, string(request.Status))
+	return r.TicketLogs.Create(&entry)
+}
+
+// notifyApprovers tells everyone who can decide a task of the stage that
+// it is waiting for them.
+func (s *approvalService) notifyApprovers(request *domain.ApprovalRequest, stage int) {
+	var userIDs []uuid.UUID
+	for _, task := range request.Tasks {
+		if task.Stage != stage || task.Status != domain.TaskPending {
+			continue
+		}
+		switch {
+		case task.ApproverID != nil:
+			userIDs = append(userIDs, *task.ApproverID)
+		case task.ApproverRole != "":
+			users, err := s.userRepo.FindByRole(task.ApproverRole)
+			if err != nil {
+				log.Printf("Approval request %s: %v", request.ID, err)
+			}
+			for _, u := range users {
+				userIDs = append(userIDs, u.ID)
+			}
+		case task.ApproverDepartment != "":
+			heads, err := s.userRepo.FindDepartmentHeads(task.ApproverDepartment)
+			if err != nil {
+				log.Printf("Approval request %s: %v", request.ID, err)
+			}
+			for _, u := range heads {
+				userIDs = append(userIDs, u.ID)
+			}
+		}
+	}
+	s.notify(userIDs, request, "Approval needed", request.Title)
+}
+
+// notifyOutcome tells the requester how a finished request ended.
+func (s *approvalService) notifyOutcome(request *domain.ApprovalRequest) {
+	var notificationType, title string
+	switch request.Status {
+	case domain.ApprovalApproved:
+		notificationType, title = NotificationSuccess, "Request approved"
+	case domain.ApprovalRejected:
+		notificationType, title = NotificationError, "Request rejected"
+	case domain.ApprovalExpired:
+		notificationType, title = NotificationWarning, "Request expired"
+	default:
+		return
+	}
+	if err := s.notifier.Notify([]uuid.UUID{request.RequesterID}, domain.Notification{
+		Type:     notificationType,
+		Title:    title,
+		Message:  request.Title,
+		TicketID: request.TicketID,
+	}); err != nil {
+		log.Printf("Approval request %s: %v", request.ID, err)
+	}
+}
+
+func (s *approvalService) notify(userIDs []uuid.UUID, request *domain.ApprovalRequest, title, message string) {
+	if len(userIDs) == 0 {
+		return
+	}
+	if err := s.notifier.Notify(userIDs, domain.Notification{
+		Type:     NotificationInfo,
+		Title:    title,
+		Message:  message,
+		TicketID: request.TicketID,
+	}); err != nil {
+		log.Printf("Approval request %s: %v", request.ID, err)
+	}
+}
+
+// decidableTask returns the user's pending task in the request's current
+// stage, preferring one assigned to them by name over one they hold
+// through their role or department. Requesters never decide on their own
+// requests.
+func decidableTask(request *domain.ApprovalRequest, user *domain.User) *domain.ApprovalTask {
+	if request.RequesterID == user.ID {
+		return nil
+	}
+	var found *domain.ApprovalTask
+	for i := range request.Tasks {
+		task := &request.Tasks[i]
+		if task.Stage != request.Stage || task.Status != domain.TaskPending || !task.CanDecide(user) {
+			continue
+		}
+		if task.ApproverID != nil {
+			return task
+		}
+		if found == nil {
+			found = task
+		}
+	}
+	return found
+}
+
+func hasOtherUser(users []domain.User, id uuid.UUID) bool {
+	for _, u := range users {
+		if u.ID != id {
+			return true
+		}
+	}
+	return false
+}
+
+// stageApproved reports whether every task of the current stage approved.
+func stageApproved(request *domain.ApprovalRequest) bool {
+	for _, task := range request.Tasks {
+		if task.Stage == request.Stage && task.Status != domain.TaskApproved {
+			return false
+		}
+	}
+	return true
+}
+
+// advanceStage starts the stage after the current one, or approves the
+// request if there is none.
+func advanceStage(request *domain.ApprovalRequest, now time.Time) {
+	next := 0
+	for _, task := range request.Tasks {
+		if task.Stage > request.Stage && (next == 0 || task.Stage < next) {
+			next = task.Stage
+		}
+	}
+	if next == 0 {
+		finishRequest(request, domain.ApprovalApproved, now)
+		return
+	}
+
+	request.Stage = next
+	for i := range request.Tasks {
+		if request.Tasks[i].Stage == next {
+			request.Tasks[i].Status = domain.TaskPending
+		}
+	}
+}
+
+// finishRequest closes the request, cancelling the tasks still open.
+func finishRequest(request *domain.ApprovalRequest, status domain.ApprovalStatus, now time.Time) {
+	request.Status = status
+	request.CompletedAt = &now
+	for i := range request.Tasks {
+		switch request.Tasks[i].Status {
+		case domain.TaskWaiting, domain.TaskPending:
+			request.Tasks[i].Status = domain.TaskCancelled
+		}
+	}
+}
+
+func invalidApprovalWorkflow(err error) error {
+	return fmt.Errorf("%w: %v", ErrInvalidApprovalWorkflow, err)
+}
+
+func invalidApproval(err error) error {
+	return fmt.Errorf("%w: %v", ErrInvalidApproval, err)
+}
@@ -0,0 +1,135 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/maintenance-system/api/internal/domain"
+)
+
+func approvalTasks(stages ...int) []domain.ApprovalTask {
+	tasks := make([]domain.ApprovalTask, len(stages))
+	for i, stage := range stages {
+		tasks[i] = domain.ApprovalTask{Stage: stage, Status: domain.TaskWaiting}
+	}
+	return tasks
+}
+
+func TestAdvanceStage(t *testing.T) {
+	now := time.Date(2025, time.January, 1, 9, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name       string
+		stages     []int
+		stage      int
+		wantStage  int
+		wantStatus domain.ApprovalStatus
+		wantTasks  []domain.ApprovalTaskStatus
+	}{
+		{
+			name: "first stage starts", stages: []int{1, 1, 2}, stage: 0,
+			wantStage: 1, wantStatus: domain.ApprovalPending,
+			wantTasks: []domain.ApprovalTaskStatus{domain.TaskPending, domain.TaskPending, domain.TaskWaiting},
+		},
+		{
+			name: "next stage starts", stages: []int{1, 1, 2}, stage: 1,
+			wantStage: 2, wantStatus: domain.ApprovalPending,
+			wantTasks: []domain.ApprovalTaskStatus{domain.TaskWaiting, domain.TaskWaiting, domain.TaskPending},
+		},
+		{
+			name: "gaps in stage numbers", stages: []int{1, 3}, stage: 1,
+			wantStage: 3, wantStatus: domain.ApprovalPending,
+			wantTasks: []domain.ApprovalTaskStatus{domain.TaskWaiting, domain.TaskPending},
+		},
+		{
+			name: "last stage approves", stages: []int{1, 2}, stage: 2,
+			wantStage: 2, wantStatus: domain.ApprovalApproved,
+			wantTasks: []domain.ApprovalTaskStatus{domain.TaskCancelled, domain.TaskCancelled},
+		},
+		{
+			name: "no steps approves", stages: nil, stage: 0,
+			wantStage: 0, wantStatus: domain.ApprovalApproved,
+			wantTasks: []domain.ApprovalTaskStatus{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := &domain.ApprovalRequest{
+				Status: domain.ApprovalPending,
+				Stage:  tt.stage,
+				Tasks:  approvalTasks(tt.stages...),
+			}
+			advanceStage(request, now)
+
+			if request.Stage != tt.wantStage || request.Status != tt.wantStatus {
+				t.Errorf("stage %d, status %s; want stage %d, status %s", request.Stage, request.Status, tt.wantStage, tt.wantStatus)
+			}
+			if tt.wantStatus == domain.ApprovalApproved && (request.CompletedAt == nil || !request.CompletedAt.Equal(now)) {
+				t.Errorf("CompletedAt = %v, want %v", request.CompletedAt, now)
+			}
+			for i, task := range request.Tasks {
+				if task.Status != tt.wantTasks[i] {
+					t.Errorf("task %d status %s, want %s", i, task.Status, tt.wantTasks[i])
+				}
+			}
+		})
+	}
+}
+
+func TestStageApproved(t *testing.T) {
+	tests := []struct {
+		name     string
+		stage    int
+		statuses []domain.ApprovalTaskStatus
+		want     bool
+	}{
+		{"all approved", 1, []domain.ApprovalTaskStatus{domain.TaskApproved, domain.TaskApproved, domain.TaskWaiting}, true},
+		{"one still pending", 1, []domain.ApprovalTaskStatus{domain.TaskApproved, domain.TaskPending, domain.TaskWaiting}, false},
+		{"one rejected", 1, []domain.ApprovalTaskStatus{domain.TaskApproved, domain.TaskRejected, domain.TaskWaiting}, false},
+		{"other stages ignored", 2, []domain.ApprovalTaskStatus{domain.TaskPending, domain.TaskPending, domain.TaskApproved}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := &domain.ApprovalRequest{Stage: tt.stage, Tasks: approvalTasks(1, 1, 2)}
+			for i, status := range tt.statuses {
+				request.Tasks[i].Status = status
+			}
+			if got := stageApproved(request); got != tt.want {
+				t.Errorf("stageApproved() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecidableTask(t *testing.T) {
+	requester := &domain.User{ID: uuid.New(), Role: domain.RoleApprover}
+	approver := &domain.User{ID: uuid.New(), Role: domain.RoleApprover}
+	named := &domain.User{ID: uuid.New(), Role: domain.RoleApprover}
+
+	request := &domain.ApprovalRequest{
+		RequesterID: requester.ID,
+		Stage:       1,
+		Tasks: []domain.ApprovalTask{
+			{Stage: 1, Status: domain.TaskPending, ApproverRole: domain.RoleApprover},
+			{Stage: 1, Status: domain.TaskPending, ApproverID: &named.ID},
+			{Stage: 2, Status: domain.TaskWaiting, ApproverRole: domain.RoleAdmin},
+		},
+	}
+	tests := []struct {
+		name string
+		user *domain.User
+		want *domain.ApprovalTask
+	}{
+		{"requester cannot decide", requester, nil},
+		{"role holder", approver, &request.Tasks[0]},
+		{"named approver preferred", named, &request.Tasks[1]},
+		{"later stage not open yet", &domain.User{ID: uuid.New(), Role: domain.RoleAdmin}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := decidableTask(request, tt.user); got != tt.want {
+				t.Errorf("decidableTask() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
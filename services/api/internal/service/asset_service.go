@@ -0,0 +1,193 @@
+package service
+
+import (
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/maintenance-system/api/internal/domain"
+	"github.com/maintenance-system/api/internal/repository"
+)
+
+var (
+	ErrAssetNotFound  = errors.New("asset not found")
+	ErrAssetCodeTaken = errors.New("asset code already in use")
+)
+
+// AssetDetail is an asset together with its repair history.
+type AssetDetail struct {
+	Asset       *domain.Asset   `json:"asset"`
+	Tickets     []domain.Ticket `json:"tickets"`
+	TicketCount int             `json:"ticketCount"`
+	OpenTickets int             `json:"openTickets"`
+	// DowntimeSeconds is how long the asset has had at least one
+	// unresolved ticket against it.
+	DowntimeSeconds int64   `json:"downtimeSeconds"`
+	TotalCost       float64 `json:"totalCost"`
+}
+
+type AssetService interface {
+	GetAll(filter repository.AssetFilter) ([]domain.Asset, int64, error)
+	GetByID(id uuid.UUID) (*domain.Asset, error)
+	GetDetail(id uuid.UUID) (*AssetDetail, error)
+	Create(asset *domain.Asset) error
+	Update(id uuid.UUID, updates map[string]interface{}) (*domain.Asset, error)
+	Delete(id uuid.UUID) error
+}
+
+type assetService struct {
+	repo repository.AssetRepository
+}
+
+func NewAssetService(repo repository.AssetRepository) AssetService {
+	return &assetService{repo: repo}
+}
+
+func (s *assetService) GetAll(filter repository.AssetFilter) ([]domain.Asset, int64, error) {
+	return s.repo.FindAll(filter)
+}
+
+func (s *assetService) GetByID(id uuid.UUID) (*domain.Asset, error) {
+	asset, err := s.repo.FindByID(id)
+	if err != nil {
+		return nil, ErrAssetNotFound
+	}
+	return asset, nil
+}
+
+func (s *assetService) GetDetail(id uuid.UUID) (*AssetDetail, error) {
+	asset, err := s.repo.FindByID(id)
+	if err != nil {
+		return nil, ErrAssetNotFound
+	}
+
+	tickets, err := s.repo.FindTickets(id)
+	if err != nil {
+		return nil, err
+	}
+
+	detail := &AssetDetail{
+		Asset:           asset,
+		Tickets:         tickets,
+		TicketCount:     len(tickets),
+		DowntimeSeconds: int64(downtime(tickets, time.Now()) / time.Second),
+	}
+	for _, t := range tickets {
+		if t.Status != domain.StatusResolved && t.Status != domain.StatusClosed {
+			detail.OpenTickets++
+		}
+		detail.TotalCost += t.TotalCost
+	}
+	return detail, nil
+}
+
+func (s *assetService) Create(asset *domain.Asset) error {
+	if _, err := s.repo.FindByCode(asset.Code); err == nil {
+		return ErrAssetCodeTaken
+	}
+	if asset.Status == "" {
+		asset.Status = domain.AssetActive
+	}
+	if asset.Category == "" {
+		asset.Category = domain.CategoryGeneral
+	}
+	return s.repo.Create(asset)
+}
+
+func (s *assetService) Update(id uuid.UUID, updates map[string]interface{}) (*domain.Asset, error) {
+	asset, err := s.repo.FindByID(id)
+	if err != nil {
+		return nil, ErrAssetNotFound
+	}
+
+	if code, ok := updates["code"].(string); ok && code != asset.Code {
+		if _, err := s.repo.FindByCode(code); err == nil {
+			return nil, ErrAssetCodeTaken
+		}
+		asset.Code = code
+	}
+	if name, ok := updates["name"].(string); ok {
+		asset.Name = name
+	}
+	if category, ok := updates["category"].(string); ok {
+		asset.Category = domain.TicketCategory(category)
+	}
+	if assetMake, ok := updates["make"].(string); ok {
+		asset.Make = assetMake
+	}
+	if model, ok := updates["model"].(string); ok {
+		asset.Model = model
+	}
+	if serial, ok := updates["serialNumber"].(string); ok {
+		asset.SerialNumber = serial
+	}
+	if installDate, ok := updates["installDate"].(time.Time); ok {
+		asset.InstallDate = &installDate
+	}
+	if warranty, ok := updates["warrantyExpiresAt"].(time.Time); ok {
+		asset.WarrantyExpiresAt = &warranty
+	}
+	if location, ok := updates["location"].(string); ok {
+		asset.Location = location
+	}
+	if locationID, ok := updates["locationId"].(uuid.UUID); ok {
+		asset.LocationID = &locationID
+		asset.LocationRef = nil
+	}
+	if status, ok := updates["status"].(string); ok {
+		asset.Status = domain.AssetStatus(status)
+	}
+	if properties, ok := updates["properties"].(map[string]interface{}); ok {
+		asset.Properties = properties
+	}
+
+	if err := s.repo.Update(asset); err != nil {
+		return nil, err
+	}
+	return asset, nil
+}
+
+func (s *assetService) Delete(id uuid.UUID) error {
+	return s.repo.Delete(id)
+}
+
+// downtime returns the total time covered by the tickets' open periods,
+// from creation until resolution (or now). Overlapping tickets count once.
+func downtime(tickets []domain.Ticket, now time.Time) time.Duration {
+	type span struct{ from, to time.Time }
+	spans := make([]span, 0, len(tickets))
+	for _, t := range tickets {
+		end := now
+		if t.ResolvedAt != nil {
+			end = *t.ResolvedAt
+		} else if t.ClosedAt != nil {
+			end = *t.ClosedAt
+		}
+		if end.After(t.CreatedAt) {
+			spans = append(spans, span{t.CreatedAt, end})
+		}
+	}
+	sort.Slice(spans, func(i, j int) bool { return spans[i].from.Before(spans[j].from) })
+
+	var total time.Duration
+	var cur *span
+	for i := range spans {
+		sp := spans[i]
+		switch {
+		case cur == nil:
+			cur = &sp
+		case !sp.from.After(cur.to):
+			if sp.to.After(cur.to) {
+				cur.to = sp.to
+			}
+		default:
+			total += cur.to.Sub(cur.from)
+			cur = &sp
+		}
+	}
+	if cur != nil {
+		total += cur.to.Sub(cur.from)
+	}
+	return total
+}
@@ -0,0 +1,369 @@
+package service
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jung-kurt/gofpdf"
+	"github.com/maintenance-system/api/internal/domain"
+	"github.com/maintenance-system/api/internal/repository"
+	qrcode "github.com/skip2/go-qrcode"
+)
+
+var (
+	ErrInvalidAssetTag = errors.New("invalid asset tag")
+	ErrAssetRetired    = errors.New("asset is retired")
+)
+
+// assetTagSigLen is how many bytes of the HMAC are kept in a tag. Six bytes
+// keep the URL short while making forged tags impractical to guess.
+const assetTagSigLen = 6
+
+// AssetSummary is what someone scanning an asset tag gets to see.
+type AssetSummary struct {
+	ID          uuid.UUID             `json:"id"`
+	Code        string                `json:"code"`
+	Name        string                `json:"name"`
+	Category    domain.TicketCategory `json:"category"`
+	Status      domain.AssetStatus    `json:"status"`
+	Location    string                `json:"location,omitempty"`
+	LocationID  *uuid.UUID            `json:"locationId,omitempty"`
+	OpenTickets int                   `json:"openTickets"`
+}
+
+// ReportOptions carries what a reporter sends besides the ticket itself.
+type ReportOptions struct {
+	CustomFields map[string]interface{}
+	// DuplicateOf adds the report as a comment on that ticket instead of
+	// filing a new one.
+	DuplicateOf *uuid.UUID
+	// CreateAnyway skips the duplicate check.
+	CreateAnyway bool
+}
+
+// ReportResult is what came of a report; with neither Comment nor
+// Duplicates set, Ticket is the one just filed.
+type ReportResult struct {
+	Ticket     *domain.Ticket       // the ticket filed or commented on
+	Comment    *domain.Comment      // set when the report went on an existing ticket
+	Duplicates []DuplicateCandidate // set when nothing was filed
+}
+
+// LabelOptions configures asset tag rendering.
+type LabelOptions struct {
+	BaseURL  string // web app URL the tags point to
+	Secret   string // HMAC key for signing tags
+	FontPath string // optional TrueType font for label text, e.g. for Thai
+}
+
+type AssetTagService interface {
+	// URL returns the signed short URL encoded in the asset's QR tag.
+	URL(assetID uuid.UUID) string
+	// Resolve returns the asset a tag token points at.
+	Resolve(token string) (*domain.Asset, error)
+	Summary(token string) (*AssetSummary, error)
+	// QRCode renders the asset's tag as "png" or "svg".
+	QRCode(assetID uuid.UUID, format string, size int) ([]byte, string, error)
+	// LabelSheet renders a printable A4 PDF of tags for the assets.
+	LabelSheet(assetIDs []uuid.UUID) ([]byte, error)
+	// Report files a ticket against the tagged asset, pre-filled with its
+	// location and category, after checking its custom fields. A report
+	// like an open ticket is not filed: signed-in reporters get the
+	// candidates back to choose from, while anonymous ones, who may not
+	// see other tickets, have it added as a comment on the closest match.
+	Report(token string, ticket *domain.Ticket, opts ReportOptions) (*ReportResult, error)
+}
+
+type assetTagService struct {
+	repo         repository.AssetRepository
+	userRepo     repository.UserRepository
+	tickets      TicketService
+	duplicates   DuplicateService
+	customFields CustomFieldService
+	opts         LabelOptions
+}
+
+func NewAssetTagService(repo repository.AssetRepository, userRepo repository.UserRepository, tickets TicketService, duplicates DuplicateService, customFields CustomFieldService, opts LabelOptions) AssetTagService {
+	opts.BaseURL = strings.TrimRight(opts.BaseURL, "/")
+	return &assetTagService{
+		repo:         repo,
+		userRepo:     userRepo,
+		tickets:      tickets,
+		duplicates:   duplicates,
+		customFields: customFields,
+		opts:         opts,
+	}
+}
+
+func (s *assetTagService) URL(assetID uuid.UUID) string {
+	return s.opts.BaseURL + "/r/" + s.token(assetID)
+}
+
+// token is the base64url asset ID followed by a truncated HMAC of it.
+func (s *assetTagService) token(assetID uuid.UUID) string {
+	enc := base64.RawURLEncoding
+	return enc.EncodeToString(assetID[:]) + "." + enc.EncodeToString(s.sign(assetID))
+}
+
+func (s *assetTagService) sign(assetID uuid.UUID) []byte {
+	mac := hmac.New(sha256.New, []byte(s.opts.Secret))
+	mac.Write(assetID[:])
+	return mac.Sum(nil)[:assetTagSigLen]
+}
+
+func (s *assetTagService) Resolve(token string) (*domain.Asset, error) {
+	idPart, sigPart, ok := strings.Cut(token, ".")
+	if !ok {
+		return nil, ErrInvalidAssetTag
+	}
+	enc := base64.RawURLEncoding
+	rawID, err := enc.DecodeString(idPart)
+	if err != nil || len(rawID) != len(uuid.UUID{}) {
+		return nil, ErrInvalidAssetTag
+	}
+	sig, err := enc.DecodeString(sigPart)
+	if err != nil {
+		return nil, ErrInvalidAssetTag
+	}
+
+	var id uuid.UUID
+	copy(id[:], rawID)
+	if !hmac.Equal(sig, s.sign(id)) {
+		return nil, ErrInvalidAssetTag
+	}
+
+	asset, err := s.repo.FindByID(id)
+	if err != nil {
+		return nil, ErrAssetNotFound
+	}
+	return asset, nil
+}
+
+func (s *assetTagService) Summary(token string) (*AssetSummary, error) {
+	asset, err := s.Resolve(token)
+	if err != nil {
+		return nil, err
+	}
+
+	tickets, err := s.repo.FindTickets(asset.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &AssetSummary{
+		ID:         asset.ID,
+		Code:       asset.Code,
+		Name:       asset.Name,
+		Category:   asset.Category,
+		Status:     asset.Status,
+		Location:   asset.Location,
+		LocationID: asset.LocationID,
+	}
+	for _, t := range tickets {
+		if t.Status != domain.StatusResolved && t.Status != domain.StatusClosed {
+			summary.OpenTickets++
+		}
+	}
+	return summary, nil
+}
+
+func (s *assetTagService) QRCode(assetID uuid.UUID, format string, size int) ([]byte, string, error) {
+	if _, err := s.repo.FindByID(assetID); err != nil {
+		return nil, "", ErrAssetNotFound
+	}
+
+	qr, err := qrcode.New(s.URL(assetID), qrcode.Medium)
+	if err != nil {
+		return nil, "", err
+	}
+
+	switch format {
+	case "svg":
+		return qrSVG(qr.Bitmap()), "image/svg+xml", nil
+	default:
+		if size < 64 || size > 2048 {
+			size = 256
+		}
+		png, err := qr.PNG(size)
+		return png, "image/png", err
+	}
+}
+
+// qrSVG draws the QR bitmap as a single SVG path, one unit per module.
+func qrSVG(bitmap [][]bool) []byte {
+	var path strings.Builder
+	for y, row := range bitmap {
+		for x, dark := range row {
+			if dark {
+				fmt.Fprintf(&path, "M%d %dh1v1h-1z", x, y)
+			}
+		}
+	}
+
+	n := len(bitmap)
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, `<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 %d %d" shape-rendering="crispEdges">`, n, n)
+	fmt.Fprintf(&buf, `<rect width="%d" height="%d" fill="#fff"/>`, n, n)
+	fmt.Fprintf(&buf, `<path d="%s" fill="#000"/></svg>`, path.String())
+	return buf.Bytes()
+}
+
+// Label sheet layout: 3 × 7 labels of 63.5 × 38.1 mm on A4, matching
+// common sticker sheets such as Avery L7160.
+const (
+	labelCols       = 3
+	labelRows       = 7
+	labelWidth      = 63.5
+	labelHeight     = 38.1
+	labelMarginX    = 7.2
+	labelMarginY    = 15.1
+	labelGapX       = 2.5
+	labelPadding    = 2.0
+	labelQRSize     = labelHeight - 2*labelPadding
+	labelFontFamily = "label"
+)
+
+func (s *assetTagService) LabelSheet(assetIDs []uuid.UUID) ([]byte, error) {
+	assets, err := s.repo.FindByIDs(assetIDs)
+	if err != nil {
+		return nil, err
+	}
+	if len(assets) == 0 {
+		return nil, ErrAssetNotFound
+	}
+
+	pdf := gofpdf.New("P", "mm", "A4", "")
+	pdf.SetMargins(0, 0, 0)
+	pdf.SetAutoPageBreak(false, 0)
+
+	// Core PDF fonts only cover Latin text; Thai names need a TrueType font.
+	family, text := "Helvetica", pdf.UnicodeTranslatorFromDescriptor("")
+	if s.opts.FontPath != "" {
+		font, err := os.ReadFile(s.opts.FontPath)
+		if err != nil {
+			return nil, err
+		}
+		pdf.AddUTF8FontFromBytes(labelFontFamily, "", font)
+		pdf.AddUTF8FontFromBytes(labelFontFamily, "B", font)
+		family, text = labelFontFamily, func(str string) string { return str }
+	}
+
+	for i, asset := range assets {
+		slot := i % (labelCols * labelRows)
+		if slot == 0 {
+			pdf.AddPage()
+		}
+		x := labelMarginX + float64(slot%labelCols)*(labelWidth+labelGapX)
+		y := labelMarginY + float64(slot/labelCols)*labelHeight
+
+		png, err := qrcode.Encode(s.URL(asset.ID), qrcode.Medium, 512)
+		if err != nil {
+			return nil, err
+		}
+		name := "qr-" + asset.ID.String()
+		pdf.RegisterImageOptionsReader(name, gofpdf.ImageOptions{ImageType: "PNG"}, bytes.NewReader(png))
+		pdf.ImageOptions(name, x+labelPadding, y+labelPadding, labelQRSize, labelQRSize, false, gofpdf.ImageOptions{ImageType: "PNG"}, 0, "")
+
+		textX := x + labelQRSize + 2*labelPadding
+		textW := labelWidth - labelQRSize - 3*labelPadding
+
+		pdf.SetXY(textX, y+labelPadding+2)
+		pdf.SetFont(family, "B", 10)
+		pdf.CellFormat(textW, 5, text(asset.Code), "", 2, "L", false, 0, "")
+		pdf.SetFont(family, "", 8)
+		pdf.SetX(textX)
+		pdf.MultiCell(textW, 3.5, text(asset.Name), "", "L", false)
+		pdf.SetXY(textX, y+labelHeight-labelPadding-8)
+		pdf.SetFont(family, "", 7)
+		pdf.MultiCell(textW, 3, text("Scan to report a problem"), "", "L", false)
+	}
+
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (s *assetTagService) Report(token string, ticket *domain.Ticket, opts ReportOptions) (*ReportResult, error) {
+	asset, err := s.Resolve(token)
+	if err != nil {
+		return nil, err
+	}
+	if asset.Status == domain.AssetRetired {
+		return nil, ErrAssetRetired
+	}
+
+	anonymous := ticket.CreatedByID == uuid.Nil
+	if anonymous {
+		reporter, err := s.userRepo.FindByEmail(domain.PublicReporterEmail)
+		if err != nil {
+			return nil, fmt.Errorf("public reporter account missing: %w", err)
+		}
+		ticket.CreatedByID = reporter.ID
+	}
+
+	if opts.DuplicateOf != nil {
+		return s.attach(*opts.DuplicateOf, ticket)
+	}
+
+	ticket.AssetID = &asset.ID
+	ticket.LocationID = asset.LocationID
+	ticket.Location = asset.Location
+	if ticket.Category == "" {
+		ticket.Category = asset.Category
+	}
+	if ticket.CustomFields, err = s.customFields.Validate(ticket.Category, opts.CustomFields, nil); err != nil {
+		return nil, err
+	}
+
+	if !opts.CreateAnyway {
+		duplicates, err := s.duplicates.Find(ticket, time.Now())
+		if err != nil {
+			return nil, err
+		}
+		if len(duplicates) > 0 {
+			if anonymous {
+				return s.attach(duplicates[0].Ticket.ID, ticket)
+			}
+			return &ReportResult{Duplicates: duplicates}, nil
+		}
+	}
+
+	// An empty priority takes the category's default when the ticket is
+	// created.
+	if err := s.tickets.Create(ticket); err != nil {
+		return nil, err
+	}
+	return &ReportResult{Ticket: ticket}, nil
+}
+
+// attach adds the report as a comment on an existing ticket, signed with
+// the reporter's name and contact when they gave them.
+func (s *assetTagService) attach(ticketID uuid.UUID, report *domain.Ticket) (*ReportResult, error) {
+	content := report.Title + "\n\n" + report.Description
+	if report.ReporterName != "" {
+		content += "\n\n— " + report.ReporterName
+		if report.ReporterContact != "" {
+			content += " (" + report.ReporterContact + ")"
+		}
+	}
+
+	comment, err := s.tickets.AddComment(ticketID, report.CreatedByID, content)
+	if err != nil {
+		return nil, err
+	}
+	ticket, err := s.tickets.GetByID(ticketID)
+	if err != nil {
+		return nil, err
+	}
+	return &ReportResult{Ticket: ticket, Comment: comment}, nil
+}
@@ -0,0 +1,198 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/maintenance-system/api/internal/domain"
+)
+
+// BusinessClock measures time that counts towards deadlines.
+type BusinessClock interface {
+	// Add returns the moment d of working time after t.
+	Add(t time.Time, d time.Duration) time.Time
+	// Elapsed returns the working time between from and to.
+	Elapsed(from, to time.Time) time.Duration
+}
+
+// wallClock counts every hour of every day. It is used when no working
+// calendar has been configured.
+type wallClock struct{}
+
+func (wallClock) Add(t time.Time, d time.Duration) time.Time {
+	return t.Add(d)
+}
+
+func (wallClock) Elapsed(from, to time.Time) time.Duration {
+	if to.Before(from) {
+		return 0
+	}
+	return to.Sub(from)
+}
+
+// maxCalendarDays bounds how many days in a row without working time the
+// calendar clock searches through, so a calendar with every day off cannot
+// loop forever.
+const maxCalendarDays = 3 * 366
+
+type window struct {
+	start, end time.Duration // offsets from local midnight
+}
+
+type calendarClock struct {
+	loc      *time.Location
+	week     [7][]window
+	holidays map[string]bool
+}
+
+// newBusinessClock builds a clock from a calendar. A nil calendar or one
+// without working hours yields a wall clock.
+func newBusinessClock(cal *domain.WorkingCalendar) (BusinessClock, error) {
+	if cal == nil || len(cal.Hours) == 0 {
+		return wallClock{}, nil
+	}
+
+	loc, err := time.LoadLocation(cal.Timezone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid timezone %q: %w", cal.Timezone, err)
+	}
+
+	c := &calendarClock{loc: loc, holidays: make(map[string]bool, len(cal.Holidays))}
+	for _, h := range cal.Hours {
+		w, err := parseWindow(h)
+		if err != nil {
+			return nil, err
+		}
+		c.week[h.Weekday] = append(c.week[h.Weekday], w)
+	}
+	for i := range c.week {
+		c.week[i] = mergeWindows(c.week[i])
+	}
+	for _, h := range cal.Holidays {
+		c.holidays[h.Date] = true
+	}
+	return c, nil
+}
+
+func parseWindow(h domain.WorkingHours) (window, error) {
+	start, err := parseClockTime(h.StartTime)
+	if err != nil {
+		return window{}, err
+	}
+	end, err := parseClockTime(h.EndTime)
+	if err != nil {
+		return window{}, err
+	}
+	if h.Weekday < time.Sunday || h.Weekday > time.Saturday || end <= start {
+		return window{}, fmt.Errorf("invalid working hours %s %s-%s", h.Weekday, h.StartTime, h.EndTime)
+	}
+	return window{start: start, end: end}, nil
+}
+
+// mergeWindows sorts a day's windows and joins those that overlap or touch,
+// so no working time is counted twice.
+func mergeWindows(windows []window) []window {
+	sort.Slice(windows, func(a, b int) bool { return windows[a].start < windows[b].start })
+	var merged []window
+	for _, w := range windows {
+		if n := len(merged); n > 0 && w.start <= merged[n-1].end {
+			if w.end > merged[n-1].end {
+				merged[n-1].end = w.end
+			}
+			continue
+		}
+		merged = append(merged, w)
+	}
+	return merged
+}
+
+// parseClockTime parses "HH:MM" into an offset from midnight. "24:00" is
+// accepted as the end of the day.
+func parseClockTime(s string) (time.Duration, error) {
+	var h, m int
+	if _, err := fmt.Sscanf(s, "%d:%d", &h, &m); err != nil || h < 0 || m < 0 || m > 59 || h*60+m > 24*60 {
+		return 0, fmt.Errorf("invalid time of day %q", s)
+	}
+	return time.Duration(h)*time.Hour + time.Duration(m)*time.Minute, nil
+}
+
+// windowsOn returns the working windows of the day starting at midnight,
+// as absolute times. Windows are laid on the wall clock, so they keep
+// their hours on days when daylight saving time starts or ends.
+func (c *calendarClock) windowsOn(midnight time.Time) [][2]time.Time {
+	if c.holidays[midnight.Format("2006-01-02")] {
+		return nil
+	}
+	y, m, d := midnight.Date()
+	at := func(offset time.Duration) time.Time {
+		return time.Date(y, m, d, int(offset/time.Hour), int(offset%time.Hour/time.Minute), 0, 0, c.loc)
+	}
+	var out [][2]time.Time
+	for _, w := range c.week[midnight.Weekday()] {
+		out = append(out, [2]time.Time{at(w.start), at(w.end)})
+	}
+	return out
+}
+
+func (c *calendarClock) midnight(t time.Time) time.Time {
+	y, m, d := t.In(c.loc).Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, c.loc)
+}
+
+func nextDay(midnight time.Time) time.Time {
+	y, m, d := midnight.Date()
+	return time.Date(y, m, d+1, 0, 0, 0, 0, midnight.Location())
+}
+
+func (c *calendarClock) Add(t time.Time, d time.Duration) time.Time {
+	if d <= 0 {
+		return t
+	}
+	day := c.midnight(t)
+	for idle := 0; idle < maxCalendarDays; idle++ {
+		windows := c.windowsOn(day)
+		if len(windows) > 0 {
+			idle = 0
+		}
+		for _, w := range windows {
+			if !t.Before(w[1]) {
+				continue
+			}
+			from := w[0]
+			if t.After(from) {
+				from = t
+			}
+			avail := w[1].Sub(from)
+			if d <= avail {
+				return from.Add(d)
+			}
+			d -= avail
+		}
+		day = nextDay(day)
+	}
+	// No working time for too long; fall back to wall-clock time.
+	return t.Add(d)
+}
+
+func (c *calendarClock) Elapsed(from, to time.Time) time.Duration {
+	if !to.After(from) {
+		return 0
+	}
+	var total time.Duration
+	for day := c.midnight(from); day.Before(to); day = nextDay(day) {
+		for _, w := range c.windowsOn(day) {
+			start, end := w[0], w[1]
+			if from.After(start) {
+				start = from
+			}
+			if to.Before(end) {
+				end = to
+			}
+			if end.After(start) {
+				total += end.Sub(start)
+			}
+		}
+	}
+	return total
+}
@@ -0,0 +1,167 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/maintenance-system/api/internal/domain"
+)
+
+// officeHours is Monday to Friday, 09:00 to 17:00.
+func officeHours() []domain.WorkingHours {
+	var hours []domain.WorkingHours
+	for day := time.Monday; day <= time.Friday; day++ {
+		hours = append(hours, domain.WorkingHours{Weekday: day, StartTime: "09:00", EndTime: "17:00"})
+	}
+	return hours
+}
+
+func mustClock(t *testing.T, timezone string, hours []domain.WorkingHours, holidays ...string) BusinessClock {
+	t.Helper()
+	cal := &domain.WorkingCalendar{Timezone: timezone, Hours: hours}
+	for _, date := range holidays {
+		cal.Holidays = append(cal.Holidays, domain.Holiday{Date: date})
+	}
+	clock, err := newBusinessClock(cal)
+	if err != nil {
+		t.Fatalf("newBusinessClock: %v", err)
+	}
+	return clock
+}
+
+func TestCalendarClock(t *testing.T) {
+	bangkok, _ := time.LoadLocation("Asia/Bangkok")
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("time zone data unavailable: %v", err)
+	}
+	at := func(loc *time.Location, m time.Month, d, h int) time.Time {
+		return time.Date(2025, m, d, h, 0, 0, 0, loc)
+	}
+	jan := func(d, h int) time.Time { return at(bangkok, time.January, d, h) } // the 6th is a Monday
+
+	office := mustClock(t, "Asia/Bangkok", officeHours(), "2025-01-08")
+	noHolidays := mustClock(t, "Asia/Bangkok", officeHours())
+	overnight := mustClock(t, "Asia/Bangkok", []domain.WorkingHours{
+		{Weekday: time.Monday, StartTime: "22:00", EndTime: "24:00"},
+		{Weekday: time.Tuesday, StartTime: "00:00", EndTime: "06:00"},
+	})
+	overlapping := mustClock(t, "Asia/Bangkok", []domain.WorkingHours{
+		{Weekday: time.Monday, StartTime: "09:00", EndTime: "12:00"},
+		{Weekday: time.Monday, StartTime: "11:00", EndTime: "14:00"},
+		{Weekday: time.Monday, StartTime: "14:00", EndTime: "15:00"},
+		{Weekday: time.Monday, StartTime: "10:00", EndTime: "11:00"},
+	})
+	// Daylight saving time starts on Sunday 9 March 2025 and ends on
+	// Sunday 2 November 2025 in New York.
+	sundays := mustClock(t, "America/New_York", []domain.WorkingHours{
+		{Weekday: time.Sunday, StartTime: "09:00", EndTime: "17:00"},
+	})
+
+	tests := []struct {
+		name     string
+		clock    BusinessClock
+		from, to time.Time
+		working  time.Duration
+	}{
+		{"within a window", office, jan(6, 10), jan(6, 12), 2 * time.Hour},
+		{"before opening", office, jan(6, 7), jan(6, 10), time.Hour},
+		{"over a night", office, jan(6, 16), jan(7, 10), 2 * time.Hour},
+		{"over a weekend", office, jan(10, 16), jan(13, 10), 2 * time.Hour},
+		{"over a holiday", office, jan(7, 16), jan(9, 10), 2 * time.Hour},
+		{"whole week", office, jan(6, 9), jan(10, 17), 32 * time.Hour},
+		{"overnight window", overnight, jan(6, 23), jan(7, 2), 3 * time.Hour},
+		{"overlapping windows count once", overlapping, jan(6, 9), jan(6, 15), 6 * time.Hour},
+		{"overlapping windows carry over", overlapping, jan(6, 9), jan(13, 10), 7 * time.Hour},
+		{"clocks go forward", sundays, at(newYork, time.March, 9, 9), at(newYork, time.March, 9, 17), 8 * time.Hour},
+		{"clocks go back", sundays, at(newYork, time.November, 2, 9), at(newYork, time.November, 2, 17), 8 * time.Hour},
+		{"longer than the search bound", noHolidays, jan(6, 9), time.Date(2025, time.January, 6+199*7+4, 17, 0, 0, 0, bangkok), 1000 * 8 * time.Hour},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.clock.Elapsed(tt.from, tt.to); got != tt.working {
+				t.Errorf("Elapsed(%v, %v) = %v, want %v", tt.from, tt.to, got, tt.working)
+			}
+			// From the opening of a window, adding the working time lands
+			// on to again.
+			if tt.from.Hour() == 9 {
+				if got := tt.clock.Add(tt.from, tt.working); !got.Equal(tt.to) {
+					t.Errorf("Add(%v, %v) = %v, want %v", tt.from, tt.working, got, tt.to)
+				}
+			}
+		})
+	}
+}
+
+func TestCalendarClockAdd(t *testing.T) {
+	bangkok, _ := time.LoadLocation("Asia/Bangkok")
+	jan := func(d, h int) time.Time { return time.Date(2025, time.January, d, h, 0, 0, 0, bangkok) }
+
+	office := mustClock(t, "Asia/Bangkok", officeHours(), "2025-01-08")
+	var everyMonday []string
+	for d := jan(6, 0); d.Year() < 2030; d = d.AddDate(0, 0, 7) {
+		everyMonday = append(everyMonday, d.Format("2006-01-02"))
+	}
+	overnight := mustClock(t, "Asia/Bangkok", []domain.WorkingHours{
+		{Weekday: time.Monday, StartTime: "22:00", EndTime: "24:00"},
+		{Weekday: time.Tuesday, StartTime: "00:00", EndTime: "06:00"},
+	})
+	neverOpen := mustClock(t, "Asia/Bangkok", []domain.WorkingHours{
+		{Weekday: time.Monday, StartTime: "09:00", EndTime: "17:00"},
+	}, everyMonday...)
+
+	tests := []struct {
+		name  string
+		clock BusinessClock
+		start time.Time
+		d     time.Duration
+		want  time.Time
+	}{
+		{"within a window", office, jan(6, 10), 2 * time.Hour, jan(6, 12)},
+		{"before opening", office, jan(6, 7), time.Hour, jan(6, 10)},
+		{"after closing", office, jan(6, 18), time.Hour, jan(7, 10)},
+		{"to the end of a window", office, jan(6, 9), 8 * time.Hour, jan(6, 17)},
+		{"over a weekend", office, jan(10, 16), 2 * time.Hour, jan(13, 10)},
+		{"over a holiday", office, jan(7, 16), 2 * time.Hour, jan(9, 10)},
+		{"overnight window", overnight, jan(6, 23), 3 * time.Hour, jan(7, 2)},
+		{"after an overnight window", overnight, jan(7, 5), 2 * time.Hour, jan(13, 23)},
+		{"nothing to add", office, jan(4, 3), 0, jan(4, 3)},
+		{"no working time falls back to the wall clock", neverOpen, jan(6, 10), time.Hour, jan(6, 11)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.clock.Add(tt.start, tt.d); !got.Equal(tt.want) {
+				t.Errorf("Add(%v, %v) = %v, want %v", tt.start, tt.d, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewBusinessClock(t *testing.T) {
+	tests := []struct {
+		name     string
+		timezone string
+		hours    []domain.WorkingHours
+		wantErr  bool
+	}{
+		{"office hours", "Asia/Bangkok", officeHours(), false},
+		{"until midnight", "Asia/Bangkok", []domain.WorkingHours{{Weekday: time.Monday, StartTime: "18:00", EndTime: "24:00"}}, false},
+		{"window across midnight", "Asia/Bangkok", []domain.WorkingHours{{Weekday: time.Monday, StartTime: "22:00", EndTime: "06:00"}}, true},
+		{"empty window", "Asia/Bangkok", []domain.WorkingHours{{Weekday: time.Monday, StartTime: "09:00", EndTime: "09:00"}}, true},
+		{"past midnight", "Asia/Bangkok", []domain.WorkingHours{{Weekday: time.Monday, StartTime: "09:00", EndTime: "24:30"}}, true},
+		{"bad weekday", "Asia/Bangkok", []domain.WorkingHours{{Weekday: 7, StartTime: "09:00", EndTime: "17:00"}}, true},
+		{"bad time zone", "Mars/Olympus", officeHours(), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newBusinessClock(&domain.WorkingCalendar{Timezone: tt.timezone, Hours: tt.hours})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("newBusinessClock() error = %v, want error %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	if clock, err := newBusinessClock(&domain.WorkingCalendar{Timezone: "Mars/Olympus"}); err != nil || clock != (wallClock{}) {
+		t.Errorf("calendar without hours = %v, %v; want the wall clock", clock, err)
+	}
+}
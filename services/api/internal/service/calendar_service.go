@@ -0,0 +1,196 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/maintenance-system/api/internal/domain"
+	"github.com/maintenance-system/api/internal/repository"
+)
+
+var (
+	ErrCalendarNotFound = errors.New("calendar not found")
+	ErrInvalidCalendar  = errors.New("invalid calendar")
+)
+
+type CalendarService interface {
+	GetAll() ([]domain.WorkingCalendar, error)
+	GetByID(id uuid.UUID) (*domain.WorkingCalendar, error)
+	Create(calendar *domain.WorkingCalendar) error
+	Update(id uuid.UUID, updates map[string]interface{}) (*domain.WorkingCalendar, error)
+	Delete(id uuid.UUID) error
+	SetHours(id uuid.UUID, hours []domain.WorkingHours) (*domain.WorkingCalendar, error)
+	AddHoliday(holiday *domain.Holiday) error
+	DeleteHoliday(calendarID, holidayID uuid.UUID) error
+	ImportHolidays(calendarID uuid.UUID, r io.Reader) ([]domain.Holiday, error)
+
+	// Clock returns the business clock of a calendar, or of the default
+	// calendar when id is nil. Without any calendar it counts wall time.
+	Clock(id *uuid.UUID) (BusinessClock, error)
+	AddBusinessTime(id *uuid.UUID, start time.Time, d time.Duration) (time.Time, error)
+	BusinessTimeBetween(id *uuid.UUID, from, to time.Time) (time.Duration, error)
+}
+
+type calendarService struct {
+	repo repository.CalendarRepository
+}
+
+func NewCalendarService(repo repository.CalendarRepository) CalendarService {
+	return &calendarService{repo: repo}
+}
+
+func (s *calendarService) GetAll() ([]domain.WorkingCalendar, error) {
+	return s.repo.FindAll()
+}
+
+func (s *calendarService) GetByID(id uuid.UUID) (*domain.WorkingCalendar, error) {
+	calendar, err := s.repo.FindByID(id)
+	if err != nil {
+		return nil, ErrCalendarNotFound
+	}
+	return calendar, nil
+}
+
+func (s *calendarService) Create(calendar *domain.WorkingCalendar) error {
+	if calendar.Timezone == "" {
+		calendar.Timezone = "Asia/Bangkok"
+	}
+	if err := validateCalendar(calendar); err != nil {
+		return err
+	}
+	return s.repo.Create(calendar)
+}
+
+func (s *calendarService) Update(id uuid.UUID, updates map[string]interface{}) (*domain.WorkingCalendar, error) {
+	calendar, err := s.repo.FindByID(id)
+	if err != nil {
+		return nil, ErrCalendarNotFound
+	}
+
+	if name, ok := updates["name"].(string); ok {
+		calendar.Name = name
+	}
+	if tz, ok := updates["timezone"].(string); ok {
+		calendar.Timezone = tz
+	}
+	if isDefault, ok := updates["isDefault"].(bool); ok {
+		calendar.IsDefault = isDefault
+	}
+
+	if err := validateCalendar(calendar); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Update(calendar); err != nil {
+		return nil, err
+	}
+	return calendar, nil
+}
+
+func (s *calendarService) Delete(id uuid.UUID) error {
+	return s.repo.Delete(id)
+}
+
+func (s *calendarService) SetHours(id uuid.UUID, hours []domain.WorkingHours) (*domain.WorkingCalendar, error) {
+	if _, err := s.repo.FindByID(id); err != nil {
+		return nil, ErrCalendarNotFound
+	}
+
+	for i := range hours {
+		hours[i].CalendarID = id
+		if _, err := parseWindow(hours[i]); err != nil {
+			return nil, invalidCalendar(err)
+		}
+	}
+
+	if err := s.repo.ReplaceHours(id, hours); err != nil {
+		return nil, err
+	}
+	return s.repo.FindByID(id)
+}
+
+func (s *calendarService) AddHoliday(holiday *domain.Holiday) error {
+	if _, err := s.repo.FindByID(holiday.CalendarID); err != nil {
+		return ErrCalendarNotFound
+	}
+	if _, err := time.Parse("2006-01-02", holiday.Date); err != nil {
+		return invalidCalendar(err)
+	}
+	return s.repo.AddHolidays([]domain.Holiday{*holiday})
+}
+
+func (s *calendarService) DeleteHoliday(calendarID, holidayID uuid.UUID) error {
+	return s.repo.DeleteHoliday(calendarID, holidayID)
+}
+
+func (s *calendarService) ImportHolidays(calendarID uuid.UUID, r io.Reader) ([]domain.Holiday, error) {
+	calendar, err := s.repo.FindByID(calendarID)
+	if err != nil {
+		return nil, ErrCalendarNotFound
+	}
+
+	loc, err := time.LoadLocation(calendar.Timezone)
+	if err != nil {
+		return nil, invalidCalendar(err)
+	}
+
+	holidays, err := parseICalHolidays(r, calendarID, loc)
+	if err != nil {
+		return nil, invalidCalendar(err)
+	}
+	if err := s.repo.AddHolidays(holidays); err != nil {
+		return nil, err
+	}
+	return holidays, nil
+}
+
+func (s *calendarService) Clock(id *uuid.UUID) (BusinessClock, error) {
+	var calendar *domain.WorkingCalendar
+	var err error
+	if id != nil {
+		calendar, err = s.repo.FindByID(*id)
+		if err != nil {
+			return nil, ErrCalendarNotFound
+		}
+	} else if calendar, err = s.repo.FindDefault(); err != nil {
+		return wallClock{}, nil
+	}
+	return newBusinessClock(calendar)
+}
+
+func (s *calendarService) AddBusinessTime(id *uuid.UUID, start time.Time, d time.Duration) (time.Time, error) {
+	clock, err := s.Clock(id)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return clock.Add(start, d), nil
+}
+
+func (s *calendarService) BusinessTimeBetween(id *uuid.UUID, from, to time.Time) (time.Duration, error) {
+	clock, err := s.Clock(id)
+	if err != nil {
+		return 0, err
+	}
+	return clock.Elapsed(from, to), nil
+}
+
+func validateCalendar(calendar *domain.WorkingCalendar) error {
+	if calendar.Name == "" {
+		return invalidCalendar(errors.New("name is required"))
+	}
+	if _, err := time.LoadLocation(calendar.Timezone); err != nil {
+		return invalidCalendar(err)
+	}
+	for _, h := range calendar.Hours {
+		if _, err := parseWindow(h); err != nil {
+			return invalidCalendar(err)
+		}
+	}
+	return nil
+}
+
+func invalidCalendar(err error) error {
+	return fmt.Errorf("%w: %v", ErrInvalidCalendar, err)
+}
@@ -0,0 +1,69 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+var ErrCaptchaFailed = errors.New("captcha verification failed")
+
+// CaptchaVerifier checks a captcha token produced by the web client.
+type CaptchaVerifier interface {
+	Verify(token, remoteIP string) error
+}
+
+// NewCaptchaVerifier returns a verifier for the siteverify protocol shared
+// by Turnstile, hCaptcha and reCAPTCHA. Without a secret every token is
+// accepted, which is meant for development only.
+func NewCaptchaVerifier(secret, verifyURL string) CaptchaVerifier {
+	if secret == "" {
+		return noCaptcha{}
+	}
+	return &siteVerifier{
+		secret:    secret,
+		verifyURL: verifyURL,
+		client:    &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+type noCaptcha struct{}
+
+func (noCaptcha) Verify(token, remoteIP string) error {
+	return nil
+}
+
+type siteVerifier struct {
+	secret    string
+	verifyURL string
+	client    *http.Client
+}
+
+func (v *siteVerifier) Verify(token, remoteIP string) error {
+	if token == "" {
+		return ErrCaptchaFailed
+	}
+
+	resp, err := v.client.PostForm(v.verifyURL, url.Values{
+		"secret":   {v.secret},
+		"response": {token},
+		"remoteip": {remoteIP},
+	})
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	var result struct {
+		Success bool `json:"success"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return err
+	}
+	if !result.Success {
+		return ErrCaptchaFailed
+	}
+	return nil
+}
@@ -0,0 +1,214 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/maintenance-system/api/internal/domain"
+	"github.com/maintenance-system/api/internal/repository"
+)
+
+var (
+	ErrCategoryNotFound = errors.New("category not found")
+	ErrCategoryExists   = errors.New("a category with this code already exists")
+	ErrInvalidCategory  = errors.New("invalid category")
+	ErrCategoryInUse    = errors.New("category is still used by tickets, settings or subcategories")
+)
+
+// typeCodePattern is what category and priority codes look like. They are
+// stored in varchar(20) columns.
+var typeCodePattern = regexp.MustCompile(`^[A-Z][A-Z0-9_]{0,19}$`)
+
+type CategoryService interface {
+	GetAll() ([]domain.Category, error)
+	GetByID(id uuid.UUID) (*domain.Category, error)
+	Create(category *domain.Category) error
+	// Update changes the category. The code is fixed; parentId uuid.Nil
+	// moves it to the top level and defaultAssigneeId uuid.Nil clears it.
+	Update(id uuid.UUID, updates map[string]interface{}) (*domain.Category, error)
+	// Delete removes a category that nothing uses; deactivate it otherwise.
+	Delete(id uuid.UUID) error
+
+	// IsActive reports whether code is a category that can be chosen.
+	IsActive(code domain.TicketCategory) bool
+	// ApplyDefaults gives a new ticket the default priority and assignee
+	// of its category where it has none of its own.
+	ApplyDefaults(ticket *domain.Ticket) error
+}
+
+type categoryService struct {
+	repo         repository.CategoryRepository
+	priorityRepo repository.PriorityRepository
+	userRepo     repository.UserRepository
+}
+
+func NewCategoryService(repo repository.CategoryRepository, priorityRepo repository.PriorityRepository, userRepo repository.UserRepository) CategoryService {
+	return &categoryService{
+		repo:         repo,
+		priorityRepo: priorityRepo,
+		userRepo:     userRepo,
+	}
+}
+
+func (s *categoryService) GetAll() ([]domain.Category, error) {
+	return s.repo.FindAll()
+}
+
+func (s *categoryService) GetByID(id uuid.UUID) (*domain.Category, error) {
+	category, err := s.repo.FindByID(id)
+	if err != nil {
+		return nil, ErrCategoryNotFound
+	}
+	return category, nil
+}
+
+func (s *categoryService) Create(category *domain.Category) error {
+	category.Code = domain.TicketCategory(strings.ToUpper(strings.TrimSpace(string(category.Code))))
+	if !typeCodePattern.MatchString(string(category.Code)) {
+		return invalidCategory(errors.New("code must be up to 20 capital letters, digits and underscores, starting with a letter"))
+	}
+	if _, err := s.repo.FindByCode(category.Code); err == nil {
+		return ErrCategoryExists
+	}
+	if err := s.validate(category); err != nil {
+		return err
+	}
+	return s.repo.Create(category)
+}
+
+func (s *categoryService) Update(id uuid.UUID, updates map[string]interface{}) (*domain.Category, error) {
+	category, err := s.repo.FindByID(id)
+	if err != nil {
+		return nil, ErrCategoryNotFound
+	}
+
+	if code, ok := updates["code"].(string); ok && domain.TicketCategory(code) != category.Code {
+		return nil, invalidCategory(errors.New("the code cannot be changed once created"))
+	}
+	if name, ok := updates["nameEn"].(string); ok {
+		category.NameEN = name
+	}
+	if name, ok := updates["nameTh"].(string); ok {
+		category.NameTH = name
+	}
+	if icon, ok := updates["icon"].(string); ok {
+		category.Icon = icon
+	}
+	if parentID, ok := updates["parentId"].(uuid.UUID); ok {
+		category.ParentID = nil
+		if parentID != uuid.Nil {
+			category.ParentID = &parentID
+		}
+	}
+	if priority, ok := updates["defaultPriority"].(string); ok {
+		category.DefaultPriority = domain.TicketPriority(priority)
+	}
+	if assigneeID, ok := updates["defaultAssigneeId"].(uuid.UUID); ok {
+		category.DefaultAssigneeID = nil
+		category.DefaultAssignee = nil
+		if assigneeID != uuid.Nil {
+			category.DefaultAssigneeID = &assigneeID
+		}
+	}
+	if active, ok := updates["active"].(bool); ok {
+		category.Active = active
+	}
+	if sortOrder, ok := updates["sortOrder"].(int); ok {
+		category.SortOrder = sortOrder
+	}
+
+	if err := s.validate(category); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Update(category); err != nil {
+		return nil, err
+	}
+	return s.repo.FindByID(category.ID)
+}
+
+func (s *categoryService) Delete(id uuid.UUID) error {
+	category, err := s.repo.FindByID(id)
+	if err != nil {
+		return ErrCategoryNotFound
+	}
+	count, err := s.repo.CountUsage(category)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return ErrCategoryInUse
+	}
+	return s.repo.Delete(id)
+}
+
+func (s *categoryService) IsActive(code domain.TicketCategory) bool {
+	category, err := s.repo.FindByCode(code)
+	return err == nil && category.Active
+}
+
+func (s *categoryService) ApplyDefaults(ticket *domain.Ticket) error {
+	category, err := s.repo.FindByCode(ticket.Category)
+	if err != nil {
+		if ticket.Priority == "" {
+			ticket.Priority = domain.PriorityMedium
+		}
+		return nil
+	}
+
+	if ticket.Priority == "" {
+		ticket.Priority = category.DefaultPriority
+	}
+	if ticket.Priority == "" {
+		ticket.Priority = domain.PriorityMedium
+	}
+	if ticket.AssignedToID == nil && ticket.VendorID == nil && category.DefaultAssigneeID != nil {
+		assigneeID := *category.DefaultAssigneeID
+		ticket.AssignedToID = &assigneeID
+	}
+	return nil
+}
+
+// validate checks the fields an admin can change.
+func (s *categoryService) validate(category *domain.Category) error {
+	category.NameEN = strings.TrimSpace(category.NameEN)
+	category.NameTH = strings.TrimSpace(category.NameTH)
+	if category.NameEN == "" {
+		return invalidCategory(errors.New("an English name is required"))
+	}
+
+	// Walk up from the new parent; meeting the category itself would make
+	// it its own ancestor.
+	for parentID := category.ParentID; parentID != nil; {
+		if *parentID == category.ID {
+			return invalidCategory(errors.New("a category cannot be placed under itself or one of its subcategories"))
+		}
+		parent, err := s.repo.FindByID(*parentID)
+		if err != nil {
+			return ErrCategoryNotFound
+		}
+		parentID = parent.ParentID
+	}
+
+	if category.DefaultPriority != "" {
+		if _, err := s.priorityRepo.FindByCode(category.DefaultPriority); err != nil {
+			return invalidCategory(fmt.Errorf("unknown priority %q", category.DefaultPriority))
+		}
+	}
+	if category.DefaultAssigneeID != nil {
+		assignee, err := s.userRepo.FindByID(*category.DefaultAssigneeID)
+		if err != nil {
+			return ErrUserNotFound
+		}
+		if assignee.Role != domain.RoleTechnician && assignee.Role != domain.RoleAdmin {
+			return invalidCategory(errors.New("the default assignee must be a technician"))
+		}
+	}
+	return nil
+}
+
+func invalidCategory(err error) error {
+	return fmt.Errorf("%w: %v", ErrInvalidCategory, err)
+}
@@ -0,0 +1,396 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/maintenance-system/api/internal/domain"
+	"github.com/maintenance-system/api/internal/repository"
+	"github.com/maintenance-system/api/internal/websocket"
+)
+
+var (
+	ErrChecklistTemplateNotFound = errors.New("checklist template not found")
+	ErrInvalidChecklistTemplate  = errors.New("invalid checklist template")
+	ErrChecklistNotFound         = errors.New("checklist not found")
+	ErrChecklistItemNotFound     = errors.New("checklist item not found")
+	ErrInvalidChecklistItem      = errors.New("invalid checklist item")
+	ErrChecklistIncomplete       = errors.New("required checklist items are incomplete")
+)
+
+type ChecklistService interface {
+	GetTemplates(activeOnly bool) ([]domain.ChecklistTemplate, error)
+	GetTemplate(id uuid.UUID) (*domain.ChecklistTemplate, error)
+	CreateTemplate(template *domain.ChecklistTemplate) error
+	// UpdateTemplate applies updates; an "items" entry replaces the items.
+	// Checklists already on tickets are not changed.
+	UpdateTemplate(id uuid.UUID, updates map[string]interface{}) (*domain.ChecklistTemplate, error)
+	DeleteTemplate(id uuid.UUID) error
+
+	// ForTicket copies the active templates matching the ticket's category
+	// and asset into new checklists, ready to be created with the ticket.
+	ForTicket(ticket *domain.Ticket) ([]domain.TicketChecklist, error)
+	GetTicketChecklists(ticketID uuid.UUID) ([]domain.TicketChecklist, error)
+	// AddToTicket copies a template onto an existing ticket.
+	AddToTicket(ticketID, templateID, userID uuid.UUID) (*domain.TicketChecklist, error)
+	// RemoveFromTicket deletes a checklist from the ticket. A checklist
+	// with required items still open cannot be removed, or it could be
+	// used to skip the check before RESOLVED.
+	RemoveFromTicket(ticketID, checklistID, userID uuid.UUID) error
+	// UpdateItem applies updates ("done", "reading", "attachmentId",
+	// "note") to an item. Completing it records the user and time.
+	UpdateItem(ticketID, itemID, userID uuid.UUID, updates map[string]interface{}) (*domain.TicketChecklistItem, error)
+	// CheckComplete returns ErrChecklistIncomplete while required items on
+	// the ticket are not done.
+	CheckComplete(ticketID uuid.UUID) error
+}
+
+type checklistService struct {
+	repo           repository.ChecklistRepository
+	ticketRepo     repository.TicketRepository
+	assetRepo      repository.AssetRepository
+	attachmentRepo repository.AttachmentRepository
+	tx             repository.Transactor
+	hub            *websocket.Hub
+}
+
+func NewChecklistService(repo repository.ChecklistRepository, ticketRepo repository.TicketRepository, assetRepo repository.AssetRepository, attachmentRepo repository.AttachmentRepository, tx repository.Transactor, hub *websocket.Hub) ChecklistService {
+	return &checklistService{
+		repo:           repo,
+		ticketRepo:     ticketRepo,
+		assetRepo:      assetRepo,
+		attachmentRepo: attachmentRepo,
+		tx:             tx,
+		hub:            hub,
+	}
+}
+
+func (s *checklistService) GetTemplates(activeOnly bool) ([]domain.ChecklistTemplate, error) {
+	return s.repo.FindTemplates(activeOnly)
+}
+
+func (s *checklistService) GetTemplate(id uuid.UUID) (*domain.ChecklistTemplate, error) {
+	template, err := s.repo.FindTemplateByID(id)
+	if err != nil {
+		return nil, ErrChecklistTemplateNotFound
+	}
+	return template, nil
+}
+
+func (s *checklistService) CreateTemplate(template *domain.ChecklistTemplate) error {
+	if err := validateChecklistTemplate(template); err != nil {
+		return err
+	}
+	return s.repo.CreateTemplate(template)
+}
+
+func (s *checklistService) UpdateTemplate(id uuid.UUID, updates map[string]interface{}) (*domain.ChecklistTemplate, error) {
+	template, err := s.repo.FindTemplateByID(id)
+	if err != nil {
+		return nil, ErrChecklistTemplateNotFound
+	}
+
+	if name, ok := updates["name"].(string); ok {
+		template.Name = name
+	}
+	if description, ok := updates["description"].(string); ok {
+		template.Description = description
+	}
+	if category, ok := updates["category"].(string); ok {
+		template.Category = domain.TicketCategory(category)
+	}
+	if category, ok := updates["assetCategory"].(string); ok {
+		template.AssetCategory = domain.TicketCategory(category)
+	}
+	if active, ok := updates["active"].(bool); ok {
+		template.Active = active
+	}
+	items, replaceItems := updates["items"].([]domain.ChecklistTemplateItem)
+	if replaceItems {
+		template.Items = items
+	}
+
+	if err := validateChecklistTemplate(template); err != nil {
+		return nil, err
+	}
+	if err := s.repo.UpdateTemplate(template, replaceItems); err != nil {
+		return nil, err
+	}
+	return s.repo.FindTemplateByID(id)
+}
+
+func (s *checklistService) DeleteTemplate(id uuid.UUID) error {
+	if _, err := s.repo.FindTemplateByID(id); err != nil {
+		return ErrChecklistTemplateNotFound
+	}
+	return s.repo.DeleteTemplate(id)
+}
+
+func (s *checklistService) ForTicket(ticket *domain.Ticket) ([]domain.TicketChecklist, error) {
+	var assetCategory domain.TicketCategory
+	if ticket.AssetID != nil {
+		asset, err := s.assetRepo.FindByID(*ticket.AssetID)
+		if err != nil {
+			return nil, ErrAssetNotFound
+		}
+		assetCategory = asset.Category
+	}
+
+	templates, err := s.repo.FindMatchingTemplates(ticket.Category, assetCategory)
+	if err != nil {
+		return nil, err
+	}
+	checklists := make([]domain.TicketChecklist, 0, len(templates))
+	for i := range templates {
+		checklists = append(checklists, instantiateChecklist(&templates[i], ticket.ID))
+	}
+	return checklists, nil
+}
+
+func (s *checklistService) GetTicketChecklists(ticketID uuid.UUID) ([]domain.TicketChecklist, error) {
+	if _, err := s.ticketRepo.FindByID(ticketID); err != nil {
+		return nil, ErrTicketNotFound
+	}
+	return s.repo.FindByTicketID(ticketID)
+}
+
+func (s *checklistService) AddToTicket(ticketID, templateID, userID uuid.UUID) (*domain.TicketChecklist, error) {
+	ticket, err := s.openTicket(ticketID)
+	if err != nil {
+		return nil, err
+	}
+	template, err := s.repo.FindTemplateByID(templateID)
+	if err != nil {
+		return nil, ErrChecklistTemplateNotFound
+	}
+
+	checklist := instantiateChecklist(template, ticket.ID)
+	err = s.tx.WithinTransaction(func(r repository.Repositories) error {
+		if err := r.Checklists.CreateChecklist(&checklist); err != nil {
+			return err
+		}
+		return r.TicketLogs.Create(&domain.TicketLog{
+			TicketID: ticket.ID,
+			UserID:   userID,
+			Action:   domain.LogActionChecklistAdded,
+			NewValue: checklist.Name,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.broadcast(ticket.ID)
+	return &checklist, nil
+}
+
+func (s *checklistService) RemoveFromTicket(ticketID, checklistID, userID uuid.UUID) error {
+	ticket, err := s.openTicket(ticketID)
+	if err != nil {
+		return err
+	}
+	checklist, err := s.repo.FindChecklistByID(checklistID)
+	if err != nil || checklist.TicketID != ticket.ID {
+		return ErrChecklistNotFound
+	}
+	if n := incompleteRequired(checklist); n > 0 {
+		return fmt.Errorf("%w (%d left); complete them before removing the checklist", ErrChecklistIncomplete, n)
+	}
+
+	err = s.tx.WithinTransaction(func(r repository.Repositories) error {
+		if err := r.Checklists.DeleteChecklist(checklist.ID); err != nil {
+			return err
+		}
+		return r.TicketLogs.Create(&domain.TicketLog{
+			TicketID: ticket.ID,
+			UserID:   userID,
+			Action:   domain.LogActionChecklistRemoved,
+			OldValue: checklist.Name,
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	s.broadcast(ticket.ID)
+	return nil
+}
+
+func (s *checklistService) UpdateItem(ticketID, itemID, userID uuid.UUID, updates map[string]interface{}) (*domain.TicketChecklistItem, error) {
+	ticket, err := s.openTicket(ticketID)
+	if err != nil {
+		return nil, err
+	}
+	item, err := s.repo.FindItemByID(itemID)
+	if err != nil || item.Checklist == nil || item.Checklist.TicketID != ticket.ID {
+		return nil, ErrChecklistItemNotFound
+	}
+
+	wasDone := item.Done
+	if reading, ok := updates["reading"].(float64); ok {
+		item.Reading = &reading
+	}
+	if attachmentID, ok := updates["attachmentId"].(uuid.UUID); ok {
+		attachment, err := s.attachmentRepo.FindByID(attachmentID)
+		if err != nil || attachment.TicketID != ticket.ID {
+			return nil, ErrAttachmentNotFound
+		}
+		if !strings.HasPrefix(attachment.Type, "image/") {
+			return nil, invalidChecklistItem(errors.New("attachment is not a photo"))
+		}
+		item.AttachmentID = &attachmentID
+	}
+	if note, ok := updates["note"].(string); ok {
+		item.Note = note
+	}
+	if done, ok := updates["done"].(bool); ok {
+		item.Done = done
+	}
+
+	if item.Done {
+		switch {
+		case item.Type == domain.ChecklistReading && item.Reading == nil:
+			return nil, invalidChecklistItem(errors.New("a reading is required"))
+		case item.Type == domain.ChecklistPhoto && item.AttachmentID == nil:
+			return nil, invalidChecklistItem(errors.New("a photo is required"))
+		}
+	}
+
+	var logs []domain.TicketLog
+	switch {
+	case item.Done && !wasDone:
+		now := time.Now()
+		item.CompletedByID = &userID
+		item.CompletedAt = &now
+		logs = append(logs, newTicketLog(ticket.ID, userID, domain.LogActionChecklistItemDone, "", describeChecklistItem(item)))
+	case !item.Done && wasDone:
+		item.CompletedByID = nil
+		item.CompletedAt = nil
+		logs = append(logs, newTicketLog(ticket.ID, userID, domain.LogActionChecklistReopened, "", item.Label))
+	}
+
+	err = s.tx.WithinTransaction(func(r repository.Repositories) error {
+		if err := r.Checklists.UpdateItem(item); err != nil {
+			return err
+		}
+		return r.TicketLogs.CreateBatch(logs)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.broadcast(ticket.ID)
+	return item, nil
+}
+
+func (s *checklistService) CheckComplete(ticketID uuid.UUID) error {
+	n, err := s.repo.CountIncomplete(ticketID)
+	if err != nil {
+		return err
+	}
+	if n > 0 {
+		return fmt.Errorf("%w (%d left)", ErrChecklistIncomplete, n)
+	}
+	return nil
+}
+
+// incompleteRequired counts the checklist's required items not yet done.
+func incompleteRequired(checklist *domain.TicketChecklist) int {
+	n := 0
+	for _, item := range checklist.Items {
+		if item.Required && !item.Done {
+			n++
+		}
+	}
+	return n
+}
+
+func (s *checklistService) openTicket(ticketID uuid.UUID) (*domain.Ticket, error) {
+	ticket, err := s.ticketRepo.FindByID(ticketID)
+	if err != nil {
+		return nil, ErrTicketNotFound
+	}
+	if ticket.Status == domain.StatusClosed {
+		return nil, ErrTicketClosed
+	}
+	return ticket, nil
+}
+
+// broadcast sends the ticket's checklists to listening clients.
+func (s *checklistService) broadcast(ticketID uuid.UUID) {
+	if s.hub == nil {
+		return
+	}
+	checklists, err := s.repo.FindByTicketID(ticketID)
+	if err != nil {
+		return
+	}
+	s.hub.Broadcast("ticket:checklists", map[string]interface{}{
+		"ticketId":   ticketID,
+		"checklists": checklists,
+	})
+}
+
+func instantiateChecklist(template *domain.ChecklistTemplate, ticketID uuid.UUID) domain.TicketChecklist {
+	templateID := template.ID
+	checklist := domain.TicketChecklist{
+		TicketID:   ticketID,
+		TemplateID: &templateID,
+		Name:       template.Name,
+		Items:      make([]domain.TicketChecklistItem, 0, len(template.Items)),
+	}
+	for _, item := range template.Items {
+		checklist.Items = append(checklist.Items, domain.TicketChecklistItem{
+			Position: item.Position,
+			Label:    item.Label,
+			Type:     item.Type,
+			Required: item.Required,
+			Unit:     item.Unit,
+		})
+	}
+	return checklist
+}
+
+func validateChecklistTemplate(template *domain.ChecklistTemplate) error {
+	if template.Name == "" {
+		return invalidChecklistTemplate(errors.New("name is required"))
+	}
+	if len(template.Items) == 0 {
+		return invalidChecklistTemplate(errors.New("at least one item is required"))
+	}
+	for i := range template.Items {
+		item := &template.Items[i]
+		if item.Label == "" {
+			return invalidChecklistTemplate(fmt.Errorf("item %d: label is required", i+1))
+		}
+		switch item.Type {
+		case "":
+			item.Type = domain.ChecklistCheck
+		case domain.ChecklistCheck, domain.ChecklistReading, domain.ChecklistPhoto:
+		default:
+			return invalidChecklistTemplate(fmt.Errorf("item %d: unknown type %q", i+1, item.Type))
+		}
+		item.Position = i + 1
+	}
+	return nil
+}
+
+func describeChecklistItem(item *domain.TicketChecklistItem) string {
+	if item.Type == domain.ChecklistReading && item.Reading != nil {
+		reading := strconv.FormatFloat(*item.Reading, 'f', -1, 64)
+		return strings.TrimSpace(fmt.Sprintf("%s: %s %s", item.Label, reading, item.Unit))
+	}
+	return item.Label
+}
+
+func invalidChecklistTemplate(err error) error {
+	return fmt.Errorf("%w: %v", ErrInvalidChecklistTemplate, err)
+}
+
+func invalidChecklistItem(err error) error {
+	return fmt.Errorf("%w: %v", ErrInvalidChecklistItem, err)
+}
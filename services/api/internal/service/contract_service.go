@@ -0,0 +1,238 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/maintenance-system/api/internal/domain"
+	"github.com/maintenance-system/api/internal/repository"
+)
+
+var (
+	ErrContractNotFound = errors.New("contract not found")
+	ErrInvalidContract  = errors.New("invalid contract")
+)
+
+type ContractService interface {
+	GetAll(filter repository.ContractFilter) ([]domain.ServiceContract, error)
+	GetByID(id uuid.UUID) (*domain.ServiceContract, error)
+	Create(contract *domain.ServiceContract) error
+	Update(id uuid.UUID, updates map[string]interface{}) (*domain.ServiceContract, error)
+	Delete(id uuid.UUID) error
+
+	// Covering returns the contract in force on the asset at the given
+	// time, or nil if there is none.
+	Covering(assetID uuid.UUID, at time.Time) (*domain.ServiceContract, error)
+	// Expiring returns the contracts ending within the next days days.
+	Expiring(days int, now time.Time) ([]domain.ServiceContract, error)
+	// RemindExpiring notifies admins once about each contract ending
+	// within the reminder window and returns how many were reminded.
+	RemindExpiring(ctx context.Context, now time.Time) (int, error)
+}
+
+type contractService struct {
+	repo         repository.ContractRepository
+	assetRepo    repository.AssetRepository
+	notifier     NotificationService
+	reminderDays int
+}
+
+func NewContractService(repo repository.ContractRepository, assetRepo repository.AssetRepository, notifier NotificationService, reminderDays int) ContractService {
+	return &contractService{
+		repo:         repo,
+		assetRepo:    assetRepo,
+		notifier:     notifier,
+		reminderDays: reminderDays,
+	}
+}
+
+func (s *contractService) GetAll(filter repository.ContractFilter) ([]domain.ServiceContract, error) {
+	return s.repo.FindAll(filter)
+}
+
+func (s *contractService) GetByID(id uuid.UUID) (*domain.ServiceContract, error) {
+	contract, err := s.repo.FindByID(id)
+	if err != nil {
+		return nil, ErrContractNotFound
+	}
+	return contract, nil
+}
+
+func (s *contractService) Create(contract *domain.ServiceContract) error {
+	if _, err := s.assetRepo.FindByID(contract.AssetID); err != nil {
+		return ErrAssetNotFound
+	}
+	if err := validateContract(contract); err != nil {
+		return err
+	}
+	contract.ReminderSentAt = nil
+	if err := s.repo.Create(contract); err != nil {
+		return err
+	}
+	created, err := s.repo.FindByID(contract.ID)
+	if err != nil {
+		return err
+	}
+	*contract = *created
+	return nil
+}
+
+func (s *contractService) Update(id uuid.UUID, updates map[string]interface{}) (*domain.ServiceContract, error) {
+	contract, err := s.repo.FindByID(id)
+	if err != nil {
+		return nil, ErrContractNotFound
+	}
+
+	if contractType, ok := updates["type"].(string); ok {
+		contract.Type = domain.ContractType(contractType)
+	}
+	if vendor, ok := updates["vendor"].(string); ok {
+		contract.Vendor = vendor
+	}
+	if number, ok := updates["contractNumber"].(string); ok {
+		contract.ContractNumber = number
+	}
+	if coverage, ok := updates["coverage"].(string); ok {
+		contract.Coverage = coverage
+	}
+	if startsAt, ok := updates["startsAt"].(time.Time); ok {
+		contract.StartsAt = startsAt
+	}
+	if endsAt, ok := updates["endsAt"].(time.Time); ok && !endsAt.Equal(contract.EndsAt) {
+		contract.EndsAt = endsAt
+		// A renewed contract gets a fresh reminder.
+		contract.ReminderSentAt = nil
+	}
+	if name, ok := updates["contactName"].(string); ok {
+		contract.ContactName = name
+	}
+	if phone, ok := updates["contactPhone"].(string); ok {
+		contract.ContactPhone = phone
+	}
+	if email, ok := updates["contactEmail"].(string); ok {
+		contract.ContactEmail = email
+	}
+	if notes, ok := updates["notes"].(string); ok {
+		contract.Notes = notes
+	}
+
+	if err := validateContract(contract); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Update(contract); err != nil {
+		return nil, err
+	}
+	return contract, nil
+}
+
+func (s *contractService) Delete(id uuid.UUID) error {
+	if _, err := s.repo.FindByID(id); err != nil {
+		return ErrContractNotFound
+	}
+	return s.repo.Delete(id)
+}
+
+func (s *contractService) Covering(assetID uuid.UUID, at time.Time) (*domain.ServiceContract, error) {
+	contract, err := s.repo.FindCovering(assetID, at)
+	if err != nil {
+		return nil, fmt.Errorf("looking up covering contract: %w", err)
+	}
+	return contract, nil
+}
+
+func (s *contractService) Expiring(days int, now time.Time) ([]domain.ServiceContract, error) {
+	if days <= 0 {
+		days = 30
+	}
+	return s.repo.FindExpiring(now, now.AddDate(0, 0, days))
+}
+
+func (s *contractService) RemindExpiring(ctx context.Context, now time.Time) (int, error) {
+	if s.reminderDays <= 0 {
+		return 0, nil
+	}
+	contracts, err := s.repo.FindDueReminders(now, now.AddDate(0, 0, s.reminderDays))
+	if err != nil {
+		return 0, err
+	}
+
+	reminded := 0
+	for i := range contracts {
+		if ctx.Err() != nil {
+			return reminded, ctx.Err()
+		}
+		contract := &contracts[i]
+		// Claim first so that concurrent runs remind about it only once.
+		claimed, err := s.repo.ClaimReminder(contract.ID, now)
+		if err != nil {
+			log.Printf("Contract %s: %v", contract.ID, err)
+			continue
+		}
+		if !claimed {
+			continue
+		}
+		if err := s.notifier.NotifyRoles([]domain.UserRole{domain.RoleAdmin}, domain.Notification{
+			Type:    NotificationWarning,
+			Title:   "Contract expiring: " + contractAssetName(contract),
+			Message: describeContractExpiry(contract, now),
+		}); err != nil {
+			log.Printf("Contract %s: %v", contract.ID, err)
+			// Try again on the next run.
+			if err := s.repo.ReleaseReminder(contract.ID, now); err != nil {
+				log.Printf("Contract %s: %v", contract.ID, err)
+			}
+			continue
+		}
+		contract.ReminderSentAt = &now
+		reminded++
+	}
+	return reminded, nil
+}
+
+func validateContract(contract *domain.ServiceContract) error {
+	switch contract.Type {
+	case "":
+		contract.Type = domain.ContractWarranty
+	case domain.ContractWarranty, domain.ContractService:
+	default:
+		return invalidContract(fmt.Errorf("unknown contract type %q", contract.Type))
+	}
+	if contract.Vendor == "" {
+		return invalidContract(errors.New("vendor is required"))
+	}
+	if contract.StartsAt.IsZero() || contract.EndsAt.IsZero() {
+		return invalidContract(errors.New("start and end are required"))
+	}
+	if !contract.EndsAt.After(contract.StartsAt) {
+		return invalidContract(errors.New("end must be after start"))
+	}
+	return nil
+}
+
+func contractAssetName(contract *domain.ServiceContract) string {
+	if contract.Asset == nil {
+		return contract.Vendor
+	}
+	return fmt.Sprintf("%s %s", contract.Asset.Code, contract.Asset.Name)
+}
+
+func describeContractExpiry(contract *domain.ServiceContract, now time.Time) string {
+	days := int(contract.EndsAt.Sub(now).Hours() / 24)
+	kind := "Warranty"
+	if contract.Type == domain.ContractService {
+		kind = "Service contract"
+	}
+	msg := fmt.Sprintf("%s with %s", kind, contract.Vendor)
+	if contract.ContractNumber != "" {
+		msg += fmt.Sprintf(" (%s)", contract.ContractNumber)
+	}
+	return fmt.Sprintf("%s ends on %s, in %d days.", msg, contract.EndsAt.Format("2006-01-02"), days)
+}
+
+func invalidContract(err error) error {
+	return fmt.Errorf("%w: %v", ErrInvalidContract, err)
+}
@@ -0,0 +1,118 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/maintenance-system/api/internal/domain"
+	"github.com/maintenance-system/api/internal/repository"
+)
+
+var (
+	ErrApprovalThresholdNotFound = errors.New("approval threshold not found")
+	ErrApprovalThresholdExists   = errors.New("an approval threshold already exists for this department and category")
+	ErrInvalidApprovalThreshold  = errors.New("invalid approval threshold")
+)
+
+type CostApprovalService interface {
+	GetAll() ([]domain.ApprovalThreshold, error)
+	GetByID(id uuid.UUID) (*domain.ApprovalThreshold, error)
+	Create(threshold *domain.ApprovalThreshold) error
+	Update(id uuid.UUID, updates map[string]interface{}) (*domain.ApprovalThreshold, error)
+	Delete(id uuid.UUID) error
+
+	// ThresholdFor returns the approval threshold that applies to a ticket
+	// in category raised by someone in department, and false if spending
+	// needs no approval. An error means the thresholds could not be read;
+	// callers must not treat that as "no approval needed".
+	ThresholdFor(department string, category domain.TicketCategory) (float64, bool, error)
+}
+
+type costApprovalService struct {
+	repo repository.ApprovalThresholdRepository
+}
+
+func NewCostApprovalService(repo repository.ApprovalThresholdRepository) CostApprovalService {
+	return &costApprovalService{repo: repo}
+}
+
+func (s *costApprovalService) GetAll() ([]domain.ApprovalThreshold, error) {
+	return s.repo.FindAll()
+}
+
+func (s *costApprovalService) GetByID(id uuid.UUID) (*domain.ApprovalThreshold, error) {
+	threshold, err := s.repo.FindByID(id)
+	if err != nil {
+		return nil, ErrApprovalThresholdNotFound
+	}
+	return threshold, nil
+}
+
+func (s *costApprovalService) Create(threshold *domain.ApprovalThreshold) error {
+	if threshold.Amount < 0 {
+		return fmt.Errorf("%w: amount cannot be negative", ErrInvalidApprovalThreshold)
+	}
+	if err := s.checkScope(threshold, uuid.Nil); err != nil {
+		return err
+	}
+	return s.repo.Create(threshold)
+}
+
+func (s *costApprovalService) Update(id uuid.UUID, updates map[string]interface{}) (*domain.ApprovalThreshold, error) {
+	threshold, err := s.repo.FindByID(id)
+	if err != nil {
+		return nil, ErrApprovalThresholdNotFound
+	}
+
+	if department, ok := updates["department"].(string); ok {
+		threshold.Department = department
+	}
+	if category, ok := updates["category"].(string); ok {
+		threshold.Category = domain.TicketCategory(category)
+	}
+	if amount, ok := updates["amount"].(float64); ok {
+		if amount < 0 {
+			return nil, fmt.Errorf("%w: amount cannot be negative", ErrInvalidApprovalThreshold)
+		}
+		threshold.Amount = amount
+	}
+
+	if err := s.checkScope(threshold, id); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Update(threshold); err != nil {
+		return nil, err
+	}
+	return threshold, nil
+}
+
+// checkScope makes sure no other threshold covers the same department and
+// category.
+func (s *costApprovalService) checkScope(threshold *domain.ApprovalThreshold, except uuid.UUID) error {
+	thresholds, err := s.repo.FindAll()
+	if err != nil {
+		return err
+	}
+	for _, t := range thresholds {
+		if t.ID != except && t.Department == threshold.Department && t.Category == threshold.Category {
+			return ErrApprovalThresholdExists
+		}
+	}
+	return nil
+}
+
+func (s *costApprovalService) Delete(id uuid.UUID) error {
+	return s.repo.Delete(id)
+}
+
+func (s *costApprovalService) ThresholdFor(department string, category domain.TicketCategory) (float64, bool, error) {
+	threshold, err := s.repo.FindMatch(department, category)
+	if err != nil {
+		return 0, false, fmt.Errorf("looking up approval threshold: %w", err)
+	}
+	if threshold == nil {
+		return 0, false, nil
+	}
+	return threshold.Amount, true, nil
+}
@@ -0,0 +1,402 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/maintenance-system/api/internal/domain"
+	"github.com/maintenance-system/api/internal/repository"
+)
+
+var (
+	ErrCustomFieldNotFound = errors.New("custom field not found")
+	ErrCustomFieldExists   = errors.New("a custom field with this key already exists")
+	ErrInvalidCustomField  = errors.New("invalid custom field")
+	ErrInvalidCustomValue  = errors.New("invalid custom field value")
+	ErrInvalidCustomFilter = errors.New("invalid custom field filter")
+)
+
+var customFieldKeyPattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,49}$`)
+
+// maxCustomTextLength caps text values.
+const maxCustomTextLength = 1000
+
+type CustomFieldService interface {
+	// GetAll returns every field, or only the active ones that apply to
+	// category if it is given.
+	GetAll(category domain.TicketCategory) ([]domain.CustomField, error)
+	GetByID(id uuid.UUID) (*domain.CustomField, error)
+	Create(field *domain.CustomField) error
+	Update(id uuid.UUID, updates map[string]interface{}) (*domain.CustomField, error)
+	// Delete removes the field and its values from every ticket.
+	Delete(id uuid.UUID) error
+
+	// Validate checks values against the fields of the category and
+	// returns them laid over current; a null value clears its field.
+	// Current values of fields that do not apply to the category are
+	// dropped, and every required active field must end up set.
+	Validate(category domain.TicketCategory, values map[string]interface{}, current domain.JSONMap) (domain.JSONMap, error)
+	// Filter parses a filter on the field with key, written "op:value" or
+	// just "value" for equality.
+	Filter(key, expr string) (repository.CustomFieldFilter, error)
+	// Sort orders tickets by the field with key.
+	Sort(key string, desc bool) (*repository.CustomFieldSort, error)
+}
+
+type customFieldService struct {
+	repo     repository.CustomFieldRepository
+	userRepo repository.UserRepository
+}
+
+func NewCustomFieldService(repo repository.CustomFieldRepository, userRepo repository.UserRepository) CustomFieldService {
+	return &customFieldService{repo: repo, userRepo: userRepo}
+}
+
+func (s *customFieldService) GetAll(category domain.TicketCategory) ([]domain.CustomField, error) {
+	fields, err := s.repo.FindAll()
+	if err != nil || category == "" {
+		return fields, err
+	}
+	applicable := make([]domain.CustomField, 0, len(fields))
+	for _, field := range fields {
+		if field.Active && field.AppliesTo(category) {
+			applicable = append(applicable, field)
+		}
+	}
+	return applicable, nil
+}
+
+func (s *customFieldService) GetByID(id uuid.UUID) (*domain.CustomField, error) {
+	field, err := s.repo.FindByID(id)
+	if err != nil {
+		return nil, ErrCustomFieldNotFound
+	}
+	return field, nil
+}
+
+func (s *customFieldService) Create(field *domain.CustomField) error {
+	field.Key = strings.TrimSpace(field.Key)
+	if !customFieldKeyPattern.MatchString(field.Key) {
+		return invalidCustomField(errors.New("key must be lowercase letters, digits and underscores, starting with a letter"))
+	}
+	if !field.Type.IsValid() {
+		return invalidCustomField(fmt.Errorf("unknown type %q", field.Type))
+	}
+	if err := validateCustomField(field); err != nil {
+		return err
+	}
+	if _, err := s.repo.FindByKey(field.Key); err == nil {
+		return ErrCustomFieldExists
+	}
+	return s.repo.Create(field)
+}
+
+func (s *customFieldService) Update(id uuid.UUID, updates map[string]interface{}) (*domain.CustomField, error) {
+	field, err := s.repo.FindByID(id)
+	if err != nil {
+		return nil, ErrCustomFieldNotFound
+	}
+
+	if key, ok := updates["key"].(string); ok && key != field.Key {
+		return nil, invalidCustomField(errors.New("key and type cannot be changed once created"))
+	}
+	if fieldType, ok := updates["type"].(domain.CustomFieldType); ok && fieldType != field.Type {
+		return nil, invalidCustomField(errors.New("key and type cannot be changed once created"))
+	}
+	if label, ok := updates["label"].(string); ok {
+		field.Label = label
+	}
+	if description, ok := updates["description"].(string); ok {
+		field.Description = description
+	}
+	if categories, ok := updates["categories"].(domain.TicketCategories); ok {
+		field.Categories = categories
+	}
+	if options, ok := updates["options"].(domain.StringList); ok {
+		field.Options = options
+	}
+	if required, ok := updates["required"].(bool); ok {
+		field.Required = required
+	}
+	if active, ok := updates["active"].(bool); ok {
+		field.Active = active
+	}
+	if sortOrder, ok := updates["sortOrder"].(int); ok {
+		field.SortOrder = sortOrder
+	}
+
+	if err := validateCustomField(field); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Update(field); err != nil {
+		return nil, err
+	}
+	return field, nil
+}
+
+func (s *customFieldService) Delete(id uuid.UUID) error {
+	field, err := s.repo.FindByID(id)
+	if err != nil {
+		return ErrCustomFieldNotFound
+	}
+	return s.repo.Delete(field)
+}
+
+func (s *customFieldService) Validate(category domain.TicketCategory, values map[string]interface{}, current domain.JSONMap) (domain.JSONMap, error) {
+	fields, err := s.fieldsByKey()
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(domain.JSONMap, len(current)+len(values))
+	for key, value := range current {
+		if field, ok := fields[key]; ok && field.AppliesTo(category) {
+			result[key] = value
+		}
+	}
+
+	for key, value := range values {
+		field, ok := fields[key]
+		if !ok {
+			return nil, invalidCustomValue(fmt.Errorf("unknown field %q", key))
+		}
+		if !field.AppliesTo(category) {
+			return nil, invalidCustomValue(fmt.Errorf("%s does not apply to %s tickets", field.Label, category))
+		}
+		if value == nil {
+			delete(result, key)
+			continue
+		}
+		if !field.Active {
+			return nil, invalidCustomValue(fmt.Errorf("%s is no longer in use", field.Label))
+		}
+		normalized, err := s.normalize(field, value)
+		if err != nil {
+			return nil, invalidCustomValue(fmt.Errorf("%s: %v", field.Label, err))
+		}
+		result[key] = normalized
+	}
+
+	for key, field := range fields {
+		if !field.Required || !field.Active || !field.AppliesTo(category) {
+			continue
+		}
+		if _, ok := result[key]; !ok {
+			return nil, invalidCustomValue(fmt.Errorf("%s is required", field.Label))
+		}
+	}
+	return result, nil
+}
+
+func (s *customFieldService) Filter(key, expr string) (repository.CustomFieldFilter, error) {
+	field, err := s.repo.FindByKey(key)
+	if err != nil {
+		return repository.CustomFieldFilter{}, invalidCustomFilter(fmt.Errorf("unknown field %q", key))
+	}
+
+	op, raw := repository.FieldOpEq, expr
+	if i := strings.Index(expr, ":"); i > 0 && isFieldOp(expr[:i]) {
+		op, raw = expr[:i], expr[i+1:]
+	}
+	filter := repository.CustomFieldFilter{Key: field.Key, Type: field.Type, Op: op}
+
+	switch op {
+	case repository.FieldOpExists:
+		exists, err := strconv.ParseBool(raw)
+		if err != nil {
+			return filter, invalidCustomFilter(fmt.Errorf("%s: exists takes true or false", key))
+		}
+		filter.Value = exists
+		return filter, nil
+	case repository.FieldOpLt, repository.FieldOpLte, repository.FieldOpGt, repository.FieldOpGte:
+		if field.Type != domain.FieldNumber && field.Type != domain.FieldDate {
+			return filter, invalidCustomFilter(fmt.Errorf("%s: %s only applies to number and date fields", key, op))
+		}
+	case repository.FieldOpContains:
+		if field.Type != domain.FieldText && field.Type != domain.FieldMultiSelect {
+			return filter, invalidCustomFilter(fmt.Errorf("%s: contains only applies to text and multi-select fields", key))
+		}
+		filter.Value = raw
+		return filter, nil
+	}
+
+	value, err := parseFieldValue(field, raw)
+	if err != nil {
+		return filter, invalidCustomFilter(fmt.Errorf("%s: %v", key, err))
+	}
+	filter.Value = value
+	return filter, nil
+}
+
+func (s *customFieldService) Sort(key string, desc bool) (*repository.CustomFieldSort, error) {
+	field, err := s.repo.FindByKey(key)
+	if err != nil {
+		return nil, invalidCustomFilter(fmt.Errorf("unknown field %q", key))
+	}
+	if field.Type == domain.FieldMultiSelect {
+		return nil, invalidCustomFilter(fmt.Errorf("%s: multi-select fields cannot be sorted", key))
+	}
+	return &repository.CustomFieldSort{Key: field.Key, Type: field.Type, Desc: desc}, nil
+}
+
+func (s *customFieldService) fieldsByKey() (map[string]*domain.CustomField, error) {
+	fields, err := s.repo.FindAll()
+	if err != nil {
+		return nil, err
+	}
+	byKey := make(map[string]*domain.CustomField, len(fields))
+	for i := range fields {
+		byKey[fields[i].Key] = &fields[i]
+	}
+	return byKey, nil
+}
+
+// normalize checks a JSON value against the field's type and returns it in
+// the form it is stored in.
+func (s *customFieldService) normalize(field *domain.CustomField, value interface{}) (interface{}, error) {
+	switch field.Type {
+	case domain.FieldText:
+		text, ok := value.(string)
+		if !ok {
+			return nil, errors.New("must be text")
+		}
+		text = strings.TrimSpace(text)
+		if len([]rune(text)) > maxCustomTextLength {
+			return nil, fmt.Errorf("must be at most %d characters", maxCustomTextLength)
+		}
+		return text, nil
+	case domain.FieldNumber:
+		number, ok := value.(float64)
+		if !ok {
+			return nil, errors.New("must be a number")
+		}
+		return number, nil
+	case domain.FieldBoolean:
+		flag, ok := value.(bool)
+		if !ok {
+			return nil, errors.New("must be true or false")
+		}
+		return flag, nil
+	case domain.FieldMultiSelect:
+		items, ok := value.([]interface{})
+		if !ok {
+			return nil, errors.New("must be a list of choices")
+		}
+		choices := make([]string, 0, len(items))
+		seen := make(map[string]bool, len(items))
+		for _, item := range items {
+			choice, ok := item.(string)
+			if !ok || !field.HasOption(choice) {
+				return nil, fmt.Errorf("%v is not one of the choices", item)
+			}
+			if !seen[choice] {
+				seen[choice] = true
+				choices = append(choices, choice)
+			}
+		}
+		return choices, nil
+	}
+
+	raw, ok := value.(string)
+	if !ok {
+		return nil, errors.New("must be a string")
+	}
+	parsed, err := parseFieldValue(field, raw)
+	if err != nil {
+		return nil, err
+	}
+	if field.Type == domain.FieldUser {
+		if _, err := s.userRepo.FindByID(uuid.MustParse(parsed.(string))); err != nil {
+			return nil, errors.New("user not found")
+		}
+	}
+	return parsed, nil
+}
+
+// parseFieldValue reads a single value of the field from text, as given
+// in a query string.
+func parseFieldValue(field *domain.CustomField, raw string) (interface{}, error) {
+	switch field.Type {
+	case domain.FieldNumber:
+		number, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return nil, errors.New("must be a number")
+		}
+		return number, nil
+	case domain.FieldBoolean:
+		flag, err := strconv.ParseBool(raw)
+		if err != nil {
+			return nil, errors.New("must be true or false")
+		}
+		return flag, nil
+	case domain.FieldDate:
+		date, err := time.Parse("2006-01-02", raw)
+		if err != nil {
+			return nil, errors.New("must be a date like 2026-03-31")
+		}
+		return date.Format("2006-01-02"), nil
+	case domain.FieldSelect, domain.FieldMultiSelect:
+		if !field.HasOption(raw) {
+			return nil, fmt.Errorf("%q is not one of the choices", raw)
+		}
+		return raw, nil
+	case domain.FieldUser:
+		id, err := uuid.Parse(raw)
+		if err != nil {
+			return nil, errors.New("must be a user ID")
+		}
+		return id.String(), nil
+	default:
+		return raw, nil
+	}
+}
+
+func isFieldOp(op string) bool {
+	switch op {
+	case repository.FieldOpEq, repository.FieldOpNe, repository.FieldOpLt, repository.FieldOpLte,
+		repository.FieldOpGt, repository.FieldOpGte, repository.FieldOpContains, repository.FieldOpExists:
+		return true
+	}
+	return false
+}
+
+func validateCustomField(field *domain.CustomField) error {
+	field.Label = strings.TrimSpace(field.Label)
+	if field.Label == "" {
+		return invalidCustomField(errors.New("label is required"))
+	}
+	hasOptions := field.Type == domain.FieldSelect || field.Type == domain.FieldMultiSelect
+	if hasOptions && len(field.Options) == 0 {
+		return invalidCustomField(errors.New("select fields need at least one option"))
+	}
+	if !hasOptions && len(field.Options) > 0 {
+		return invalidCustomField(errors.New("only select fields take options"))
+	}
+	seen := make(map[string]bool, len(field.Options))
+	for i, option := range field.Options {
+		option = strings.TrimSpace(option)
+		if option == "" || seen[option] {
+			return invalidCustomField(errors.New("options must be distinct and not empty"))
+		}
+		seen[option] = true
+		field.Options[i] = option
+	}
+	return nil
+}
+
+func invalidCustomField(err error) error {
+	return fmt.Errorf("%w: %v", ErrInvalidCustomField, err)
+}
+
+func invalidCustomValue(err error) error {
+	return fmt.Errorf("%w: %v", ErrInvalidCustomValue, err)
+}
+
+func invalidCustomFilter(err error) error {
+	return fmt.Errorf("%w: %v", ErrInvalidCustomFilter, err)
+}
@@ -0,0 +1,369 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/maintenance-system/api/internal/domain"
+	"github.com/maintenance-system/api/internal/repository"
+)
+
+var (
+	ErrDepartmentNotFound = errors.New("department not found")
+	ErrDepartmentExists   = errors.New("a department with this name or cost center already exists")
+	ErrInvalidDepartment  = errors.New("invalid department")
+	ErrDepartmentInUse    = errors.New("department still has users or tickets")
+)
+
+// budgetAlertPercents are the shares of a budget at which the department
+// is warned, highest first.
+var budgetAlertPercents = []int{100, 80}
+
+// DepartmentBudget compares a department's budget for a year with what its
+// tickets cost, month by month.
+type DepartmentBudget struct {
+	Department  domain.Department `json:"department"`
+	Year        int               `json:"year"`
+	Budget      float64           `json:"budget"`
+	Actual      float64           `json:"actual"`
+	Remaining   float64           `json:"remaining"`
+	PercentUsed *float64          `json:"percentUsed"` // nil without a budget
+	Tickets     int64             `json:"tickets"`
+	Months      []MonthBudget     `json:"months"`
+}
+
+type MonthBudget struct {
+	Month       string   `json:"month"` // "2026-03"
+	Budget      float64  `json:"budget"`
+	Actual      float64  `json:"actual"`
+	Remaining   float64  `json:"remaining"`
+	PercentUsed *float64 `json:"percentUsed"`
+	Tickets     int64    `json:"tickets"`
+}
+
+type DepartmentService interface {
+	GetAll() ([]domain.Department, error)
+	GetByID(id uuid.UUID) (*domain.Department, error)
+	Create(department *domain.Department) error
+	Update(id uuid.UUID, updates map[string]interface{}) (*domain.Department, error)
+	Delete(id uuid.UUID) error
+
+	// BudgetReport returns budget against actual for every department in
+	// the year.
+	BudgetReport(year int) ([]DepartmentBudget, error)
+	// Budget returns budget against actual for one department in the year.
+	Budget(id uuid.UUID, year int) (*DepartmentBudget, error)
+	// CheckBudgets warns admins and department heads once when a
+	// department's spending reaches 80% or 100% of its budget for the
+	// current month or year, and returns how many warnings were sent.
+	CheckBudgets(ctx context.Context, now time.Time) (int, error)
+}
+
+type departmentService struct {
+	repo     repository.DepartmentRepository
+	userRepo repository.UserRepository
+	notifier NotificationService
+}
+
+func NewDepartmentService(repo repository.DepartmentRepository, userRepo repository.UserRepository, notifier NotificationService) DepartmentService {
+	return &departmentService{
+		repo:     repo,
+		userRepo: userRepo,
+		notifier: notifier,
+	}
+}
+
+func (s *departmentService) GetAll() ([]domain.Department, error) {
+	return s.repo.FindAll()
+}
+
+func (s *departmentService) GetByID(id uuid.UUID) (*domain.Department, error) {
+	department, err := s.repo.FindByID(id)
+	if err != nil {
+		return nil, ErrDepartmentNotFound
+	}
+	return department, nil
+}
+
+func (s *departmentService) Create(department *domain.Department) error {
+	if err := validateDepartment(department); err != nil {
+		return err
+	}
+	if err := s.checkUnique(department); err != nil {
+		return err
+	}
+	return s.repo.Create(department)
+}
+
+func (s *departmentService) Update(id uuid.UUID, updates map[string]interface{}) (*domain.Department, error) {
+	department, err := s.repo.FindByID(id)
+	if err != nil {
+		return nil, ErrDepartmentNotFound
+	}
+	previousName := department.Name
+
+	if name, ok := updates["name"].(string); ok {
+		department.Name = name
+	}
+	if costCenter, ok := updates["costCenter"].(string); ok {
+		department.CostCenter = costCenter
+	}
+	if annual, ok := updates["annualBudget"].(float64); ok {
+		department.AnnualBudget = annual
+	}
+	if monthly, ok := updates["monthlyBudget"].(float64); ok {
+		department.MonthlyBudget = monthly
+	}
+
+	if err := validateDepartment(department); err != nil {
+		return nil, err
+	}
+	if err := s.checkUnique(department); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Update(department, previousName); err != nil {
+		return nil, err
+	}
+	return department, nil
+}
+
+func (s *departmentService) Delete(id uuid.UUID) error {
+	if _, err := s.repo.FindByID(id); err != nil {
+		return ErrDepartmentNotFound
+	}
+	count, err := s.repo.CountReferences(id)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return ErrDepartmentInUse
+	}
+	return s.repo.Delete(id)
+}
+
+// checkUnique rejects a name or cost center already used by another
+// department.
+func (s *departmentService) checkUnique(department *domain.Department) error {
+	departments, err := s.repo.FindAll()
+	if err != nil {
+		return err
+	}
+	for _, d := range departments {
+		if d.ID == department.ID {
+			continue
+		}
+		if strings.EqualFold(d.Name, department.Name) ||
+			(department.CostCenter != "" && d.CostCenter == department.CostCenter) {
+			return ErrDepartmentExists
+		}
+	}
+	return nil
+}
+
+func (s *departmentService) BudgetReport(year int) ([]DepartmentBudget, error) {
+	departments, err := s.repo.FindAll()
+	if err != nil {
+		return nil, err
+	}
+	spending, err := s.repo.Spending(yearSpending(year, nil))
+	if err != nil {
+		return nil, err
+	}
+
+	report := make([]DepartmentBudget, 0, len(departments))
+	for _, department := range departments {
+		report = append(report, buildBudget(department, year, spending))
+	}
+	return report, nil
+}
+
+func (s *departmentService) Budget(id uuid.UUID, year int) (*DepartmentBudget, error) {
+	department, err := s.repo.FindByID(id)
+	if err != nil {
+		return nil, ErrDepartmentNotFound
+	}
+	spending, err := s.repo.Spending(yearSpending(year, &id))
+	if err != nil {
+		return nil, err
+	}
+	budget := buildBudget(*department, year, spending)
+	return &budget, nil
+}
+
+func (s *departmentService) CheckBudgets(ctx context.Context, now time.Time) (int, error) {
+	report, err := s.BudgetReport(now.Year())
+	if err != nil {
+		return 0, err
+	}
+	month := now.Format("2006-01")
+
+	sent := 0
+	for i := range report {
+		if ctx.Err() != nil {
+			return sent, ctx.Err()
+		}
+		budget := &report[i]
+		if s.alert(budget.Department, fmt.Sprint(budget.Year), budget.Budget, budget.Actual) {
+			sent++
+		}
+		for _, m := range budget.Months {
+			if m.Month == month && s.alert(budget.Department, m.Month, m.Budget, m.Actual) {
+				sent++
+			}
+		}
+	}
+	return sent, nil
+}
+
+// alert warns about the highest threshold the spending has reached, unless
+// that warning has already gone out for the period. Lower thresholds are
+// recorded alongside so they are not sent afterwards.
+func (s *departmentService) alert(department domain.Department, period string, budget, actual float64) bool {
+	if budget <= 0 {
+		return false
+	}
+	percent := 0
+	for _, p := range budgetAlertPercents {
+		if actual >= budget*float64(p)/100 {
+			percent = p
+			break
+		}
+	}
+	if percent == 0 {
+		return false
+	}
+
+	created, err := s.repo.CreateAlert(&domain.BudgetAlert{
+		DepartmentID: department.ID,
+		Period:       period,
+		Percent:      percent,
+		Budget:       budget,
+		Actual:       actual,
+	})
+	if err != nil {
+		log.Printf("Department %s: %v", department.ID, err)
+		return false
+	}
+	if !created {
+		return false
+	}
+	for _, p := range budgetAlertPercents {
+		if p < percent {
+			if _, err := s.repo.CreateAlert(&domain.BudgetAlert{
+				DepartmentID: department.ID,
+				Period:       period,
+				Percent:      p,
+				Budget:       budget,
+				Actual:       actual,
+			}); err != nil {
+				log.Printf("Department %s: %v", department.ID, err)
+			}
+		}
+	}
+
+	notification := domain.Notification{
+		Type:    NotificationWarning,
+		Title:   fmt.Sprintf("%s has used %d%% of its %s budget", department.Name, percent, period),
+		Message: fmt.Sprintf("Spent %.2f of %.2f", actual, budget),
+	}
+	if err := s.notifier.NotifyRoles([]domain.UserRole{domain.RoleAdmin}, notification); err != nil {
+		log.Printf("Department %s: %v", department.ID, err)
+	}
+	heads, err := s.userRepo.FindDepartmentHeads(department.Name)
+	if err != nil {
+		log.Printf("Department %s: %v", department.ID, err)
+		return true
+	}
+	ids := make([]uuid.UUID, 0, len(heads))
+	for _, head := range heads {
+		if head.Role != domain.RoleAdmin {
+			ids = append(ids, head.ID)
+		}
+	}
+	if len(ids) > 0 {
+		if err := s.notifier.Notify(ids, notification); err != nil {
+			log.Printf("Department %s: %v", department.ID, err)
+		}
+	}
+	return true
+}
+
+func yearSpending(year int, departmentID *uuid.UUID) repository.SpendingFilter {
+	from := time.Date(year, time.January, 1, 0, 0, 0, 0, time.Local)
+	return repository.SpendingFilter{
+		From:         from,
+		To:           from.AddDate(1, 0, 0),
+		DepartmentID: departmentID,
+	}
+}
+
+// buildBudget lays the department's spending out over the twelve months of
+// the year.
+func buildBudget(department domain.Department, year int, spending []repository.DepartmentSpending) DepartmentBudget {
+	budget := DepartmentBudget{
+		Department: department,
+		Year:       year,
+		Budget:     department.YearBudget(),
+		Months:     make([]MonthBudget, 12),
+	}
+	for i := range budget.Months {
+		budget.Months[i] = MonthBudget{
+			Month:  fmt.Sprintf("%04d-%02d", year, i+1),
+			Budget: roundCost(department.MonthBudget()),
+		}
+	}
+	for _, row := range spending {
+		if row.DepartmentID != department.ID {
+			continue
+		}
+		for i := range budget.Months {
+			if budget.Months[i].Month == row.Month {
+				budget.Months[i].Actual += row.Cost
+				budget.Months[i].Tickets += row.Tickets
+			}
+		}
+	}
+
+	for i := range budget.Months {
+		m := &budget.Months[i]
+		m.Actual = roundCost(m.Actual)
+		m.Remaining = roundCost(m.Budget - m.Actual)
+		m.PercentUsed = percentOf(m.Actual, m.Budget)
+		budget.Actual += m.Actual
+		budget.Tickets += m.Tickets
+	}
+	budget.Actual = roundCost(budget.Actual)
+	budget.Remaining = roundCost(budget.Budget - budget.Actual)
+	budget.PercentUsed = percentOf(budget.Actual, budget.Budget)
+	return budget
+}
+
+func percentOf(actual, budget float64) *float64 {
+	if budget <= 0 {
+		return nil
+	}
+	percent := math.Round(actual/budget*1000) / 10
+	return &percent
+}
+
+func validateDepartment(department *domain.Department) error {
+	department.Name = strings.TrimSpace(department.Name)
+	department.CostCenter = strings.TrimSpace(department.CostCenter)
+	if department.Name == "" {
+		return invalidDepartment(errors.New("name is required"))
+	}
+	if department.AnnualBudget < 0 || department.MonthlyBudget < 0 {
+		return invalidDepartment(errors.New("budgets cannot be negative"))
+	}
+	return nil
+}
+
+func invalidDepartment(err error) error {
+	return fmt.Errorf("%w: %v", ErrInvalidDepartment, err)
+}
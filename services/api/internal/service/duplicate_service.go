@@ -0,0 +1,86 @@
+package service
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/maintenance-system/api/internal/domain"
+	"github.com/maintenance-system/api/internal/repository"
+)
+
+const (
+	// duplicateMinScore is the lowest score reported as a likely duplicate.
+	duplicateMinScore = 0.3
+	// duplicateLimit caps how many candidates are returned.
+	duplicateLimit = 5
+	// titleWeight is the share of the score that comes from the titles; the
+	// rest comes from the descriptions.
+	titleWeight = 0.7
+)
+
+// DuplicateCandidate is an open ticket that a new ticket may repeat. Score
+// runs from 0 to 1, higher meaning more alike.
+type DuplicateCandidate struct {
+	Ticket *domain.Ticket `json:"ticket"`
+	Score  float64        `json:"score"`
+}
+
+type DuplicateService interface {
+	// Find returns the open tickets most like ticket: same category, same
+	// asset or location, raised within the duplicate window, with similar
+	// title and description. Best matches come first.
+	Find(ticket *domain.Ticket, now time.Time) ([]DuplicateCandidate, error)
+}
+
+type duplicateService struct {
+	ticketRepo repository.TicketRepository
+	windowDays int
+}
+
+// NewDuplicateService looks windowDays back for duplicates; zero or less
+// turns detection off.
+func NewDuplicateService(ticketRepo repository.TicketRepository, windowDays int) DuplicateService {
+	return &duplicateService{ticketRepo: ticketRepo, windowDays: windowDays}
+}
+
+func (s *duplicateService) Find(ticket *domain.Ticket, now time.Time) ([]DuplicateCandidate, error) {
+	if s.windowDays <= 0 {
+		return nil, nil
+	}
+
+	tickets, err := s.ticketRepo.FindDuplicateCandidates(repository.DuplicateCriteria{
+		Category:   ticket.Category,
+		AssetID:    ticket.AssetID,
+		LocationID: ticket.LocationID,
+		Location:   ticket.Location,
+		Since:      now.AddDate(0, 0, -s.windowDays),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var candidates []DuplicateCandidate
+	for i := range tickets {
+		if tickets[i].ID == ticket.ID {
+			continue
+		}
+		score := titleWeight*textSimilarity(ticket.Title, tickets[i].Title) +
+			(1-titleWeight)*textSimilarity(ticket.Description, tickets[i].Description)
+		if score < duplicateMinScore {
+			continue
+		}
+		candidates = append(candidates, DuplicateCandidate{
+			Ticket: &tickets[i],
+			Score:  math.Round(score*100) / 100,
+		})
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].Score > candidates[j].Score
+	})
+	if len(candidates) > duplicateLimit {
+		candidates = candidates[:duplicateLimit]
+	}
+	return candidates, nil
+}
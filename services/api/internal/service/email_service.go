@@ -12,6 +12,8 @@ type EmailService interface {
 	SendTicketCreated(toEmail, toName, ticketTitle, ticketID string) error
 	SendTicketAssigned(toEmail, toName, ticketTitle, ticketID string) error
 	SendTicketUpdated(toEmail, toName, ticketTitle, ticketID, oldStatus, newStatus string) error
+	SendTicketEscalated(toEmail, toName, ticketTitle, ticketID, reason string) error
+	SendVendorDispatch(toEmail, toName, ticketTitle, ticketID, link string) error
 }
 
 type emailService struct {
@@ -96,3 +98,32 @@ func (s *emailService) SendTicketUpdated(toEmail, toName, ticketTitle, ticketID,
 
 	return s.send(toEmail, subject, body)
 }
+
+func (s *emailService) SendTicketEscalated(toEmail, toName, ticketTitle, ticketID, reason string) error {
+	subject := fmt.Sprintf("แจ้งเตือนงานค้าง: %s", ticketTitle)
+	body := fmt.Sprintf(`
+		<h2>สวัสดี %s</h2>
+		<p>รายการแจ้งซ่อมต่อไปนี้ถูกยกระดับ (Escalate):</p>
+		<p><strong>หัวข้อ:</strong> %s</p>
+		<p><strong>รหัส:</strong> %s</p>
+		<p><strong>เหตุผล:</strong> %s</p>
+		<hr>
+		<p>เข้าสู่ระบบเพื่อดำเนินการ</p>
+	`, toName, ticketTitle, ticketID[:8], reason)
+
+	return s.send(toEmail, subject, body)
+}
+
+func (s *emailService) SendVendorDispatch(toEmail, toName, ticketTitle, ticketID, link string) error {
+	subject := fmt.Sprintf("งานซ่อมสำหรับ %s: %s", toName, ticketTitle)
+	body := fmt.Sprintf(`
+		<h2>สวัสดี %s</h2>
+		<p>มีงานแจ้งซ่อมถูกส่งถึงคุณ:</p>
+		<p><strong>หัวข้อ:</strong> %s</p>
+		<p><strong>รหัส:</strong> %s</p>
+		<hr>
+		<p><a href="%s">เปิดลิงก์นี้</a> เพื่อดูรายละเอียด แสดงความคิดเห็น และแนบไฟล์ (ไม่ต้องเข้าสู่ระบบ)</p>
+	`, toName, ticketTitle, ticketID[:8], link)
+
+	return s.send(toEmail, subject, body)
+}
@@ -0,0 +1,244 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/maintenance-system/api/internal/domain"
+	"github.com/maintenance-system/api/internal/repository"
+	"github.com/maintenance-system/api/internal/websocket"
+)
+
+var ErrEscalationRuleNotFound = errors.New("escalation rule not found")
+
+type EscalationService interface {
+	GetRules() ([]domain.EscalationRule, error)
+	GetRule(id uuid.UUID) (*domain.EscalationRule, error)
+	CreateRule(rule *domain.EscalationRule) error
+	UpdateRule(id uuid.UUID, updates map[string]interface{}) (*domain.EscalationRule, error)
+	DeleteRule(id uuid.UUID) error
+
+	// Run checks every active rule against the tickets once and returns
+	// how many escalations were performed.
+	Run(ctx context.Context, now time.Time) (int, error)
+}
+
+type escalationService struct {
+	repo       repository.EscalationRepository
+	userRepo   repository.UserRepository
+	priorities PriorityService
+	tx         repository.Transactor
+	email      EmailService
+	hub        *websocket.Hub
+}
+
+func NewEscalationService(repo repository.EscalationRepository, userRepo repository.UserRepository, priorities PriorityService, tx repository.Transactor, email EmailService, hub *websocket.Hub) EscalationService {
+	return &escalationService{
+		repo:       repo,
+		userRepo:   userRepo,
+		priorities: priorities,
+		tx:         tx,
+		email:      email,
+		hub:        hub,
+	}
+}
+
+func (s *escalationService) GetRules() ([]domain.EscalationRule, error) {
+	return s.repo.FindRules(false)
+}
+
+func (s *escalationService) GetRule(id uuid.UUID) (*domain.EscalationRule, error) {
+	rule, err := s.repo.FindRuleByID(id)
+	if err != nil {
+		return nil, ErrEscalationRuleNotFound
+	}
+	return rule, nil
+}
+
+func (s *escalationService) CreateRule(rule *domain.EscalationRule) error {
+	return s.repo.CreateRule(rule)
+}
+
+func (s *escalationService) UpdateRule(id uuid.UUID, updates map[string]interface{}) (*domain.EscalationRule, error) {
+	rule, err := s.repo.FindRuleByID(id)
+	if err != nil {
+		return nil, ErrEscalationRuleNotFound
+	}
+
+	if name, ok := updates["name"].(string); ok {
+		rule.Name = name
+	}
+	if minutes, ok := updates["thresholdMinutes"].(int); ok {
+		rule.ThresholdMinutes = minutes
+	}
+	if priority, ok := updates["priority"].(string); ok {
+		rule.Priority = domain.TicketPriority(priority)
+	}
+	if category, ok := updates["category"].(string); ok {
+		rule.Category = domain.TicketCategory(category)
+	}
+	if bump, ok := updates["bumpPriority"].(bool); ok {
+		rule.BumpPriority = bump
+	}
+	if reassignTo, ok := updates["reassignToId"].(uuid.UUID); ok {
+		rule.ReassignToID = &reassignTo
+		rule.ReassignTo = nil
+	}
+	if notify, ok := updates["notifyAdmins"].(bool); ok {
+		rule.NotifyAdmins = notify
+	}
+	if active, ok := updates["active"].(bool); ok {
+		rule.Active = active
+	}
+
+	if err := s.repo.UpdateRule(rule); err != nil {
+		return nil, err
+	}
+	return rule, nil
+}
+
+func (s *escalationService) DeleteRule(id uuid.UUID) error {
+	return s.repo.DeleteRule(id)
+}
+
+func (s *escalationService) Run(ctx context.Context, now time.Time) (int, error) {
+	rules, err := s.repo.FindRules(true)
+	if err != nil {
+		return 0, err
+	}
+
+	escalated := 0
+	for i := range rules {
+		rule := &rules[i]
+		candidates, err := s.repo.FindCandidates(rule, now)
+		if err != nil {
+			return escalated, fmt.Errorf("rule %s: %w", rule.Name, err)
+		}
+		for _, c := range candidates {
+			if ctx.Err() != nil {
+				return escalated, ctx.Err()
+			}
+			ok, err := s.escalate(rule, c, now)
+			if err != nil {
+				log.Printf("Escalation: rule %s, ticket %s: %v", rule.Name, c.Ticket.ID, err)
+				continue
+			}
+			if ok {
+				escalated++
+			}
+		}
+	}
+	return escalated, nil
+}
+
+// escalate applies the rule's actions to one ticket. The ticket is read
+// again under a row lock, so the change never overwrites a user's edit
+// made since the scan, and the condition and how long it has held are
+// checked again. The claim, the ticket change and its log entries
+// commit together; if another instance already claimed this occurrence,
+// or the ticket no longer meets the condition, nothing happens and false
+// is returned.
+func (s *escalationService) escalate(rule *domain.EscalationRule, c repository.EscalationCandidate, now time.Time) (bool, error) {
+	var ticket *domain.Ticket
+	err := s.tx.WithinTransaction(func(r repository.Repositories) error {
+		var err error
+		ticket, err = r.Tickets.FindByID(c.Ticket.ID, repository.ForUpdate())
+		if err != nil {
+			return err
+		}
+		if !conditionHolds(rule.Condition, ticket, now) {
+			ticket = nil
+			return nil
+		}
+		// The scan's start time may be stale too, e.g. a comment since
+		// then is new activity.
+		since, err := r.Escalations.ConditionSince(rule.Condition, ticket.ID)
+		if err != nil {
+			return err
+		}
+		cutoff := now.Add(-time.Duration(rule.ThresholdMinutes) * time.Minute)
+		if since == nil || !since.Before(cutoff) {
+			ticket = nil
+			return nil
+		}
+		ok, err := r.Escalations.Claim(&domain.TicketEscalation{
+			TicketID:       ticket.ID,
+			RuleID:         rule.ID,
+			ConditionSince: *since,
+		})
+		if err != nil || !ok {
+			ticket = nil
+			return err
+		}
+
+		before := *ticket
+		if rule.BumpPriority {
+			ticket.Priority = s.priorities.Next(ticket.Priority)
+		}
+		if rule.ReassignToID != nil {
+			ticket.AssignedToID = rule.ReassignToID
+			ticket.AssignedTo = nil
+		}
+		ticket.UpdatedAt = now
+
+		// Escalations are attributed to the admin who set up the rule.
+		logs := diffTicket(&before, ticket, rule.CreatedByID)
+		logs = append(logs, newTicketLog(ticket.ID, rule.CreatedByID, domain.LogActionEscalated, string(rule.Condition), rule.Name))
+		if err := r.Tickets.Update(ticket); err != nil {
+			return err
+		}
+		return r.TicketLogs.CreateBatch(logs)
+	})
+	if err != nil || ticket == nil {
+		return false, err
+	}
+
+	s.notify(rule, ticket)
+	return true, nil
+}
+
+// conditionHolds re-checks the rule's condition on a freshly read ticket;
+// it mirrors the filters in EscalationRepository.FindCandidates.
+func conditionHolds(condition domain.EscalationCondition, ticket *domain.Ticket, now time.Time) bool {
+	switch condition {
+	case domain.ConditionUnassigned:
+		return ticket.Status == domain.StatusOpen && ticket.AssignedToID == nil
+	case domain.ConditionNoActivity:
+		return ticket.Status == domain.StatusInProgress
+	case domain.ConditionOverdue:
+		return ticket.DueDate != nil && ticket.DueDate.Before(now) && ticket.SLAPausedAt == nil &&
+			ticket.Status != domain.StatusResolved && ticket.Status != domain.StatusClosed
+	}
+	return false
+}
+
+func (s *escalationService) notify(rule *domain.EscalationRule, ticket *domain.Ticket) {
+	if s.hub != nil {
+		s.hub.Broadcast("ticket:escalated", map[string]interface{}{
+			"ticket": ticket,
+			"rule":   rule.Name,
+		})
+	}
+
+	if s.email == nil {
+		return
+	}
+	if rule.ReassignTo != nil {
+		s.email.SendTicketAssigned(rule.ReassignTo.Email, rule.ReassignTo.Name, ticket.Title, ticket.ID.String())
+	}
+	if !rule.NotifyAdmins {
+		return
+	}
+	admins, err := s.userRepo.FindByRole(domain.RoleAdmin)
+	if err != nil {
+		log.Printf("Escalation: failed to load admins: %v", err)
+		return
+	}
+	for _, admin := range admins {
+		s.email.SendTicketEscalated(admin.Email, admin.Name, ticket.Title, ticket.ID.String(), rule.Name)
+	}
+}
@@ -0,0 +1,138 @@
+package service
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/maintenance-system/api/internal/domain"
+)
+
+// parseICalHolidays reads the VEVENTs of an iCalendar (RFC 5545) file as
+// whole-day holidays. Multi-day events produce one holiday per day; a
+// DTEND falling on midnight is exclusive as the RFC specifies. Timed events
+// mark every day they touch as a holiday.
+func parseICalHolidays(r io.Reader, calendarID uuid.UUID, loc *time.Location) ([]domain.Holiday, error) {
+	lines, err := unfoldICalLines(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var holidays []domain.Holiday
+	seen := make(map[string]bool)
+	var inEvent bool
+	var summary string
+	var start, end *time.Time
+	var endExclusive bool
+
+	for _, line := range lines {
+		name, params, value := splitICalLine(line)
+		switch {
+		case name == "BEGIN" && value == "VEVENT":
+			inEvent, summary, start, end = true, "", nil, nil
+		case name == "END" && value == "VEVENT":
+			if !inEvent || start == nil {
+				return nil, fmt.Errorf("VEVENT without DTSTART")
+			}
+			last := *start
+			if end != nil && end.After(*start) {
+				last = *end
+				if endExclusive {
+					last = end.AddDate(0, 0, -1)
+				}
+			}
+			for d := *start; !d.After(last); d = d.AddDate(0, 0, 1) {
+				date := d.Format("2006-01-02")
+				if seen[date] {
+					continue
+				}
+				seen[date] = true
+				holidays = append(holidays, domain.Holiday{CalendarID: calendarID, Date: date, Name: summary})
+			}
+			inEvent = false
+		case !inEvent:
+		case name == "SUMMARY":
+			summary = unescapeICalText(value)
+		case name == "DTSTART", name == "DTEND":
+			t, atMidnight, err := parseICalDate(value, params, loc)
+			if err != nil {
+				return nil, err
+			}
+			if name == "DTSTART" {
+				start = &t
+			} else {
+				end, endExclusive = &t, atMidnight
+			}
+		}
+	}
+
+	return holidays, nil
+}
+
+// unfoldICalLines joins continuation lines (those starting with a space or
+// tab) onto the previous line.
+func unfoldICalLines(r io.Reader) ([]string, error) {
+	var lines []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines, scanner.Err()
+}
+
+// splitICalLine splits "NAME;PARAM=X:value" into its parts.
+func splitICalLine(line string) (name string, params map[string]string, value string) {
+	head, value, _ := strings.Cut(line, ":")
+	parts := strings.Split(head, ";")
+	params = make(map[string]string)
+	for _, p := range parts[1:] {
+		k, v, _ := strings.Cut(p, "=")
+		params[strings.ToUpper(k)] = v
+	}
+	return strings.ToUpper(parts[0]), params, value
+}
+
+// parseICalDate returns the calendar day of a DATE or DATE-TIME value as
+// midnight UTC, and whether the value was exactly midnight in loc.
+func parseICalDate(value string, params map[string]string, loc *time.Location) (time.Time, bool, error) {
+	var t time.Time
+	var err error
+	switch {
+	case len(value) == 8:
+		t, err = time.Parse("20060102", value)
+	case strings.HasSuffix(value, "Z"):
+		t, err = time.Parse("20060102T150405Z", value)
+		t = t.In(loc)
+	default:
+		tzLoc := loc
+		if tzid := params["TZID"]; tzid != "" {
+			if l, lerr := time.LoadLocation(tzid); lerr == nil {
+				tzLoc = l
+			}
+		}
+		t, err = time.ParseInLocation("20060102T150405", value, tzLoc)
+		t = t.In(loc)
+	}
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("invalid iCalendar date %q", value)
+	}
+	y, m, d := t.Date()
+	day := time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	atMidnight := t.Hour() == 0 && t.Minute() == 0 && t.Second() == 0
+	return day, atMidnight, nil
+}
+
+func unescapeICalText(s string) string {
+	r := strings.NewReplacer(`\n`, " ", `\N`, " ", `\,`, ",", `\;`, ";", `\\`, `\`)
+	return strings.TrimSpace(r.Replace(s))
+}
@@ -0,0 +1,119 @@
+package service
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestParseICalHolidays(t *testing.T) {
+	bangkok, err := time.LoadLocation("Asia/Bangkok")
+	if err != nil {
+		t.Skipf("time zone data unavailable: %v", err)
+	}
+
+	event := func(lines ...string) string {
+		return "BEGIN:VEVENT\r\n" + strings.Join(lines, "\r\n") + "\r\nEND:VEVENT\r\n"
+	}
+	calendar := func(events ...string) string {
+		return "BEGIN:VCALENDAR\r\nVERSION:2.0\r\n" + strings.Join(events, "") + "END:VCALENDAR\r\n"
+	}
+
+	tests := []struct {
+		name      string
+		ics       string
+		wantDates []string
+		wantNames []string
+		wantErr   bool
+	}{
+		{
+			name:      "all-day event",
+			ics:       calendar(event("SUMMARY:New Year's Day", "DTSTART;VALUE=DATE:20250101", "DTEND;VALUE=DATE:20250102")),
+			wantDates: []string{"2025-01-01"},
+			wantNames: []string{"New Year's Day"},
+		},
+		{
+			name:      "end date is exclusive",
+			ics:       calendar(event("SUMMARY:Songkran", "DTSTART;VALUE=DATE:20250413", "DTEND;VALUE=DATE:20250416")),
+			wantDates: []string{"2025-04-13", "2025-04-14", "2025-04-15"},
+		},
+		{
+			name:      "no end date",
+			ics:       calendar(event("DTSTART;VALUE=DATE:20250505")),
+			wantDates: []string{"2025-05-05"},
+		},
+		{
+			name:      "UTC times fall on the local day",
+			ics:       calendar(event("DTSTART:20250101T220000Z", "DTEND:20250102T020000Z")),
+			wantDates: []string{"2025-01-02"},
+		},
+		{
+			name:      "timed event touches every day it spans",
+			ics:       calendar(event("DTSTART;TZID=Asia/Bangkok:20250105T180000", "DTEND;TZID=Asia/Bangkok:20250106T090000")),
+			wantDates: []string{"2025-01-05", "2025-01-06"},
+		},
+		{
+			name:      "timed event ending at midnight",
+			ics:       calendar(event("DTSTART;TZID=Asia/Bangkok:20250105T090000", "DTEND;TZID=Asia/Bangkok:20250106T000000")),
+			wantDates: []string{"2025-01-05"},
+		},
+		{
+			name:      "folded and escaped summary",
+			ics:       calendar(event("SUMMARY:Chakri\\, Memorial", "  Day", "DTSTART;VALUE=DATE:20250406")),
+			wantDates: []string{"2025-04-06"},
+			wantNames: []string{"Chakri, Memorial Day"},
+		},
+		{
+			name: "same day twice",
+			ics: calendar(
+				event("SUMMARY:First", "DTSTART;VALUE=DATE:20251205"),
+				event("SUMMARY:Second", "DTSTART;VALUE=DATE:20251205"),
+			),
+			wantDates: []string{"2025-12-05"},
+			wantNames: []string{"First"},
+		},
+		{
+			name:    "event without a start",
+			ics:     calendar(event("SUMMARY:Nothing")),
+			wantErr: true,
+		},
+		{
+			name:    "bad date",
+			ics:     calendar(event("DTSTART;VALUE=DATE:2025-01-01")),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calendarID := uuid.New()
+			holidays, err := parseICalHolidays(strings.NewReader(tt.ics), calendarID, bangkok)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("parseICalHolidays() = %v, want an error", holidays)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseICalHolidays() error = %v", err)
+			}
+
+			var dates []string
+			for _, h := range holidays {
+				dates = append(dates, h.Date)
+				if h.CalendarID != calendarID {
+					t.Errorf("holiday %s in calendar %s, want %s", h.Date, h.CalendarID, calendarID)
+				}
+			}
+			if strings.Join(dates, ",") != strings.Join(tt.wantDates, ",") {
+				t.Fatalf("dates = %v, want %v", dates, tt.wantDates)
+			}
+			for i, name := range tt.wantNames {
+				if holidays[i].Name != name {
+					t.Errorf("holiday %s named %q, want %q", holidays[i].Date, holidays[i].Name, name)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,504 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/maintenance-system/api/internal/domain"
+	"github.com/maintenance-system/api/internal/repository"
+)
+
+var (
+	ErrPartNotFound          = errors.New("part not found")
+	ErrPartSKUTaken          = errors.New("part SKU already in use")
+	ErrPartInStock           = errors.New("part still has stock on hand")
+	ErrInvalidPart           = errors.New("invalid part")
+	ErrStockLocationNotFound = errors.New("stock location not found")
+	ErrStockLocationTaken    = errors.New("stock location name already in use")
+	ErrStockLocationInUse    = errors.New("stock location has stock movements")
+	ErrInvalidStockLocation  = errors.New("invalid stock location")
+	ErrInsufficientStock     = errors.New("insufficient stock")
+	ErrInvalidMovement       = errors.New("invalid stock movement")
+	ErrTicketClosed          = errors.New("ticket is closed")
+)
+
+type InventoryService interface {
+	GetParts(filter repository.PartFilter) ([]domain.Part, int64, error)
+	GetPart(id uuid.UUID) (*domain.Part, error)
+	CreatePart(part *domain.Part) error
+	UpdatePart(id uuid.UUID, updates map[string]interface{}) (*domain.Part, error)
+	DeletePart(id uuid.UUID) error
+
+	GetStockLocations() ([]domain.StockLocation, error)
+	CreateStockLocation(location *domain.StockLocation) error
+	UpdateStockLocation(id uuid.UUID, updates map[string]interface{}) (*domain.StockLocation, error)
+	DeleteStockLocation(id uuid.UUID) error
+
+	GetMovements(filter repository.MovementFilter) ([]domain.StockMovement, int64, error)
+	// Receive books delivered stock in. A non-zero unitCost becomes the
+	// part's current unit cost.
+	Receive(partID, stockLocationID uuid.UUID, quantity, unitCost float64, userID uuid.UUID, note string) (*domain.StockMovement, error)
+	// Adjust corrects the stock level after a count; delta may be negative.
+	Adjust(partID, stockLocationID uuid.UUID, delta float64, userID uuid.UUID, note string) (*domain.StockMovement, error)
+
+	GetTicketParts(ticketID uuid.UUID) ([]repository.TicketPart, error)
+	// IssueToTicket takes parts out of stock for a ticket and adds their
+	// cost to the ticket.
+	IssueToTicket(ticketID, partID, stockLocationID uuid.UUID, quantity float64, userID uuid.UUID, note string) (*domain.StockMovement, error)
+	// ReturnFromTicket puts unused parts back into stock at the cost they
+	// were issued at.
+	ReturnFromTicket(ticketID, partID, stockLocationID uuid.UUID, quantity float64, userID uuid.UUID, note string) (*domain.StockMovement, error)
+}
+
+type inventoryService struct {
+	repo          repository.InventoryRepository
+	ticketRepo    repository.TicketRepository
+	tx            repository.Transactor
+	notifications NotificationService
+}
+
+func NewInventoryService(repo repository.InventoryRepository, ticketRepo repository.TicketRepository, tx repository.Transactor, notifications NotificationService) InventoryService {
+	return &inventoryService{
+		repo:          repo,
+		ticketRepo:    ticketRepo,
+		tx:            tx,
+		notifications: notifications,
+	}
+}
+
+func (s *inventoryService) GetParts(filter repository.PartFilter) ([]domain.Part, int64, error) {
+	return s.repo.FindParts(filter)
+}
+
+func (s *inventoryService) GetPart(id uuid.UUID) (*domain.Part, error) {
+	part, err := s.repo.FindPartByID(id)
+	if err != nil {
+		return nil, ErrPartNotFound
+	}
+	return part, nil
+}
+
+func (s *inventoryService) CreatePart(part *domain.Part) error {
+	if _, err := s.repo.FindPartBySKU(part.SKU); err == nil {
+		return ErrPartSKUTaken
+	}
+	if part.Unit == "" {
+		part.Unit = "pcs"
+	}
+	if err := validatePart(part); err != nil {
+		return err
+	}
+	return s.repo.CreatePart(part)
+}
+
+func (s *inventoryService) UpdatePart(id uuid.UUID, updates map[string]interface{}) (*domain.Part, error) {
+	part, err := s.repo.FindPartByID(id)
+	if err != nil {
+		return nil, ErrPartNotFound
+	}
+
+	if sku, ok := updates["sku"].(string); ok && sku != part.SKU {
+		if _, err := s.repo.FindPartBySKU(sku); err == nil {
+			return nil, ErrPartSKUTaken
+		}
+		part.SKU = sku
+	}
+	if name, ok := updates["name"].(string); ok {
+		part.Name = name
+	}
+	if description, ok := updates["description"].(string); ok {
+		part.Description = description
+	}
+	if unit, ok := updates["unit"].(string); ok {
+		part.Unit = unit
+	}
+	if unitCost, ok := updates["unitCost"].(float64); ok {
+		part.UnitCost = unitCost
+	}
+	if minStock, ok := updates["minStock"].(float64); ok {
+		part.MinStock = minStock
+	}
+
+	if err := validatePart(part); err != nil {
+		return nil, err
+	}
+	if err := s.repo.UpdatePart(part); err != nil {
+		return nil, err
+	}
+	return part, nil
+}
+
+func (s *inventoryService) DeletePart(id uuid.UUID) error {
+	part, err := s.repo.FindPartByID(id)
+	if err != nil {
+		return ErrPartNotFound
+	}
+	if part.OnHand != 0 {
+		return ErrPartInStock
+	}
+	return s.repo.DeletePart(id)
+}
+
+func (s *inventoryService) GetStockLocations() ([]domain.StockLocation, error) {
+	return s.repo.FindStockLocations()
+}
+
+func (s *inventoryService) CreateStockLocation(location *domain.StockLocation) error {
+	if location.Name == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidStockLocation)
+	}
+	if err := s.checkStockLocationName(location.Name, uuid.Nil); err != nil {
+		return err
+	}
+	return s.repo.CreateStockLocation(location)
+}
+
+func (s *inventoryService) UpdateStockLocation(id uuid.UUID, updates map[string]interface{}) (*domain.StockLocation, error) {
+	location, err := s.repo.FindStockLocationByID(id)
+	if err != nil {
+		return nil, ErrStockLocationNotFound
+	}
+
+	if name, ok := updates["name"].(string); ok && name != location.Name {
+		if err := s.checkStockLocationName(name, id); err != nil {
+			return nil, err
+		}
+		location.Name = name
+	}
+	if description, ok := updates["description"].(string); ok {
+		location.Description = description
+	}
+	if locationID, ok := updates["locationId"].(uuid.UUID); ok {
+		location.LocationID = &locationID
+	}
+
+	if err := s.repo.UpdateStockLocation(location); err != nil {
+		return nil, err
+	}
+	return location, nil
+}
+
+func (s *inventoryService) checkStockLocationName(name string, except uuid.UUID) error {
+	locations, err := s.repo.FindStockLocations()
+	if err != nil {
+		return err
+	}
+	for _, l := range locations {
+		if l.Name == name && l.ID != except {
+			return ErrStockLocationTaken
+		}
+	}
+	return nil
+}
+
+func (s *inventoryService) DeleteStockLocation(id uuid.UUID) error {
+	if _, err := s.repo.FindStockLocationByID(id); err != nil {
+		return ErrStockLocationNotFound
+	}
+	// Locations with history stay so the ledger keeps pointing somewhere.
+	count, err := s.repo.CountMovements(id)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return ErrStockLocationInUse
+	}
+	return s.repo.DeleteStockLocation(id)
+}
+
+func (s *inventoryService) GetMovements(filter repository.MovementFilter) ([]domain.StockMovement, int64, error) {
+	return s.repo.FindMovements(filter)
+}
+
+func (s *inventoryService) Receive(partID, stockLocationID uuid.UUID, quantity, unitCost float64, userID uuid.UUID, note string) (*domain.StockMovement, error) {
+	if quantity <= 0 {
+		return nil, invalidMovement(errors.New("quantity must be positive"))
+	}
+	if unitCost < 0 {
+		return nil, invalidMovement(errors.New("unit cost cannot be negative"))
+	}
+	part, err := s.findStock(partID, stockLocationID)
+	if err != nil {
+		return nil, err
+	}
+
+	if unitCost == 0 {
+		unitCost = part.UnitCost
+	}
+	movement := &domain.StockMovement{
+		PartID:          partID,
+		StockLocationID: stockLocationID,
+		Type:            domain.MovementReceive,
+		Quantity:        quantity,
+		UnitCost:        unitCost,
+		UserID:          userID,
+		Note:            note,
+	}
+
+	err = s.tx.WithinTransaction(func(r repository.Repositories) error {
+		if _, err := r.Inventory.ApplyMovement(movement); err != nil {
+			return err
+		}
+		if unitCost == part.UnitCost {
+			return nil
+		}
+		part.UnitCost = unitCost
+		return r.Inventory.UpdatePart(part)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return movement, nil
+}
+
+func (s *inventoryService) Adjust(partID, stockLocationID uuid.UUID, delta float64, userID uuid.UUID, note string) (*domain.StockMovement, error) {
+	if delta == 0 {
+		return nil, invalidMovement(errors.New("adjustment cannot be zero"))
+	}
+	if note == "" {
+		return nil, invalidMovement(errors.New("a reason is required for adjustments"))
+	}
+	part, err := s.findStock(partID, stockLocationID)
+	if err != nil {
+		return nil, err
+	}
+
+	movement := &domain.StockMovement{
+		PartID:          partID,
+		StockLocationID: stockLocationID,
+		Type:            domain.MovementAdjust,
+		Quantity:        delta,
+		UnitCost:        part.UnitCost,
+		UserID:          userID,
+		Note:            note,
+	}
+	if err := s.apply(movement, nil); err != nil {
+		return nil, err
+	}
+	s.checkLowStock(part, delta)
+	return movement, nil
+}
+
+func (s *inventoryService) GetTicketParts(ticketID uuid.UUID) ([]repository.TicketPart, error) {
+	if _, err := s.ticketRepo.FindByID(ticketID); err != nil {
+		return nil, ErrTicketNotFound
+	}
+	return s.repo.FindTicketParts(ticketID)
+}
+
+func (s *inventoryService) IssueToTicket(ticketID, partID, stockLocationID uuid.UUID, quantity float64, userID uuid.UUID, note string) (*domain.StockMovement, error) {
+	if quantity <= 0 {
+		return nil, invalidMovement(errors.New("quantity must be positive"))
+	}
+	ticket, err := s.openTicket(ticketID)
+	if err != nil {
+		return nil, err
+	}
+	part, err := s.findStock(partID, stockLocationID)
+	if err != nil {
+		return nil, err
+	}
+
+	movement := &domain.StockMovement{
+		PartID:          partID,
+		StockLocationID: stockLocationID,
+		Type:            domain.MovementIssue,
+		Quantity:        -quantity,
+		UnitCost:        part.UnitCost,
+		TicketID:        &ticket.ID,
+		UserID:          userID,
+		Note:            note,
+	}
+	err = s.apply(movement, func(r repository.Repositories) error {
+		if err := r.Tickets.AddPartsCost(ticket.ID, roundCost(quantity*part.UnitCost)); err != nil {
+			return err
+		}
+		return r.TicketLogs.Create(&domain.TicketLog{
+			TicketID:  ticket.ID,
+			UserID:    userID,
+			Action:    domain.LogActionPartsUsed,
+			NewValue:  partQuantity(part, quantity),
+			CreatedAt: movement.CreatedAt,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.checkLowStock(part, -quantity)
+	return movement, nil
+}
+
+func (s *inventoryService) ReturnFromTicket(ticketID, partID, stockLocationID uuid.UUID, quantity float64, userID uuid.UUID, note string) (*domain.StockMovement, error) {
+	if quantity <= 0 {
+		return nil, invalidMovement(errors.New("quantity must be positive"))
+	}
+	ticket, err := s.openTicket(ticketID)
+	if err != nil {
+		return nil, err
+	}
+	part, err := s.findStock(partID, stockLocationID)
+	if err != nil {
+		return nil, err
+	}
+
+	var movement *domain.StockMovement
+	err = s.tx.WithinTransaction(func(r repository.Repositories) error {
+		// The ticket lock keeps concurrent returns from each passing the
+		// issued-quantity check.
+		if _, err := r.Tickets.FindByID(ticket.ID, repository.ForUpdate()); err != nil {
+			return ErrTicketNotFound
+		}
+		used, err := r.Inventory.FindTicketParts(ticket.ID)
+		if err != nil {
+			return err
+		}
+		var issued *repository.TicketPart
+		for i := range used {
+			if used[i].PartID == partID && used[i].StockLocationID == stockLocationID {
+				issued = &used[i]
+			}
+		}
+		if issued == nil || quantity > issued.Quantity {
+			return invalidMovement(errors.New("cannot return more than was issued to the ticket from this location"))
+		}
+
+		unitCost := roundCost(issued.Cost / issued.Quantity)
+		movement = &domain.StockMovement{
+			PartID:          partID,
+			StockLocationID: stockLocationID,
+			Type:            domain.MovementReturn,
+			Quantity:        quantity,
+			UnitCost:        unitCost,
+			TicketID:        &ticket.ID,
+			UserID:          userID,
+			Note:            note,
+		}
+		if err := applyMovement(r, movement); err != nil {
+			return err
+		}
+		if err := r.Tickets.AddPartsCost(ticket.ID, -roundCost(quantity*unitCost)); err != nil {
+			return err
+		}
+		return r.TicketLogs.Create(&domain.TicketLog{
+			TicketID:  ticket.ID,
+			UserID:    userID,
+			Action:    domain.LogActionPartsReturned,
+			NewValue:  partQuantity(part, quantity),
+			CreatedAt: movement.CreatedAt,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return movement, nil
+}
+
+// apply records the movement, and runs within in the same transaction.
+func (s *inventoryService) apply(movement *domain.StockMovement, within func(r repository.Repositories) error) error {
+	return s.tx.WithinTransaction(func(r repository.Repositories) error {
+		if err := applyMovement(r, movement); err != nil {
+			return err
+		}
+		if within == nil {
+			return nil
+		}
+		return within(r)
+	})
+}
+
+func applyMovement(r repository.Repositories, movement *domain.StockMovement) error {
+	ok, err := r.Inventory.ApplyMovement(movement)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrInsufficientStock
+	}
+	return nil
+}
+
+func (s *inventoryService) findStock(partID, stockLocationID uuid.UUID) (*domain.Part, error) {
+	part, err := s.repo.FindPartByID(partID)
+	if err != nil {
+		return nil, ErrPartNotFound
+	}
+	if _, err := s.repo.FindStockLocationByID(stockLocationID); err != nil {
+		return nil, ErrStockLocationNotFound
+	}
+	return part, nil
+}
+
+func (s *inventoryService) openTicket(ticketID uuid.UUID) (*domain.Ticket, error) {
+	ticket, err := s.ticketRepo.FindByID(ticketID)
+	if err != nil {
+		return nil, ErrTicketNotFound
+	}
+	if ticket.Status == domain.StatusClosed {
+		return nil, ErrTicketClosed
+	}
+	return ticket, nil
+}
+
+// checkLowStock notifies admins when a movement of delta took the part
+// from above its threshold to at or below it. Failures are only logged;
+// the movement has already been committed.
+func (s *inventoryService) checkLowStock(part *domain.Part, delta float64) {
+	if part.MinStock <= 0 || delta >= 0 || s.notifications == nil {
+		return
+	}
+	onHand, err := s.repo.OnHand(part.ID)
+	if err != nil {
+		log.Printf("Inventory: failed to read stock of %s: %v", part.SKU, err)
+		return
+	}
+	if onHand > part.MinStock || onHand-delta <= part.MinStock {
+		return
+	}
+
+	err = s.notifications.NotifyRoles([]domain.UserRole{domain.RoleAdmin}, domain.Notification{
+		Type:    NotificationWarning,
+		Title:   "Low stock: " + part.SKU,
+		Message: fmt.Sprintf("%s is down to %s %s (minimum %s).", part.Name, formatQuantity(onHand), part.Unit, formatQuantity(part.MinStock)),
+	})
+	if err != nil {
+		log.Printf("Inventory: failed to send low-stock notification for %s: %v", part.SKU, err)
+	}
+}
+
+func validatePart(part *domain.Part) error {
+	if part.SKU == "" {
+		return invalidPart(errors.New("SKU is required"))
+	}
+	if part.Name == "" {
+		return invalidPart(errors.New("name is required"))
+	}
+	if part.UnitCost < 0 || part.MinStock < 0 {
+		return invalidPart(errors.New("unit cost and minimum stock cannot be negative"))
+	}
+	return nil
+}
+
+func partQuantity(part *domain.Part, quantity float64) string {
+	return fmt.Sprintf("%s × %s %s", part.SKU, formatQuantity(quantity), part.Unit)
+}
+
+func formatQuantity(q float64) string {
+	return strconv.FormatFloat(q, 'f', -1, 64)
+}
+
+// roundCost rounds to the two decimals costs are stored with.
+func roundCost(cost float64) float64 {
+	return math.Round(cost*100) / 100
+}
+
+func invalidPart(err error) error {
+	return fmt.Errorf("%w: %v", ErrInvalidPart, err)
+}
+
+func invalidMovement(err error) error {
+	return fmt.Errorf("%w: %v", ErrInvalidMovement, err)
+}
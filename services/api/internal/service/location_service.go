@@ -0,0 +1,445 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/google/uuid"
+	"github.com/maintenance-system/api/internal/domain"
+	"github.com/maintenance-system/api/internal/repository"
+)
+
+var (
+	ErrLocationNotFound = errors.New("location not found")
+	ErrInvalidLocation  = errors.New("invalid location")
+	ErrLocationInUse    = errors.New("location still has sub-locations, tickets or assets")
+)
+
+// LocationMigrationReport is the outcome of mapping free-text locations
+// onto the location tree.
+type LocationMigrationReport struct {
+	DryRun    bool                `json:"dryRun"`
+	Matched   []LocationMatch     `json:"matched"`
+	Unmatched []UnmatchedLocation `json:"unmatched"`
+}
+
+type LocationMatch struct {
+	Text       string    `json:"text"`
+	LocationID uuid.UUID `json:"locationId"`
+	FullName   string    `json:"fullName"`
+	Tickets    int64     `json:"tickets"`
+	Assets     int64     `json:"assets"`
+}
+
+type UnmatchedLocation struct {
+	Text       string   `json:"text"`
+	Tickets    int64    `json:"tickets"`
+	Assets     int64    `json:"assets"`
+	Candidates []string `json:"candidates,omitempty"`
+}
+
+type LocationService interface {
+	GetAll() ([]domain.Location, error)
+	// GetTree returns the root locations with their descendants nested.
+	GetTree() ([]domain.Location, error)
+	GetByID(id uuid.UUID) (*domain.Location, error)
+	Create(location *domain.Location) error
+	Update(id uuid.UUID, updates map[string]interface{}) (*domain.Location, error)
+	Delete(id uuid.UUID) error
+
+	// MigrateLegacy maps the free-text locations of tickets and assets onto
+	// the tree where a single location matches. With dryRun nothing is
+	// written.
+	MigrateLegacy(dryRun bool) (*LocationMigrationReport, error)
+}
+
+type locationService struct {
+	repo repository.LocationRepository
+}
+
+func NewLocationService(repo repository.LocationRepository) LocationService {
+	return &locationService{repo: repo}
+}
+
+func (s *locationService) GetAll() ([]domain.Location, error) {
+	locations, err := s.repo.FindAll()
+	if err != nil {
+		return nil, err
+	}
+	fillFullNames(locations)
+	return locations, nil
+}
+
+func (s *locationService) GetTree() ([]domain.Location, error) {
+	locations, err := s.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	// Locations come in path order, so every parent precedes its children;
+	// building bottom-up from the end keeps the copies complete.
+	children := make(map[uuid.UUID][]domain.Location)
+	var roots []domain.Location
+	for i := len(locations) - 1; i >= 0; i-- {
+		loc := locations[i]
+		loc.Children = children[loc.ID]
+		if loc.ParentID == nil {
+			roots = append([]domain.Location{loc}, roots...)
+		} else {
+			children[*loc.ParentID] = append([]domain.Location{loc}, children[*loc.ParentID]...)
+		}
+	}
+	return roots, nil
+}
+
+func (s *locationService) GetByID(id uuid.UUID) (*domain.Location, error) {
+	location, err := s.repo.FindByID(id)
+	if err != nil {
+		return nil, ErrLocationNotFound
+	}
+	location.FullName = s.fullName(location)
+	return location, nil
+}
+
+func (s *locationService) Create(location *domain.Location) error {
+	var parent *domain.Location
+	if location.ParentID != nil {
+		p, err := s.repo.FindByID(*location.ParentID)
+		if err != nil {
+			return ErrLocationNotFound
+		}
+		parent = p
+	}
+	if err := validateLocation(location, parent); err != nil {
+		return err
+	}
+	if err := s.repo.Create(location); err != nil {
+		return err
+	}
+	location.FullName = s.fullName(location)
+	return nil
+}
+
+func (s *locationService) Update(id uuid.UUID, updates map[string]interface{}) (*domain.Location, error) {
+	location, err := s.repo.FindByID(id)
+	if err != nil {
+		return nil, ErrLocationNotFound
+	}
+
+	if name, ok := updates["name"].(string); ok {
+		location.Name = name
+	}
+	if code, ok := updates["code"].(string); ok {
+		location.Code = code
+	}
+	if locationType, ok := updates["type"].(string); ok {
+		location.Type = domain.LocationType(locationType)
+	}
+
+	// parentId is uuid.Nil to move the location to the top level.
+	parentID, move := updates["parentId"].(uuid.UUID)
+	var parent *domain.Location
+	if move && parentID != uuid.Nil {
+		parent, err = s.repo.FindByID(parentID)
+		if err != nil {
+			return nil, ErrLocationNotFound
+		}
+		if strings.HasPrefix(parent.Path, location.Path) {
+			return nil, invalidLocation(errors.New("a location cannot be moved under itself"))
+		}
+	} else if !move && location.ParentID != nil {
+		parent, err = s.repo.FindByID(*location.ParentID)
+		if err != nil {
+			return nil, ErrLocationNotFound
+		}
+	}
+
+	if err := validateLocation(location, parent); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Update(location); err != nil {
+		return nil, err
+	}
+	if move {
+		if err := s.repo.Move(location, parent); err != nil {
+			return nil, err
+		}
+	}
+
+	location.FullName = s.fullName(location)
+	return location, nil
+}
+
+func (s *locationService) Delete(id uuid.UUID) error {
+	refs, err := s.repo.CountReferences(id)
+	if err != nil {
+		return err
+	}
+	if refs > 0 {
+		return ErrLocationInUse
+	}
+	return s.repo.Delete(id)
+}
+
+func (s *locationService) MigrateLegacy(dryRun bool) (*LocationMigrationReport, error) {
+	locations, err := s.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	legacy, err := s.repo.FindLegacyLocations()
+	if err != nil {
+		return nil, err
+	}
+
+	matcher := newLocationMatcher(locations)
+	report := &LocationMigrationReport{
+		DryRun:    dryRun,
+		Matched:   []LocationMatch{},
+		Unmatched: []UnmatchedLocation{},
+	}
+
+	for _, l := range legacy {
+		matches := matcher.match(l.Text)
+		if len(matches) != 1 {
+			unmatched := UnmatchedLocation{Text: l.Text, Tickets: l.Tickets, Assets: l.Assets}
+			for _, m := range matches {
+				unmatched.Candidates = append(unmatched.Candidates, m.FullName)
+			}
+			report.Unmatched = append(report.Unmatched, unmatched)
+			continue
+		}
+
+		match := LocationMatch{
+			Text:       l.Text,
+			LocationID: matches[0].ID,
+			FullName:   matches[0].FullName,
+			Tickets:    l.Tickets,
+			Assets:     l.Assets,
+		}
+		if !dryRun {
+			match.Tickets, match.Assets, err = s.repo.AssignLegacyLocation(l.Text, match.LocationID)
+			if err != nil {
+				return nil, err
+			}
+		}
+		report.Matched = append(report.Matched, match)
+	}
+	return report, nil
+}
+
+// fullName joins the names of the location's ancestors and its own.
+func (s *locationService) fullName(location *domain.Location) string {
+	names := []string{location.Name}
+	for parentID := location.ParentID; parentID != nil; {
+		parent, err := s.repo.FindByID(*parentID)
+		if err != nil {
+			break
+		}
+		names = append([]string{parent.Name}, names...)
+		parentID = parent.ParentID
+	}
+	return strings.Join(names, " / ")
+}
+
+// fillFullNames sets FullName on locations listed in path order.
+func fillFullNames(locations []domain.Location) {
+	names := make(map[uuid.UUID]string, len(locations))
+	for i := range locations {
+		loc := &locations[i]
+		loc.FullName = loc.Name
+		if loc.ParentID != nil {
+			if parent, ok := names[*loc.ParentID]; ok {
+				loc.FullName = parent + " / " + loc.Name
+			}
+		}
+		names[loc.ID] = loc.FullName
+	}
+}
+
+// validateLocation checks that the location sits below its parent: sites
+// at the top, then buildings, floors and rooms.
+func validateLocation(location, parent *domain.Location) error {
+	if location.Name == "" {
+		return invalidLocation(errors.New("name is required"))
+	}
+	depth := location.Type.Depth()
+	if depth < 0 {
+		return invalidLocation(fmt.Errorf("unknown location type %q", location.Type))
+	}
+	if parent == nil {
+		if location.Type != domain.LocationSite {
+			return invalidLocation(errors.New("only a site can be at the top level"))
+		}
+		return nil
+	}
+	if depth <= parent.Type.Depth() {
+		return invalidLocation(fmt.Errorf("a %s cannot be inside a %s", location.Type, parent.Type))
+	}
+	return nil
+}
+
+func invalidLocation(err error) error {
+	return fmt.Errorf("%w: %v", ErrInvalidLocation, err)
+}
+
+// locationTypeWords are words that only say what kind of place follows,
+// e.g. "Bldg" in "Bldg B". They are ignored when matching free text.
+var locationTypeWords = []string{
+	"site", "building", "bldg", "blg", "floor", "flr", "fl", "level", "lvl", "room", "rm",
+	"อาคาร", "ตึก", "ชั้น", "ห้อง",
+}
+
+// locationMatcher matches free-text locations against the tree by their
+// significant words, ignoring case, punctuation and type words.
+type locationMatcher struct {
+	locations []domain.Location
+	own       [][]string        // significant words of each location's name
+	chain     []map[string]bool // words of the location and its ancestors
+}
+
+func newLocationMatcher(locations []domain.Location) *locationMatcher {
+	m := &locationMatcher{
+		locations: locations,
+		own:       make([][]string, len(locations)),
+		chain:     make([]map[string]bool, len(locations)),
+	}
+	index := make(map[uuid.UUID]int, len(locations))
+	for i, loc := range locations {
+		index[loc.ID] = i
+		m.own[i] = locationWords(loc.Name)
+		m.chain[i] = make(map[string]bool)
+		if loc.ParentID != nil {
+			if p, ok := index[*loc.ParentID]; ok {
+				for w := range m.chain[p] {
+					m.chain[i][w] = true
+				}
+			}
+		}
+		for _, w := range m.own[i] {
+			m.chain[i][w] = true
+		}
+	}
+	return m
+}
+
+// match returns the deepest locations whose own name words all appear in
+// text and whose path accounts for every word of text.
+func (m *locationMatcher) match(text string) []domain.Location {
+	words := locationWords(text)
+	if len(words) == 0 {
+		return nil
+	}
+	inText := make(map[string]bool, len(words))
+	for _, w := range words {
+		inText[w] = true
+	}
+
+	var best []domain.Location
+	bestDepth := -1
+	for i, loc := range m.locations {
+		if len(m.own[i]) == 0 {
+			continue
+		}
+		ok := true
+		for _, w := range m.own[i] {
+			if !inText[w] {
+				ok = false
+				break
+			}
+		}
+		for _, w := range words {
+			if !ok {
+				break
+			}
+			ok = m.chain[i][w]
+		}
+		if !ok {
+			continue
+		}
+		switch {
+		case loc.Depth > bestDepth:
+			best = []domain.Location{loc}
+			bestDepth = loc.Depth
+		case loc.Depth == bestDepth:
+			best = append(best, loc)
+		}
+	}
+	return best
+}
+
+// locationWords splits s into lower-case words, dropping punctuation,
+// type words and ordinal suffixes ("2nd" → "2"). Letters and digits are
+// split apart so "Room201" and "Room 201" agree.
+func locationWords(s string) []string {
+	var words []string
+	var cur []rune
+	var curDigit bool
+	flush := func() {
+		if len(cur) > 0 {
+			words = append(words, string(cur))
+			cur = cur[:0]
+		}
+	}
+	for _, r := range strings.ToLower(s) {
+		switch {
+		case unicode.IsDigit(r):
+			if len(cur) > 0 && !curDigit {
+				flush()
+			}
+			curDigit = true
+			cur = append(cur, r)
+		case unicode.IsLetter(r) || unicode.IsMark(r):
+			if len(cur) > 0 && curDigit {
+				flush()
+			}
+			curDigit = false
+			cur = append(cur, r)
+		default:
+			flush()
+		}
+	}
+	flush()
+
+	out := make([]string, 0, len(words))
+	for i, w := range words {
+		w = stripTypePrefix(w)
+		if w == "" {
+			continue
+		}
+		if i > 0 && isOrdinalSuffix(w) && isDigits(words[i-1]) {
+			continue
+		}
+		out = append(out, w)
+	}
+	return out
+}
+
+// stripTypePrefix removes a leading type word. Thai is written without
+// spaces, so "ชั้นสอง" becomes "สอง"; an English type word must be the
+// whole word.
+func stripTypePrefix(w string) string {
+	for _, t := range locationTypeWords {
+		if w == t {
+			return ""
+		}
+		if t[0] >= 0x80 && strings.HasPrefix(w, t) {
+			return w[len(t):]
+		}
+	}
+	return w
+}
+
+func isOrdinalSuffix(w string) bool {
+	return w == "st" || w == "nd" || w == "rd" || w == "th"
+}
+
+func isDigits(w string) bool {
+	for _, r := range w {
+		if !unicode.IsDigit(r) {
+			return false
+		}
+	}
+	return w != ""
+}
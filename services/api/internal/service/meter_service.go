@@ -0,0 +1,509 @@
+package service
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/maintenance-system/api/internal/domain"
+	"github.com/maintenance-system/api/internal/repository"
+)
+
+var (
+	ErrMeterNotFound     = errors.New("meter not found")
+	ErrInvalidMeter      = errors.New("invalid meter")
+	ErrMeterRuleNotFound = errors.New("meter rule not found")
+	ErrInvalidMeterRule  = errors.New("invalid meter rule")
+	ErrInvalidReading    = errors.New("invalid meter reading")
+	ErrInvalidImport     = errors.New("invalid reading import")
+
+	// errMeterRuleOpen rolls back a generated ticket when the rule's
+	// previous ticket turned out to be still open.
+	errMeterRuleOpen = errors.New("meter rule ticket still open")
+)
+
+// maxImportRows caps the readings accepted in one CSV import.
+const maxImportRows = 5000
+
+// MeterImportResult reports a CSV import. Rows with errors are skipped;
+// the rest are recorded.
+type MeterImportResult struct {
+	Imported int                `json:"imported"`
+	Errors   []MeterImportError `json:"errors"`
+}
+
+type MeterImportError struct {
+	Line  int    `json:"line"`
+	Error string `json:"error"`
+}
+
+type MeterService interface {
+	GetByAsset(assetID uuid.UUID) ([]domain.Meter, error)
+	GetByID(id uuid.UUID) (*domain.Meter, error)
+	Create(meter *domain.Meter) error
+	Update(id uuid.UUID, updates map[string]interface{}) (*domain.Meter, error)
+	Delete(id uuid.UUID) error
+
+	GetReadings(meterID uuid.UUID, limit int) ([]domain.MeterReading, error)
+	// Record stores a reading. If it is the meter's latest, the meter's
+	// rules are evaluated and tickets raised for those that fire.
+	Record(reading *domain.MeterReading) error
+	// Import records readings from CSV with a header row naming the
+	// columns meterId, value and, optionally, readAt (RFC 3339).
+	Import(r io.Reader, userID uuid.UUID) (*MeterImportResult, error)
+
+	CreateRule(rule *domain.MeterRule) error
+	UpdateRule(meterID, ruleID uuid.UUID, updates map[string]interface{}) (*domain.MeterRule, error)
+	DeleteRule(meterID, ruleID uuid.UUID) error
+}
+
+type meterService struct {
+	repo      repository.MeterRepository
+	assetRepo repository.AssetRepository
+	userRepo  repository.UserRepository
+	tickets   TicketService
+	tx        repository.Transactor
+}
+
+func NewMeterService(repo repository.MeterRepository, assetRepo repository.AssetRepository, userRepo repository.UserRepository, tickets TicketService, tx repository.Transactor) MeterService {
+	return &meterService{
+		repo:      repo,
+		assetRepo: assetRepo,
+		userRepo:  userRepo,
+		tickets:   tickets,
+		tx:        tx,
+	}
+}
+
+func (s *meterService) GetByAsset(assetID uuid.UUID) ([]domain.Meter, error) {
+	if _, err := s.assetRepo.FindByID(assetID); err != nil {
+		return nil, ErrAssetNotFound
+	}
+	return s.repo.FindByAsset(assetID)
+}
+
+func (s *meterService) GetByID(id uuid.UUID) (*domain.Meter, error) {
+	meter, err := s.repo.FindByID(id)
+	if err != nil {
+		return nil, ErrMeterNotFound
+	}
+	return meter, nil
+}
+
+func (s *meterService) Create(meter *domain.Meter) error {
+	if _, err := s.assetRepo.FindByID(meter.AssetID); err != nil {
+		return ErrAssetNotFound
+	}
+	if meter.Name == "" {
+		return invalidMeter(errors.New("name is required"))
+	}
+	meter.LastValue = nil
+	meter.LastReadAt = nil
+	return s.repo.Create(meter)
+}
+
+func (s *meterService) Update(id uuid.UUID, updates map[string]interface{}) (*domain.Meter, error) {
+	meter, err := s.repo.FindByID(id)
+	if err != nil {
+		return nil, ErrMeterNotFound
+	}
+
+	if name, ok := updates["name"].(string); ok {
+		meter.Name = name
+	}
+	if unit, ok := updates["unit"].(string); ok {
+		meter.Unit = unit
+	}
+	if cumulative, ok := updates["cumulative"].(bool); ok {
+		meter.Cumulative = cumulative
+	}
+
+	if meter.Name == "" {
+		return nil, invalidMeter(errors.New("name is required"))
+	}
+	if err := s.repo.Update(meter); err != nil {
+		return nil, err
+	}
+	return meter, nil
+}
+
+func (s *meterService) Delete(id uuid.UUID) error {
+	if _, err := s.repo.FindByID(id); err != nil {
+		return ErrMeterNotFound
+	}
+	return s.repo.Delete(id)
+}
+
+func (s *meterService) GetReadings(meterID uuid.UUID, limit int) ([]domain.MeterReading, error) {
+	if _, err := s.repo.FindByID(meterID); err != nil {
+		return nil, ErrMeterNotFound
+	}
+	if limit <= 0 || limit > 500 {
+		limit = 100
+	}
+	return s.repo.FindReadings(meterID, limit)
+}
+
+func (s *meterService) Record(reading *domain.MeterReading) error {
+	meter, err := s.repo.FindByID(reading.MeterID)
+	if err != nil {
+		return ErrMeterNotFound
+	}
+	return s.record(meter, reading, time.Now())
+}
+
+func (s *meterService) record(meter *domain.Meter, reading *domain.MeterReading, now time.Time) error {
+	if reading.ReadAt.IsZero() {
+		reading.ReadAt = now
+	}
+	if reading.ReadAt.After(now) {
+		return invalidReading(errors.New("reading cannot be in the future"))
+	}
+
+	// The meter is locked so that concurrent readings see each other's
+	// last value.
+	latest := false
+	err := s.tx.WithinTransaction(func(r repository.Repositories) error {
+		locked, err := r.Meters.Lock(meter.ID)
+		if err != nil {
+			return ErrMeterNotFound
+		}
+		meter.LastValue = locked.LastValue
+		meter.LastReadAt = locked.LastReadAt
+
+		latest = meter.LastReadAt == nil || !reading.ReadAt.Before(*meter.LastReadAt)
+		if latest && meter.Cumulative && meter.LastValue != nil && reading.Value < *meter.LastValue {
+			return invalidReading(fmt.Errorf("%s only counts up; last reading was %s", meter.Name, formatQuantity(*meter.LastValue)))
+		}
+
+		if err := r.Meters.CreateReading(reading); err != nil {
+			return err
+		}
+		if !latest {
+			return nil
+		}
+		if err := r.Meters.SetLastReading(meter.ID, reading.Value, reading.ReadAt); err != nil {
+			return err
+		}
+		meter.LastValue = &reading.Value
+		meter.LastReadAt = &reading.ReadAt
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// Backfilled readings are kept for history but do not fire rules.
+	if latest {
+		s.evaluate(meter, reading, now)
+	}
+	return nil
+}
+
+// evaluate runs the meter's active rules against a new reading. Failures
+// are only logged; the reading has already been recorded.
+func (s *meterService) evaluate(meter *domain.Meter, reading *domain.MeterReading, now time.Time) {
+	for i := range meter.Rules {
+		rule := &meter.Rules[i]
+		if !rule.Active {
+			continue
+		}
+		// A DELTA rule without a baseline starts counting from its first
+		// reading.
+		if rule.Type == domain.MeterRuleDelta && rule.Baseline == nil {
+			value := reading.Value
+			rule.Baseline = &value
+			if err := s.repo.UpdateRule(rule); err != nil {
+				log.Printf("Meter %s, rule %s: %v", meter.Name, rule.Name, err)
+			}
+			continue
+		}
+		if !rule.Fires(reading.Value) {
+			continue
+		}
+		if err := s.trigger(meter, rule, reading, now); err != nil {
+			log.Printf("Meter %s, rule %s: %v", meter.Name, rule.Name, err)
+		}
+	}
+}
+
+// trigger raises the rule's ticket. The ticket and the rule's bookkeeping
+// commit together; nothing is created while the rule's previous ticket is
+// still open.
+func (s *meterService) trigger(meter *domain.Meter, rule *domain.MeterRule, reading *domain.MeterReading, now time.Time) error {
+	if rule.LastTicketID != nil {
+		if last, err := s.tickets.GetByID(*rule.LastTicketID); err == nil && ticketOpen(last) {
+			return nil
+		}
+	}
+
+	ticket := &domain.Ticket{
+		Title:        rule.TicketTitle,
+		Description:  meterTicketDescription(meter, rule, reading),
+		Priority:     rule.TicketPriority,
+		AssetID:      &meter.AssetID,
+		AssignedToID: rule.AssigneeID,
+		// Generated tickets are attributed to the rule's creator.
+		CreatedByID: rule.CreatedByID,
+	}
+	if ticket.Title == "" {
+		ticket.Title = fmt.Sprintf("%s: %s", meter.Name, rule.Name)
+	}
+	if meter.Asset != nil {
+		ticket.Title = fmt.Sprintf("[%s] %s", meter.Asset.Code, ticket.Title)
+		ticket.Category = meter.Asset.Category
+		ticket.Location = meter.Asset.Location
+		ticket.LocationID = meter.Asset.LocationID
+	}
+
+	err := s.tickets.CreateWith(ticket, func(r repository.Repositories) error {
+		locked, err := r.Meters.LockRule(rule.ID)
+		if err != nil {
+			return err
+		}
+		if locked.LastTicketID != nil {
+			if last, err := r.Tickets.FindByID(*locked.LastTicketID); err == nil && ticketOpen(last) {
+				return errMeterRuleOpen
+			}
+		}
+		locked.Advance(reading.Value)
+		locked.LastTicketID = &ticket.ID
+		locked.LastTriggeredAt = &now
+		if err := r.Meters.UpdateRule(locked); err != nil {
+			return err
+		}
+		*rule = *locked
+		return nil
+	})
+	if errors.Is(err, errMeterRuleOpen) {
+		return nil
+	}
+	return err
+}
+
+func (s *meterService) Import(r io.Reader, userID uuid.UUID) (*MeterImportResult, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, invalidImport(errors.New("missing header row"))
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	meterCol, ok := columns["meterid"]
+	if !ok {
+		return nil, invalidImport(errors.New("missing meterId column"))
+	}
+	valueCol, ok := columns["value"]
+	if !ok {
+		return nil, invalidImport(errors.New("missing value column"))
+	}
+	readAtCol, hasReadAt := columns["readat"]
+
+	result := &MeterImportResult{Errors: []MeterImportError{}}
+	meters := make(map[uuid.UUID]*domain.Meter)
+	now := time.Now()
+	for rows := 0; ; rows++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if rows == maxImportRows {
+			return nil, invalidImport(fmt.Errorf("more than %d rows", maxImportRows))
+		}
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			result.Errors = append(result.Errors, MeterImportError{Line: parseErr.Line, Error: parseErr.Err.Error()})
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		line, _ := reader.FieldPos(0)
+
+		reading, meterID, err := parseReadingRow(record, meterCol, valueCol, readAtCol, hasReadAt)
+		if err == nil {
+			meter, ok := meters[meterID]
+			if !ok {
+				if meter, err = s.repo.FindByID(meterID); err != nil {
+					err = ErrMeterNotFound
+				} else {
+					meters[meterID] = meter
+				}
+			}
+			if err == nil {
+				reading.RecordedByID = userID
+				err = s.record(meter, reading, now)
+			}
+		}
+		if err != nil {
+			result.Errors = append(result.Errors, MeterImportError{Line: line, Error: err.Error()})
+			continue
+		}
+		result.Imported++
+	}
+	return result, nil
+}
+
+func parseReadingRow(record []string, meterCol, valueCol, readAtCol int, hasReadAt bool) (*domain.MeterReading, uuid.UUID, error) {
+	field := func(i int) string {
+		if i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+
+	meterID, err := uuid.Parse(field(meterCol))
+	if err != nil {
+		return nil, uuid.Nil, invalidReading(errors.New("meterId is not a valid ID"))
+	}
+	value, err := strconv.ParseFloat(field(valueCol), 64)
+	if err != nil {
+		return nil, uuid.Nil, invalidReading(errors.New("value is not a number"))
+	}
+	reading := &domain.MeterReading{MeterID: meterID, Value: value}
+	if hasReadAt && field(readAtCol) != "" {
+		if reading.ReadAt, err = time.Parse(time.RFC3339, field(readAtCol)); err != nil {
+			return nil, uuid.Nil, invalidReading(errors.New("readAt is not an RFC 3339 time"))
+		}
+	}
+	return reading, meterID, nil
+}
+
+func (s *meterService) CreateRule(rule *domain.MeterRule) error {
+	meter, err := s.repo.FindByID(rule.MeterID)
+	if err != nil {
+		return ErrMeterNotFound
+	}
+	if err := s.validateRule(rule); err != nil {
+		return err
+	}
+	// Interval counting starts from the meter's current reading unless a
+	// baseline is given, e.g. the run-hours at the last service.
+	if rule.Type == domain.MeterRuleDelta && rule.Baseline == nil && meter.LastValue != nil {
+		baseline := *meter.LastValue
+		rule.Baseline = &baseline
+	}
+	return s.repo.CreateRule(rule)
+}
+
+func (s *meterService) UpdateRule(meterID, ruleID uuid.UUID, updates map[string]interface{}) (*domain.MeterRule, error) {
+	rule, err := s.repo.FindRuleByID(ruleID)
+	if err != nil || rule.MeterID != meterID {
+		return nil, ErrMeterRuleNotFound
+	}
+
+	if name, ok := updates["name"].(string); ok {
+		rule.Name = name
+	}
+	if operator, ok := updates["operator"].(string); ok {
+		rule.Operator = domain.MeterOperator(operator)
+	}
+	if value, ok := updates["value"].(float64); ok {
+		rule.Value = value
+	}
+	if baseline, ok := updates["baseline"].(float64); ok {
+		rule.Baseline = &baseline
+	}
+	if active, ok := updates["active"].(bool); ok {
+		rule.Active = active
+	}
+	if title, ok := updates["ticketTitle"].(string); ok {
+		rule.TicketTitle = title
+	}
+	if description, ok := updates["ticketDescription"].(string); ok {
+		rule.TicketDescription = description
+	}
+	if priority, ok := updates["ticketPriority"].(string); ok {
+		rule.TicketPriority = domain.TicketPriority(priority)
+	}
+	if assigneeID, ok := updates["assigneeId"].(uuid.UUID); ok {
+		rule.AssigneeID = &assigneeID
+	}
+
+	if err := s.validateRule(rule); err != nil {
+		return nil, err
+	}
+	if err := s.repo.UpdateRule(rule); err != nil {
+		return nil, err
+	}
+	return rule, nil
+}
+
+func (s *meterService) DeleteRule(meterID, ruleID uuid.UUID) error {
+	rule, err := s.repo.FindRuleByID(ruleID)
+	if err != nil || rule.MeterID != meterID {
+		return ErrMeterRuleNotFound
+	}
+	return s.repo.DeleteRule(rule.ID)
+}
+
+func (s *meterService) validateRule(rule *domain.MeterRule) error {
+	if rule.Name == "" {
+		return invalidMeterRule(errors.New("name is required"))
+	}
+	switch rule.Type {
+	case domain.MeterRuleThreshold:
+		if !rule.Operator.IsValid() {
+			return invalidMeterRule(fmt.Errorf("unknown operator %q", rule.Operator))
+		}
+		rule.Baseline = nil
+	case domain.MeterRuleDelta:
+		if rule.Value <= 0 {
+			return invalidMeterRule(errors.New("interval must be positive"))
+		}
+		rule.Operator = ""
+	default:
+		return invalidMeterRule(fmt.Errorf("unknown rule type %q", rule.Type))
+	}
+	if rule.AssigneeID != nil {
+		if _, err := s.userRepo.FindByID(*rule.AssigneeID); err != nil {
+			return ErrUserNotFound
+		}
+	}
+	return nil
+}
+
+func ticketOpen(ticket *domain.Ticket) bool {
+	return ticket.Status != domain.StatusResolved && ticket.Status != domain.StatusClosed
+}
+
+func meterTicketDescription(meter *domain.Meter, rule *domain.MeterRule, reading *domain.MeterReading) string {
+	var b strings.Builder
+	if rule.TicketDescription != "" {
+		b.WriteString(rule.TicketDescription)
+		b.WriteString("\n\n")
+	}
+	fmt.Fprintf(&b, "Raised by meter rule %q: %s read %s", rule.Name, meter.Name, formatQuantity(reading.Value))
+	if meter.Unit != "" {
+		b.WriteString(" " + meter.Unit)
+	}
+	fmt.Fprintf(&b, " at %s.", reading.ReadAt.Format(time.RFC3339))
+	return b.String()
+}
+
+func invalidMeter(err error) error {
+	return fmt.Errorf("%w: %v", ErrInvalidMeter, err)
+}
+
+func invalidMeterRule(err error) error {
+	return fmt.Errorf("%w: %v", ErrInvalidMeterRule, err)
+}
+
+func invalidReading(err error) error {
+	return fmt.Errorf("%w: %v", ErrInvalidReading, err)
+}
+
+func invalidImport(err error) error {
+	return fmt.Errorf("%w: %v", ErrInvalidImport, err)
+}
@@ -0,0 +1,123 @@
+package service
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/maintenance-system/api/internal/domain"
+	"github.com/maintenance-system/api/internal/repository"
+	"github.com/maintenance-system/api/internal/websocket"
+)
+
+var ErrNotificationNotFound = errors.New("notification not found")
+
+// Notification types understood by the web app.
+const (
+	NotificationInfo    = "info"
+	NotificationSuccess = "success"
+	NotificationWarning = "warning"
+	NotificationError   = "error"
+	NotificationTicket  = "ticket"
+)
+
+type NotificationService interface {
+	// GetForUser returns the user's latest notifications and how many of
+	// all their notifications are unread.
+	GetForUser(userID uuid.UUID, unreadOnly bool, limit int) ([]domain.Notification, int64, error)
+	MarkAsRead(id, userID uuid.UUID) error
+	MarkAllAsRead(userID uuid.UUID) error
+
+	// Notify stores a copy of the notification for each user and pushes it
+	// to connected clients.
+	Notify(userIDs []uuid.UUID, notification domain.Notification) error
+	// NotifyRoles notifies every active user with one of the roles.
+	NotifyRoles(roles []domain.UserRole, notification domain.Notification) error
+}
+
+type notificationService struct {
+	repo     repository.NotificationRepository
+	userRepo repository.UserRepository
+	hub      *websocket.Hub
+}
+
+func NewNotificationService(repo repository.NotificationRepository, userRepo repository.UserRepository, hub *websocket.Hub) NotificationService {
+	return &notificationService{
+		repo:     repo,
+		userRepo: userRepo,
+		hub:      hub,
+	}
+}
+
+func (s *notificationService) GetForUser(userID uuid.UUID, unreadOnly bool, limit int) ([]domain.Notification, int64, error) {
+	notifications, err := s.repo.FindByUserID(userID, unreadOnly, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+	unread, err := s.repo.CountUnread(userID)
+	if err != nil {
+		return nil, 0, err
+	}
+	return notifications, unread, nil
+}
+
+func (s *notificationService) MarkAsRead(id, userID uuid.UUID) error {
+	if err := s.repo.MarkAsRead(id, userID); err != nil {
+		return ErrNotificationNotFound
+	}
+	return nil
+}
+
+func (s *notificationService) MarkAllAsRead(userID uuid.UUID) error {
+	return s.repo.MarkAllAsRead(userID)
+}
+
+func (s *notificationService) Notify(userIDs []uuid.UUID, notification domain.Notification) error {
+	if notification.Type == "" {
+		notification.Type = NotificationInfo
+	}
+
+	seen := make(map[uuid.UUID]bool, len(userIDs))
+	notifications := make([]domain.Notification, 0, len(userIDs))
+	for _, userID := range userIDs {
+		if seen[userID] {
+			continue
+		}
+		seen[userID] = true
+		n := notification
+		n.ID = uuid.New()
+		n.UserID = userID
+		n.Read = false
+		n.CreatedAt = time.Now()
+		notifications = append(notifications, n)
+	}
+
+	if err := s.repo.CreateBatch(notifications); err != nil {
+		return err
+	}
+
+	// The hub has no per-user channels; clients pick out their own
+	// notifications by userId.
+	if s.hub != nil {
+		for _, n := range notifications {
+			s.hub.Broadcast("notification:created", n)
+		}
+	}
+	return nil
+}
+
+func (s *notificationService) NotifyRoles(roles []domain.UserRole, notification domain.Notification) error {
+	var userIDs []uuid.UUID
+	for _, role := range roles {
+		users, err := s.userRepo.FindByRole(role)
+		if err != nil {
+			return err
+		}
+		for _, u := range users {
+			if u.Status == domain.StatusActive {
+				userIDs = append(userIDs, u.ID)
+			}
+		}
+	}
+	return s.Notify(userIDs, notification)
+}
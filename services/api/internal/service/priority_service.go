@@ -0,0 +1,166 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/maintenance-system/api/internal/domain"
+	"github.com/maintenance-system/api/internal/repository"
+)
+
+var (
+	ErrPriorityNotFound = errors.New("priority not found")
+	ErrPriorityExists   = errors.New("a priority with this code already exists")
+	ErrInvalidPriority  = errors.New("invalid priority")
+	ErrPriorityInUse    = errors.New("priority is still used by tickets or settings")
+)
+
+type PriorityService interface {
+	// GetAll returns the priorities from least to most urgent.
+	GetAll() ([]domain.Priority, error)
+	GetByID(id uuid.UUID) (*domain.Priority, error)
+	Create(priority *domain.Priority) error
+	// Update changes the priority. The code is fixed; slaPolicyId uuid.Nil
+	// unlinks the SLA policy.
+	Update(id uuid.UUID, updates map[string]interface{}) (*domain.Priority, error)
+	// Delete removes a priority that nothing uses; deactivate it otherwise.
+	Delete(id uuid.UUID) error
+
+	// IsActive reports whether code is a priority that can be chosen.
+	IsActive(code domain.TicketPriority) bool
+	// Next returns the active priority one step more urgent than code, or
+	// code itself if there is none.
+	Next(code domain.TicketPriority) domain.TicketPriority
+}
+
+type priorityService struct {
+	repo    repository.PriorityRepository
+	slaRepo repository.SLAPolicyRepository
+}
+
+func NewPriorityService(repo repository.PriorityRepository, slaRepo repository.SLAPolicyRepository) PriorityService {
+	return &priorityService{repo: repo, slaRepo: slaRepo}
+}
+
+func (s *priorityService) GetAll() ([]domain.Priority, error) {
+	return s.repo.FindAll()
+}
+
+func (s *priorityService) GetByID(id uuid.UUID) (*domain.Priority, error) {
+	priority, err := s.repo.FindByID(id)
+	if err != nil {
+		return nil, ErrPriorityNotFound
+	}
+	return priority, nil
+}
+
+func (s *priorityService) Create(priority *domain.Priority) error {
+	priority.Code = domain.TicketPriority(strings.ToUpper(strings.TrimSpace(string(priority.Code))))
+	if !typeCodePattern.MatchString(string(priority.Code)) {
+		return invalidPriority(errors.New("code must be up to 20 capital letters, digits and underscores, starting with a letter"))
+	}
+	if _, err := s.repo.FindByCode(priority.Code); err == nil {
+		return ErrPriorityExists
+	}
+	if err := s.validate(priority); err != nil {
+		return err
+	}
+	return s.repo.Create(priority)
+}
+
+func (s *priorityService) Update(id uuid.UUID, updates map[string]interface{}) (*domain.Priority, error) {
+	priority, err := s.repo.FindByID(id)
+	if err != nil {
+		return nil, ErrPriorityNotFound
+	}
+
+	if code, ok := updates["code"].(string); ok && domain.TicketPriority(code) != priority.Code {
+		return nil, invalidPriority(errors.New("the code cannot be changed once created"))
+	}
+	if name, ok := updates["nameEn"].(string); ok {
+		priority.NameEN = name
+	}
+	if name, ok := updates["nameTh"].(string); ok {
+		priority.NameTH = name
+	}
+	if rank, ok := updates["rank"].(int); ok {
+		priority.Rank = rank
+	}
+	if color, ok := updates["color"].(string); ok {
+		priority.Color = color
+	}
+	if policyID, ok := updates["slaPolicyId"].(uuid.UUID); ok {
+		priority.SLAPolicyID = nil
+		priority.SLAPolicy = nil
+		if policyID != uuid.Nil {
+			priority.SLAPolicyID = &policyID
+		}
+	}
+	if active, ok := updates["active"].(bool); ok {
+		priority.Active = active
+	}
+
+	if err := s.validate(priority); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Update(priority); err != nil {
+		return nil, err
+	}
+	return s.repo.FindByID(priority.ID)
+}
+
+func (s *priorityService) Delete(id uuid.UUID) error {
+	priority, err := s.repo.FindByID(id)
+	if err != nil {
+		return ErrPriorityNotFound
+	}
+	count, err := s.repo.CountUsage(priority.Code)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return ErrPriorityInUse
+	}
+	return s.repo.Delete(id)
+}
+
+func (s *priorityService) IsActive(code domain.TicketPriority) bool {
+	priority, err := s.repo.FindByCode(code)
+	return err == nil && priority.Active
+}
+
+func (s *priorityService) Next(code domain.TicketPriority) domain.TicketPriority {
+	current, err := s.repo.FindByCode(code)
+	if err != nil {
+		return code
+	}
+	next, err := s.repo.FindNext(current.Rank)
+	if err != nil {
+		return code
+	}
+	return next.Code
+}
+
+func (s *priorityService) validate(priority *domain.Priority) error {
+	priority.NameEN = strings.TrimSpace(priority.NameEN)
+	priority.NameTH = strings.TrimSpace(priority.NameTH)
+	priority.Color = strings.ToLower(strings.TrimSpace(priority.Color))
+	if priority.NameEN == "" {
+		return invalidPriority(errors.New("an English name is required"))
+	}
+	if priority.Color != "" && !tagColorPattern.MatchString(priority.Color) {
+		return invalidPriority(fmt.Errorf("color %q is not a #rrggbb hex color", priority.Color))
+	}
+	if priority.SLAPolicyID != nil {
+		if _, err := s.slaRepo.FindByID(*priority.SLAPolicyID); err != nil {
+			return ErrSLAPolicyNotFound
+		}
+	}
+	return nil
+}
+
+func invalidPriority(err error) error {
+	return fmt.Errorf("%w: %v", ErrInvalidPriority, err)
+}
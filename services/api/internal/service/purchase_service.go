@@ -0,0 +1,490 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/maintenance-system/api/internal/domain"
+	"github.com/maintenance-system/api/internal/repository"
+)
+
+var (
+	ErrPurchaseNotFound   = errors.New("purchase request not found")
+	ErrInvalidPurchase    = errors.New("invalid purchase request")
+	ErrPurchaseNotDraft   = errors.New("purchase request has been submitted and can no longer be edited")
+	ErrPurchaseTransition = errors.New("purchase request status change not allowed")
+)
+
+// purchaseTransitions lists the statuses each status may move to:
+//
+//	DRAFT -> SUBMITTED -> APPROVED -> ORDERED -> RECEIVED
+//
+// Anything not yet received may be cancelled.
+var purchaseTransitions = map[domain.PurchaseStatus][]domain.PurchaseStatus{
+	domain.PurchaseDraft:     {domain.PurchaseSubmitted, domain.PurchaseCancelled},
+	domain.PurchaseSubmitted: {domain.PurchaseApproved, domain.PurchaseCancelled},
+	domain.PurchaseApproved:  {domain.PurchaseOrdered, domain.PurchaseCancelled},
+	domain.PurchaseOrdered:   {domain.PurchaseReceived, domain.PurchaseCancelled},
+}
+
+type PurchaseService interface {
+	GetAll(filter repository.PurchaseFilter) ([]domain.PurchaseRequest, int64, error)
+	GetByID(id uuid.UUID) (*domain.PurchaseRequest, error)
+	// Create drafts a request for the ticket. Lines for a part default
+	// their description, unit and cost from the part.
+	Create(request *domain.PurchaseRequest) error
+	// Update edits a draft; a "lines" entry replaces the lines.
+	Update(id uuid.UUID, updates map[string]interface{}) (*domain.PurchaseRequest, error)
+	// Delete removes a draft.
+	Delete(id uuid.UUID) error
+
+	// Submit sends the request for approval and puts the ticket in
+	// PENDING until the goods arrive.
+	Submit(id, userID uuid.UUID) (*domain.PurchaseRequest, error)
+	Approve(id, userID uuid.UUID) (*domain.PurchaseRequest, error)
+	// Order records that the goods were ordered from the supplier.
+	Order(id, userID uuid.UUID, reference string) (*domain.PurchaseRequest, error)
+	// Receive records that the goods arrived. With a stock location, lines
+	// for parts are received into it; everything else is charged to the
+	// ticket as parts cost. The ticket goes back to IN_PROGRESS once
+	// nothing else is on order for it.
+	Receive(id, userID uuid.UUID, stockLocationID *uuid.UUID) (*domain.PurchaseRequest, error)
+	// Cancel withdraws the request, releasing the ticket like Receive.
+	Cancel(id, userID uuid.UUID) (*domain.PurchaseRequest, error)
+}
+
+type purchaseService struct {
+	repo       repository.PurchaseRepository
+	ticketRepo repository.TicketRepository
+	inventory  repository.InventoryRepository
+	tickets    TicketService
+	tx         repository.Transactor
+	notifier   NotificationService
+}
+
+func NewPurchaseService(repo repository.PurchaseRepository, ticketRepo repository.TicketRepository, inventory repository.InventoryRepository, tickets TicketService, tx repository.Transactor, notifier NotificationService) PurchaseService {
+	return &purchaseService{
+		repo:       repo,
+		ticketRepo: ticketRepo,
+		inventory:  inventory,
+		tickets:    tickets,
+		tx:         tx,
+		notifier:   notifier,
+	}
+}
+
+func (s *purchaseService) GetAll(filter repository.PurchaseFilter) ([]domain.PurchaseRequest, int64, error) {
+	return s.repo.FindAll(filter)
+}
+
+func (s *purchaseService) GetByID(id uuid.UUID) (*domain.PurchaseRequest, error) {
+	request, err := s.repo.FindByID(id)
+	if err != nil {
+		return nil, ErrPurchaseNotFound
+	}
+	return request, nil
+}
+
+func (s *purchaseService) Create(request *domain.PurchaseRequest) error {
+	ticket, err := s.ticketRepo.FindByID(request.TicketID)
+	if err != nil {
+		return ErrTicketNotFound
+	}
+	if ticket.Status == domain.StatusClosed {
+		return ErrTicketClosed
+	}
+	if err := s.prepareLines(request.Lines); err != nil {
+		return err
+	}
+
+	request.Status = domain.PurchaseDraft
+	request.SumLines()
+	if err := s.repo.Create(request); err != nil {
+		return err
+	}
+	created, err := s.repo.FindByID(request.ID)
+	if err != nil {
+		return err
+	}
+	*request = *created
+	return nil
+}
+
+func (s *purchaseService) Update(id uuid.UUID, updates map[string]interface{}) (*domain.PurchaseRequest, error) {
+	lines, replaceLines := updates["lines"].([]domain.PurchaseRequestLine)
+	if replaceLines {
+		if err := s.prepareLines(lines); err != nil {
+			return nil, err
+		}
+	}
+
+	err := s.tx.WithinTransaction(func(r repository.Repositories) error {
+		request, err := r.Purchases.Lock(id)
+		if err != nil {
+			return ErrPurchaseNotFound
+		}
+		if request.Status != domain.PurchaseDraft {
+			return ErrPurchaseNotDraft
+		}
+
+		if supplier, ok := updates["supplier"].(string); ok {
+			request.Supplier = supplier
+		}
+		if notes, ok := updates["notes"].(string); ok {
+			request.Notes = notes
+		}
+		if replaceLines {
+			request.Lines = lines
+			request.SumLines()
+		}
+		return r.Purchases.Update(request, replaceLines)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.FindByID(id)
+}
+
+func (s *purchaseService) Delete(id uuid.UUID) error {
+	return s.tx.WithinTransaction(func(r repository.Repositories) error {
+		request, err := r.Purchases.Lock(id)
+		if err != nil {
+			return ErrPurchaseNotFound
+		}
+		if request.Status != domain.PurchaseDraft {
+			return ErrPurchaseNotDraft
+		}
+		return r.Purchases.Delete(id)
+	})
+}
+
+func (s *purchaseService) Submit(id, userID uuid.UUID) (*domain.PurchaseRequest, error) {
+	current, err := s.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	ticket, err := s.ticketRepo.FindByID(current.TicketID)
+	if err != nil {
+		return nil, ErrTicketNotFound
+	}
+	if ticket.Status == domain.StatusClosed {
+		return nil, ErrTicketClosed
+	}
+
+	var request *domain.PurchaseRequest
+	_, err = s.tickets.Hold(current.TicketID, userID, func(r repository.Repositories) error {
+		var err error
+		request, err = s.transition(r, id, domain.PurchaseSubmitted)
+		if err != nil {
+			return err
+		}
+		now := request.UpdatedAt
+		request.SubmittedAt = &now
+		if err := r.Purchases.Update(request, false); err != nil {
+			return err
+		}
+		return r.TicketLogs.Create(&domain.TicketLog{
+			TicketID:  request.TicketID,
+			UserID:    userID,
+			Action:    domain.LogActionPurchaseSubmitted,
+			NewValue:  describePurchase(request),
+			CreatedAt: now,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.notifyRoles([]domain.UserRole{domain.RoleApprover, domain.RoleAdmin}, request, "Purchase request awaiting approval")
+	return request, nil
+}
+
+func (s *purchaseService) Approve(id, userID uuid.UUID) (*domain.PurchaseRequest, error) {
+	var request *domain.PurchaseRequest
+	err := s.tx.WithinTransaction(func(r repository.Repositories) error {
+		var err error
+		request, err = s.transition(r, id, domain.PurchaseApproved)
+		if err != nil {
+			return err
+		}
+		request.ApprovedByID = &userID
+		now := request.UpdatedAt
+		request.ApprovedAt = &now
+		return r.Purchases.Update(request, false)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.notifyRequester(request, NotificationSuccess, "Purchase request approved")
+	return s.repo.FindByID(id)
+}
+
+func (s *purchaseService) Order(id, userID uuid.UUID, reference string) (*domain.PurchaseRequest, error) {
+	var request *domain.PurchaseRequest
+	err := s.tx.WithinTransaction(func(r repository.Repositories) error {
+		var err error
+		request, err = s.transition(r, id, domain.PurchaseOrdered)
+		if err != nil {
+			return err
+		}
+		now := request.UpdatedAt
+		request.OrderedAt = &now
+		request.OrderReference = reference
+		return r.Purchases.Update(request, false)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return request, nil
+}
+
+func (s *purchaseService) Receive(id, userID uuid.UUID, stockLocationID *uuid.UUID) (*domain.PurchaseRequest, error) {
+	current, err := s.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if stockLocationID != nil {
+		if _, err := s.inventory.FindStockLocationByID(*stockLocationID); err != nil {
+			return nil, ErrStockLocationNotFound
+		}
+	}
+
+	var request *domain.PurchaseRequest
+	err = s.settle(current, userID, func(r repository.Repositories) error {
+		var err error
+		request, err = s.transition(r, id, domain.PurchaseReceived)
+		if err != nil {
+			return err
+		}
+		request.ReceivedByID = &userID
+		now := request.UpdatedAt
+		request.ReceivedAt = &now
+		if err := r.Purchases.Update(request, false); err != nil {
+			return err
+		}
+		charged, err := s.receiveLines(r, request, userID, stockLocationID)
+		if err != nil {
+			return err
+		}
+		if charged > 0 {
+			if err := r.Tickets.AddPartsCost(request.TicketID, charged); err != nil {
+				return err
+			}
+		}
+		return r.TicketLogs.Create(&domain.TicketLog{
+			TicketID:  request.TicketID,
+			UserID:    userID,
+			Action:    domain.LogActionPurchaseReceived,
+			NewValue:  describePurchase(request),
+			CreatedAt: now,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.notifyRequester(request, NotificationSuccess, "Purchased goods received")
+	return request, nil
+}
+
+func (s *purchaseService) Cancel(id, userID uuid.UUID) (*domain.PurchaseRequest, error) {
+	current, err := s.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	submitted := current.SubmittedAt != nil
+
+	var request *domain.PurchaseRequest
+	save := func(r repository.Repositories) error {
+		var err error
+		request, err = s.transition(r, id, domain.PurchaseCancelled)
+		if err != nil {
+			return err
+		}
+		// Submitted in the meantime: the ticket hold was not planned for.
+		if (request.SubmittedAt != nil) != submitted {
+			return fmt.Errorf("%w: request was submitted concurrently", ErrPurchaseTransition)
+		}
+		now := request.UpdatedAt
+		request.CancelledAt = &now
+		if err := r.Purchases.Update(request, false); err != nil {
+			return err
+		}
+		if !submitted {
+			return nil
+		}
+		return r.TicketLogs.Create(&domain.TicketLog{
+			TicketID:  request.TicketID,
+			UserID:    userID,
+			Action:    domain.LogActionPurchaseCancelled,
+			OldValue:  describePurchase(request),
+			CreatedAt: now,
+		})
+	}
+	if submitted {
+		err = s.settle(current, userID, save)
+	} else {
+		err = s.tx.WithinTransaction(save)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return request, nil
+}
+
+// transition locks the request within the transaction and moves it to
+// status to, without saving. Holding the lock until the caller commits
+// keeps two concurrent calls from both making the same move.
+func (s *purchaseService) transition(r repository.Repositories, id uuid.UUID, to domain.PurchaseStatus) (*domain.PurchaseRequest, error) {
+	request, err := r.Purchases.Lock(id)
+	if err != nil {
+		return nil, ErrPurchaseNotFound
+	}
+	if !purchaseTransitionAllowed(request.Status, to) {
+		return nil, fmt.Errorf("%w: %s -> %s", ErrPurchaseTransition, request.Status, to)
+	}
+	if to == domain.PurchaseSubmitted && len(request.Lines) == 0 {
+		return nil, invalidPurchase(errors.New("at least one line is required"))
+	}
+	request.Status = to
+	request.UpdatedAt = time.Now()
+	return request, nil
+}
+
+func purchaseTransitionAllowed(from, to domain.PurchaseStatus) bool {
+	for _, next := range purchaseTransitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// settle runs within for a request that no longer holds up its ticket,
+// releasing the ticket if no other request does.
+func (s *purchaseService) settle(request *domain.PurchaseRequest, userID uuid.UUID, within func(r repository.Repositories) error) error {
+	outstanding, err := s.repo.CountOutstanding(request.TicketID, request.ID)
+	if err != nil {
+		return err
+	}
+	if outstanding > 0 {
+		return s.tx.WithinTransaction(within)
+	}
+	_, err = s.tickets.Release(request.TicketID, userID, within)
+	return err
+}
+
+// receiveLines posts the request's part lines into stock at
+// stockLocationID, if given, and returns the cost of the remaining lines,
+// which is charged to the ticket.
+func (s *purchaseService) receiveLines(r repository.Repositories, request *domain.PurchaseRequest, userID uuid.UUID, stockLocationID *uuid.UUID) (float64, error) {
+	charged := 0.0
+	for _, line := range request.Lines {
+		if line.PartID == nil || stockLocationID == nil {
+			charged += line.Total()
+			continue
+		}
+		part, err := r.Inventory.FindPartByID(*line.PartID)
+		if err != nil {
+			return 0, ErrPartNotFound
+		}
+		unitCost := line.UnitCost
+		if unitCost == 0 {
+			unitCost = part.UnitCost
+		}
+		movement := &domain.StockMovement{
+			PartID:          part.ID,
+			StockLocationID: *stockLocationID,
+			Type:            domain.MovementReceive,
+			Quantity:        line.Quantity,
+			UnitCost:        unitCost,
+			UserID:          userID,
+			Note:            "Purchase request " + request.ID.String(),
+		}
+		if _, err := r.Inventory.ApplyMovement(movement); err != nil {
+			return 0, err
+		}
+		if unitCost != part.UnitCost {
+			part.UnitCost = unitCost
+			if err := r.Inventory.UpdatePart(part); err != nil {
+				return 0, err
+			}
+		}
+	}
+	return roundCost(charged), nil
+}
+
+// prepareLines validates the lines, numbers them and fills in the part
+// defaults.
+func (s *purchaseService) prepareLines(lines []domain.PurchaseRequestLine) error {
+	for i := range lines {
+		line := &lines[i]
+		line.Position = i + 1
+		if line.PartID != nil {
+			part, err := s.inventory.FindPartByID(*line.PartID)
+			if err != nil {
+				return ErrPartNotFound
+			}
+			if line.Description == "" {
+				line.Description = part.Name
+			}
+			if line.Unit == "" {
+				line.Unit = part.Unit
+			}
+			if line.UnitCost == 0 {
+				line.UnitCost = part.UnitCost
+			}
+		}
+		if line.Description == "" {
+			return invalidPurchase(fmt.Errorf("line %d: description is required", i+1))
+		}
+		if line.Quantity <= 0 {
+			return invalidPurchase(fmt.Errorf("line %d: quantity must be positive", i+1))
+		}
+		if line.UnitCost < 0 {
+			return invalidPurchase(fmt.Errorf("line %d: unit cost cannot be negative", i+1))
+		}
+		if line.Unit == "" {
+			line.Unit = "pcs"
+		}
+	}
+	return nil
+}
+
+func (s *purchaseService) notifyRoles(roles []domain.UserRole, request *domain.PurchaseRequest, title string) {
+	if err := s.notifier.NotifyRoles(roles, domain.Notification{
+		Type:     NotificationInfo,
+		Title:    title,
+		Message:  describePurchase(request),
+		TicketID: &request.TicketID,
+	}); err != nil {
+		log.Printf("Purchase request %s: %v", request.ID, err)
+	}
+}
+
+func (s *purchaseService) notifyRequester(request *domain.PurchaseRequest, notificationType, title string) {
+	if err := s.notifier.Notify([]uuid.UUID{request.RequestedByID}, domain.Notification{
+		Type:     notificationType,
+		Title:    title,
+		Message:  describePurchase(request),
+		TicketID: &request.TicketID,
+	}); err != nil {
+		log.Printf("Purchase request %s: %v", request.ID, err)
+	}
+}
+
+func describePurchase(request *domain.PurchaseRequest) string {
+	msg := fmt.Sprintf("%d items, est. %.2f", len(request.Lines), request.EstimatedCost)
+	if request.Supplier != "" {
+		msg = request.Supplier + ": " + msg
+	}
+	return msg
+}
+
+func invalidPurchase(err error) error {
+	return fmt.Errorf("%w: %v", ErrInvalidPurchase, err)
+}
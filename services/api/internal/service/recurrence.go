@@ -0,0 +1,281 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxRecurrencePeriods bounds rule expansion so a rule whose periods never
+// produce an occurrence (e.g. BYMONTHDAY=31 with FREQ=MONTHLY;INTERVAL=12
+// starting in February) cannot loop forever.
+const maxRecurrencePeriods = 10000
+
+var rruleWeekdays = map[string]time.Weekday{
+	"SU": time.Sunday,
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+}
+
+// weekdayNum is a BYDAY entry: n > 0 is the nth weekday of the month,
+// n < 0 counts from the end, and n == 0 means every such weekday.
+type weekdayNum struct {
+	n   int
+	day time.Weekday
+}
+
+// recurrenceRule is the subset of an RFC 5545 RRULE the scheduler
+// supports.
+type recurrenceRule struct {
+	freq       string
+	interval   int
+	byDay      []weekdayNum
+	byMonthDay []int
+	count      int
+	until      *time.Time
+}
+
+func parseRRule(s string) (*recurrenceRule, error) {
+	s = strings.TrimPrefix(strings.ToUpper(strings.TrimSpace(s)), "RRULE:")
+	r := &recurrenceRule{interval: 1}
+
+	for _, part := range strings.Split(s, ";") {
+		key, value, ok := strings.Cut(part, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid rule part %q", part)
+		}
+		switch key {
+		case "FREQ":
+			if value != "DAILY" && value != "WEEKLY" && value != "MONTHLY" {
+				return nil, fmt.Errorf("unsupported FREQ %q", value)
+			}
+			r.freq = value
+		case "INTERVAL":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 {
+				return nil, fmt.Errorf("invalid INTERVAL %q", value)
+			}
+			r.interval = n
+		case "BYDAY":
+			for _, v := range strings.Split(value, ",") {
+				wd, err := parseWeekdayNum(v)
+				if err != nil {
+					return nil, err
+				}
+				r.byDay = append(r.byDay, wd)
+			}
+		case "BYMONTHDAY":
+			for _, v := range strings.Split(value, ",") {
+				n, err := strconv.Atoi(v)
+				if err != nil || n == 0 || n < -31 || n > 31 {
+					return nil, fmt.Errorf("invalid BYMONTHDAY %q", v)
+				}
+				r.byMonthDay = append(r.byMonthDay, n)
+			}
+		case "COUNT":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 {
+				return nil, fmt.Errorf("invalid COUNT %q", value)
+			}
+			r.count = n
+		case "UNTIL":
+			t, err := parseRRuleUntil(value)
+			if err != nil {
+				return nil, err
+			}
+			r.until = &t
+		default:
+			return nil, fmt.Errorf("unsupported rule part %q", key)
+		}
+	}
+
+	if r.freq == "" {
+		return nil, fmt.Errorf("FREQ is required")
+	}
+	if r.count > 0 && r.until != nil {
+		return nil, fmt.Errorf("COUNT and UNTIL cannot both be set")
+	}
+	if r.freq != "MONTHLY" {
+		if len(r.byMonthDay) > 0 {
+			return nil, fmt.Errorf("BYMONTHDAY requires FREQ=MONTHLY")
+		}
+		for _, wd := range r.byDay {
+			if wd.n != 0 {
+				return nil, fmt.Errorf("numbered BYDAY requires FREQ=MONTHLY")
+			}
+		}
+	}
+	return r, nil
+}
+
+func parseWeekdayNum(s string) (weekdayNum, error) {
+	if len(s) < 2 {
+		return weekdayNum{}, fmt.Errorf("invalid BYDAY %q", s)
+	}
+	day, ok := rruleWeekdays[s[len(s)-2:]]
+	if !ok {
+		return weekdayNum{}, fmt.Errorf("invalid BYDAY %q", s)
+	}
+	wd := weekdayNum{day: day}
+	if prefix := s[:len(s)-2]; prefix != "" {
+		n, err := strconv.Atoi(prefix)
+		if err != nil || n == 0 || n < -5 || n > 5 {
+			return weekdayNum{}, fmt.Errorf("invalid BYDAY %q", s)
+		}
+		wd.n = n
+	}
+	return wd, nil
+}
+
+func parseRRuleUntil(s string) (time.Time, error) {
+	for _, layout := range []string{"20060102T150405Z", "20060102"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			if layout == "20060102" {
+				t = t.Add(24*time.Hour - time.Second) // the whole day is included
+			}
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid UNTIL %q", s)
+}
+
+// each calls yield with every occurrence in order, starting at dtstart,
+// until yield returns false or the rule ends.
+func (r *recurrenceRule) each(dtstart time.Time, yield func(time.Time) bool) {
+	emitted := 0
+	for period := 0; period < maxRecurrencePeriods; period++ {
+		for _, t := range r.candidates(dtstart, period) {
+			if t.Before(dtstart) {
+				continue
+			}
+			if r.until != nil && t.After(*r.until) {
+				return
+			}
+			if r.count > 0 && emitted >= r.count {
+				return
+			}
+			emitted++
+			if !yield(t) {
+				return
+			}
+		}
+	}
+}
+
+// between returns up to limit occurrences in [from, to). A zero to means no
+// upper bound.
+func (r *recurrenceRule) between(dtstart, from, to time.Time, limit int) []time.Time {
+	var out []time.Time
+	r.each(dtstart, func(t time.Time) bool {
+		if !to.IsZero() && !t.Before(to) {
+			return false
+		}
+		if !t.Before(from) {
+			out = append(out, t)
+		}
+		return limit <= 0 || len(out) < limit
+	})
+	return out
+}
+
+// candidates returns the sorted occurrence times of one period (day, week
+// or month) after dtstart.
+func (r *recurrenceRule) candidates(dtstart time.Time, period int) []time.Time {
+	loc := dtstart.Location()
+	y, m, d := dtstart.Date()
+	hh, mm, ss := dtstart.Clock()
+	at := func(y int, m time.Month, d int) time.Time {
+		return time.Date(y, m, d, hh, mm, ss, 0, loc)
+	}
+	step := period * r.interval
+
+	switch r.freq {
+	case "DAILY":
+		return []time.Time{at(y, m, d+step)}
+
+	case "WEEKLY":
+		monday := d - (int(dtstart.Weekday())+6)%7 + step*7
+		days := r.byDay
+		if len(days) == 0 {
+			days = []weekdayNum{{day: dtstart.Weekday()}}
+		}
+		var out []time.Time
+		for _, wd := range days {
+			out = append(out, at(y, m, monday+(int(wd.day)+6)%7))
+		}
+		sortTimes(out)
+		return out
+
+	default: // MONTHLY
+		first := time.Date(y, m+time.Month(step), 1, 0, 0, 0, 0, loc)
+		fy, fm, _ := first.Date()
+		dim := time.Date(fy, fm+1, 0, 0, 0, 0, 0, loc).Day()
+
+		monthDays := make(map[int]bool)
+		for _, md := range r.byMonthDay {
+			if md < 0 {
+				md = dim + md + 1
+			}
+			if md >= 1 && md <= dim {
+				monthDays[md] = true
+			}
+		}
+
+		var days []int
+		switch {
+		case len(r.byDay) > 0:
+			for _, wd := range r.byDay {
+				for _, day := range weekdaysInMonth(first, dim, wd) {
+					// BYMONTHDAY and BYDAY together must both match.
+					if len(r.byMonthDay) == 0 || monthDays[day] {
+						days = append(days, day)
+					}
+				}
+			}
+		case len(r.byMonthDay) > 0:
+			for day := range monthDays {
+				days = append(days, day)
+			}
+		case d <= dim:
+			days = []int{d}
+		}
+
+		sort.Ints(days)
+		var out []time.Time
+		for i, day := range days {
+			if i > 0 && days[i-1] == day {
+				continue
+			}
+			out = append(out, at(fy, fm, day))
+		}
+		return out
+	}
+}
+
+// weekdaysInMonth returns the days of the month matching wd.
+func weekdaysInMonth(first time.Time, dim int, wd weekdayNum) []int {
+	firstMatch := 1 + (int(wd.day)-int(first.Weekday())+7)%7
+	var all []int
+	for day := firstMatch; day <= dim; day += 7 {
+		all = append(all, day)
+	}
+	switch {
+	case wd.n == 0:
+		return all
+	case wd.n > 0 && wd.n <= len(all):
+		return []int{all[wd.n-1]}
+	case wd.n < 0 && -wd.n <= len(all):
+		return []int{all[len(all)+wd.n]}
+	}
+	return nil
+}
+
+func sortTimes(ts []time.Time) {
+	sort.Slice(ts, func(i, j int) bool { return ts[i].Before(ts[j]) })
+}
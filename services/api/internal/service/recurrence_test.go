@@ -0,0 +1,90 @@
+package service
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRecurrenceBetween(t *testing.T) {
+	day := func(y int, m time.Month, d int) time.Time {
+		return time.Date(y, m, d, 9, 0, 0, 0, time.UTC)
+	}
+	jan1 := day(2025, time.January, 1) // a Wednesday
+
+	tests := []struct {
+		name     string
+		rule     string
+		dtstart  time.Time
+		from, to time.Time
+		limit    int
+		want     []time.Time
+	}{
+		{
+			name: "daily every other day", rule: "FREQ=DAILY;INTERVAL=2",
+			dtstart: jan1, from: jan1, limit: 3,
+			want: []time.Time{jan1, day(2025, time.January, 3), day(2025, time.January, 5)},
+		},
+		{
+			name: "window excludes to", rule: "FREQ=DAILY",
+			dtstart: jan1, from: day(2025, time.January, 10), to: day(2025, time.January, 12),
+			want: []time.Time{day(2025, time.January, 10), day(2025, time.January, 11)},
+		},
+		{
+			name: "weekly on several days", rule: "RRULE:FREQ=WEEKLY;BYDAY=MO,WE",
+			dtstart: jan1, from: jan1, limit: 3,
+			want: []time.Time{jan1, day(2025, time.January, 6), day(2025, time.January, 8)},
+		},
+		{
+			name: "monthly on the last day", rule: "FREQ=MONTHLY;BYMONTHDAY=-1",
+			dtstart: jan1, from: jan1, limit: 3,
+			want: []time.Time{day(2025, time.January, 31), day(2025, time.February, 28), day(2025, time.March, 31)},
+		},
+		{
+			name: "monthly on the second Tuesday", rule: "FREQ=MONTHLY;BYDAY=2TU",
+			dtstart: jan1, from: jan1, limit: 3,
+			want: []time.Time{day(2025, time.January, 14), day(2025, time.February, 11), day(2025, time.March, 11)},
+		},
+		{
+			name: "monthly skips short months", rule: "FREQ=MONTHLY",
+			dtstart: day(2025, time.January, 31), from: jan1, limit: 3,
+			want: []time.Time{day(2025, time.January, 31), day(2025, time.March, 31), day(2025, time.May, 31)},
+		},
+		{
+			name: "count ends the rule", rule: "FREQ=DAILY;COUNT=2",
+			dtstart: jan1, from: jan1, limit: 10,
+			want: []time.Time{jan1, day(2025, time.January, 2)},
+		},
+		{
+			name: "count includes occurrences before from", rule: "FREQ=DAILY;COUNT=3",
+			dtstart: jan1, from: day(2025, time.January, 2), limit: 10,
+			want: []time.Time{day(2025, time.January, 2), day(2025, time.January, 3)},
+		},
+		{
+			name: "until includes its whole day", rule: "FREQ=DAILY;UNTIL=20250103",
+			dtstart: jan1, from: jan1, limit: 10,
+			want: []time.Time{jan1, day(2025, time.January, 2), day(2025, time.January, 3)},
+		},
+		{
+			name: "day of month that never occurs", rule: "FREQ=MONTHLY;INTERVAL=12;BYMONTHDAY=31",
+			dtstart: day(2025, time.February, 1), from: jan1, limit: 1,
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := parseRRule(tt.rule)
+			if err != nil {
+				t.Fatalf("parseRRule(%q): %v", tt.rule, err)
+			}
+			got := rule.between(tt.dtstart, tt.from, tt.to, tt.limit)
+			if len(got) != len(tt.want) {
+				t.Fatalf("between() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if !got[i].Equal(tt.want[i]) {
+					t.Fatalf("between() = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
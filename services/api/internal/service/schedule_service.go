@@ -0,0 +1,278 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/maintenance-system/api/internal/domain"
+	"github.com/maintenance-system/api/internal/repository"
+)
+
+var (
+	ErrScheduleNotFound = errors.New("schedule not found")
+	ErrInvalidSchedule  = errors.New("invalid schedule")
+
+	// errOccurrenceTaken rolls back a generated ticket whose occurrence
+	// was claimed first by another instance.
+	errOccurrenceTaken = errors.New("occurrence already generated")
+)
+
+// maxPreviewOccurrences caps how many occurrences Preview returns.
+const maxPreviewOccurrences = 100
+
+type ScheduleService interface {
+	GetAll() ([]domain.MaintenanceSchedule, error)
+	GetByID(id uuid.UUID) (*domain.MaintenanceSchedule, error)
+	Create(schedule *domain.MaintenanceSchedule) error
+	Update(id uuid.UUID, updates map[string]interface{}) (*domain.MaintenanceSchedule, error)
+	Delete(id uuid.UUID) error
+	GetOccurrences(id uuid.UUID) ([]domain.ScheduleOccurrence, error)
+
+	// Preview returns the next count occurrences of the schedule from now.
+	Preview(id uuid.UUID, count int, now time.Time) ([]time.Time, error)
+	// Run generates the tickets for every active schedule's occurrences
+	// that fall within its lead time, including any missed since the last
+	// generated one, and returns how many were created.
+	Run(ctx context.Context, now time.Time) (int, error)
+}
+
+type scheduleService struct {
+	repo    repository.ScheduleRepository
+	tickets TicketService
+}
+
+func NewScheduleService(repo repository.ScheduleRepository, tickets TicketService) ScheduleService {
+	return &scheduleService{repo: repo, tickets: tickets}
+}
+
+func (s *scheduleService) GetAll() ([]domain.MaintenanceSchedule, error) {
+	return s.repo.FindAll(false)
+}
+
+func (s *scheduleService) GetByID(id uuid.UUID) (*domain.MaintenanceSchedule, error) {
+	schedule, err := s.repo.FindByID(id)
+	if err != nil {
+		return nil, ErrScheduleNotFound
+	}
+	return schedule, nil
+}
+
+func (s *scheduleService) Create(schedule *domain.MaintenanceSchedule) error {
+	if schedule.Timezone == "" {
+		schedule.Timezone = "Asia/Bangkok"
+	}
+	if schedule.TicketCategory == "" {
+		schedule.TicketCategory = domain.CategoryGeneral
+	}
+	if schedule.TicketPriority == "" {
+		schedule.TicketPriority = domain.PriorityMedium
+	}
+	if err := validateSchedule(schedule); err != nil {
+		return err
+	}
+	return s.repo.Create(schedule)
+}
+
+func (s *scheduleService) Update(id uuid.UUID, updates map[string]interface{}) (*domain.MaintenanceSchedule, error) {
+	schedule, err := s.repo.FindByID(id)
+	if err != nil {
+		return nil, ErrScheduleNotFound
+	}
+
+	if name, ok := updates["name"].(string); ok {
+		schedule.Name = name
+	}
+	if rrule, ok := updates["rrule"].(string); ok {
+		schedule.RRule = rrule
+	}
+	if startAt, ok := updates["startAt"].(time.Time); ok {
+		schedule.StartAt = startAt
+	}
+	if tz, ok := updates["timezone"].(string); ok {
+		schedule.Timezone = tz
+	}
+	if leadDays, ok := updates["leadDays"].(int); ok {
+		schedule.LeadDays = leadDays
+	}
+	if active, ok := updates["active"].(bool); ok {
+		schedule.Active = active
+	}
+	if title, ok := updates["ticketTitle"].(string); ok {
+		schedule.TicketTitle = title
+	}
+	if description, ok := updates["ticketDescription"].(string); ok {
+		schedule.TicketDescription = description
+	}
+	if category, ok := updates["ticketCategory"].(string); ok {
+		schedule.TicketCategory = domain.TicketCategory(category)
+	}
+	if priority, ok := updates["ticketPriority"].(string); ok {
+		schedule.TicketPriority = domain.TicketPriority(priority)
+	}
+	if location, ok := updates["ticketLocation"].(string); ok {
+		schedule.TicketLocation = location
+	}
+	if assigneeID, ok := updates["assigneeId"].(uuid.UUID); ok {
+		schedule.AssigneeID = &assigneeID
+		schedule.Assignee = nil
+	}
+
+	if err := validateSchedule(schedule); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Update(schedule); err != nil {
+		return nil, err
+	}
+	return schedule, nil
+}
+
+func (s *scheduleService) Delete(id uuid.UUID) error {
+	return s.repo.Delete(id)
+}
+
+func (s *scheduleService) GetOccurrences(id uuid.UUID) ([]domain.ScheduleOccurrence, error) {
+	if _, err := s.repo.FindByID(id); err != nil {
+		return nil, ErrScheduleNotFound
+	}
+	return s.repo.FindOccurrences(id)
+}
+
+func (s *scheduleService) Preview(id uuid.UUID, count int, now time.Time) ([]time.Time, error) {
+	schedule, err := s.repo.FindByID(id)
+	if err != nil {
+		return nil, ErrScheduleNotFound
+	}
+	if count < 1 || count > maxPreviewOccurrences {
+		count = maxPreviewOccurrences
+	}
+
+	rule, dtstart, err := scheduleRule(schedule)
+	if err != nil {
+		return nil, err
+	}
+	return rule.between(dtstart, now, time.Time{}, count), nil
+}
+
+func (s *scheduleService) Run(ctx context.Context, now time.Time) (int, error) {
+	schedules, err := s.repo.FindAll(true)
+	if err != nil {
+		return 0, err
+	}
+
+	created := 0
+	for i := range schedules {
+		schedule := &schedules[i]
+		rule, dtstart, err := scheduleRule(schedule)
+		if err != nil {
+			log.Printf("Schedule %s: %v", schedule.Name, err)
+			continue
+		}
+
+		// Start after the last generated occurrence, so occurrences that
+		// fell due while the job was not running are still generated, and
+		// include the horizon itself, so a lead of 0 days generates on the
+		// day.
+		from := schedule.CreatedAt
+		last, err := s.repo.LastOccurrence(schedule.ID)
+		if err != nil {
+			log.Printf("Schedule %s: %v", schedule.Name, err)
+			continue
+		}
+		if last != nil {
+			from = last.Add(time.Nanosecond)
+		}
+		horizon := now.AddDate(0, 0, schedule.LeadDays).Add(time.Nanosecond)
+		for _, occursAt := range rule.between(dtstart, from, horizon, 0) {
+			if ctx.Err() != nil {
+				return created, ctx.Err()
+			}
+			ok, err := s.generate(schedule, occursAt)
+			if err != nil {
+				log.Printf("Schedule %s, occurrence %s: %v", schedule.Name, occursAt.Format(time.RFC3339), err)
+				continue
+			}
+			if ok {
+				created++
+			}
+		}
+	}
+	return created, nil
+}
+
+// generate creates the ticket for one occurrence. The ticket and the
+// occurrence record commit together; if the occurrence was already
+// generated nothing is created and false is returned.
+func (s *scheduleService) generate(schedule *domain.MaintenanceSchedule, occursAt time.Time) (bool, error) {
+	dueDate := occursAt
+	ticket := &domain.Ticket{
+		Title:        schedule.TicketTitle,
+		Description:  schedule.TicketDescription,
+		Category:     schedule.TicketCategory,
+		Priority:     schedule.TicketPriority,
+		Location:     schedule.TicketLocation,
+		DueDate:      &dueDate,
+		ScheduleID:   &schedule.ID,
+		AssignedToID: schedule.AssigneeID,
+		// Generated tickets are attributed to the schedule's creator.
+		CreatedByID: schedule.CreatedByID,
+	}
+
+	err := s.tickets.CreateWith(ticket, func(r repository.Repositories) error {
+		ok, err := r.Schedules.ClaimOccurrence(&domain.ScheduleOccurrence{
+			ScheduleID: schedule.ID,
+			OccursAt:   occursAt,
+			TicketID:   ticket.ID,
+		})
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return errOccurrenceTaken
+		}
+		return nil
+	})
+	if errors.Is(err, errOccurrenceTaken) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// scheduleRule parses the schedule's rule and returns it with the start
+// time in the schedule's timezone, so occurrences keep their local time of
+// day across DST changes.
+func scheduleRule(schedule *domain.MaintenanceSchedule) (*recurrenceRule, time.Time, error) {
+	loc, err := time.LoadLocation(schedule.Timezone)
+	if err != nil {
+		return nil, time.Time{}, invalidSchedule(err)
+	}
+	rule, err := parseRRule(schedule.RRule)
+	if err != nil {
+		return nil, time.Time{}, invalidSchedule(err)
+	}
+	return rule, schedule.StartAt.In(loc), nil
+}
+
+func validateSchedule(schedule *domain.MaintenanceSchedule) error {
+	if schedule.Name == "" {
+		return invalidSchedule(errors.New("name is required"))
+	}
+	if schedule.TicketTitle == "" {
+		return invalidSchedule(errors.New("ticket title is required"))
+	}
+	if schedule.StartAt.IsZero() {
+		return invalidSchedule(errors.New("start time is required"))
+	}
+	if schedule.LeadDays < 0 {
+		return invalidSchedule(errors.New("lead days cannot be negative"))
+	}
+	_, _, err := scheduleRule(schedule)
+	return err
+}
+
+func invalidSchedule(err error) error {
+	return fmt.Errorf("%w: %v", ErrInvalidSchedule, err)
+}
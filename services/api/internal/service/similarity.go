@@ -0,0 +1,41 @@
+package service
+
+import (
+	"strings"
+	"unicode"
+)
+
+// textSimilarity scores how alike two texts are, from 0 to 1, by the share
+// of trigrams they have in common, the way pg_trgm does. Words are runs of
+// letters, digits and combining marks, so Thai vowel and tone marks stay
+// with their consonants. Thai is written without spaces, so a Thai phrase
+// is one long word and matches on its trigrams rather than whole words.
+func textSimilarity(a, b string) float64 {
+	ta, tb := trigrams(a), trigrams(b)
+	if len(ta) == 0 || len(tb) == 0 {
+		return 0
+	}
+	shared := 0
+	for gram := range ta {
+		if tb[gram] {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(ta)+len(tb)-shared)
+}
+
+func trigrams(text string) map[string]bool {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && !unicode.IsMark(r)
+	})
+	grams := make(map[string]bool)
+	for _, word := range words {
+		// Two spaces in front and one behind, as pg_trgm pads words, so
+		// short words still give trigrams and word starts weigh more.
+		runes := []rune("  " + word + " ")
+		for i := 0; i+3 <= len(runes); i++ {
+			grams[string(runes[i:i+3])] = true
+		}
+	}
+	return grams
+}
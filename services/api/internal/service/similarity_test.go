@@ -0,0 +1,59 @@
+package service
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTrigrams(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []string
+	}{
+		{"empty", "", nil},
+		{"punctuation only", "!? -", nil},
+		{"one letter", "a", []string{"  a", " a "}},
+		{"padded word", "Ab", []string{"  a", " ab", "ab "}},
+		{"words split on punctuation", "a-b", []string{"  a", " a ", "  b", " b "}},
+		{"thai marks stay with consonants", "แอร์", []string{"  แ", " แอ", "แอร", "อร์", "ร์ "}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want := make(map[string]bool)
+			for _, gram := range tt.want {
+				want[gram] = true
+			}
+			if got := trigrams(tt.text); !reflect.DeepEqual(got, want) {
+				t.Errorf("trigrams(%q) = %v, want %v", tt.text, got, want)
+			}
+		})
+	}
+}
+
+func TestTextSimilarity(t *testing.T) {
+	tests := []struct {
+		name     string
+		a, b     string
+		min, max float64
+	}{
+		{"identical", "air con leaking", "air con leaking", 1, 1},
+		{"case and punctuation ignored", "Air-con LEAKING!", "air con leaking", 1, 1},
+		{"empty", "", "air con leaking", 0, 0},
+		{"nothing shared", "door", "lamp", 0, 0},
+		{"extra words", "air con leaking", "air con leaking in room 301", 0.5, 0.99},
+		{"thai phrase", "แอร์ไม่เย็น", "แอร์ไม่เย็นห้อง 301", 0.4, 0.99},
+		{"unrelated", "printer jammed", "air con leaking", 0, 0.1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := textSimilarity(tt.a, tt.b)
+			if got < tt.min || got > tt.max {
+				t.Errorf("textSimilarity(%q, %q) = %.3f, want between %.2f and %.2f", tt.a, tt.b, got, tt.min, tt.max)
+			}
+			if back := textSimilarity(tt.b, tt.a); back != got {
+				t.Errorf("textSimilarity is not symmetric: %.3f and %.3f", got, back)
+			}
+		})
+	}
+}
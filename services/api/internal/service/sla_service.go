@@ -0,0 +1,174 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/maintenance-system/api/internal/domain"
+	"github.com/maintenance-system/api/internal/repository"
+)
+
+var ErrSLAPolicyNotFound = errors.New("SLA policy not found")
+
+type SLAService interface {
+	GetAll() ([]domain.SLAPolicy, error)
+	GetByID(id uuid.UUID) (*domain.SLAPolicy, error)
+	Create(policy *domain.SLAPolicy) error
+	Update(id uuid.UUID, updates map[string]interface{}) (*domain.SLAPolicy, error)
+	Delete(id uuid.UUID) error
+
+	// ApplyPolicy sets the ticket's SLA policy and deadlines from its
+	// priority and category, counting working time on the policy's
+	// calendar. Time already spent paused is added on top. Without a
+	// matching policy the deadlines are cleared. A due date set by hand
+	// (DueDateManual) is kept either way.
+	ApplyPolicy(ticket *domain.Ticket) error
+	// PauseClock stops the SLA clock, e.g. while a ticket is PENDING.
+	PauseClock(ticket *domain.Ticket, now time.Time)
+	// ResumeClock restarts the clock and pushes the open deadlines back by
+	// the working time spent paused.
+	ResumeClock(ticket *domain.Ticket, now time.Time) error
+	// ClockFor returns the business clock that governs the ticket's SLA.
+	ClockFor(ticket *domain.Ticket) (BusinessClock, error)
+}
+
+type slaService struct {
+	repo      repository.SLAPolicyRepository
+	calendars CalendarService
+}
+
+func NewSLAService(repo repository.SLAPolicyRepository, calendars CalendarService) SLAService {
+	return &slaService{repo: repo, calendars: calendars}
+}
+
+func (s *slaService) GetAll() ([]domain.SLAPolicy, error) {
+	return s.repo.FindAll()
+}
+
+func (s *slaService) GetByID(id uuid.UUID) (*domain.SLAPolicy, error) {
+	policy, err := s.repo.FindByID(id)
+	if err != nil {
+		return nil, ErrSLAPolicyNotFound
+	}
+	return policy, nil
+}
+
+func (s *slaService) Create(policy *domain.SLAPolicy) error {
+	return s.repo.Create(policy)
+}
+
+func (s *slaService) Update(id uuid.UUID, updates map[string]interface{}) (*domain.SLAPolicy, error) {
+	policy, err := s.repo.FindByID(id)
+	if err != nil {
+		return nil, ErrSLAPolicyNotFound
+	}
+
+	if name, ok := updates["name"].(string); ok {
+		policy.Name = name
+	}
+	if minutes, ok := updates["responseMinutes"].(int); ok {
+		policy.ResponseMinutes = minutes
+	}
+	if minutes, ok := updates["resolutionMinutes"].(int); ok {
+		policy.ResolutionMinutes = minutes
+	}
+	if calendarID, ok := updates["calendarId"].(uuid.UUID); ok {
+		policy.CalendarID = &calendarID
+	}
+
+	if err := s.repo.Update(policy); err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
+
+func (s *slaService) Delete(id uuid.UUID) error {
+	return s.repo.Delete(id)
+}
+
+func (s *slaService) ApplyPolicy(ticket *domain.Ticket) error {
+	policy, err := s.repo.FindMatch(ticket.Priority, ticket.Category)
+	if err != nil {
+		return fmt.Errorf("looking up SLA policy: %w", err)
+	}
+	if policy == nil {
+		// No policy for this combination: the ticket has no SLA.
+		ticket.SLAPolicyID = nil
+		ticket.SLAPolicy = nil
+		ticket.ResponseDueAt = nil
+		if !ticket.DueDateManual {
+			ticket.DueDate = nil
+		}
+		return nil
+	}
+
+	clock, err := s.calendars.Clock(policy.CalendarID)
+	if err != nil {
+		return err
+	}
+
+	paused := time.Duration(ticket.SLAPausedSeconds) * time.Second
+
+	ticket.SLAPolicyID = &policy.ID
+	ticket.SLAPolicy = policy
+	responseDue := clock.Add(ticket.CreatedAt, policy.ResponseTarget()+paused)
+	ticket.ResponseDueAt = &responseDue
+	if !ticket.DueDateManual {
+		resolutionDue := clock.Add(ticket.CreatedAt, policy.ResolutionTarget()+paused)
+		ticket.DueDate = &resolutionDue
+	}
+
+	return nil
+}
+
+func (s *slaService) ClockFor(ticket *domain.Ticket) (BusinessClock, error) {
+	policy := ticket.SLAPolicy
+	if policy == nil && ticket.SLAPolicyID != nil {
+		policy, _ = s.repo.FindByID(*ticket.SLAPolicyID)
+	}
+	if policy == nil {
+		return s.calendars.Clock(nil)
+	}
+	return s.calendars.Clock(policy.CalendarID)
+}
+
+func (s *slaService) PauseClock(ticket *domain.Ticket, now time.Time) {
+	if ticket.SLAPausedAt != nil {
+		return
+	}
+	ticket.SLAPausedAt = &now
+}
+
+func (s *slaService) ResumeClock(ticket *domain.Ticket, now time.Time) error {
+	if ticket.SLAPausedAt == nil {
+		return nil
+	}
+
+	clock, err := s.ClockFor(ticket)
+	if err != nil {
+		return err
+	}
+
+	pausedAt := *ticket.SLAPausedAt
+	ticket.SLAPausedSeconds += int64(clock.Elapsed(pausedAt, now) / time.Second)
+	ticket.SLAPausedAt = nil
+
+	// Whatever working time was left on a deadline when the clock stopped
+	// is still left now.
+	resume := func(due *time.Time) *time.Time {
+		if !due.After(pausedAt) {
+			return due
+		}
+		next := clock.Add(now, clock.Elapsed(pausedAt, *due))
+		return &next
+	}
+	if ticket.ResponseDueAt != nil && ticket.FirstRespondedAt == nil {
+		ticket.ResponseDueAt = resume(ticket.ResponseDueAt)
+	}
+	if ticket.DueDate != nil && ticket.ResolvedAt == nil {
+		ticket.DueDate = resume(ticket.DueDate)
+	}
+	return nil
+}
@@ -0,0 +1,288 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/maintenance-system/api/internal/domain"
+	"github.com/maintenance-system/api/internal/repository"
+	"github.com/maintenance-system/api/internal/websocket"
+)
+
+var (
+	ErrTagNotFound = errors.New("tag not found")
+	ErrTagExists   = errors.New("a tag with this name already exists")
+	ErrInvalidTag  = errors.New("invalid tag")
+)
+
+var tagColorPattern = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
+// TagChange reports what a retagging did.
+type TagChange struct {
+	Added   int `json:"added"`   // ticket/tag pairs added
+	Removed int `json:"removed"` // ticket/tag pairs removed
+	Tickets int `json:"tickets"` // tickets whose tags changed
+}
+
+type TagService interface {
+	GetAll() ([]domain.Tag, error)
+	GetByID(id uuid.UUID) (*domain.Tag, error)
+	Create(tag *domain.Tag) error
+	// Update changes the tag in place, so a rename shows on every ticket
+	// with it at once.
+	Update(id uuid.UUID, updates map[string]interface{}) (*domain.Tag, error)
+	Delete(id uuid.UUID) error
+	// Merge folds the source tags into the target: their tickets get the
+	// target tag and the sources are deleted, all in one transaction that
+	// also logs the change on each ticket.
+	Merge(targetID uuid.UUID, sourceIDs []uuid.UUID, userID uuid.UUID) (*domain.Tag, error)
+
+	// Retag adds and removes tags on the tickets in one transaction and
+	// logs each change on its ticket.
+	Retag(ticketIDs, add, remove []uuid.UUID, userID uuid.UUID) (*TagChange, error)
+	// TicketTags returns the tags on a ticket.
+	TicketTags(ticketID uuid.UUID) ([]domain.Tag, error)
+}
+
+type tagService struct {
+	repo       repository.TagRepository
+	ticketRepo repository.TicketRepository
+	tx         repository.Transactor
+	hub        *websocket.Hub
+}
+
+func NewTagService(repo repository.TagRepository, ticketRepo repository.TicketRepository, tx repository.Transactor, hub *websocket.Hub) TagService {
+	return &tagService{
+		repo:       repo,
+		ticketRepo: ticketRepo,
+		tx:         tx,
+		hub:        hub,
+	}
+}
+
+func (s *tagService) GetAll() ([]domain.Tag, error) {
+	return s.repo.FindAll()
+}
+
+func (s *tagService) GetByID(id uuid.UUID) (*domain.Tag, error) {
+	tag, err := s.repo.FindByID(id)
+	if err != nil {
+		return nil, ErrTagNotFound
+	}
+	return tag, nil
+}
+
+func (s *tagService) Create(tag *domain.Tag) error {
+	if err := validateTag(tag); err != nil {
+		return err
+	}
+	if err := s.checkUnique(tag); err != nil {
+		return err
+	}
+	return s.repo.Create(tag)
+}
+
+func (s *tagService) Update(id uuid.UUID, updates map[string]interface{}) (*domain.Tag, error) {
+	tag, err := s.repo.FindByID(id)
+	if err != nil {
+		return nil, ErrTagNotFound
+	}
+
+	if name, ok := updates["name"].(string); ok {
+		tag.Name = name
+	}
+	if color, ok := updates["color"].(string); ok {
+		tag.Color = color
+	}
+	if description, ok := updates["description"].(string); ok {
+		tag.Description = description
+	}
+
+	if err := validateTag(tag); err != nil {
+		return nil, err
+	}
+	if err := s.checkUnique(tag); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Update(tag); err != nil {
+		return nil, err
+	}
+	return tag, nil
+}
+
+func (s *tagService) Delete(id uuid.UUID) error {
+	if _, err := s.repo.FindByID(id); err != nil {
+		return ErrTagNotFound
+	}
+	return s.repo.Delete(id)
+}
+
+func (s *tagService) Merge(targetID uuid.UUID, sourceIDs []uuid.UUID, userID uuid.UUID) (*domain.Tag, error) {
+	target, err := s.repo.FindByID(targetID)
+	if err != nil {
+		return nil, ErrTagNotFound
+	}
+	if len(sourceIDs) == 0 {
+		return nil, invalidTag(errors.New("no tags to merge"))
+	}
+	for _, id := range sourceIDs {
+		if id == target.ID {
+			return nil, invalidTag(errors.New("a tag cannot be merged into itself"))
+		}
+	}
+	names, err := s.tagNames(sourceIDs)
+	if err != nil {
+		return nil, err
+	}
+	names[target.ID] = target.Name
+
+	var added, removed []domain.TicketTag
+	err = s.tx.WithinTransaction(func(r repository.Repositories) error {
+		var err error
+		if added, removed, err = r.Tags.Merge(sourceIDs, target.ID); err != nil {
+			return err
+		}
+		return r.TicketLogs.CreateBatch(tagLogs(added, removed, names, userID))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.broadcastTagged(append(added, removed...))
+	return target, nil
+}
+
+func (s *tagService) Retag(ticketIDs, add, remove []uuid.UUID, userID uuid.UUID) (*TagChange, error) {
+	if len(ticketIDs) == 0 {
+		return nil, invalidTag(errors.New("no tickets given"))
+	}
+	if len(add) == 0 && len(remove) == 0 {
+		return nil, invalidTag(errors.New("no tags to add or remove"))
+	}
+	for _, id := range add {
+		for _, other := range remove {
+			if id == other {
+				return nil, invalidTag(fmt.Errorf("tag %s is both added and removed", id))
+			}
+		}
+	}
+
+	names, err := s.tagNames(append(append([]uuid.UUID{}, add...), remove...))
+	if err != nil {
+		return nil, err
+	}
+
+	var added, removed []domain.TicketTag
+	err = s.tx.WithinTransaction(func(r repository.Repositories) error {
+		var err error
+		if len(add) > 0 {
+			if added, err = r.Tags.Tag(ticketIDs, add); err != nil {
+				return err
+			}
+		}
+		if len(remove) > 0 {
+			if removed, err = r.Tags.Untag(ticketIDs, remove); err != nil {
+				return err
+			}
+		}
+
+		return r.TicketLogs.CreateBatch(tagLogs(added, removed, names, userID))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	tickets := s.broadcastTagged(append(added, removed...))
+	return &TagChange{Added: len(added), Removed: len(removed), Tickets: tickets}, nil
+}
+
+// broadcastTagged tells clients which tickets the pairs touched and returns
+// how many there were.
+func (s *tagService) broadcastTagged(pairs []domain.TicketTag) int {
+	changed := make(map[uuid.UUID]bool)
+	for _, pair := range pairs {
+		changed[pair.TicketID] = true
+	}
+	if s.hub != nil && len(changed) > 0 {
+		ids := make([]uuid.UUID, 0, len(changed))
+		for id := range changed {
+			ids = append(ids, id)
+		}
+		s.hub.Broadcast("tickets:tagged", map[string]interface{}{
+			"ticketIds": ids,
+		})
+	}
+	return len(changed)
+}
+
+func (s *tagService) TicketTags(ticketID uuid.UUID) ([]domain.Tag, error) {
+	ticket, err := s.ticketRepo.FindByID(ticketID)
+	if err != nil {
+		return nil, ErrTicketNotFound
+	}
+	return ticket.Tags, nil
+}
+
+// checkUnique rejects a name already used by another tag, ignoring case.
+func (s *tagService) checkUnique(tag *domain.Tag) error {
+	existing, err := s.repo.FindByName(tag.Name)
+	if err == nil && existing.ID != tag.ID {
+		return ErrTagExists
+	}
+	return nil
+}
+
+// tagNames maps each of the tags to its name, failing if any is missing.
+func (s *tagService) tagNames(ids []uuid.UUID) (map[uuid.UUID]string, error) {
+	tags, err := s.repo.FindByIDs(ids)
+	if err != nil {
+		return nil, err
+	}
+	names := make(map[uuid.UUID]string, len(tags))
+	for _, tag := range tags {
+		names[tag.ID] = tag.Name
+	}
+	for _, id := range ids {
+		if _, ok := names[id]; !ok {
+			return nil, ErrTagNotFound
+		}
+	}
+	return names, nil
+}
+
+// tagLogs logs each added and removed pair on its ticket.
+func tagLogs(added, removed []domain.TicketTag, names map[uuid.UUID]string, userID uuid.UUID) []domain.TicketLog {
+	logs := make([]domain.TicketLog, 0, len(added)+len(removed))
+	for _, pair := range added {
+		logs = append(logs, newTicketLog(pair.TicketID, userID, domain.LogActionTagAdded, "", names[pair.TagID]))
+	}
+	for _, pair := range removed {
+		logs = append(logs, newTicketLog(pair.TicketID, userID, domain.LogActionTagRemoved, names[pair.TagID], ""))
+	}
+	return logs
+}
+
+func validateTag(tag *domain.Tag) error {
+	tag.Name = strings.TrimSpace(tag.Name)
+	tag.Color = strings.ToLower(strings.TrimSpace(tag.Color))
+	if tag.Name == "" {
+		return invalidTag(errors.New("name is required"))
+	}
+	if len([]rune(tag.Name)) > 50 {
+		return invalidTag(errors.New("name must be at most 50 characters"))
+	}
+	if tag.Color == "" {
+		tag.Color = domain.DefaultTagColor
+	}
+	if !tagColorPattern.MatchString(tag.Color) {
+		return invalidTag(fmt.Errorf("color %q is not a #rrggbb hex color", tag.Color))
+	}
+	return nil
+}
+
+func invalidTag(err error) error {
+	return fmt.Errorf("%w: %v", ErrInvalidTag, err)
+}
@@ -0,0 +1,104 @@
+package service
+
+import (
+	"encoding/json"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/maintenance-system/api/internal/domain"
+)
+
+// diffTicket returns one log entry for every tracked field that differs
+// between before and after.
+func diffTicket(before, after *domain.Ticket, userID uuid.UUID) []domain.TicketLog {
+	var logs []domain.TicketLog
+	add := func(action, oldValue, newValue string) {
+		if oldValue == newValue {
+			return
+		}
+		logs = append(logs, newTicketLog(after.ID, userID, action, oldValue, newValue))
+	}
+
+	add(domain.LogActionTitleChanged, before.Title, after.Title)
+	add(domain.LogActionDescriptionChanged, before.Description, after.Description)
+	add(domain.LogActionStatusChanged, string(before.Status), string(after.Status))
+	add(domain.LogActionPriorityChanged, string(before.Priority), string(after.Priority))
+	add(domain.LogActionCategoryChanged, string(before.Category), string(after.Category))
+	add(domain.LogActionLocationChanged, before.Location, after.Location)
+	add(domain.LogActionLocationChanged, uuidString(before.LocationID), uuidString(after.LocationID))
+	add(domain.LogActionAssigneeChanged, uuidString(before.AssignedToID), uuidString(after.AssignedToID))
+	add(domain.LogActionVendorChanged, uuidString(before.VendorID), uuidString(after.VendorID))
+	add(domain.LogActionDueDateChanged, timeString(before.DueDate), timeString(after.DueDate))
+	add(domain.LogActionAssetChanged, uuidString(before.AssetID), uuidString(after.AssetID))
+	add(domain.LogActionParentChanged, uuidString(before.ParentID), uuidString(after.ParentID))
+	add(domain.LogActionDepartmentChanged, uuidString(before.DepartmentID), uuidString(after.DepartmentID))
+	add(domain.LogActionCostChanged, costString(before.VendorCost), costString(after.VendorCost))
+	add(domain.LogActionEstimateChanged, costString(estimate(before)), costString(estimate(after)))
+	for _, key := range customFieldKeys(before.CustomFields, after.CustomFields) {
+		add(domain.LogActionCustomFieldChanged, customFieldString(before.CustomFields, key), customFieldString(after.CustomFields, key))
+	}
+
+	return logs
+}
+
+func newTicketLog(ticketID, userID uuid.UUID, action, oldValue, newValue string) domain.TicketLog {
+	return domain.TicketLog{
+		TicketID:  ticketID,
+		UserID:    userID,
+		Action:    action,
+		OldValue:  oldValue,
+		NewValue:  newValue,
+		CreatedAt: time.Now(),
+	}
+}
+
+func uuidString(id *uuid.UUID) string {
+	if id == nil {
+		return ""
+	}
+	return id.String()
+}
+
+func timeString(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+func estimate(t *domain.Ticket) float64 {
+	return t.EstimatedLaborCost + t.EstimatedPartsCost + t.EstimatedVendorCost
+}
+
+func costString(cost float64) string {
+	return strconv.FormatFloat(cost, 'f', 2, 64)
+}
+
+// customFieldKeys returns the keys set in either map, sorted.
+func customFieldKeys(before, after domain.JSONMap) []string {
+	seen := make(map[string]bool, len(before)+len(after))
+	var keys []string
+	for _, values := range []domain.JSONMap{before, after} {
+		for key := range values {
+			if !seen[key] {
+				seen[key] = true
+				keys = append(keys, key)
+			}
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// customFieldString writes a custom field as "key=value" for the log, or ""
+// when it is not set.
+func customFieldString(values domain.JSONMap, key string) string {
+	value, ok := values[key]
+	if !ok {
+		return ""
+	}
+	b, _ := json.Marshal(value)
+	return key + "=" + string(b)
+}
@@ -0,0 +1,77 @@
+package service
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/maintenance-system/api/internal/domain"
+	"github.com/maintenance-system/api/internal/repository"
+)
+
+var (
+	ErrInvalidParent  = errors.New("a ticket cannot be placed under itself or one of its sub-tickets")
+	ErrOpenSubTickets = errors.New("ticket has sub-tickets that are not resolved")
+)
+
+func (s *ticketService) CreateChild(parentID uuid.UUID, child *domain.Ticket) error {
+	parent, err := s.repo.FindByID(parentID)
+	if err != nil {
+		return ErrTicketNotFound
+	}
+	if parent.Status == domain.StatusClosed {
+		return ErrTicketClosed
+	}
+
+	child.ParentID = &parent.ID
+	if child.LocationID == nil && child.Location == "" {
+		child.LocationID = parent.LocationID
+		child.Location = parent.Location
+	}
+	if child.AssetID == nil {
+		child.AssetID = parent.AssetID
+	}
+	if child.DepartmentID == nil {
+		child.DepartmentID = parent.DepartmentID
+	}
+
+	return s.CreateWith(child, func(r repository.Repositories) error {
+		return r.TicketLogs.Create(&domain.TicketLog{
+			TicketID: parent.ID,
+			UserID:   child.CreatedByID,
+			Action:   domain.LogActionSubTicketAdded,
+			NewValue: child.Title,
+		})
+	})
+}
+
+// checkParent rejects moving the ticket under parentID if that would make
+// it its own ancestor.
+func (s *ticketService) checkParent(ticket *domain.Ticket, parentID uuid.UUID) error {
+	for id := &parentID; id != nil; {
+		if *id == ticket.ID {
+			return ErrInvalidParent
+		}
+		ancestor, err := s.repo.FindByID(*id)
+		if err != nil {
+			return ErrTicketNotFound
+		}
+		id = ancestor.ParentID
+	}
+	return nil
+}
+
+// checkSubTickets blocks resolving or closing a ticket while any of its
+// sub-tickets is still open.
+func (s *ticketService) checkSubTickets(ticket *domain.Ticket, to domain.TicketStatus) error {
+	if to != domain.StatusResolved && to != domain.StatusClosed {
+		return nil
+	}
+	progress, err := s.repo.Progress([]uuid.UUID{ticket.ID})
+	if err != nil {
+		return err
+	}
+	if p, ok := progress[ticket.ID]; ok && !p.Complete() {
+		return ErrOpenSubTickets
+	}
+	return nil
+}
@@ -0,0 +1,183 @@
+package service
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/maintenance-system/api/internal/domain"
+	"github.com/maintenance-system/api/internal/repository"
+)
+
+var (
+	ErrNotAwaitingApproval   = errors.New("ticket is not awaiting cost approval")
+	ErrCostApprovalForbidden = errors.New("not permitted to decide on cost approvals")
+	ErrCostRejected          = errors.New("cost estimate was rejected; revise the estimate first")
+	ErrCostFieldsForbidden   = errors.New("only approvers and admins may change the cost estimate or department")
+)
+
+// checkCostRejected keeps a ticket whose estimate was turned down from
+// moving anywhere but CLOSED until the estimate is revised.
+func checkCostRejected(ticket *domain.Ticket, to domain.TicketStatus) error {
+	if ticket.CostApproval == domain.CostApprovalRejected && to != domain.StatusClosed {
+		return ErrCostRejected
+	}
+	return nil
+}
+
+// checkCostFields refuses changes to the fields that decide whether a
+// ticket needs cost approval unless editor may decide on approvals.
+func checkCostFields(before, after *domain.Ticket, editor *domain.User) error {
+	if canDecideCost(editor) {
+		return nil
+	}
+	if before.EstimatedLaborCost != after.EstimatedLaborCost ||
+		before.EstimatedPartsCost != after.EstimatedPartsCost ||
+		before.EstimatedVendorCost != after.EstimatedVendorCost ||
+		uuidString(before.DepartmentID) != uuidString(after.DepartmentID) {
+		return ErrCostFieldsForbidden
+	}
+	return nil
+}
+
+func canDecideCost(user *domain.User) bool {
+	return user.Role == domain.RoleApprover || user.Role == domain.RoleAdmin
+}
+
+// holdForCostApproval moves the ticket to AWAITING_APPROVAL when its
+// estimate is over the threshold for the ticket's department and
+// category, unless that much has already been approved. An estimate
+// lowered below the threshold releases a held ticket.
+func (s *ticketService) holdForCostApproval(ticket *domain.Ticket, now time.Time) error {
+	if ticket.Status == domain.StatusResolved || ticket.Status == domain.StatusClosed {
+		return nil
+	}
+	ticket.SumCosts()
+	if ticket.CostApproval == domain.CostApprovalApproved && ticket.EstimatedCost <= ticket.ApprovedCost {
+		return nil
+	}
+
+	threshold, ok, err := s.costs.ThresholdFor(s.costDepartment(ticket), ticket.Category)
+	if err != nil {
+		return err
+	}
+	if !ok || ticket.EstimatedCost <= threshold {
+		if ticket.CostApproval == domain.CostApprovalApproved {
+			return nil
+		}
+		ticket.CostApproval = ""
+		if ticket.Status == domain.StatusAwaitingApproval {
+			return s.releaseFromApproval(ticket, now)
+		}
+		return nil
+	}
+
+	ticket.CostApproval = domain.CostApprovalPending
+	ticket.CostDecidedByID = nil
+	ticket.CostDecidedAt = nil
+	ticket.CostDecisionReason = ""
+	if ticket.Status == domain.StatusAwaitingApproval {
+		return nil
+	}
+
+	// Not a changeStatus: waiting for approval is not a response.
+	from := ticket.Status
+	ticket.StatusBeforeApproval = from
+	applyTransition(ticket, domain.StatusAwaitingApproval, now)
+	if !from.HoldsSLA() {
+		s.sla.PauseClock(ticket, now)
+	}
+	return nil
+}
+
+// releaseFromApproval returns a held ticket to the status it had before.
+func (s *ticketService) releaseFromApproval(ticket *domain.Ticket, now time.Time) error {
+	to := ticket.StatusBeforeApproval
+	if to == "" {
+		to = domain.StatusOpen
+	}
+	ticket.StatusBeforeApproval = ""
+	return s.changeStatus(ticket, to, now)
+}
+
+// costDepartment returns the name of the department the ticket is charged
+// to, falling back to the requester's department.
+func (s *ticketService) costDepartment(ticket *domain.Ticket) string {
+	if ticket.DepartmentID != nil {
+		if ticket.Department != nil && ticket.Department.ID == *ticket.DepartmentID {
+			return ticket.Department.Name
+		}
+		if department, err := s.deptRepo.FindByID(*ticket.DepartmentID); err == nil {
+			return department.Name
+		}
+	}
+	if ticket.CreatedBy != nil {
+		return ticket.CreatedBy.Department
+	}
+	requester, err := s.userRepo.FindByID(ticket.CreatedByID)
+	if err != nil {
+		return ""
+	}
+	return requester.Department
+}
+
+func (s *ticketService) ApproveCost(ticketID, approverID uuid.UUID, note string) (*domain.Ticket, error) {
+	return s.decideCost(ticketID, approverID, domain.CostApprovalApproved, note)
+}
+
+func (s *ticketService) RejectCost(ticketID, approverID uuid.UUID, reason string) (*domain.Ticket, error) {
+	return s.decideCost(ticketID, approverID, domain.CostApprovalRejected, reason)
+}
+
+func (s *ticketService) decideCost(ticketID, approverID uuid.UUID, decision domain.CostApprovalStatus, reason string) (*domain.Ticket, error) {
+	ticket, err := s.repo.FindByID(ticketID)
+	if err != nil {
+		return nil, ErrTicketNotFound
+	}
+	approver, err := s.userRepo.FindByID(approverID)
+	if err != nil {
+		return nil, ErrUserNotFound
+	}
+	if !canDecideCost(approver) {
+		return nil, ErrCostApprovalForbidden
+	}
+	if ticket.Status != domain.StatusAwaitingApproval {
+		return nil, ErrNotAwaitingApproval
+	}
+
+	before := *ticket
+	now := time.Now()
+
+	ticket.CostApproval = decision
+	ticket.CostDecidedByID = &approverID
+	ticket.CostDecidedAt = &now
+	ticket.CostDecisionReason = reason
+	action := domain.LogActionCostRejected
+	if decision == domain.CostApprovalApproved {
+		ticket.ApprovedCost = ticket.EstimatedCost
+		action = domain.LogActionCostApproved
+	}
+	if err := s.releaseFromApproval(ticket, now); err != nil {
+		return nil, err
+	}
+	ticket.UpdatedAt = now
+	ticket.EvaluateSLA(now)
+
+	logs := diffTicket(&before, ticket, approverID)
+	logs = append(logs, newTicketLog(ticket.ID, approverID, action, costString(ticket.EstimatedCost), reason))
+	err = s.tx.WithinTransaction(func(r repository.Repositories) error {
+		if err := r.Tickets.Update(ticket); err != nil {
+			return err
+		}
+		return r.TicketLogs.CreateBatch(logs)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if s.hub != nil {
+		s.hub.Broadcast("ticket:updated", ticket)
+	}
+
+	return ticket, nil
+}
@@ -0,0 +1,296 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/maintenance-system/api/internal/domain"
+	"github.com/maintenance-system/api/internal/repository"
+)
+
+var (
+	ErrLinkNotFound  = errors.New("ticket link not found")
+	ErrLinkExists    = errors.New("tickets are already linked this way")
+	ErrInvalidLink   = errors.New("invalid ticket link")
+	ErrInvalidMerge  = errors.New("invalid merge")
+	ErrTicketBlocked = errors.New("ticket is blocked by tickets that are still open")
+)
+
+// TicketLinkView is a link as seen from one of its tickets: Type reads
+// from that ticket to Ticket, the other end.
+type TicketLinkView struct {
+	ID          uuid.UUID             `json:"id"`
+	Type        domain.TicketLinkType `json:"type"`
+	Ticket      *domain.Ticket        `json:"ticket"`
+	CreatedByID uuid.UUID             `json:"createdById"`
+	CreatedAt   time.Time             `json:"createdAt"`
+}
+
+func viewLink(link *domain.TicketLink, from uuid.UUID) TicketLinkView {
+	view := TicketLinkView{
+		ID:          link.ID,
+		Type:        link.Type,
+		Ticket:      link.Target,
+		CreatedByID: link.CreatedByID,
+		CreatedAt:   link.CreatedAt,
+	}
+	if link.SourceID != from {
+		view.Type = link.Type.Inverse()
+		view.Ticket = link.Source
+	}
+	return view
+}
+
+func (s *ticketService) GetLinks(ticketID uuid.UUID) ([]TicketLinkView, error) {
+	if _, err := s.repo.FindByID(ticketID); err != nil {
+		return nil, ErrTicketNotFound
+	}
+	links, err := s.linkRepo.FindByTicket(ticketID)
+	if err != nil {
+		return nil, err
+	}
+	views := make([]TicketLinkView, len(links))
+	for i := range links {
+		views[i] = viewLink(&links[i], ticketID)
+	}
+	return views, nil
+}
+
+func (s *ticketService) AddLink(ticketID, otherID uuid.UUID, linkType domain.TicketLinkType, userID uuid.UUID) (*TicketLinkView, error) {
+	if !linkType.IsValid() {
+		return nil, invalidLink(fmt.Errorf("unknown link type %q", linkType))
+	}
+	if ticketID == otherID {
+		return nil, invalidLink(errors.New("a ticket cannot be linked to itself"))
+	}
+	ticket, err := s.repo.FindByID(ticketID)
+	if err != nil {
+		return nil, ErrTicketNotFound
+	}
+	other, err := s.repo.FindByID(otherID)
+	if err != nil {
+		return nil, ErrTicketNotFound
+	}
+
+	link := &domain.TicketLink{
+		SourceID:    ticket.ID,
+		TargetID:    other.ID,
+		Type:        linkType,
+		CreatedByID: userID,
+	}
+	if !linkType.Stored() {
+		link.SourceID, link.TargetID = other.ID, ticket.ID
+		link.Type = linkType.Inverse()
+	}
+
+	exists, err := s.linkRepo.Exists(link.SourceID, link.TargetID, link.Type)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, ErrLinkExists
+	}
+	// The same link the other way round is either the same thing (related)
+	// or a contradiction (each blocks or duplicates the other).
+	reversed, err := s.linkRepo.Exists(link.TargetID, link.SourceID, link.Type)
+	if err != nil {
+		return nil, err
+	}
+	if reversed && link.Type == domain.LinkRelatedTo {
+		return nil, ErrLinkExists
+	}
+	if reversed {
+		return nil, invalidLink(fmt.Errorf("the other ticket is already linked as %s this one", link.Type))
+	}
+	// Tickets blocking each other in a circle could never be resolved.
+	if link.Type == domain.LinkBlocks {
+		cycle, err := s.linkRepo.Reaches(link.TargetID, link.SourceID, domain.LinkBlocks)
+		if err != nil {
+			return nil, err
+		}
+		if cycle {
+			return nil, invalidLink(errors.New("the link would make tickets block each other in a circle"))
+		}
+	}
+
+	err = s.tx.WithinTransaction(func(r repository.Repositories) error {
+		if err := r.Links.Create(link); err != nil {
+			return err
+		}
+		return r.TicketLogs.CreateBatch([]domain.TicketLog{
+			newTicketLog(ticket.ID, userID, domain.LogActionLinkAdded, "", linkValue(linkType, other.ID)),
+			newTicketLog(other.ID, userID, domain.LogActionLinkAdded, "", linkValue(linkType.Inverse(), ticket.ID)),
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	link.Source, link.Target = ticket, other
+	if link.SourceID != ticket.ID {
+		link.Source, link.Target = other, ticket
+	}
+	view := viewLink(link, ticket.ID)
+	return &view, nil
+}
+
+func (s *ticketService) RemoveLink(ticketID, linkID, userID uuid.UUID) error {
+	link, err := s.linkRepo.FindByID(linkID)
+	if err != nil || (link.SourceID != ticketID && link.TargetID != ticketID) {
+		return ErrLinkNotFound
+	}
+
+	return s.tx.WithinTransaction(func(r repository.Repositories) error {
+		if err := r.Links.Delete(link.ID); err != nil {
+			return err
+		}
+		return r.TicketLogs.CreateBatch([]domain.TicketLog{
+			newTicketLog(link.SourceID, userID, domain.LogActionLinkRemoved, linkValue(link.Type, link.TargetID), ""),
+			newTicketLog(link.TargetID, userID, domain.LogActionLinkRemoved, linkValue(link.Type.Inverse(), link.SourceID), ""),
+		})
+	})
+}
+
+func (s *ticketService) Merge(primaryID uuid.UUID, duplicateIDs []uuid.UUID, userID uuid.UUID) (*domain.Ticket, error) {
+	if len(duplicateIDs) == 0 {
+		return nil, invalidMerge(errors.New("no duplicates given"))
+	}
+	primary, err := s.repo.FindByID(primaryID)
+	if err != nil {
+		return nil, ErrTicketNotFound
+	}
+	if primary.Status == domain.StatusClosed {
+		return nil, ErrTicketClosed
+	}
+
+	now := time.Now()
+	seen := make(map[uuid.UUID]bool)
+	var duplicates []*domain.Ticket
+	var logs []domain.TicketLog
+	for _, id := range duplicateIDs {
+		if id == primary.ID {
+			return nil, invalidMerge(errors.New("a ticket cannot be merged into itself"))
+		}
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		duplicate, err := s.repo.FindByID(id)
+		if err != nil {
+			return nil, ErrTicketNotFound
+		}
+		if duplicate.MergedIntoID != nil {
+			return nil, invalidMerge(fmt.Errorf("ticket %s was already merged", duplicate.ID))
+		}
+		if duplicate.Status != domain.StatusClosed {
+			if err := checkTransitionBy(duplicate, domain.StatusClosed, actorSystem); err != nil {
+				return nil, err
+			}
+			if err := s.checkHeld(duplicate, domain.StatusClosed); err != nil {
+				return nil, err
+			}
+		}
+		if err := s.checkSubTickets(duplicate, domain.StatusClosed); err != nil {
+			return nil, err
+		}
+
+		before := *duplicate
+		duplicate.MergedIntoID = &primary.ID
+		if duplicate.Status != domain.StatusClosed {
+			if err := s.changeStatus(duplicate, domain.StatusClosed, now); err != nil {
+				return nil, err
+			}
+		}
+		duplicate.UpdatedAt = now
+		duplicate.EvaluateSLA(now)
+
+		logs = append(logs, diffTicket(&before, duplicate, userID)...)
+		logs = append(logs,
+			newTicketLog(duplicate.ID, userID, domain.LogActionMergedInto, "", primary.ID.String()),
+			newTicketLog(primary.ID, userID, domain.LogActionMerged, "", duplicate.Title),
+		)
+		duplicates = append(duplicates, duplicate)
+	}
+
+	err = s.tx.WithinTransaction(func(r repository.Repositories) error {
+		for _, duplicate := range duplicates {
+			// History moves before the merge itself is logged, so the
+			// duplicate keeps a record of where it went.
+			if err := r.Comments.Reassign(duplicate.ID, primary.ID); err != nil {
+				return err
+			}
+			if err := r.Attachments.Reassign(duplicate.ID, primary.ID); err != nil {
+				return err
+			}
+			if err := r.TicketLogs.Reassign(duplicate.ID, primary.ID); err != nil {
+				return err
+			}
+
+			linked, err := r.Links.Exists(duplicate.ID, primary.ID, domain.LinkDuplicateOf)
+			if err != nil {
+				return err
+			}
+			if !linked {
+				if err := r.Links.Create(&domain.TicketLink{
+					SourceID:    duplicate.ID,
+					TargetID:    primary.ID,
+					Type:        domain.LinkDuplicateOf,
+					CreatedByID: userID,
+				}); err != nil {
+					return err
+				}
+			}
+			if err := r.Tickets.Update(duplicate); err != nil {
+				return err
+			}
+		}
+		return r.TicketLogs.CreateBatch(logs)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	merged, err := s.repo.FindByID(primary.ID)
+	if err != nil {
+		return nil, err
+	}
+	if s.hub != nil {
+		for _, duplicate := range duplicates {
+			s.hub.Broadcast("ticket:updated", duplicate)
+		}
+		s.hub.Broadcast("ticket:updated", merged)
+	}
+	return merged, nil
+}
+
+// checkBlockers stops a ticket from being resolved while a ticket that
+// blocks it is still open.
+func (s *ticketService) checkBlockers(ticket *domain.Ticket, to domain.TicketStatus) error {
+	if to != domain.StatusResolved {
+		return nil
+	}
+	count, err := s.linkRepo.CountOpenBlockers(ticket.ID)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return ErrTicketBlocked
+	}
+	return nil
+}
+
+// linkValue is how a link is written in the ticket log.
+func linkValue(linkType domain.TicketLinkType, other uuid.UUID) string {
+	return fmt.Sprintf("%s %s", linkType, other)
+}
+
+func invalidLink(err error) error {
+	return fmt.Errorf("%w: %v", ErrInvalidLink, err)
+}
+
+func invalidMerge(err error) error {
+	return fmt.Errorf("%w: %v", ErrInvalidMerge, err)
+}
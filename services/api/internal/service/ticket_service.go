@@ -2,6 +2,7 @@ package service
 
 import (
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
@@ -10,44 +11,150 @@ import (
 	"github.com/maintenance-system/api/internal/websocket"
 )
 
+var ErrAttachmentNotFound = errors.New("attachment not found")
+
 type TicketService interface {
 	Create(ticket *domain.Ticket) error
-	GetByID(id uuid.UUID) (*domain.Ticket, error)
+	// CreateWith creates the ticket and runs within in the same
+	// transaction, so related records commit or roll back with it.
+	CreateWith(ticket *domain.Ticket, within func(r repository.Repositories) error) error
+	GetByID(id uuid.UUID, opts ...repository.FindOption) (*domain.Ticket, error)
+	// CreateChild creates a sub-ticket under the parent. It takes the
+	// parent's location, asset and department unless given its own.
+	CreateChild(parentID uuid.UUID, child *domain.Ticket) error
 	GetAll(filter repository.TicketFilter) ([]domain.Ticket, int64, error)
 	Update(id uuid.UUID, updates map[string]interface{}, editorID uuid.UUID) (*domain.Ticket, error)
-	Delete(id uuid.UUID) error
+	Delete(id, userID uuid.UUID) error
 	AssignTechnician(ticketID, techID, assignerID uuid.UUID) error
+	// AssignVendor dispatches the ticket to an outside vendor in place of
+	// a technician. The caller checks the vendor exists. within runs in
+	// the same transaction.
+	AssignVendor(ticketID, vendorID, assignerID uuid.UUID, within func(r repository.Repositories) error) (*domain.Ticket, error)
+	// Hold moves an IN_PROGRESS ticket to PENDING while it waits on
+	// something outside the team, such as goods on order. Release moves a
+	// PENDING ticket back to IN_PROGRESS. Tickets in other statuses are
+	// left as they are. within runs in the same transaction.
+	Hold(ticketID, userID uuid.UUID, within func(r repository.Repositories) error) (*domain.Ticket, error)
+	Release(ticketID, userID uuid.UUID, within func(r repository.Repositories) error) (*domain.Ticket, error)
 	AddComment(ticketID, userID uuid.UUID, content string) (*domain.Comment, error)
+	// AddVendorComment posts a comment from the ticket's vendor, filed
+	// under the vendor portal account.
+	AddVendorComment(ticketID, vendorID uuid.UUID, content string) (*domain.Comment, error)
 	GetComments(ticketID uuid.UUID) ([]domain.Comment, error)
-	GetStats() (map[string]int64, error)
+	AddAttachment(attachment *domain.Attachment, userID uuid.UUID) error
+	DeleteAttachment(ticketID, attachmentID, userID uuid.UUID) error
+	GetStats() (*repository.TicketStats, error)
 	GetLogs(ticketID uuid.UUID) ([]domain.TicketLog, error)
+	// ApproveCost signs off a ticket's cost estimate and releases it from
+	// AWAITING_APPROVAL.
+	ApproveCost(ticketID, approverID uuid.UUID, note string) (*domain.Ticket, error)
+	// RejectCost turns the estimate down; the ticket cannot progress until
+	// the estimate is revised.
+	RejectCost(ticketID, approverID uuid.UUID, reason string) (*domain.Ticket, error)
 	LogActivity(ticketID, userID uuid.UUID, action, oldValue, newValue string) error
+
+	// GetLinks returns the ticket's links to other tickets, each read from
+	// this ticket's side.
+	GetLinks(ticketID uuid.UUID) ([]TicketLinkView, error)
+	// AddLink links the ticket to another. BLOCKED_BY and DUPLICATED_BY
+	// are stored as the other ticket's BLOCKS and DUPLICATE_OF.
+	AddLink(ticketID, otherID uuid.UUID, linkType domain.TicketLinkType, userID uuid.UUID) (*TicketLinkView, error)
+	RemoveLink(ticketID, linkID, userID uuid.UUID) error
+	// Merge folds duplicates into the primary ticket: their comments,
+	// attachments and logs move to it, and each duplicate is linked to it
+	// and closed.
+	Merge(primaryID uuid.UUID, duplicateIDs []uuid.UUID, userID uuid.UUID) (*domain.Ticket, error)
 }
 
 type ticketService struct {
 	repo        repository.TicketRepository
 	commentRepo repository.CommentRepository
 	userRepo    repository.UserRepository
+	deptRepo    repository.DepartmentRepository
 	logRepo     repository.TicketLogRepository
+	linkRepo    repository.TicketLinkRepository
+	sla         SLAService
+	costs       CostApprovalService
+	approvals   ApprovalService
+	checklists  ChecklistService
+	contracts   ContractService
+	categories  CategoryService
+	tx          repository.Transactor
 	hub         *websocket.Hub
 }
 
-func NewTicketService(repo repository.TicketRepository, commentRepo repository.CommentRepository, userRepo repository.UserRepository, logRepo repository.TicketLogRepository, hub *websocket.Hub) TicketService {
+func NewTicketService(repo repository.TicketRepository, commentRepo repository.CommentRepository, userRepo repository.UserRepository, deptRepo repository.DepartmentRepository, logRepo repository.TicketLogRepository, linkRepo repository.TicketLinkRepository, sla SLAService, costs CostApprovalService, approvals ApprovalService, checklists ChecklistService, contracts ContractService, categories CategoryService, tx repository.Transactor, hub *websocket.Hub) TicketService {
 	return &ticketService{
 		repo:        repo,
 		commentRepo: commentRepo,
 		userRepo:    userRepo,
+		deptRepo:    deptRepo,
 		logRepo:     logRepo,
+		linkRepo:    linkRepo,
+		sla:         sla,
+		costs:       costs,
+		approvals:   approvals,
+		checklists:  checklists,
+		contracts:   contracts,
+		categories:  categories,
+		tx:          tx,
 		hub:         hub,
 	}
 }
 
 func (s *ticketService) Create(ticket *domain.Ticket) error {
+	return s.CreateWith(ticket, nil)
+}
+
+func (s *ticketService) CreateWith(ticket *domain.Ticket, within func(r repository.Repositories) error) error {
 	ticket.Status = domain.StatusOpen
 	ticket.CreatedAt = time.Now()
 	ticket.UpdatedAt = time.Now()
 
-	if err := s.repo.Create(ticket); err != nil {
+	// The category's defaults come first so the SLA policy matches the
+	// priority the ticket ends up with.
+	if err := s.categories.ApplyDefaults(ticket); err != nil {
+		return err
+	}
+
+	// A due date given up front wins over the SLA resolution target.
+	ticket.DueDateManual = ticket.DueDate != nil
+	if err := s.sla.ApplyPolicy(ticket); err != nil {
+		return err
+	}
+	s.chargeDepartment(ticket)
+	if err := s.holdForCostApproval(ticket, ticket.CreatedAt); err != nil {
+		return err
+	}
+	ticket.EvaluateSLA(ticket.CreatedAt)
+	if err := s.flagContract(ticket, ticket.CreatedAt); err != nil {
+		return err
+	}
+
+	checklists, err := s.checklists.ForTicket(ticket)
+	if err != nil {
+		return err
+	}
+	ticket.Checklists = checklists
+
+	err = s.tx.WithinTransaction(func(r repository.Repositories) error {
+		if err := r.Tickets.Create(ticket); err != nil {
+			return err
+		}
+		if err := r.TicketLogs.Create(&domain.TicketLog{
+			TicketID: ticket.ID,
+			UserID:   ticket.CreatedByID,
+			Action:   domain.LogActionCreated,
+			NewValue: ticket.Title,
+		}); err != nil {
+			return err
+		}
+		if within != nil {
+			return within(r)
+		}
+		return nil
+	})
+	if err != nil {
 		return err
 	}
 
@@ -59,8 +166,8 @@ func (s *ticketService) Create(ticket *domain.Ticket) error {
 	return nil
 }
 
-func (s *ticketService) GetByID(id uuid.UUID) (*domain.Ticket, error) {
-	return s.repo.FindByID(id)
+func (s *ticketService) GetByID(id uuid.UUID, opts ...repository.FindOption) (*domain.Ticket, error) {
+	return s.repo.FindByID(id, opts...)
 }
 
 func (s *ticketService) GetAll(filter repository.TicketFilter) ([]domain.Ticket, int64, error) {
@@ -70,36 +177,131 @@ func (s *ticketService) GetAll(filter repository.TicketFilter) ([]domain.Ticket,
 func (s *ticketService) Update(id uuid.UUID, updates map[string]interface{}, editorID uuid.UUID) (*domain.Ticket, error) {
 	ticket, err := s.repo.FindByID(id)
 	if err != nil {
-		return nil, err
+		return nil, ErrTicketNotFound
+	}
+
+	editor, err := s.userRepo.FindByID(editorID)
+	if err != nil {
+		return nil, ErrUserNotFound
 	}
 
+	before := *ticket
+	now := time.Now()
+
 	// Apply updates
-	// Note: In a real app, strict validation would go here
 	if title, ok := updates["title"].(string); ok {
 		ticket.Title = title
 	}
 	if desc, ok := updates["description"].(string); ok {
 		ticket.Description = desc
 	}
-	if status, ok := updates["status"].(string); ok {
-		ticket.Status = domain.TicketStatus(status)
-		if ticket.Status == domain.StatusResolved {
-			now := time.Now()
-			ticket.ResolvedAt = &now
+	if status, ok := updates["status"].(string); ok && domain.TicketStatus(status) != ticket.Status {
+		to := domain.TicketStatus(status)
+		if err := checkTransition(ticket, to, editor); err != nil {
+			return nil, err
+		}
+		if err := s.checkHeld(ticket, to); err != nil {
+			return nil, err
+		}
+		if to == domain.StatusResolved {
+			if err := s.checklists.CheckComplete(ticket.ID); err != nil {
+				return nil, err
+			}
+		}
+		if err := s.checkSubTickets(ticket, to); err != nil {
+			return nil, err
+		}
+		if err := s.checkBlockers(ticket, to); err != nil {
+			return nil, err
+		}
+		if err := s.changeStatus(ticket, to, now); err != nil {
+			return nil, err
 		}
 	}
 	if priority, ok := updates["priority"].(string); ok {
 		ticket.Priority = domain.TicketPriority(priority)
 	}
+	if category, ok := updates["category"].(string); ok {
+		ticket.Category = domain.TicketCategory(category)
+	}
+	// A due date set by hand sticks through later priority and category
+	// changes until the editor asks for the SLA target again.
+	recompute, _ := updates["recomputeDueDate"].(bool)
+	if dueDate, ok := updates["dueDate"].(time.Time); ok {
+		ticket.DueDate = &dueDate
+		ticket.DueDateManual = true
+	} else if recompute {
+		ticket.DueDateManual = false
+	}
+	if recompute || ticket.Priority != before.Priority || ticket.Category != before.Category {
+		if err := s.sla.ApplyPolicy(ticket); err != nil {
+			return nil, err
+		}
+	}
+	if location, ok := updates["location"].(string); ok {
+		ticket.Location = location
+	}
+	if locationID, ok := updates["locationId"].(uuid.UUID); ok {
+		ticket.LocationID = &locationID
+		ticket.LocationRef = nil
+	}
+	if assetID, ok := updates["assetId"].(uuid.UUID); ok {
+		ticket.AssetID = &assetID
+		ticket.Asset = nil
+		if err := s.flagContract(ticket, now); err != nil {
+			return nil, err
+		}
+	}
+	if parentID, ok := updates["parentId"].(uuid.UUID); ok {
+		if err := s.checkParent(ticket, parentID); err != nil {
+			return nil, err
+		}
+		ticket.ParentID = &parentID
+	}
+	if departmentID, ok := updates["departmentId"].(uuid.UUID); ok {
+		ticket.DepartmentID = &departmentID
+		ticket.Department = nil
+	}
+	if fields, ok := updates["customFields"].(domain.JSONMap); ok {
+		ticket.CustomFields = fields
+	}
+	if cost, ok := updates["vendorCost"].(float64); ok {
+		ticket.VendorCost = cost
+	}
+	if cost, ok := updates["estimatedLaborCost"].(float64); ok {
+		ticket.EstimatedLaborCost = cost
+	}
+	if cost, ok := updates["estimatedPartsCost"].(float64); ok {
+		ticket.EstimatedPartsCost = cost
+	}
+	if cost, ok := updates["estimatedVendorCost"].(float64); ok {
+		ticket.EstimatedVendorCost = cost
+	}
+	if err := checkCostFields(&before, ticket, editor); err != nil {
+		return nil, err
+	}
+	// The department decides which threshold applies, so moving the ticket
+	// to another one is checked again like a new estimate.
+	if estimate(ticket) != estimate(&before) || uuidString(ticket.DepartmentID) != uuidString(before.DepartmentID) {
+		if err := s.holdForCostApproval(ticket, now); err != nil {
+			return nil, err
+		}
+	}
 
-	ticket.UpdatedAt = time.Now()
+	ticket.UpdatedAt = now
+	ticket.EvaluateSLA(now)
 
-	if err := s.repo.Update(ticket); err != nil {
+	logs := diffTicket(&before, ticket, editorID)
+	err = s.tx.WithinTransaction(func(r repository.Repositories) error {
+		if err := r.Tickets.Update(ticket); err != nil {
+			return err
+		}
+		return r.TicketLogs.CreateBatch(logs)
+	})
+	if err != nil {
 		return nil, err
 	}
 
-	// TODO: Create Log entry (skipped for brevity)
-
 	// Realtime notification
 	if s.hub != nil {
 		s.hub.Broadcast("ticket:updated", ticket)
@@ -108,8 +310,24 @@ func (s *ticketService) Update(id uuid.UUID, updates map[string]interface{}, edi
 	return ticket, nil
 }
 
-func (s *ticketService) Delete(id uuid.UUID) error {
-	if err := s.repo.Delete(id); err != nil {
+func (s *ticketService) Delete(id, userID uuid.UUID) error {
+	ticket, err := s.repo.FindByID(id)
+	if err != nil {
+		return ErrTicketNotFound
+	}
+
+	err = s.tx.WithinTransaction(func(r repository.Repositories) error {
+		if err := r.TicketLogs.Create(&domain.TicketLog{
+			TicketID: id,
+			UserID:   userID,
+			Action:   domain.LogActionDeleted,
+			OldValue: ticket.Title,
+		}); err != nil {
+			return err
+		}
+		return r.Tickets.Delete(id)
+	})
+	if err != nil {
 		return err
 	}
 
@@ -123,7 +341,7 @@ func (s *ticketService) Delete(id uuid.UUID) error {
 func (s *ticketService) AssignTechnician(ticketID, techID, assignerID uuid.UUID) error {
 	ticket, err := s.repo.FindByID(ticketID)
 	if err != nil {
-		return err
+		return ErrTicketNotFound
 	}
 
 	tech, err := s.userRepo.FindByID(techID)
@@ -135,11 +353,34 @@ func (s *ticketService) AssignTechnician(ticketID, techID, assignerID uuid.UUID)
 		return errors.New("assigned user is not a technician")
 	}
 
-	ticket.AssignedToID = &techID
-	ticket.Status = domain.StatusInProgress // Auto update status
-	ticket.UpdatedAt = time.Now()
+	if ticket.Status == domain.StatusResolved || ticket.Status == domain.StatusClosed {
+		return &TransitionError{From: ticket.Status, To: domain.StatusInProgress, Err: ErrInvalidTransition}
+	}
 
-	if err := s.repo.Update(ticket); err != nil {
+	before := *ticket
+	now := time.Now()
+	ticket.AssignedToID = &techID
+	ticket.AssignedTo = tech
+	ticket.VendorID = nil
+	ticket.Vendor = nil
+	// Auto update status, unless the ticket is held back
+	if ticket.Status == domain.StatusOpen && s.checkHeld(ticket, domain.StatusInProgress) == nil {
+		if err := s.changeStatus(ticket, domain.StatusInProgress, now); err != nil {
+			return err
+		}
+	}
+	markResponded(ticket, now)
+	ticket.UpdatedAt = now
+	ticket.EvaluateSLA(now)
+
+	logs := diffTicket(&before, ticket, assignerID)
+	err = s.tx.WithinTransaction(func(r repository.Repositories) error {
+		if err := r.Tickets.Update(ticket); err != nil {
+			return err
+		}
+		return r.TicketLogs.CreateBatch(logs)
+	})
+	if err != nil {
 		return err
 	}
 
@@ -150,20 +391,176 @@ func (s *ticketService) AssignTechnician(ticketID, techID, assignerID uuid.UUID)
 	return nil
 }
 
+func (s *ticketService) AssignVendor(ticketID, vendorID, assignerID uuid.UUID, within func(r repository.Repositories) error) (*domain.Ticket, error) {
+	ticket, err := s.repo.FindByID(ticketID)
+	if err != nil {
+		return nil, ErrTicketNotFound
+	}
+	if ticket.Status == domain.StatusResolved || ticket.Status == domain.StatusClosed {
+		return nil, &TransitionError{From: ticket.Status, To: domain.StatusInProgress, Err: ErrInvalidTransition}
+	}
+
+	before := *ticket
+	now := time.Now()
+	ticket.VendorID = &vendorID
+	ticket.Vendor = nil
+	ticket.VendorAssignedAt = &now
+	ticket.VendorRespondedAt = nil
+	ticket.AssignedToID = nil
+	ticket.AssignedTo = nil
+	// Auto update status, unless the ticket is held back
+	if ticket.Status == domain.StatusOpen && s.checkHeld(ticket, domain.StatusInProgress) == nil {
+		if err := s.changeStatus(ticket, domain.StatusInProgress, now); err != nil {
+			return nil, err
+		}
+	}
+	markResponded(ticket, now)
+	ticket.UpdatedAt = now
+	ticket.EvaluateSLA(now)
+
+	logs := diffTicket(&before, ticket, assignerID)
+	err = s.tx.WithinTransaction(func(r repository.Repositories) error {
+		if err := r.Tickets.Update(ticket); err != nil {
+			return err
+		}
+		if err := r.TicketLogs.CreateBatch(logs); err != nil {
+			return err
+		}
+		if within == nil {
+			return nil
+		}
+		return within(r)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if s.hub != nil {
+		s.hub.Broadcast("ticket:updated", ticket)
+	}
+
+	return ticket, nil
+}
+
+func (s *ticketService) Hold(ticketID, userID uuid.UUID, within func(r repository.Repositories) error) (*domain.Ticket, error) {
+	return s.setWaiting(ticketID, userID, domain.StatusPending, within)
+}
+
+func (s *ticketService) Release(ticketID, userID uuid.UUID, within func(r repository.Repositories) error) (*domain.Ticket, error) {
+	return s.setWaiting(ticketID, userID, domain.StatusInProgress, within)
+}
+
+func (s *ticketService) setWaiting(ticketID, userID uuid.UUID, to domain.TicketStatus, within func(r repository.Repositories) error) (*domain.Ticket, error) {
+	ticket, err := s.repo.FindByID(ticketID)
+	if err != nil {
+		return nil, ErrTicketNotFound
+	}
+
+	before := *ticket
+	now := time.Now()
+	// A move the lifecycle does not allow the system, a rejected estimate
+	// or a pending approval keeps the ticket where it is.
+	if checkTransitionBy(ticket, to, actorSystem) == nil && s.checkHeld(ticket, to) == nil {
+		if err := s.changeStatus(ticket, to, now); err != nil {
+			return nil, err
+		}
+		ticket.UpdatedAt = now
+		ticket.EvaluateSLA(now)
+	}
+
+	logs := diffTicket(&before, ticket, userID)
+	err = s.tx.WithinTransaction(func(r repository.Repositories) error {
+		if len(logs) > 0 {
+			if err := r.Tickets.Update(ticket); err != nil {
+				return err
+			}
+			if err := r.TicketLogs.CreateBatch(logs); err != nil {
+				return err
+			}
+		}
+		if within == nil {
+			return nil
+		}
+		return within(r)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(logs) > 0 && s.hub != nil {
+		s.hub.Broadcast("ticket:updated", ticket)
+	}
+
+	return ticket, nil
+}
+
 func (s *ticketService) AddComment(ticketID, userID uuid.UUID, content string) (*domain.Comment, error) {
-	comment := &domain.Comment{
+	ticket, err := s.repo.FindByID(ticketID)
+	if err != nil {
+		return nil, ErrTicketNotFound
+	}
+
+	return s.addComment(ticket, &domain.Comment{
 		TicketID:  ticketID,
 		UserID:    userID,
 		Content:   content,
 		CreatedAt: time.Now(),
+	})
+}
+
+func (s *ticketService) AddVendorComment(ticketID, vendorID uuid.UUID, content string) (*domain.Comment, error) {
+	ticket, err := s.repo.FindByID(ticketID)
+	if err != nil || ticket.VendorID == nil || *ticket.VendorID != vendorID {
+		return nil, ErrTicketNotFound
 	}
+	if ticket.Status == domain.StatusClosed {
+		return nil, ErrTicketClosed
+	}
+	portal, err := s.userRepo.FindByEmail(domain.VendorPortalEmail)
+	if err != nil {
+		return nil, fmt.Errorf("vendor portal account missing: %w", err)
+	}
+
+	return s.addComment(ticket, &domain.Comment{
+		TicketID:  ticketID,
+		UserID:    portal.ID,
+		VendorID:  &vendorID,
+		Content:   content,
+		CreatedAt: time.Now(),
+	})
+}
 
-	if err := s.commentRepo.Create(comment); err != nil {
+func (s *ticketService) addComment(ticket *domain.Ticket, comment *domain.Comment) (*domain.Comment, error) {
+	// A reply from anyone but the requester counts as the first response.
+	responding := comment.UserID != ticket.CreatedByID && ticket.FirstRespondedAt == nil
+	vendorResponding := comment.VendorID != nil && ticket.VendorRespondedAt == nil
+
+	err := s.tx.WithinTransaction(func(r repository.Repositories) error {
+		if err := r.Comments.Create(comment); err != nil {
+			return err
+		}
+		if responding || vendorResponding {
+			markResponded(ticket, comment.CreatedAt)
+			if vendorResponding {
+				ticket.VendorRespondedAt = &comment.CreatedAt
+			}
+			if err := r.Tickets.Update(ticket); err != nil {
+				return err
+			}
+		}
+		return r.TicketLogs.Create(&domain.TicketLog{
+			TicketID: ticket.ID,
+			UserID:   comment.UserID,
+			Action:   domain.LogActionCommentAdded,
+			NewValue: comment.Content,
+		})
+	})
+	if err != nil {
 		return nil, err
 	}
 
 	// Load user data to include in response
-	user, err := s.userRepo.FindByID(userID)
+	user, err := s.userRepo.FindByID(comment.UserID)
 	if err == nil {
 		comment.User = user
 	}
@@ -171,11 +568,117 @@ func (s *ticketService) AddComment(ticketID, userID uuid.UUID, content string) (
 	return comment, nil
 }
 
+// checkHeld reports whether something other than the workflow itself
+// keeps the ticket from moving to status to: a rejected cost estimate or
+// a pending approval request. Every status change outside the approval
+// flows goes through it, automatic ones included.
+func (s *ticketService) checkHeld(ticket *domain.Ticket, to domain.TicketStatus) error {
+	if err := checkCostRejected(ticket, to); err != nil {
+		return err
+	}
+	return s.approvals.CheckTransition(ticket.ID, to)
+}
+
+// changeStatus applies a validated transition and keeps the SLA clock in
+// step: it is paused while the ticket is PENDING, and leaving OPEN counts
+// as the first response.
+func (s *ticketService) changeStatus(ticket *domain.Ticket, to domain.TicketStatus, now time.Time) error {
+	from := ticket.Status
+	applyTransition(ticket, to, now)
+
+	if from == domain.StatusOpen {
+		markResponded(ticket, now)
+	}
+	if to.HoldsSLA() && !from.HoldsSLA() {
+		s.sla.PauseClock(ticket, now)
+	} else if from.HoldsSLA() && !to.HoldsSLA() {
+		return s.sla.ResumeClock(ticket, now)
+	}
+	return nil
+}
+
+// flagContract records the warranty or service contract covering the
+// ticket's asset at the given time, if any.
+func (s *ticketService) flagContract(ticket *domain.Ticket, at time.Time) error {
+	ticket.ContractID = nil
+	ticket.Contract = nil
+	if ticket.AssetID == nil {
+		return nil
+	}
+	contract, err := s.contracts.Covering(*ticket.AssetID, at)
+	if err != nil {
+		return err
+	}
+	if contract != nil {
+		ticket.ContractID = &contract.ID
+	}
+	return nil
+}
+
+// chargeDepartment charges the ticket's costs to the requester's
+// department unless a department was given.
+func (s *ticketService) chargeDepartment(ticket *domain.Ticket) {
+	if ticket.DepartmentID != nil {
+		return
+	}
+	requester := ticket.CreatedBy
+	if requester == nil {
+		found, err := s.userRepo.FindByID(ticket.CreatedByID)
+		if err != nil {
+			return
+		}
+		requester = found
+	}
+	ticket.DepartmentID = requester.DepartmentID
+}
+
+func markResponded(ticket *domain.Ticket, now time.Time) {
+	if ticket.FirstRespondedAt == nil {
+		ticket.FirstRespondedAt = &now
+	}
+}
+
 func (s *ticketService) GetComments(ticketID uuid.UUID) ([]domain.Comment, error) {
 	return s.commentRepo.FindByTicketID(ticketID)
 }
 
-func (s *ticketService) GetStats() (map[string]int64, error) {
+func (s *ticketService) AddAttachment(attachment *domain.Attachment, userID uuid.UUID) error {
+	if _, err := s.repo.FindByID(attachment.TicketID); err != nil {
+		return ErrTicketNotFound
+	}
+
+	return s.tx.WithinTransaction(func(r repository.Repositories) error {
+		if err := r.Attachments.Create(attachment); err != nil {
+			return err
+		}
+		return r.TicketLogs.Create(&domain.TicketLog{
+			TicketID: attachment.TicketID,
+			UserID:   userID,
+			Action:   domain.LogActionAttachmentAdded,
+			NewValue: attachment.Filename,
+		})
+	})
+}
+
+func (s *ticketService) DeleteAttachment(ticketID, attachmentID, userID uuid.UUID) error {
+	return s.tx.WithinTransaction(func(r repository.Repositories) error {
+		attachment, err := r.Attachments.FindByID(attachmentID)
+		if err != nil || attachment.TicketID != ticketID {
+			return ErrAttachmentNotFound
+		}
+		if err := r.Attachments.Delete(attachmentID); err != nil {
+			return err
+		}
+		return r.TicketLogs.Create(&domain.TicketLog{
+			TicketID: ticketID,
+			UserID:   userID,
+			Action:   domain.LogActionAttachmentRemoved,
+			OldValue: attachment.Filename,
+		})
+	})
+}
+
+func (s *ticketService) GetStats() (*repository.TicketStats, error) {
 	return s.repo.GetStats()
 }
 
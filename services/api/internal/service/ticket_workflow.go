@@ -0,0 +1,147 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/maintenance-system/api/internal/domain"
+)
+
+var (
+	ErrInvalidStatus       = errors.New("invalid ticket status")
+	ErrInvalidTransition   = errors.New("status transition not allowed")
+	ErrTransitionForbidden = errors.New("not permitted to perform this status transition")
+	ErrTicketNotFound      = errors.New("ticket not found")
+)
+
+// TransitionError describes a rejected status change. It wraps one of
+// ErrInvalidStatus, ErrInvalidTransition or ErrTransitionForbidden so
+// callers can use errors.Is to pick a response code.
+type TransitionError struct {
+	From domain.TicketStatus
+	To   domain.TicketStatus
+	Err  error
+}
+
+func (e *TransitionError) Error() string {
+	return fmt.Sprintf("%s: %s -> %s", e.Err.Error(), e.From, e.To)
+}
+
+func (e *TransitionError) Unwrap() error {
+	return e.Err
+}
+
+// transitionActor is who may trigger a transition.
+type transitionActor int
+
+const (
+	actorRequester transitionActor = 1 << iota
+	actorTechnician
+	actorAdmin
+	// actorSystem is the service itself acting for another workflow, such
+	// as a purchase request holding its ticket; no user has it.
+	actorSystem
+)
+
+type statusTransition struct {
+	from   domain.TicketStatus
+	to     domain.TicketStatus
+	actors transitionActor
+}
+
+// ticketTransitions is the ticket lifecycle:
+//
+//	OPEN -> IN_PROGRESS -> PENDING <-> IN_PROGRESS
+//	IN_PROGRESS/PENDING -> RESOLVED -> CLOSED
+//
+// A requester may cancel an OPEN ticket or reopen a RESOLVED one. Only an
+// admin may reopen a CLOSED ticket.
+//
+// AWAITING_APPROVAL is entered and left by the cost approval flow, not by
+// a status change; the only way out by hand is cancelling the ticket.
+//
+// A pending approval request on the ticket may also block some
+// transitions; see ApprovalService.CheckTransition.
+//
+// The system puts an IN_PROGRESS ticket on hold while goods are on order
+// and takes it off hold once they arrive. Merging a duplicate into another
+// ticket closes it from any status; that is the only way to close work in
+// progress without resolving it.
+var ticketTransitions = []statusTransition{
+	{domain.StatusOpen, domain.StatusInProgress, actorTechnician | actorAdmin},
+	{domain.StatusOpen, domain.StatusClosed, actorRequester | actorAdmin | actorSystem},
+	{domain.StatusInProgress, domain.StatusPending, actorTechnician | actorAdmin | actorSystem},
+	{domain.StatusInProgress, domain.StatusResolved, actorTechnician | actorAdmin},
+	{domain.StatusInProgress, domain.StatusClosed, actorSystem},
+	{domain.StatusPending, domain.StatusInProgress, actorTechnician | actorAdmin | actorSystem},
+	{domain.StatusPending, domain.StatusResolved, actorTechnician | actorAdmin},
+	{domain.StatusPending, domain.StatusClosed, actorSystem},
+	{domain.StatusResolved, domain.StatusClosed, actorRequester | actorAdmin | actorSystem},
+	{domain.StatusResolved, domain.StatusInProgress, actorRequester | actorTechnician | actorAdmin},
+	{domain.StatusClosed, domain.StatusOpen, actorAdmin},
+	{domain.StatusAwaitingApproval, domain.StatusClosed, actorRequester | actorAdmin | actorSystem},
+}
+
+func actorsFor(ticket *domain.Ticket, user *domain.User) transitionActor {
+	var a transitionActor
+	if ticket.CreatedByID == user.ID {
+		a |= actorRequester
+	}
+	switch user.Role {
+	case domain.RoleTechnician:
+		a |= actorTechnician
+	case domain.RoleAdmin:
+		a |= actorAdmin
+	}
+	return a
+}
+
+// canWorkOn reports whether user may act on the ticket at all: its
+// requester, technicians and admins.
+func canWorkOn(ticket *domain.Ticket, user *domain.User) bool {
+	return actorsFor(ticket, user) != 0
+}
+
+// checkTransition validates moving ticket to status `to` on behalf of user.
+func checkTransition(ticket *domain.Ticket, to domain.TicketStatus, user *domain.User) error {
+	return checkTransitionBy(ticket, to, actorsFor(ticket, user))
+}
+
+// checkTransitionBy validates moving ticket to status `to` by actors.
+func checkTransitionBy(ticket *domain.Ticket, to domain.TicketStatus, actors transitionActor) error {
+	from := ticket.Status
+	if !to.IsValid() {
+		return &TransitionError{From: from, To: to, Err: ErrInvalidStatus}
+	}
+	for _, t := range ticketTransitions {
+		if t.from != from || t.to != to {
+			continue
+		}
+		if t.actors&actors == 0 {
+			return &TransitionError{From: from, To: to, Err: ErrTransitionForbidden}
+		}
+		return nil
+	}
+	return &TransitionError{From: from, To: to, Err: ErrInvalidTransition}
+}
+
+// applyTransition sets the new status and stamps or clears the lifecycle
+// timestamps that go with it.
+func applyTransition(ticket *domain.Ticket, to domain.TicketStatus, now time.Time) {
+	from := ticket.Status
+	ticket.Status = to
+
+	switch to {
+	case domain.StatusResolved:
+		ticket.ResolvedAt = &now
+		ticket.ClosedAt = nil
+	case domain.StatusClosed:
+		ticket.ClosedAt = &now
+	case domain.StatusOpen, domain.StatusInProgress:
+		if from == domain.StatusResolved || from == domain.StatusClosed {
+			ticket.ResolvedAt = nil
+			ticket.ClosedAt = nil
+		}
+	}
+}
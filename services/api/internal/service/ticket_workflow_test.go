@@ -0,0 +1,81 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/maintenance-system/api/internal/domain"
+)
+
+func TestCheckTransition(t *testing.T) {
+	requester := &domain.User{ID: uuid.New(), Role: domain.RoleUser}
+	technician := &domain.User{ID: uuid.New(), Role: domain.RoleTechnician}
+	admin := &domain.User{ID: uuid.New(), Role: domain.RoleAdmin}
+	other := &domain.User{ID: uuid.New(), Role: domain.RoleUser}
+
+	tests := []struct {
+		name     string
+		from, to domain.TicketStatus
+		user     *domain.User
+		want     error
+	}{
+		{"technician starts work", domain.StatusOpen, domain.StatusInProgress, technician, nil},
+		{"requester cannot start work", domain.StatusOpen, domain.StatusInProgress, requester, ErrTransitionForbidden},
+		{"requester cancels open ticket", domain.StatusOpen, domain.StatusClosed, requester, nil},
+		{"technician cannot cancel", domain.StatusOpen, domain.StatusClosed, technician, ErrTransitionForbidden},
+		{"someone else cannot cancel", domain.StatusOpen, domain.StatusClosed, other, ErrTransitionForbidden},
+		{"technician resolves", domain.StatusInProgress, domain.StatusResolved, technician, nil},
+		{"requester reopens resolved", domain.StatusResolved, domain.StatusInProgress, requester, nil},
+		{"requester closes resolved", domain.StatusResolved, domain.StatusClosed, requester, nil},
+		{"only admin reopens closed", domain.StatusClosed, domain.StatusOpen, requester, ErrTransitionForbidden},
+		{"admin reopens closed", domain.StatusClosed, domain.StatusOpen, admin, nil},
+		{"cannot skip to resolved", domain.StatusOpen, domain.StatusResolved, admin, ErrInvalidTransition},
+		{"approval hold left by hand only to close", domain.StatusAwaitingApproval, domain.StatusInProgress, admin, ErrInvalidTransition},
+		{"requester cancels held ticket", domain.StatusAwaitingApproval, domain.StatusClosed, requester, nil},
+		{"unknown status", domain.StatusOpen, domain.TicketStatus("DONE"), admin, ErrInvalidStatus},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ticket := &domain.Ticket{Status: tt.from, CreatedByID: requester.ID}
+			err := checkTransition(ticket, tt.to, tt.user)
+			if tt.want == nil {
+				if err != nil {
+					t.Fatalf("checkTransition() = %v, want nil", err)
+				}
+				return
+			}
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("checkTransition() = %v, want %v", err, tt.want)
+			}
+			var te *TransitionError
+			if !errors.As(err, &te) || te.From != tt.from || te.To != tt.to {
+				t.Errorf("checkTransition() = %#v, want a TransitionError from %s to %s", err, tt.from, tt.to)
+			}
+		})
+	}
+}
+
+func TestCheckTransitionBySystem(t *testing.T) {
+	tests := []struct {
+		name     string
+		from, to domain.TicketStatus
+		want     error
+	}{
+		{"holds work in progress", domain.StatusInProgress, domain.StatusPending, nil},
+		{"releases a hold", domain.StatusPending, domain.StatusInProgress, nil},
+		{"does not hold open tickets", domain.StatusOpen, domain.StatusPending, ErrInvalidTransition},
+		{"does not start work", domain.StatusOpen, domain.StatusInProgress, ErrTransitionForbidden},
+		{"does not resolve", domain.StatusInProgress, domain.StatusResolved, ErrTransitionForbidden},
+		{"closes a merged duplicate", domain.StatusInProgress, domain.StatusClosed, nil},
+		{"does not reopen", domain.StatusClosed, domain.StatusOpen, ErrTransitionForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkTransitionBy(&domain.Ticket{Status: tt.from}, tt.to, actorSystem)
+			if tt.want == nil && err != nil || tt.want != nil && !errors.Is(err, tt.want) {
+				t.Errorf("checkTransitionBy() = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
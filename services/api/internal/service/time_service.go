@@ -0,0 +1,405 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/maintenance-system/api/internal/domain"
+	"github.com/maintenance-system/api/internal/repository"
+)
+
+var (
+	ErrTimeEntryNotFound  = errors.New("time entry not found")
+	ErrInvalidTimeEntry   = errors.New("invalid time entry")
+	ErrTimerRunning       = errors.New("a timer is already running")
+	ErrNoRunningTimer     = errors.New("no timer is running")
+	ErrTimeEntryForbidden = errors.New("not permitted to log time for another user")
+)
+
+// maxEntryDuration caps a single time entry; longer work is logged as
+// several entries.
+const maxEntryDuration = 24 * time.Hour
+
+// TicketTime is the time logged on a ticket.
+type TicketTime struct {
+	Entries         []domain.TimeEntry `json:"entries"`
+	TotalSeconds    int64              `json:"totalSeconds"`
+	BillableSeconds int64              `json:"billableSeconds"`
+	LaborCost       float64            `json:"laborCost"`
+}
+
+type TimeService interface {
+	GetTicketTime(ticketID uuid.UUID) (*TicketTime, error)
+	// GetRunning returns the user's running timer, or nil if there is none.
+	GetRunning(userID uuid.UUID) (*domain.TimeEntry, error)
+	Start(ticketID, userID uuid.UUID, note string, billable bool, now time.Time) (*domain.TimeEntry, error)
+	// Stop ends the user's running timer and books its cost on the ticket.
+	// A timer running for more than 24 hours is stopped at 24 hours.
+	Stop(userID uuid.UUID, now time.Time) (*domain.TimeEntry, error)
+	// AddEntry records time worked afterwards. Only admins may record time
+	// for someone other than themselves.
+	AddEntry(entry *domain.TimeEntry, actorID uuid.UUID) error
+	// Correct changes an entry on an admin's behalf; the change and reason
+	// are written to the ticket log.
+	Correct(id uuid.UUID, updates map[string]interface{}, adminID uuid.UUID, reason string) (*domain.TimeEntry, error)
+	Delete(id, adminID uuid.UUID, reason string) error
+	Summary(filter repository.TimeSummaryFilter) ([]repository.TimeSummary, error)
+
+	GetRates() ([]domain.LaborRate, error)
+	SetRate(role domain.UserRole, hourlyRate float64) (*domain.LaborRate, error)
+}
+
+type timeService struct {
+	repo       repository.TimeEntryRepository
+	ticketRepo repository.TicketRepository
+	userRepo   repository.UserRepository
+	tx         repository.Transactor
+}
+
+func NewTimeService(repo repository.TimeEntryRepository, ticketRepo repository.TicketRepository, userRepo repository.UserRepository, tx repository.Transactor) TimeService {
+	return &timeService{
+		repo:       repo,
+		ticketRepo: ticketRepo,
+		userRepo:   userRepo,
+		tx:         tx,
+	}
+}
+
+func (s *timeService) GetTicketTime(ticketID uuid.UUID) (*TicketTime, error) {
+	if _, err := s.ticketRepo.FindByID(ticketID); err != nil {
+		return nil, ErrTicketNotFound
+	}
+	entries, err := s.repo.FindByTicketID(ticketID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &TicketTime{Entries: entries}
+	for _, e := range entries {
+		result.TotalSeconds += e.DurationSeconds
+		if e.Billable {
+			result.BillableSeconds += e.DurationSeconds
+		}
+		result.LaborCost += e.Cost
+	}
+	result.LaborCost = roundCost(result.LaborCost)
+	return result, nil
+}
+
+func (s *timeService) GetRunning(userID uuid.UUID) (*domain.TimeEntry, error) {
+	entry, err := s.repo.FindRunning(userID)
+	if err != nil {
+		return nil, nil
+	}
+	return entry, nil
+}
+
+func (s *timeService) Start(ticketID, userID uuid.UUID, note string, billable bool, now time.Time) (*domain.TimeEntry, error) {
+	ticket, err := s.ticketRepo.FindByID(ticketID)
+	if err != nil {
+		return nil, ErrTicketNotFound
+	}
+	if ticket.Status == domain.StatusClosed {
+		return nil, ErrTicketClosed
+	}
+	user, err := s.userRepo.FindByID(userID)
+	if err != nil {
+		return nil, ErrUserNotFound
+	}
+	rate, err := s.rateFor(user)
+	if err != nil {
+		return nil, err
+	}
+
+	entry := &domain.TimeEntry{
+		TicketID:   ticketID,
+		UserID:     userID,
+		StartedAt:  now,
+		Note:       note,
+		Billable:   billable,
+		HourlyRate: rate,
+	}
+	ok, err := s.repo.StartTimer(entry)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, ErrTimerRunning
+	}
+	return entry, nil
+}
+
+func (s *timeService) Stop(userID uuid.UUID, now time.Time) (*domain.TimeEntry, error) {
+	entry, err := s.repo.FindRunning(userID)
+	if err != nil {
+		return nil, ErrNoRunningTimer
+	}
+
+	stopTimer(entry, now)
+
+	err = s.tx.WithinTransaction(func(r repository.Repositories) error {
+		// Only the call that actually stops the timer books its cost.
+		stopped, err := r.TimeEntries.StopTimer(entry)
+		if err != nil {
+			return err
+		}
+		if !stopped {
+			return ErrNoRunningTimer
+		}
+		if err := r.Tickets.AddLaborCost(entry.TicketID, entry.Cost); err != nil {
+			return err
+		}
+		return r.TicketLogs.Create(&domain.TicketLog{
+			TicketID:  entry.TicketID,
+			UserID:    userID,
+			Action:    domain.LogActionTimeLogged,
+			NewValue:  describeTimeEntry(entry),
+			CreatedAt: now,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+// stopTimer ends a running entry at now and prices it. A timer left
+// running longer than maxEntryDuration is cut off there, the same limit
+// AddEntry and Correct enforce; the rest is for an admin to correct.
+func stopTimer(entry *domain.TimeEntry, now time.Time) {
+	if now.Before(entry.StartedAt) {
+		now = entry.StartedAt
+	}
+	if limit := entry.StartedAt.Add(maxEntryDuration); now.After(limit) {
+		now = limit
+	}
+	entry.EndedAt = &now
+	entry.DurationSeconds = int64(now.Sub(entry.StartedAt) / time.Second)
+	entry.Cost = entryCost(entry)
+}
+
+func (s *timeService) AddEntry(entry *domain.TimeEntry, actorID uuid.UUID) error {
+	ticket, err := s.ticketRepo.FindByID(entry.TicketID)
+	if err != nil {
+		return ErrTicketNotFound
+	}
+	if ticket.Status == domain.StatusClosed {
+		return ErrTicketClosed
+	}
+
+	if entry.UserID == uuid.Nil {
+		entry.UserID = actorID
+	}
+	if entry.UserID != actorID {
+		actor, err := s.userRepo.FindByID(actorID)
+		if err != nil || actor.Role != domain.RoleAdmin {
+			return ErrTimeEntryForbidden
+		}
+	}
+	user, err := s.userRepo.FindByID(entry.UserID)
+	if err != nil {
+		return ErrUserNotFound
+	}
+
+	if err := validateTimeEntry(entry.StartedAt, entry.Duration(), time.Now()); err != nil {
+		return err
+	}
+	if entry.HourlyRate, err = s.rateFor(user); err != nil {
+		return err
+	}
+	endedAt := entry.StartedAt.Add(entry.Duration())
+	entry.EndedAt = &endedAt
+	entry.Manual = true
+	entry.Cost = entryCost(entry)
+
+	return s.tx.WithinTransaction(func(r repository.Repositories) error {
+		if err := r.TimeEntries.Create(entry); err != nil {
+			return err
+		}
+		if err := r.Tickets.AddLaborCost(entry.TicketID, entry.Cost); err != nil {
+			return err
+		}
+		return r.TicketLogs.Create(&domain.TicketLog{
+			TicketID:  entry.TicketID,
+			UserID:    actorID,
+			Action:    domain.LogActionTimeLogged,
+			NewValue:  describeTimeEntry(entry),
+			CreatedAt: time.Now(),
+		})
+	})
+}
+
+func (s *timeService) Correct(id uuid.UUID, updates map[string]interface{}, adminID uuid.UUID, reason string) (*domain.TimeEntry, error) {
+	if reason == "" {
+		return nil, invalidTimeEntry(errors.New("a reason is required for corrections"))
+	}
+
+	// The entry is read under a lock so that a timer stopped meanwhile is
+	// corrected from where Stop left it, cost included.
+	var entry *domain.TimeEntry
+	err := s.tx.WithinTransaction(func(r repository.Repositories) error {
+		var err error
+		entry, err = r.TimeEntries.Lock(id)
+		if err != nil {
+			return ErrTimeEntryNotFound
+		}
+		before := *entry
+
+		if startedAt, ok := updates["startedAt"].(time.Time); ok {
+			entry.StartedAt = startedAt
+		}
+		if seconds, ok := updates["durationSeconds"].(int64); ok {
+			// Giving a running timer a duration stops it.
+			entry.DurationSeconds = seconds
+			endedAt := entry.StartedAt
+			entry.EndedAt = &endedAt
+		}
+		if note, ok := updates["note"].(string); ok {
+			entry.Note = note
+		}
+		if billable, ok := updates["billable"].(bool); ok {
+			entry.Billable = billable
+		}
+
+		if !entry.Running() {
+			if err := validateTimeEntry(entry.StartedAt, entry.Duration(), time.Now()); err != nil {
+				return err
+			}
+			endedAt := entry.StartedAt.Add(entry.Duration())
+			entry.EndedAt = &endedAt
+			entry.Cost = entryCost(entry)
+		}
+		entry.CorrectedByID = &adminID
+		entry.CorrectionReason = reason
+
+		if err := r.TimeEntries.Update(entry); err != nil {
+			return err
+		}
+		if delta := entry.Cost - before.Cost; delta != 0 {
+			if err := r.Tickets.AddLaborCost(entry.TicketID, delta); err != nil {
+				return err
+			}
+		}
+		return r.TicketLogs.Create(&domain.TicketLog{
+			TicketID:  entry.TicketID,
+			UserID:    adminID,
+			Action:    domain.LogActionTimeCorrected,
+			OldValue:  describeTimeEntry(&before),
+			NewValue:  describeTimeEntry(entry) + " — " + reason,
+			CreatedAt: time.Now(),
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+func (s *timeService) Delete(id, adminID uuid.UUID, reason string) error {
+	if reason == "" {
+		return invalidTimeEntry(errors.New("a reason is required for deletions"))
+	}
+
+	return s.tx.WithinTransaction(func(r repository.Repositories) error {
+		entry, err := r.TimeEntries.Lock(id)
+		if err != nil {
+			return ErrTimeEntryNotFound
+		}
+		if err := r.TimeEntries.Delete(id); err != nil {
+			return err
+		}
+		if entry.Cost != 0 {
+			if err := r.Tickets.AddLaborCost(entry.TicketID, -entry.Cost); err != nil {
+				return err
+			}
+		}
+		return r.TicketLogs.Create(&domain.TicketLog{
+			TicketID:  entry.TicketID,
+			UserID:    adminID,
+			Action:    domain.LogActionTimeDeleted,
+			OldValue:  describeTimeEntry(entry),
+			NewValue:  reason,
+			CreatedAt: time.Now(),
+		})
+	})
+}
+
+func (s *timeService) Summary(filter repository.TimeSummaryFilter) ([]repository.TimeSummary, error) {
+	switch filter.GroupBy {
+	case "":
+		filter.GroupBy = repository.GroupByTechnician
+	case repository.GroupByTechnician, repository.GroupByDepartment, repository.GroupByTicket:
+	default:
+		return nil, invalidTimeEntry(fmt.Errorf("cannot group by %q", filter.GroupBy))
+	}
+	return s.repo.Summarize(filter)
+}
+
+func (s *timeService) GetRates() ([]domain.LaborRate, error) {
+	return s.repo.FindRates()
+}
+
+func (s *timeService) SetRate(role domain.UserRole, hourlyRate float64) (*domain.LaborRate, error) {
+	if hourlyRate < 0 {
+		return nil, invalidTimeEntry(errors.New("hourly rate cannot be negative"))
+	}
+	rate := &domain.LaborRate{Role: role, HourlyRate: hourlyRate, UpdatedAt: time.Now()}
+	if err := s.repo.SaveRate(rate); err != nil {
+		return nil, err
+	}
+	return rate, nil
+}
+
+// rateFor returns the user's own hourly rate, or else their role's.
+func (s *timeService) rateFor(user *domain.User) (float64, error) {
+	if user.HourlyRate != nil {
+		return *user.HourlyRate, nil
+	}
+	rate, err := s.repo.FindRate(user.Role)
+	if err != nil {
+		// No rate configured for the role: the time is still tracked.
+		return 0, nil
+	}
+	return rate.HourlyRate, nil
+}
+
+func entryCost(entry *domain.TimeEntry) float64 {
+	if !entry.Billable {
+		return 0
+	}
+	return roundCost(entry.Duration().Hours() * entry.HourlyRate)
+}
+
+func validateTimeEntry(startedAt time.Time, duration time.Duration, now time.Time) error {
+	if startedAt.IsZero() {
+		return invalidTimeEntry(errors.New("start time is required"))
+	}
+	if duration <= 0 {
+		return invalidTimeEntry(errors.New("duration must be positive"))
+	}
+	if duration > maxEntryDuration {
+		return invalidTimeEntry(errors.New("a single entry cannot exceed 24 hours"))
+	}
+	if startedAt.Add(duration).After(now) {
+		return invalidTimeEntry(errors.New("entry cannot end in the future"))
+	}
+	return nil
+}
+
+// describeTimeEntry summarises an entry for the ticket log.
+func describeTimeEntry(entry *domain.TimeEntry) string {
+	billable := "billable"
+	if !entry.Billable {
+		billable = "non-billable"
+	}
+	if entry.Running() {
+		return fmt.Sprintf("%s, running, %s", entry.StartedAt.Format(time.RFC3339), billable)
+	}
+	return fmt.Sprintf("%s, %s, %s", entry.StartedAt.Format(time.RFC3339), entry.Duration(), billable)
+}
+
+func invalidTimeEntry(err error) error {
+	return fmt.Errorf("%w: %v", ErrInvalidTimeEntry, err)
+}
@@ -0,0 +1,91 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/maintenance-system/api/internal/domain"
+)
+
+func TestEntryCost(t *testing.T) {
+	tests := []struct {
+		name     string
+		seconds  int64
+		rate     float64
+		billable bool
+		want     float64
+	}{
+		{"hour and a half", 90 * 60, 300, true, 450},
+		{"rounded to cents", 1, 100, true, 0.03},
+		{"non-billable", 90 * 60, 300, false, 0},
+		{"no rate", 90 * 60, 0, true, 0},
+		{"no time", 0, 300, true, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entry := &domain.TimeEntry{DurationSeconds: tt.seconds, HourlyRate: tt.rate, Billable: tt.billable}
+			if got := entryCost(entry); got != tt.want {
+				t.Errorf("entryCost() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateTimeEntry(t *testing.T) {
+	now := time.Date(2025, time.January, 2, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name      string
+		startedAt time.Time
+		duration  time.Duration
+		wantErr   bool
+	}{
+		{"valid", now.Add(-2 * time.Hour), time.Hour, false},
+		{"ends now", now.Add(-time.Hour), time.Hour, false},
+		{"exactly 24 hours", now.Add(-24 * time.Hour), 24 * time.Hour, false},
+		{"no start", time.Time{}, time.Hour, true},
+		{"zero duration", now.Add(-time.Hour), 0, true},
+		{"negative duration", now.Add(-time.Hour), -time.Minute, true},
+		{"longer than 24 hours", now.Add(-48 * time.Hour), 24*time.Hour + time.Second, true},
+		{"ends in the future", now.Add(-time.Hour), 2 * time.Hour, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateTimeEntry(tt.startedAt, tt.duration, now)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidTimeEntry) {
+					t.Errorf("validateTimeEntry() = %v, want %v", err, ErrInvalidTimeEntry)
+				}
+			} else if err != nil {
+				t.Errorf("validateTimeEntry() = %v, want nil", err)
+			}
+		})
+	}
+}
+
+func TestStopTimer(t *testing.T) {
+	start := time.Date(2025, time.January, 1, 9, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name        string
+		now         time.Time
+		wantEnd     time.Time
+		wantSeconds int64
+		wantCost    float64
+	}{
+		{"two hours", start.Add(2 * time.Hour), start.Add(2 * time.Hour), 7200, 200},
+		{"capped at 24 hours", start.Add(30 * time.Hour), start.Add(24 * time.Hour), 86400, 2400},
+		{"clock behind the start", start.Add(-time.Minute), start, 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entry := &domain.TimeEntry{StartedAt: start, HourlyRate: 100, Billable: true}
+			stopTimer(entry, tt.now)
+			if entry.EndedAt == nil || !entry.EndedAt.Equal(tt.wantEnd) {
+				t.Errorf("EndedAt = %v, want %v", entry.EndedAt, tt.wantEnd)
+			}
+			if entry.DurationSeconds != tt.wantSeconds || entry.Cost != tt.wantCost {
+				t.Errorf("duration %ds, cost %v; want %ds, %v", entry.DurationSeconds, entry.Cost, tt.wantSeconds, tt.wantCost)
+			}
+		})
+	}
+}
@@ -15,11 +15,12 @@ type UserService interface {
 }
 
 type userService struct {
-	userRepo repository.UserRepository
+	userRepo       repository.UserRepository
+	departmentRepo repository.DepartmentRepository
 }
 
-func NewUserService(userRepo repository.UserRepository) UserService {
-	return &userService{userRepo: userRepo}
+func NewUserService(userRepo repository.UserRepository, departmentRepo repository.DepartmentRepository) UserService {
+	return &userService{userRepo: userRepo, departmentRepo: departmentRepo}
 }
 
 func (s *userService) GetAll(page, limit int) ([]domain.User, int64, error) {
@@ -49,11 +50,31 @@ func (s *userService) Update(id uuid.UUID, updates map[string]interface{}) (*dom
 		user.Phone = phone
 	}
 	if department, ok := updates["department"].(string); ok {
+		// A name that matches a department links the user to it; any other
+		// text is kept as is.
 		user.Department = department
+		user.DepartmentID = nil
+		if found, err := s.departmentRepo.FindByName(department); err == nil {
+			user.DepartmentID = &found.ID
+		}
+	}
+	if departmentID, ok := updates["departmentId"].(uuid.UUID); ok {
+		department, err := s.departmentRepo.FindByID(departmentID)
+		if err != nil {
+			return nil, ErrDepartmentNotFound
+		}
+		user.Department = department.Name
+		user.DepartmentID = &department.ID
 	}
 	if status, ok := updates["status"].(string); ok && status != "" {
 		user.Status = domain.UserStatus(status)
 	}
+	if hourlyRate, ok := updates["hourlyRate"].(float64); ok {
+		user.HourlyRate = &hourlyRate
+	}
+	if head, ok := updates["departmentHead"].(bool); ok {
+		user.DepartmentHead = head
+	}
 
 	if err := s.userRepo.Update(user); err != nil {
 		return nil, err
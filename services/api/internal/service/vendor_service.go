@@ -0,0 +1,431 @@
+package service
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/maintenance-system/api/internal/domain"
+	"github.com/maintenance-system/api/internal/repository"
+)
+
+var (
+	ErrVendorNotFound    = errors.New("vendor not found")
+	ErrVendorExists      = errors.New("a vendor with this name already exists")
+	ErrInvalidVendor     = errors.New("invalid vendor")
+	ErrVendorInactive    = errors.New("vendor is inactive")
+	ErrVendorLinkInvalid = errors.New("vendor link is invalid or has expired")
+)
+
+// VendorLinkOptions configures the links sent to vendors.
+type VendorLinkOptions struct {
+	BaseURL   string // web app URL the links point to
+	ValidDays int    // how long a link works
+}
+
+// VendorDispatch is the result of dispatching a ticket to a vendor. URL is
+// only available now; the token is stored hashed.
+type VendorDispatch struct {
+	Ticket    *domain.Ticket `json:"ticket"`
+	URL       string         `json:"url"`
+	ExpiresAt time.Time      `json:"expiresAt"`
+}
+
+// VendorTicketView is what a vendor sees through their ticket link.
+type VendorTicketView struct {
+	ID          uuid.UUID             `json:"id"`
+	Title       string                `json:"title"`
+	Description string                `json:"description"`
+	Status      domain.TicketStatus   `json:"status"`
+	Priority    domain.TicketPriority `json:"priority"`
+	Category    domain.TicketCategory `json:"category"`
+	Location    string                `json:"location,omitempty"`
+	Asset       *VendorAssetView      `json:"asset,omitempty"`
+	DueDate     *time.Time            `json:"dueDate,omitempty"`
+	CreatedAt   time.Time             `json:"createdAt"`
+	Vendor      string                `json:"vendor"`
+	Comments    []VendorCommentView   `json:"comments"`
+	Attachments []domain.Attachment   `json:"attachments"`
+	ExpiresAt   time.Time             `json:"expiresAt"`
+}
+
+type VendorAssetView struct {
+	Code         string `json:"code"`
+	Name         string `json:"name"`
+	Make         string `json:"make,omitempty"`
+	Model        string `json:"model,omitempty"`
+	SerialNumber string `json:"serialNumber,omitempty"`
+}
+
+type VendorCommentView struct {
+	Author     string    `json:"author"`
+	FromVendor bool      `json:"fromVendor"`
+	Content    string    `json:"content"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
+
+type VendorService interface {
+	GetAll(filter repository.VendorFilter) ([]domain.Vendor, error)
+	GetByID(id uuid.UUID) (*domain.Vendor, error)
+	Create(vendor *domain.Vendor) error
+	// Update applies updates; a "contacts" entry replaces the contacts.
+	Update(id uuid.UUID, updates map[string]interface{}) (*domain.Vendor, error)
+	Delete(id uuid.UUID) error
+
+	// Dispatch assigns the ticket to the vendor and issues a new link for
+	// it, revoking any earlier ones. The link is emailed to the vendor.
+	Dispatch(ticketID, vendorID, userID uuid.UUID) (*VendorDispatch, error)
+	// RevokeLinks stops the ticket's vendor links from working.
+	RevokeLinks(ticketID uuid.UUID) error
+	Stats(filter repository.VendorStatsFilter) ([]repository.VendorStats, error)
+
+	// The portal methods act through a vendor link token.
+	PortalTicket(token string) (*VendorTicketView, error)
+	PortalComment(token, content string) (*VendorCommentView, error)
+	PortalAttachment(token string, attachment *domain.Attachment) error
+	// CheckLink returns ErrVendorLinkInvalid unless token opens a live
+	// link, and ErrTicketClosed if the link's ticket is closed. Uploads
+	// are checked with it before anything is written.
+	CheckLink(token string) error
+}
+
+type vendorService struct {
+	repo       repository.VendorRepository
+	ticketRepo repository.TicketRepository
+	userRepo   repository.UserRepository
+	tickets    TicketService
+	email      EmailService
+	opts       VendorLinkOptions
+}
+
+func NewVendorService(repo repository.VendorRepository, ticketRepo repository.TicketRepository, userRepo repository.UserRepository, tickets TicketService, email EmailService, opts VendorLinkOptions) VendorService {
+	opts.BaseURL = strings.TrimRight(opts.BaseURL, "/")
+	if opts.ValidDays <= 0 {
+		opts.ValidDays = 30
+	}
+	return &vendorService{
+		repo:       repo,
+		ticketRepo: ticketRepo,
+		userRepo:   userRepo,
+		tickets:    tickets,
+		email:      email,
+		opts:       opts,
+	}
+}
+
+func (s *vendorService) GetAll(filter repository.VendorFilter) ([]domain.Vendor, error) {
+	return s.repo.FindAll(filter)
+}
+
+func (s *vendorService) GetByID(id uuid.UUID) (*domain.Vendor, error) {
+	vendor, err := s.repo.FindByID(id)
+	if err != nil {
+		return nil, ErrVendorNotFound
+	}
+	return vendor, nil
+}
+
+func (s *vendorService) Create(vendor *domain.Vendor) error {
+	if err := validateVendor(vendor); err != nil {
+		return err
+	}
+	if _, err := s.repo.FindByName(vendor.Name); err == nil {
+		return ErrVendorExists
+	}
+	return s.repo.Create(vendor)
+}
+
+func (s *vendorService) Update(id uuid.UUID, updates map[string]interface{}) (*domain.Vendor, error) {
+	vendor, err := s.repo.FindByID(id)
+	if err != nil {
+		return nil, ErrVendorNotFound
+	}
+
+	if name, ok := updates["name"].(string); ok && name != vendor.Name {
+		if _, err := s.repo.FindByName(name); err == nil {
+			return nil, ErrVendorExists
+		}
+		vendor.Name = name
+	}
+	if email, ok := updates["email"].(string); ok {
+		vendor.Email = email
+	}
+	if phone, ok := updates["phone"].(string); ok {
+		vendor.Phone = phone
+	}
+	if address, ok := updates["address"].(string); ok {
+		vendor.Address = address
+	}
+	if categories, ok := updates["categories"].(domain.TicketCategories); ok {
+		vendor.Categories = categories
+	}
+	if notes, ok := updates["notes"].(string); ok {
+		vendor.Notes = notes
+	}
+	if active, ok := updates["active"].(bool); ok {
+		vendor.Active = active
+	}
+	contacts, replaceContacts := updates["contacts"].([]domain.VendorContact)
+	if replaceContacts {
+		vendor.Contacts = contacts
+	}
+
+	if err := validateVendor(vendor); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Update(vendor, replaceContacts); err != nil {
+		return nil, err
+	}
+	return s.repo.FindByID(id)
+}
+
+func (s *vendorService) Delete(id uuid.UUID) error {
+	if _, err := s.repo.FindByID(id); err != nil {
+		return ErrVendorNotFound
+	}
+	return s.repo.Delete(id)
+}
+
+func (s *vendorService) Dispatch(ticketID, vendorID, userID uuid.UUID) (*VendorDispatch, error) {
+	vendor, err := s.repo.FindByID(vendorID)
+	if err != nil {
+		return nil, ErrVendorNotFound
+	}
+	if !vendor.Active {
+		return nil, ErrVendorInactive
+	}
+
+	token, err := newVendorToken()
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	access := &domain.VendorAccess{
+		VendorID:    vendor.ID,
+		TicketID:    ticketID,
+		TokenHash:   hashVendorToken(token),
+		ExpiresAt:   now.AddDate(0, 0, s.opts.ValidDays),
+		CreatedByID: userID,
+	}
+	// The assignment and the new link commit together, so the vendor is
+	// never left assigned without a working link.
+	ticket, err := s.tickets.AssignVendor(ticketID, vendor.ID, userID, func(r repository.Repositories) error {
+		if err := r.Vendors.RevokeAccess(ticketID, now); err != nil {
+			return err
+		}
+		return r.Vendors.CreateAccess(access)
+	})
+	if err != nil {
+		return nil, err
+	}
+	ticket.Vendor = vendor
+
+	dispatch := &VendorDispatch{
+		Ticket:    ticket,
+		URL:       s.opts.BaseURL + "/vendor/" + token,
+		ExpiresAt: access.ExpiresAt,
+	}
+	if to, name := vendorRecipient(vendor); to != "" {
+		if err := s.email.SendVendorDispatch(to, name, ticket.Title, ticket.ID.String(), dispatch.URL); err != nil {
+			log.Printf("Vendor %s, ticket %s: %v", vendor.Name, ticket.ID, err)
+		}
+	}
+	return dispatch, nil
+}
+
+func (s *vendorService) RevokeLinks(ticketID uuid.UUID) error {
+	if _, err := s.ticketRepo.FindByID(ticketID); err != nil {
+		return ErrTicketNotFound
+	}
+	return s.repo.RevokeAccess(ticketID, time.Now())
+}
+
+func (s *vendorService) Stats(filter repository.VendorStatsFilter) ([]repository.VendorStats, error) {
+	return s.repo.Stats(filter)
+}
+
+func (s *vendorService) PortalTicket(token string) (*VendorTicketView, error) {
+	access, ticket, err := s.resolve(token)
+	if err != nil {
+		return nil, err
+	}
+
+	view := &VendorTicketView{
+		ID:          ticket.ID,
+		Title:       ticket.Title,
+		Description: ticket.Description,
+		Status:      ticket.Status,
+		Priority:    ticket.Priority,
+		Category:    ticket.Category,
+		Location:    ticket.Location,
+		DueDate:     ticket.DueDate,
+		CreatedAt:   ticket.CreatedAt,
+		Comments:    make([]VendorCommentView, 0, len(ticket.Comments)),
+		Attachments: ticket.Attachments,
+		ExpiresAt:   access.ExpiresAt,
+	}
+	if ticket.Vendor != nil {
+		view.Vendor = ticket.Vendor.Name
+	}
+	if ticket.Asset != nil {
+		view.Asset = &VendorAssetView{
+			Code:         ticket.Asset.Code,
+			Name:         ticket.Asset.Name,
+			Make:         ticket.Asset.Make,
+			Model:        ticket.Asset.Model,
+			SerialNumber: ticket.Asset.SerialNumber,
+		}
+	}
+	if view.Attachments == nil {
+		view.Attachments = []domain.Attachment{}
+	}
+	for _, c := range ticket.Comments {
+		comment := VendorCommentView{
+			FromVendor: c.VendorID != nil,
+			Content:    c.Content,
+			CreatedAt:  c.CreatedAt,
+		}
+		switch {
+		case comment.FromVendor:
+			comment.Author = view.Vendor
+		case c.User != nil:
+			comment.Author = c.User.Name
+		}
+		view.Comments = append(view.Comments, comment)
+	}
+	return view, nil
+}
+
+func (s *vendorService) PortalComment(token, content string) (*VendorCommentView, error) {
+	access, ticket, err := s.resolve(token)
+	if err != nil {
+		return nil, err
+	}
+	comment, err := s.tickets.AddVendorComment(ticket.ID, access.VendorID, content)
+	if err != nil {
+		return nil, err
+	}
+
+	view := &VendorCommentView{
+		FromVendor: true,
+		Content:    comment.Content,
+		CreatedAt:  comment.CreatedAt,
+	}
+	if ticket.Vendor != nil {
+		view.Author = ticket.Vendor.Name
+	}
+	return view, nil
+}
+
+func (s *vendorService) CheckLink(token string) error {
+	_, err := s.openTicket(token)
+	return err
+}
+
+func (s *vendorService) PortalAttachment(token string, attachment *domain.Attachment) error {
+	ticket, err := s.openTicket(token)
+	if err != nil {
+		return err
+	}
+	portal, err := s.userRepo.FindByEmail(domain.VendorPortalEmail)
+	if err != nil {
+		return fmt.Errorf("vendor portal account missing: %w", err)
+	}
+	attachment.TicketID = ticket.ID
+	return s.tickets.AddAttachment(attachment, portal.ID)
+}
+
+// openTicket resolves token to its ticket, which must not be closed.
+func (s *vendorService) openTicket(token string) (*domain.Ticket, error) {
+	_, ticket, err := s.resolve(token)
+	if err != nil {
+		return nil, err
+	}
+	if ticket.Status == domain.StatusClosed {
+		return nil, ErrTicketClosed
+	}
+	return ticket, nil
+}
+
+// resolve returns the live link for token and its ticket. A link stops
+// working once the ticket is reassigned away from its vendor.
+func (s *vendorService) resolve(token string) (*domain.VendorAccess, *domain.Ticket, error) {
+	if token == "" {
+		return nil, nil, ErrVendorLinkInvalid
+	}
+	access, err := s.repo.FindAccessByHash(hashVendorToken(token))
+	if err != nil {
+		return nil, nil, ErrVendorLinkInvalid
+	}
+	now := time.Now()
+	if !access.Valid(now) {
+		return nil, nil, ErrVendorLinkInvalid
+	}
+	ticket, err := s.ticketRepo.FindByID(access.TicketID)
+	if err != nil || ticket.VendorID == nil || *ticket.VendorID != access.VendorID {
+		return nil, nil, ErrVendorLinkInvalid
+	}
+	if err := s.repo.TouchAccess(access.ID, now); err != nil {
+		log.Printf("Vendor link %s: %v", access.ID, err)
+	}
+	return access, ticket, nil
+}
+
+func validateVendor(vendor *domain.Vendor) error {
+	if vendor.Name == "" {
+		return invalidVendor(errors.New("name is required"))
+	}
+	for _, category := range vendor.Categories {
+		if category == "" {
+			return invalidVendor(errors.New("empty category"))
+		}
+	}
+	primaries := 0
+	for i := range vendor.Contacts {
+		if vendor.Contacts[i].Name == "" {
+			return invalidVendor(fmt.Errorf("contact %d: name is required", i+1))
+		}
+		if vendor.Contacts[i].Primary {
+			primaries++
+		}
+	}
+	if primaries > 1 {
+		return invalidVendor(errors.New("only one contact can be primary"))
+	}
+	return nil
+}
+
+// vendorRecipient returns where dispatch emails go: the primary contact
+// if they have an email, else the vendor's own address.
+func vendorRecipient(vendor *domain.Vendor) (string, string) {
+	for _, contact := range vendor.Contacts {
+		if contact.Primary && contact.Email != "" {
+			return contact.Email, contact.Name
+		}
+	}
+	return vendor.Email, vendor.Name
+}
+
+func newVendorToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func hashVendorToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func invalidVendor(err error) error {
+	return fmt.Errorf("%w: %v", ErrInvalidVendor, err)
+}